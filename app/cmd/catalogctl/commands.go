@@ -0,0 +1,370 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	CategoryJsonRepository "github.com/lucasti79/meli-interview/internal/category/infra/jsonstore"
+	"github.com/lucasti79/meli-interview/internal/infra/jsonstore"
+	"github.com/lucasti79/meli-interview/internal/product"
+	ProductJsonRepository "github.com/lucasti79/meli-interview/internal/product/infra/jsonstore"
+	"github.com/lucasti79/meli-interview/internal/product/service"
+)
+
+// openProducts opens the products file for the commands that write to it. As
+// the server does, it cuts off what an interrupted write left at its end.
+func (c *cli) openProducts(opts ...jsonstore.Option) (*jsonstore.JSONRepository[product.Product], error) {
+	store, err := ProductJsonRepository.NewProductStore(c.products, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", c.products, err)
+	}
+	return store, nil
+}
+
+// readProducts opens the products file for the commands that only read it,
+// leaving the file as it is found.
+func (c *cli) readProducts() (*jsonstore.JSONRepository[product.Product], error) {
+	return c.openProducts(jsonstore.WithReadOnly())
+}
+
+func (c *cli) table() *tabwriter.Writer {
+	return tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+}
+
+func validateCommand(c *cli, args []string) error {
+	flags := c.newFlags("validate", "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	store, err := c.readProducts()
+	if err != nil {
+		return err
+	}
+
+	// the store checks every product while it loads the file
+	report := store.Report()
+	problems := 0
+	for _, line := range report.CorruptedLines {
+		problems++
+		if line.Interrupted {
+			fmt.Fprintf(c.stdout, "line %d: cannot be decoded, left by an interrupted write: %s\n", line.Line, line.Error)
+			continue
+		}
+		fmt.Fprintf(c.stdout, "line %d: cannot be decoded: %s\n", line.Line, line.Error)
+	}
+	for _, record := range report.InvalidRecords {
+		for _, fe := range record.Errors {
+			problems++
+			fmt.Fprintf(c.stdout, "line %d: product %q: %s %s\n", record.Line, record.ID, fe.Field, fe.Message)
+		}
+	}
+
+	stats := store.Stats()
+	fmt.Fprintf(c.stdout, "%s: %d lines, %d products, %d problems\n", c.products, stats.TotalLines, stats.LiveLines, problems)
+	if problems > 0 {
+		return errProblems
+	}
+	return nil
+}
+
+func indexCommand(c *cli, args []string) error {
+	flags := c.newFlags("index", "")
+	showLines := flags.Bool("lines", false, "also print the line, offset and state of every line")
+	id := flags.String("id", "", "print where the latest version of this product is")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// the index lives in memory: opening the store is what the server does
+	// at startup to build it
+	start := time.Now()
+	store, err := c.readProducts()
+	if err != nil {
+		return err
+	}
+	elapsed := time.Since(start)
+
+	stats := store.Stats()
+	fmt.Fprintf(c.stdout, "%s indexed in %s\n", c.products, elapsed.Round(time.Microsecond))
+	fmt.Fprintf(c.stdout, "lines: %d total, %d live, %d dead, %d corrupted (%.0f%% dead)\n",
+		stats.TotalLines, stats.LiveLines, stats.DeadLines, stats.CorruptedLines, stats.DeadRatio*100)
+
+	if byCategory, ok := store.Index(ProductJsonRepository.CategoryIndex).(*jsonstore.KeywordIndex[product.Product]); ok {
+		w := c.table()
+		fmt.Fprintln(w, "CATEGORY KEY\tPRODUCTS")
+		for _, key := range byCategory.Keys() {
+			fmt.Fprintf(w, "%s\t%d\n", key, byCategory.Lookup(key).Count())
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if !*showLines && *id == "" {
+		return nil
+	}
+	w := c.table()
+	fmt.Fprintln(w, "LINE\tOFFSET\tSTATE\tID")
+	found := false
+	err = store.Lines(context.Background(), func(line jsonstore.Line[product.Product]) error {
+		if *id != "" {
+			if line.ID != *id || line.State != jsonstore.LineLive {
+				return nil
+			}
+			found = true
+		}
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\n", line.Number, line.Offset, line.State, line.ID)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if *id != "" && !found {
+		fmt.Fprintf(c.stdout, "product %q is not in the index\n", *id)
+		return errProblems
+	}
+	return nil
+}
+
+func compactCommand(c *cli, args []string) error {
+	flags := c.newFlags("compact", "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	store, err := c.openProducts()
+	if err != nil {
+		return err
+	}
+
+	before := store.Stats()
+	if err := store.Compact(); err != nil {
+		return err
+	}
+	after := store.Stats()
+	fmt.Fprintf(c.stdout, "%s: %d lines before, %d after, %d removed\n",
+		c.products, before.TotalLines, after.TotalLines, before.TotalLines-after.TotalLines)
+	return nil
+}
+
+var sorts = []string{
+	product.SortByPrice, product.SortByRating, product.SortByReviews, product.SortByName, product.SortByDiscount,
+}
+
+func exportCommand(c *cli, args []string) error {
+	flags := c.newFlags("export", "")
+	format := flags.String("format", product.FormatCSV, "csv or jsonl")
+	output := flags.String("o", "", "file to write, standard output when empty")
+	var filters product.ProductFilter
+	flags.StringVar(&filters.Name, "name", "", "only products whose name contains this")
+	categories := flags.String("category", "", "only products in these comma separated categories")
+	flags.Float64Var(&filters.MinPrice, "min-price", 0, "only products at least this price")
+	flags.Float64Var(&filters.MaxPrice, "max-price", 0, "only products at most this price")
+	flags.StringVar(&filters.Sort, "sort", "", "sort field, prefixed with - for descending order; product ID when empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	filters.Categories = splitList(*categories)
+	if field, _ := filters.SortField(); field != "" && !slices.Contains(sorts, field) {
+		fmt.Fprintf(c.stderr, "unknown sort %q: use one of %s\n", filters.Sort, strings.Join(sorts, ", "))
+		return errUsage
+	}
+
+	store, err := c.readProducts()
+	if err != nil {
+		return err
+	}
+
+	out := c.stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	writer, err := product.NewWriter(out, *format)
+	if err != nil {
+		return err
+	}
+
+	svc := service.NewService(ProductJsonRepository.NewProductRepositoryFromStore(store))
+	count := 0
+	err = svc.Export(filters, func(p product.Product) error {
+		count++
+		return writer.Write(p)
+	})
+	if err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	if *output != "" {
+		fmt.Fprintf(c.stdout, "%d products written to %s\n", count, *output)
+	}
+	return nil
+}
+
+func importCommand(c *cli, args []string) error {
+	flags := c.newFlags("import", "FILE")
+	format := flags.String("format", "", "csv or jsonl, taken from the file extension when empty")
+	var opts product.ImportOptions
+	flags.StringVar(&opts.Mode, "mode", product.ImportInsert, "insert rejects existing product IDs, upsert replaces them")
+	flags.BoolVar(&opts.DryRun, "dry-run", false, "check the file without writing any product")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errUsage
+	}
+	fileName := flags.Arg(0)
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(fileName), ".")
+	}
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	rows, err := product.DecodeImport(f, *format)
+	if err != nil {
+		return err
+	}
+
+	store, err := c.openProducts()
+	if err != nil {
+		return err
+	}
+	categories, err := CategoryJsonRepository.NewCategoryRepository(c.categories)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", c.categories, err)
+	}
+	svc := service.NewService(ProductJsonRepository.NewProductRepositoryFromStore(store), service.WithCategories(categories))
+
+	report, err := svc.Import(rows, opts)
+	for _, e := range report.Errors {
+		fmt.Fprintln(c.stdout, e.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	summary := fmt.Sprintf("%d rows: %d created, %d updated, %d failed", report.Rows, report.Created, report.Updated, report.Failed)
+	switch {
+	case report.Failed > 0:
+		summary += "; nothing was written"
+	case report.DryRun:
+		summary += " (dry run)"
+	}
+	fmt.Fprintln(c.stdout, summary)
+	if report.Failed > 0 {
+		return errProblems
+	}
+	return nil
+}
+
+func duplicatesCommand(c *cli, args []string) error {
+	flags := c.newFlags("duplicates", "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	store, err := c.readProducts()
+	if err != nil {
+		return err
+	}
+
+	type versions struct {
+		lines []string
+		live  int
+	}
+	byID := make(map[string]*versions)
+	var order []string
+	err = store.Lines(context.Background(), func(line jsonstore.Line[product.Product]) error {
+		if line.ID == "" {
+			return nil
+		}
+		v, ok := byID[line.ID]
+		if !ok {
+			v = &versions{}
+			byID[line.ID] = v
+			order = append(order, line.ID)
+		}
+		number := fmt.Sprint(line.Number)
+		if line.State == jsonstore.LineTombstone {
+			number += " (deleted)"
+		}
+		v.lines = append(v.lines, number)
+		if line.State == jsonstore.LineLive {
+			v.live = line.Number
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	w := c.table()
+	fmt.Fprintln(w, "PRODUCT ID\tLINES\tLIVE LINE")
+	count := 0
+	for _, id := range order {
+		v := byID[id]
+		if len(v.lines) < 2 {
+			continue
+		}
+		count++
+		live := "-"
+		if v.live > 0 {
+			live = fmt.Sprint(v.live)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", id, strings.Join(v.lines, ", "), live)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.stdout, "%d product IDs on more than one line; compact keeps only their live line\n", count)
+	return nil
+}
+
+func statsCommand(c *cli, args []string) error {
+	flags := c.newFlags("stats", "")
+	only := flags.String("category", "", "only these comma separated categories")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	store, err := c.readProducts()
+	if err != nil {
+		return err
+	}
+
+	stats, err := CategoryJsonRepository.NewStatsRepository(store).GetStats(splitList(*only))
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(stats))
+	for name := range stats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := c.table()
+	fmt.Fprintln(w, "CATEGORY\tPRODUCTS\tIN STOCK\tMIN PRICE\tMAX PRICE\tAVG PRICE\tAVG RATING")
+	for _, name := range names {
+		s := stats[name]
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.2f\t%.2f\t%.2f\t%.2f\n",
+			name, s.ProductCount, s.InStockCount, s.MinPrice, s.MaxPrice, s.AvgPrice, s.AvgRating)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,123 @@
+// Command catalogctl maintains the catalog files offline: it validates and
+// inspects products.jsonl, compacts it, imports and exports CSV, finds
+// product IDs written more than once and prints per-category statistics.
+//
+// It opens the files through the same stores as the server, so it should not
+// write to them while the server is running.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// errProblems ends a command that reported what is wrong on its own, so only
+// the exit status is left to set.
+var errProblems = errors.New("problems found")
+
+// cli holds the global flags and where commands write.
+type cli struct {
+	stdout, stderr io.Writer
+	products       string
+	categories     string
+}
+
+type command struct {
+	usage string
+	run   func(c *cli, args []string) error
+}
+
+var commands = map[string]command{
+	"validate":   {"check every line of the products file against the product schema", validateCommand},
+	"index":      {"build the offset index of the products file and print what it holds", indexCommand},
+	"compact":    {"rewrite the products file without dead and deleted lines", compactCommand},
+	"export":     {"write the products as CSV or JSON Lines", exportCommand},
+	"import":     {"create or replace products from a CSV or JSON Lines file", importCommand},
+	"duplicates": {"list the product IDs written on more than one line", duplicatesCommand},
+	"stats":      {"print product statistics per category", statsCommand},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the exit status: 1 when the
+// command failed or found problems, 2 when it was misused.
+func run(args []string, stdout, stderr io.Writer) int {
+	c := &cli{stdout: stdout, stderr: stderr}
+
+	flags := flag.NewFlagSet("catalogctl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&c.products, "products", "products.jsonl", "products file")
+	flags.StringVar(&c.categories, "categories", "categories.jsonl", "categories file")
+	flags.Usage = func() { printUsage(flags) }
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	name := flags.Arg(0)
+	cmd, ok := commands[name]
+	if !ok {
+		if name != "" {
+			fmt.Fprintf(stderr, "unknown command %q\n", name)
+		}
+		printUsage(flags)
+		return 2
+	}
+
+	err := cmd.run(c, flags.Args()[1:])
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp), errors.Is(err, errUsage):
+		return 2
+	case errors.Is(err, errProblems):
+		return 1
+	default:
+		fmt.Fprintf(stderr, "%s: %v\n", name, err)
+		return 1
+	}
+}
+
+func printUsage(flags *flag.FlagSet) {
+	out := flags.Output()
+	fmt.Fprintln(out, "usage: catalogctl [flags] <command> [command flags]")
+	fmt.Fprintln(out, "\ncommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "  %-11s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprintln(out, "\nflags:")
+	flags.PrintDefaults()
+}
+
+// errUsage ends a command whose arguments were rejected after its usage was
+// printed.
+var errUsage = errors.New("usage")
+
+// newFlags returns the flag set of a command, printing errors to c.stderr.
+func (c *cli) newFlags(name, arguments string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(c.stderr, "usage: catalogctl %s [flags] %s\n", name, arguments)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
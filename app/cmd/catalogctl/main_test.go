@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	mouse = `{"productId":"p1","name":"Mouse","category":"Electronics","price":25,"rating":4.5,"inStock":true}`
+	mug   = `{"productId":"p2","name":"Mug","category":"Home","price":12.5,"rating":4}`
+)
+
+// catalog writes the products and categories files and returns the global
+// flags pointing at them.
+func catalog(t *testing.T, products ...string) []string {
+	t.Helper()
+	dir := t.TempDir()
+	productsFile := filepath.Join(dir, "products.jsonl")
+	categoriesFile := filepath.Join(dir, "categories.jsonl")
+	require.NoError(t, os.WriteFile(productsFile, []byte(strings.Join(products, "\n")+"\n"), 0o600))
+	categories := `{"categoryId":"c1","slug":"electronics","name":"Electronics"}` + "\n" +
+		`{"categoryId":"c2","slug":"home","name":"Home"}` + "\n"
+	require.NoError(t, os.WriteFile(categoriesFile, []byte(categories), 0o600))
+	return []string{"-products", productsFile, "-categories", categoriesFile}
+}
+
+func runCommand(t *testing.T, args ...string) (int, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String() + stderr.String()
+}
+
+func TestValidate_ReportsUndecodableAndInvalidLines(t *testing.T) {
+	flags := catalog(t, mouse,
+		`{"productId":"p3","name":"","category":"Home","price":0}`,
+		`{"productId":`,
+		`{"productId":"p4","name":"Lamp","category":"Home","price":20,"rating":7,"image":"lamp.png"}`)
+
+	code, out := runCommand(t, append(flags, "validate")...)
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, out, `line 2: product "p3": name is required`)
+	assert.Contains(t, out, `line 2: product "p3": price must be greater than 0`)
+	assert.Contains(t, out, "line 3: cannot be decoded")
+	assert.Contains(t, out, `line 4: product "p4": image must be a URL`)
+	assert.Contains(t, out, `line 4: product "p4": rating must be at most 5`)
+	assert.Contains(t, out, "4 lines, 3 products, 5 problems")
+
+	code, out = runCommand(t, append(catalog(t, mouse, mug), "validate")...)
+	assert.Equal(t, 0, code, out)
+}
+
+func TestValidate_ReportsAnInterruptedWriteWithoutRepairingIt(t *testing.T) {
+	flags := catalog(t, mouse, mug)
+	content := mouse + "\n" + mug + "\n" + `{"productId":"p3","name":"La`
+	require.NoError(t, os.WriteFile(flags[1], []byte(content), 0o600))
+
+	code, out := runCommand(t, append(flags, "validate")...)
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, out, "line 3: cannot be decoded, left by an interrupted write")
+	assert.Contains(t, out, "3 lines, 2 products, 1 problems")
+	data, err := os.ReadFile(flags[1])
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+}
+
+func TestDuplicatesAndCompact(t *testing.T) {
+	updated := `{"productId":"p1","name":"Mouse v2","category":"Electronics","price":30}`
+	flags := catalog(t, mouse, mug, updated, `{"$deleted":"p2"}`)
+
+	code, out := runCommand(t, append(flags, "duplicates")...)
+	require.Equal(t, 0, code, out)
+	assert.Regexp(t, `p1\s+1, 3\s+3`, out)
+	assert.Regexp(t, `p2\s+2, 4 \(deleted\)\s+-`, out)
+	assert.Contains(t, out, "2 product IDs on more than one line")
+
+	code, out = runCommand(t, append(flags, "index", "-id", "p1")...)
+	require.Equal(t, 0, code, out)
+	assert.Contains(t, out, "lines: 4 total, 1 live, 3 dead, 0 corrupted (75% dead)")
+	assert.Regexp(t, `3\s+\d+\s+live\s+p1`, out)
+
+	code, out = runCommand(t, append(flags, "compact")...)
+	require.Equal(t, 0, code, out)
+	assert.Contains(t, out, "4 lines before, 1 after, 3 removed")
+
+	code, out = runCommand(t, append(flags, "duplicates")...)
+	require.Equal(t, 0, code, out)
+	assert.Contains(t, out, "0 product IDs on more than one line")
+}
+
+func TestImportAndExportCSV(t *testing.T) {
+	flags := catalog(t, mouse)
+	file := filepath.Join(t.TempDir(), "new.csv")
+	csv := "productId,name,category,price\n" +
+		"p1,Mouse,Electronics,27\n" +
+		"p2,Mug,Home,12.5\n"
+	require.NoError(t, os.WriteFile(file, []byte(csv), 0o600))
+
+	code, out := runCommand(t, append(flags, "import", file)...)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, out, "line 2: productId: already exists")
+	assert.Contains(t, out, "nothing was written")
+
+	code, out = runCommand(t, append(flags, "import", "-mode", "upsert", "-dry-run", file)...)
+	require.Equal(t, 0, code, out)
+	assert.Contains(t, out, "2 rows: 1 created, 1 updated, 0 failed (dry run)")
+
+	code, out = runCommand(t, append(flags, "import", "-mode", "upsert", file)...)
+	require.Equal(t, 0, code, out)
+
+	code, out = runCommand(t, append(flags, "export", "-sort", "price")...)
+	require.Equal(t, 0, code, out)
+	assert.Equal(t, "productId,name,description,price,originalPrice,category,image,inStock,stock,reserved,rating,reviews\n"+
+		"p2,Mug,,12.5,,Home,,false,,0,,0\n"+
+		"p1,Mouse,,27,,Electronics,,false,,0,,0\n", out)
+
+	code, _ = runCommand(t, append(flags, "export", "-sort", "colour")...)
+	assert.Equal(t, 2, code)
+}
+
+func TestStats(t *testing.T) {
+	code, out := runCommand(t, append(catalog(t, mouse, mug), "stats", "-category", "electronics")...)
+
+	require.Equal(t, 0, code, out)
+	assert.Regexp(t, `electronics\s+1\s+1\s+25.00\s+25.00\s+25.00\s+4.50`, out)
+	assert.NotContains(t, out, "home")
+}
+
+func TestRun_RejectsUnknownCommands(t *testing.T) {
+	code, out := runCommand(t, "reindex")
+
+	assert.Equal(t, 2, code)
+	assert.Contains(t, out, `unknown command "reindex"`)
+	assert.Contains(t, out, "usage: catalogctl")
+}
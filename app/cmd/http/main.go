@@ -19,14 +19,22 @@ import (
 // @version 1.0
 // @description This is an example API
 // @BasePath /
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description A JWT with the admin role, sent as "Bearer <token>"
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description An API key created by an admin, granted the scopes the endpoint requires
 func main() {
 	cfg := config.LoadConfig()
 
-	if err := factory.InitFactory(); err != nil {
+	if err := factory.InitFactory(cfg); err != nil {
 		log.Fatalf("failed to initialize AppFactory: %v", err)
 	}
 
-	r := router.NewRouter()
+	r := router.NewRouter(cfg)
 
 	addr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
 	fmt.Println("starting server on", addr)
@@ -0,0 +1,18 @@
+package router
+
+import (
+	"net/http"
+
+	chi "github.com/go-chi/chi/v5"
+	"github.com/lucasti79/meli-interview/internal/apikey/api"
+)
+
+func buildAPIKeysRoutes(apiKeyHandler *api.Handler) http.Handler {
+	r := chi.NewRouter()
+	r.Use(requireAdmin)
+	r.Get("/", apiKeyHandler.GetAll) // GET /api/v1/apikeys
+	r.Post("/", apiKeyHandler.Create)
+	r.Get("/{keyId}", apiKeyHandler.GetByID)
+	r.Delete("/{keyId}", apiKeyHandler.Revoke)
+	return r
+}
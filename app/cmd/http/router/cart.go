@@ -0,0 +1,19 @@
+package router
+
+import (
+	"net/http"
+
+	chi "github.com/go-chi/chi/v5"
+	"github.com/lucasti79/meli-interview/internal/cart/api"
+)
+
+func buildCartsRoutes(cartHandler *api.Handler) http.Handler {
+	r := chi.NewRouter()
+	r.Post("/", cartHandler.Create) // POST /api/v1/carts
+	r.Get("/{cartId}", cartHandler.GetByID)
+	r.Get("/{cartId}/totals", cartHandler.GetTotals)
+	r.Post("/{cartId}/items", cartHandler.AddItem)
+	r.Put("/{cartId}/items/{productId}", cartHandler.UpdateItem)
+	r.Delete("/{cartId}/items/{productId}", cartHandler.RemoveItem)
+	return r
+}
@@ -9,7 +9,20 @@ import (
 
 func buildCategoriesRoutes(categoryHandler *api.Handler) http.Handler {
 	r := chi.NewRouter()
-	r.Get("/", categoryHandler.GetAll) // GET /api/v1/categories
-	r.Get("/{categoryName}", categoryHandler.GetByName)
+
+	r.Group(func(r chi.Router) {
+		r.Use(limitCatalogRead)
+		r.Get("/", categoryHandler.GetAll) // GET /api/v1/categories
+		r.Get("/tree", categoryHandler.GetTree)
+		r.Get("/{categoryName}", categoryHandler.GetByName)
+		r.Get("/{categoryName}/breadcrumbs", categoryHandler.GetBreadcrumbs)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(requireCatalogWrite)
+		r.Post("/", categoryHandler.Create)
+		r.Put("/{categoryId}", categoryHandler.Update)
+		r.Delete("/{categoryId}", categoryHandler.Delete)
+	})
 	return r
 }
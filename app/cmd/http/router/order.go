@@ -0,0 +1,21 @@
+package router
+
+import (
+	"net/http"
+
+	chi "github.com/go-chi/chi/v5"
+	"github.com/lucasti79/meli-interview/internal/order/api"
+)
+
+func buildOrdersRoutes(orderHandler *api.Handler) http.Handler {
+	r := chi.NewRouter()
+	r.Post("/", orderHandler.Create) // POST /api/v1/orders
+	r.With(requireUser).Get("/{orderId}", orderHandler.GetByID)
+
+	r.Group(func(r chi.Router) {
+		r.Use(requireAdmin)
+		r.Get("/", orderHandler.GetAll)
+		r.Put("/{orderId}/status", orderHandler.UpdateStatus)
+	})
+	return r
+}
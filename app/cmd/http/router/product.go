@@ -11,8 +11,24 @@ func buildProductsRoutes(productHandler *api.Handler) http.Handler {
 
 	r := chi.NewRouter()
 
-	r.Get("/", productHandler.GetAll)
-	r.Get("/{productId}", productHandler.GetByID)
+	r.Group(func(r chi.Router) {
+		r.Use(limitCatalogRead)
+		r.Get("/", productHandler.GetAll)
+		r.Get("/search", productHandler.Search)
+		r.Get("/{productId}", productHandler.GetByID)
+		r.Get("/{productId}/stock", productHandler.GetStock)
+	})
+	r.With(requireExport).Get("/export", productHandler.Export)
+
+	r.Group(func(r chi.Router) {
+		r.Use(requireCatalogWrite)
+		r.Post("/import", productHandler.Import)
+		r.Post("/{productId}/stock", productHandler.AdjustStock)
+		r.Post("/", productHandler.Create)
+		r.Put("/{productId}", productHandler.Update)
+		r.Patch("/{productId}", productHandler.Patch)
+		r.Delete("/{productId}", productHandler.Delete)
+	})
 
 	return r
 }
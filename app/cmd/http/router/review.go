@@ -0,0 +1,16 @@
+package router
+
+import (
+	"net/http"
+
+	chi "github.com/go-chi/chi/v5"
+	"github.com/lucasti79/meli-interview/internal/review/api"
+)
+
+func buildReviewsRoutes(reviewHandler *api.Handler) http.Handler {
+	r := chi.NewRouter()
+	r.With(limitCatalogRead).Get("/", reviewHandler.GetAll) // GET /api/v1/products/{productId}/reviews
+	r.With(requireUser).Post("/", reviewHandler.Create)
+	r.With(requireAdmin).Delete("/{reviewId}", reviewHandler.Delete)
+	return r
+}
@@ -9,7 +9,28 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	"github.com/lucasti79/meli-interview/config"
+	"github.com/lucasti79/meli-interview/internal/apikey"
+	ApiKeyApi "github.com/lucasti79/meli-interview/internal/apikey/api"
+	"github.com/lucasti79/meli-interview/internal/auth"
 	"github.com/lucasti79/meli-interview/internal/factory"
+	"github.com/lucasti79/meli-interview/internal/ratelimit"
+)
+
+var (
+	// requireAdmin guards the endpoints that manage orders, reviews and API
+	// keys. The shopper's own carts stay public.
+	requireAdmin = auth.RequireRole(auth.RoleAdmin)
+	// requireUser guards the endpoints that act for a signed-in user: writing
+	// reviews and reading orders.
+	requireUser = auth.RequireUser
+	// requireCatalogWrite guards the endpoints that change the catalog, for
+	// admins and the API keys granted catalog:write.
+	requireCatalogWrite = auth.RequireScope(apikey.ScopeCatalogWrite)
+	// requireExport guards the export of the catalog.
+	requireExport = auth.RequireScope(apikey.ScopeExport)
+	// limitCatalogRead keeps reads of the catalog public, but turns away the
+	// API keys not granted catalog:read.
+	limitCatalogRead = auth.LimitScope(apikey.ScopeCatalogRead)
 )
 
 type router struct {
@@ -24,19 +45,28 @@ func (router *router) MapRoutes(appFactory *factory.AppFactory) http.Handler {
 		allowedOrigins = strings.Split(origins, ",")
 	}
 
+	// behind a reverse proxy every request comes from the proxy, so clients
+	// are told apart by the headers it sets
+	if router.cfg.RateLimit.TrustProxy {
+		r.Use(middleware.RealIP)
+	}
 	r.Use(
 		middleware.Logger,
 		middleware.Recoverer,
 		middleware.StripSlashes,
-		middleware.Timeout(router.cfg.Server.TimeoutRead),
-		middleware.Heartbeat("/ping"),
 	)
+	// the storage layer honors the request context, so a zero timeout must
+	// not be turned into an already expired deadline
+	if router.cfg.Server.TimeoutRead > 0 {
+		r.Use(middleware.Timeout(router.cfg.Server.TimeoutRead))
+	}
+	r.Use(middleware.Heartbeat("/ping"))
 
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   allowedOrigins,
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", ApiKeyApi.APIKeyHeader},
+		ExposedHeaders:   []string{"Link", "Content-Disposition", "Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -44,18 +74,66 @@ func (router *router) MapRoutes(appFactory *factory.AppFactory) http.Handler {
 	r.Mount("/", buildDocsRoutes())
 
 	r.Route("/api/v1", func(rp chi.Router) {
+		// failed authentications are answered before the limits of the route
+		// groups are reached, so they are counted per IP in front of them
+		if limit := router.cfg.RateLimit.For("auth"); limit.Rate > 0 {
+			rp.Use(ratelimit.NewLimiter(limit).LimitFailures)
+		}
+		if appFactory.Authenticator != nil {
+			rp.Use(appFactory.Authenticator.Authenticate)
+		}
+		// API keys are read after tokens, so a request cannot carry both
+		if appFactory.APIKeyHandler != nil {
+			rp.Use(appFactory.APIKeyHandler.Authenticate)
+		}
+
 		rp.Route("/products", func(rp chi.Router) {
+			router.limit(rp, "products")
+			rp.Mount("/{productId}/reviews", buildReviewsRoutes(appFactory.ReviewHandler))
 			rp.Mount("/", buildProductsRoutes(appFactory.ProductHandler))
 		})
 
 		rp.Route("/categories", func(rp chi.Router) {
+			router.limit(rp, "categories")
 			rp.Mount("/", buildCategoriesRoutes(appFactory.CategoryHandler))
 		})
+
+		rp.Route("/carts", func(rp chi.Router) {
+			router.limit(rp, "carts")
+			rp.Mount("/", buildCartsRoutes(appFactory.CartHandler))
+		})
+
+		rp.Route("/orders", func(rp chi.Router) {
+			router.limit(rp, "orders")
+			rp.Mount("/", buildOrdersRoutes(appFactory.OrderHandler))
+		})
+
+		if appFactory.APIKeyHandler != nil {
+			rp.Route("/apikeys", func(rp chi.Router) {
+				router.limit(rp, "apikeys")
+				rp.Mount("/", buildAPIKeysRoutes(appFactory.APIKeyHandler))
+			})
+		}
+
+		rp.Route("/status", func(rp chi.Router) {
+			router.limit(rp, "status")
+			rp.Mount("/", buildStatusRoutes(appFactory.StatusHandler))
+		})
 	})
 
 	return r
 }
 
-func NewRouter() *router {
-	return &router{}
+// limit counts the requests to a route group against the limit of the group,
+// per API key or IP. It runs after authentication, so requests with an API
+// key are counted against the key; the ones that fail to authenticate are
+// counted by the auth group instead.
+func (router *router) limit(r chi.Router, group string) {
+	if limit := router.cfg.RateLimit.For(group); limit.Rate > 0 {
+		r.Use(ratelimit.NewLimiter(limit).Limit)
+	}
+}
+
+func NewRouter(cfg *config.Config) *router {
+	return &router{cfg: *cfg}
 }
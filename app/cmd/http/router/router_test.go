@@ -1,18 +1,62 @@
 package router_test
 
 import (
+	"encoding/json"
+	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/lucasti79/meli-interview/cmd/http/router"
+	"github.com/lucasti79/meli-interview/config"
+	ApiKeyApi "github.com/lucasti79/meli-interview/internal/apikey/api"
+	ApiKeyJsonRepository "github.com/lucasti79/meli-interview/internal/apikey/infra/jsonstore"
+	"github.com/lucasti79/meli-interview/internal/auth"
+	CartJsonRepository "github.com/lucasti79/meli-interview/internal/cart/infra/jsonstore"
 	"github.com/lucasti79/meli-interview/internal/factory"
+	OrderJsonRepository "github.com/lucasti79/meli-interview/internal/order/infra/jsonstore"
+	ProductJsonRepository "github.com/lucasti79/meli-interview/internal/product/infra/jsonstore"
+	ReviewJsonRepository "github.com/lucasti79/meli-interview/internal/review/infra/jsonstore"
+	StatusApi "github.com/lucasti79/meli-interview/internal/status/api"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+const testSecret = "test-secret"
+
+func authenticator(t *testing.T) *auth.Authenticator {
+	t.Helper()
+	a, err := auth.NewAuthenticator(config.AuthConfig{Algorithm: config.AlgorithmHS256, Secret: testSecret})
+	require.NoError(t, err)
+	return a
+}
+
+// bearer returns the Authorization header of a token of the user u1 with
+// roles.
+func bearer(t *testing.T, roles ...string) string {
+	t.Helper()
+	return bearerFor(t, "u1", roles...)
+}
+
+// bearerFor returns the Authorization header of a token of subject with roles.
+func bearerFor(t *testing.T, subject string, roles ...string) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: subject, ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+		Roles:            roles,
+	}).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return "Bearer " + token
+}
+
 func TestRouterMounts(t *testing.T) {
 	factory := &factory.AppFactory{}
 
-	r := router.NewRouter().MapRoutes(factory)
+	r := router.NewRouter(&config.Config{}).MapRoutes(factory)
 
 	req := httptest.NewRequest("GET", "/api/v1/products", nil)
 	resp := httptest.NewRecorder()
@@ -22,3 +66,439 @@ func TestRouterMounts(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.Contains(t, resp.Body.String(), "")
 }
+
+func TestRouterServesProductsWithAndWithoutReadTimeout(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "products.jsonl")
+	require.NoError(t, os.WriteFile(fp, []byte(`{"productId":"p1","name":"Phone","price":10,"category":"Electronics"}`+"\n"), 0o600))
+
+	repo, err := ProductJsonRepository.NewProductRepository(fp)
+	require.NoError(t, err)
+	handler, err := factory.NewProductHandler(repo, nil)
+	require.NoError(t, err)
+
+	for _, timeout := range []time.Duration{0, 5 * time.Second} {
+		cfg := &config.Config{Server: config.ServerConfig{TimeoutRead: timeout}}
+		r := router.NewRouter(cfg).MapRoutes(&factory.AppFactory{ProductHandler: handler})
+
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, httptest.NewRequest("GET", "/api/v1/products", nil))
+
+		assert.Equal(t, http.StatusOK, resp.Code, "timeout %s", timeout)
+		assert.Contains(t, resp.Body.String(), `"productId":"p1"`)
+	}
+}
+
+func TestRouterServesCartsPricedFromProducts(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "products.jsonl")
+	require.NoError(t, os.WriteFile(fp, []byte(`{"productId":"p1","name":"Phone","price":10,"category":"Electronics","inStock":true}`+"\n"), 0o600))
+
+	products, err := ProductJsonRepository.NewProductRepository(fp)
+	require.NoError(t, err)
+	carts, err := CartJsonRepository.NewCartRepository(filepath.Join(dir, "carts.jsonl"))
+	require.NoError(t, err)
+	handler, err := factory.NewCartHandler(carts, products)
+	require.NoError(t, err)
+	r := router.NewRouter(&config.Config{}).MapRoutes(&factory.AppFactory{CartHandler: handler})
+
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest("POST", "/api/v1/carts", nil))
+	require.Equal(t, http.StatusCreated, resp.Code)
+	location := resp.Header().Get("Location")
+
+	req := httptest.NewRequest("POST", location+"/items", strings.NewReader(`{"productId":"p1","quantity":3}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest("GET", location+"/totals", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"subtotal":30`)
+	assert.Contains(t, resp.Body.String(), `"status":"available"`)
+}
+
+func TestRouterPlacesOrdersOnceTakingStock(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "products.jsonl")
+	require.NoError(t, os.WriteFile(fp, []byte(`{"productId":"p1","name":"Phone","price":10,"category":"Electronics","inStock":true,"stock":3}`+"\n"), 0o600))
+
+	products, err := ProductJsonRepository.NewProductRepository(fp)
+	require.NoError(t, err)
+	orders, err := OrderJsonRepository.NewOrderRepository(filepath.Join(dir, "orders.jsonl"))
+	require.NoError(t, err)
+	handler, err := factory.NewOrderHandler(orders, products, nil)
+	require.NoError(t, err)
+	r := router.NewRouter(&config.Config{}).MapRoutes(&factory.AppFactory{OrderHandler: handler, Authenticator: authenticator(t)})
+
+	place := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/v1/orders", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", key)
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+		return resp
+	}
+
+	resp := place("k1", `{"items":[{"productId":"p1","quantity":2}]}`)
+	require.Equal(t, http.StatusCreated, resp.Code, resp.Body.String())
+	location := resp.Header().Get("Location")
+
+	// the retry returns the same order without taking stock again
+	resp = place("k1", `{"items":[{"productId":"p1","quantity":2}]}`)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	assert.Equal(t, location, resp.Header().Get("Location"))
+
+	resp = place("k2", `{"items":[{"productId":"p1","quantity":2}]}`)
+	require.Equal(t, http.StatusConflict, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"code":"product/not-available"`)
+
+	// units adding up past the cap would wrap around and add stock
+	resp = place("k3", `{"items":[{"productId":"p1","quantity":6000},{"productId":"p1","quantity":6000}]}`)
+	require.Equal(t, http.StatusBadRequest, resp.Code, resp.Body.String())
+
+	p, err := products.GetByID("p1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, *p.Stock)
+
+	req := httptest.NewRequest("PUT", location+"/status", strings.NewReader(`{"status":"cancelled"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", bearer(t, auth.RoleAdmin))
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	p, err = products.GetByID("p1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, *p.Stock)
+
+	req = httptest.NewRequest("GET", "/api/v1/orders?status=cancelled", nil)
+	req.Header.Set("Authorization", bearer(t, auth.RoleAdmin))
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"totalCount":1`)
+	assert.Contains(t, resp.Body.String(), `"unitPrice":10`)
+}
+
+func TestRouterReadsOrdersOnlyForTheirUser(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "products.jsonl")
+	require.NoError(t, os.WriteFile(fp, []byte(`{"productId":"p1","name":"Phone","price":10,"category":"Electronics","inStock":true,"stock":3}`+"\n"), 0o600))
+
+	products, err := ProductJsonRepository.NewProductRepository(fp)
+	require.NoError(t, err)
+	orders, err := OrderJsonRepository.NewOrderRepository(filepath.Join(dir, "orders.jsonl"))
+	require.NoError(t, err)
+	handler, err := factory.NewOrderHandler(orders, products, nil)
+	require.NoError(t, err)
+	r := router.NewRouter(&config.Config{}).MapRoutes(&factory.AppFactory{OrderHandler: handler, Authenticator: authenticator(t)})
+
+	req := httptest.NewRequest("POST", "/api/v1/orders", strings.NewReader(`{"items":[{"productId":"p1","quantity":1}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", "k1")
+	req.Header.Set("Authorization", bearer(t))
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusCreated, resp.Code, resp.Body.String())
+	location := resp.Header().Get("Location")
+
+	tests := []struct {
+		name          string
+		authorization string
+		status        int
+	}{
+		{name: "anonymous", status: http.StatusUnauthorized},
+		{name: "owner", authorization: bearer(t), status: http.StatusOK},
+		{name: "other user", authorization: bearerFor(t, "u2"), status: http.StatusNotFound},
+		{name: "admin", authorization: bearerFor(t, "u3", auth.RoleAdmin), status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", location, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			resp := httptest.NewRecorder()
+			r.ServeHTTP(resp, req)
+			assert.Equal(t, tt.status, resp.Code, resp.Body.String())
+		})
+	}
+}
+
+func TestRouterServesReviewsNextToTheirProduct(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "products.jsonl")
+	require.NoError(t, os.WriteFile(fp, []byte(`{"productId":"p1","name":"Phone","price":10,"category":"Electronics","rating":4,"reviews":1}`+"\n"), 0o600))
+
+	products, err := ProductJsonRepository.NewProductRepository(fp)
+	require.NoError(t, err)
+	productHandler, err := factory.NewProductHandler(products, nil)
+	require.NoError(t, err)
+	reviews, err := ReviewJsonRepository.NewReviewRepository(filepath.Join(dir, "reviews.jsonl"))
+	require.NoError(t, err)
+	reviewHandler, err := factory.NewReviewHandler(reviews, products)
+	require.NoError(t, err)
+	r := router.NewRouter(&config.Config{}).MapRoutes(&factory.AppFactory{ProductHandler: productHandler, ReviewHandler: reviewHandler, Authenticator: authenticator(t)})
+
+	post := func(authorization, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/v1/products/p1/reviews", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+		return resp
+	}
+
+	resp := post("", `{"author":"Ana","stars":5,"title":"Great"}`)
+	require.Equal(t, http.StatusUnauthorized, resp.Code)
+
+	// the author is the user of the token, not whoever the body names
+	resp = post(bearer(t), `{"author":"Ana","stars":5,"title":"Great"}`)
+	require.Equal(t, http.StatusForbidden, resp.Code, resp.Body.String())
+
+	resp = post(bearer(t), `{"stars":5,"title":"Great"}`)
+	require.Equal(t, http.StatusCreated, resp.Code, resp.Body.String())
+	location := resp.Header().Get("Location")
+
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest("GET", "/api/v1/products/p1", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"rating":4.5`)
+	assert.Contains(t, resp.Body.String(), `"reviews":2`)
+
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest("GET", "/api/v1/products/p1/reviews?sort=-stars", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"author":"u1"`)
+	assert.Contains(t, resp.Body.String(), `"userId":"u1"`)
+
+	req := httptest.NewRequest("DELETE", location, nil)
+	req.Header.Set("Authorization", bearer(t, auth.RoleAdmin))
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusNoContent, resp.Code, resp.Body.String())
+
+	p, err := products.GetByID("p1")
+	require.NoError(t, err)
+	assert.Equal(t, 4.0, *p.Rating)
+	assert.Equal(t, 1, p.Reviews)
+}
+
+func TestRouterRequiresAdminForCatalogWrites(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "products.jsonl")
+	require.NoError(t, os.WriteFile(fp, []byte(`{"productId":"p1","name":"Phone","price":10,"category":"Electronics"}`+"\n"), 0o600))
+
+	repo, err := ProductJsonRepository.NewProductRepository(fp)
+	require.NoError(t, err)
+	handler, err := factory.NewProductHandler(repo, nil)
+	require.NoError(t, err)
+	r := router.NewRouter(&config.Config{}).MapRoutes(&factory.AppFactory{ProductHandler: handler, Authenticator: authenticator(t)})
+
+	expired, err := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute))},
+		Roles:            []string{auth.RoleAdmin},
+	}).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		method        string
+		authorization string
+		status        int
+		code          string
+	}{
+		{name: "public read", method: "GET", status: http.StatusOK},
+		{name: "public read with a token", method: "GET", authorization: bearer(t), status: http.StatusOK},
+		{name: "public read with a forged token", method: "GET", authorization: bearer(t) + "x", status: http.StatusUnauthorized, code: "unauthorized"},
+		{name: "write without a token", method: "DELETE", status: http.StatusUnauthorized, code: "unauthorized"},
+		{name: "write with an expired token", method: "DELETE", authorization: "Bearer " + expired, status: http.StatusUnauthorized, code: "unauthorized"},
+		{name: "write with a malformed header", method: "DELETE", authorization: "Basic dTE6cHc=", status: http.StatusUnauthorized, code: "unauthorized"},
+		{name: "write without the admin role", method: "DELETE", authorization: bearer(t, "customer"), status: http.StatusForbidden, code: "forbidden"},
+		// runs last: p1 is deleted
+		{name: "write as admin", method: "DELETE", authorization: bearer(t, auth.RoleAdmin), status: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/v1/products/p1", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			resp := httptest.NewRecorder()
+			r.ServeHTTP(resp, req)
+
+			require.Equal(t, tt.status, resp.Code, resp.Body.String())
+			if tt.code != "" {
+				assert.Contains(t, resp.Body.String(), `"code":"`+tt.code+`"`)
+			}
+		})
+	}
+}
+
+func TestRouterAuthenticatesPartnersWithAPIKeys(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "products.jsonl")
+	require.NoError(t, os.WriteFile(fp, []byte(`{"productId":"p1","name":"Phone","price":10,"category":"Electronics"}`+"\n"), 0o600))
+
+	repo, err := ProductJsonRepository.NewProductRepository(fp)
+	require.NoError(t, err)
+	productHandler, err := factory.NewProductHandler(repo, nil)
+	require.NoError(t, err)
+	keys, err := ApiKeyJsonRepository.NewAPIKeyRepository(filepath.Join(dir, "apikeys.jsonl"))
+	require.NoError(t, err)
+	apiKeyHandler, err := factory.NewAPIKeyHandler(keys)
+	require.NoError(t, err)
+	r := router.NewRouter(&config.Config{}).MapRoutes(&factory.AppFactory{ProductHandler: productHandler, APIKeyHandler: apiKeyHandler, Authenticator: authenticator(t)})
+
+	do := func(method, target, authorization, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		if key != "" {
+			req.Header.Set(ApiKeyApi.APIKeyHeader, key)
+		}
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+		return resp
+	}
+	create := func(scopes string) ApiKeyApi.APIKeyCreatedResult {
+		resp := do("POST", "/api/v1/apikeys", bearer(t, auth.RoleAdmin), "", `{"name":"Partner","scopes":`+scopes+`}`)
+		require.Equal(t, http.StatusCreated, resp.Code, resp.Body.String())
+		var created ApiKeyApi.APIKeyCreatedResult
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &created))
+		return created
+	}
+
+	reader := create(`["catalog:read","catalog:export"]`)
+	writer := create(`["catalog:write"]`)
+
+	// only admins manage keys
+	require.Equal(t, http.StatusUnauthorized, do("POST", "/api/v1/apikeys", "", "", `{"name":"Partner","scopes":["catalog:read"]}`).Code)
+	require.Equal(t, http.StatusForbidden, do("GET", "/api/v1/apikeys", "", writer.Key, "").Code)
+
+	require.Equal(t, http.StatusOK, do("GET", "/api/v1/products/p1", "", reader.Key, "").Code)
+	require.Equal(t, http.StatusOK, do("GET", "/api/v1/products/export", "", reader.Key, "").Code)
+	require.Equal(t, http.StatusUnauthorized, do("GET", "/api/v1/products/export", "", "", "").Code)
+	require.Equal(t, http.StatusForbidden, do("DELETE", "/api/v1/products/p1", "", reader.Key, "").Code)
+	require.Equal(t, http.StatusForbidden, do("GET", "/api/v1/products/p1", "", writer.Key, "").Code)
+	require.Equal(t, http.StatusUnauthorized, do("GET", "/api/v1/products/p1", "", "mk_unknown", "").Code)
+	require.Equal(t, http.StatusUnauthorized, do("GET", "/api/v1/products/p1", bearer(t), reader.Key, "").Code)
+
+	resp := do("GET", "/api/v1/apikeys/"+reader.Data.Id, bearer(t, auth.RoleAdmin), "", "")
+	require.Equal(t, http.StatusOK, resp.Code)
+	var got ApiKeyApi.APIKeyResult
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &got))
+	require.NotNil(t, got.Data.LastUsedAt)
+	assert.NotContains(t, resp.Body.String(), reader.Key)
+
+	require.Equal(t, http.StatusNoContent, do("DELETE", "/api/v1/apikeys/"+reader.Data.Id, bearer(t, auth.RoleAdmin), "", "").Code)
+	resp = do("GET", "/api/v1/products/p1", "", reader.Key, "")
+	require.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"code":"unauthorized"`)
+
+	require.Equal(t, http.StatusNoContent, do("DELETE", "/api/v1/products/p1", "", writer.Key, "").Code)
+}
+
+func TestRouterRateLimitsFailedAuthentications(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "products.jsonl")
+	require.NoError(t, os.WriteFile(fp, []byte(`{"productId":"p1","name":"Phone","price":10,"category":"Electronics"}`+"\n"), 0o600))
+
+	repo, err := ProductJsonRepository.NewProductRepository(fp)
+	require.NoError(t, err)
+	handler, err := factory.NewProductHandler(repo, nil)
+	require.NoError(t, err)
+	// the products group is not limited, so only failures are counted
+	cfg := &config.Config{RateLimit: config.RateLimitConfig{
+		Groups: map[string]config.RateLimit{"auth": {Rate: 1, Burst: 3}},
+	}}
+	r := router.NewRouter(cfg).MapRoutes(&factory.AppFactory{ProductHandler: handler, Authenticator: authenticator(t)})
+
+	get := func(ip, authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/v1/products/p1", nil)
+		req.RemoteAddr = ip + ":5123"
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+		return resp
+	}
+
+	for range 5 {
+		require.Equal(t, http.StatusOK, get("203.0.113.7", bearer(t)).Code)
+	}
+	for range 3 {
+		require.Equal(t, http.StatusUnauthorized, get("203.0.113.7", "Bearer not-a-token").Code)
+	}
+	limited := get("203.0.113.7", "Bearer not-a-token")
+	require.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Contains(t, limited.Body.String(), `"code":"too many requests"`)
+	require.Equal(t, http.StatusTooManyRequests, get("203.0.113.7", bearer(t)).Code)
+
+	require.Equal(t, http.StatusOK, get("203.0.113.8", bearer(t)).Code, "other clients are not turned away")
+}
+
+func TestRouterRateLimitsEachClient(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "products.jsonl")
+	require.NoError(t, os.WriteFile(fp, []byte(`{"productId":"p1","name":"Phone","price":10,"category":"Electronics"}`+"\n"), 0o600))
+
+	repo, err := ProductJsonRepository.NewProductRepository(fp)
+	require.NoError(t, err)
+	handler, err := factory.NewProductHandler(repo, nil)
+	require.NoError(t, err)
+	cfg := &config.Config{RateLimit: config.RateLimitConfig{
+		Groups: map[string]config.RateLimit{"products": {Rate: 1, Burst: 2}},
+	}}
+	statusHandler := StatusApi.NewHandler(nil)
+	r := router.NewRouter(cfg).MapRoutes(&factory.AppFactory{ProductHandler: handler, StatusHandler: statusHandler})
+
+	get := func(ip, forwardedFor, target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", target, nil)
+		req.RemoteAddr = ip + ":5123"
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		resp := httptest.NewRecorder()
+		r.ServeHTTP(resp, req)
+		return resp
+	}
+
+	first := get("203.0.113.7", "", "/api/v1/products/p1")
+	require.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2", first.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("X-RateLimit-Remaining"))
+	require.Equal(t, http.StatusOK, get("203.0.113.7", "", "/api/v1/products/p1").Code)
+
+	limited := get("203.0.113.7", "", "/api/v1/products/p1")
+	require.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "1", limited.Header().Get("Retry-After"))
+	assert.Equal(t, "0", limited.Header().Get("X-RateLimit-Remaining"))
+	assert.Contains(t, limited.Body.String(), `"code":"too many requests"`)
+
+	// the forwarded address is not trusted unless the config says so
+	require.Equal(t, http.StatusTooManyRequests, get("203.0.113.7", "198.51.100.1", "/api/v1/products/p1").Code)
+	require.Equal(t, http.StatusOK, get("203.0.113.8", "", "/api/v1/products/p1").Code)
+
+	// groups without a limit are not limited
+	for range 3 {
+		resp := get("203.0.113.7", "", "/api/v1/status")
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.Empty(t, resp.Header().Get("X-RateLimit-Limit"))
+	}
+
+	// behind a proxy clients are told apart by the address it forwards
+	cfg.RateLimit.TrustProxy = true
+	r = router.NewRouter(cfg).MapRoutes(&factory.AppFactory{ProductHandler: handler})
+	for range 2 {
+		require.Equal(t, http.StatusOK, get("10.0.0.1", "198.51.100.1", "/api/v1/products/p1").Code)
+	}
+	require.Equal(t, http.StatusTooManyRequests, get("10.0.0.1", "198.51.100.1", "/api/v1/products/p1").Code)
+	require.Equal(t, http.StatusOK, get("10.0.0.1", "198.51.100.2", "/api/v1/products/p1").Code)
+}
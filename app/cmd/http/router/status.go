@@ -0,0 +1,15 @@
+package router
+
+import (
+	"net/http"
+
+	chi "github.com/go-chi/chi/v5"
+	"github.com/lucasti79/meli-interview/internal/status/api"
+)
+
+func buildStatusRoutes(statusHandler *api.Handler) http.Handler {
+	r := chi.NewRouter()
+	r.Get("/", statusHandler.GetStatus)                                  // GET /api/v1/status
+	r.With(requireAdmin).Get("/{store}/report", statusHandler.GetReport) // GET /api/v1/status/{store}/report
+	return r
+}
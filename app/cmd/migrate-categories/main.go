@@ -0,0 +1,43 @@
+// Command migrate-categories seeds the categories store from the distinct
+// categories found in the products file. Categories that already exist are
+// left untouched, so it is safe to run more than once.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	CategoryJsonRepository "github.com/lucasti79/meli-interview/internal/category/infra/jsonstore"
+	ProductJsonRepository "github.com/lucasti79/meli-interview/internal/product/infra/jsonstore"
+)
+
+func main() {
+	productsFile := flag.String("products", "products.jsonl", "products file to read categories from")
+	categoriesFile := flag.String("categories", "categories.jsonl", "categories file to seed")
+	dryRun := flag.Bool("dry-run", false, "print the categories that would be created without writing them")
+	flag.Parse()
+
+	products, err := ProductJsonRepository.NewProductStore(*productsFile)
+	if err != nil {
+		log.Fatalf("failed to open %s: %v", *productsFile, err)
+	}
+	categories, err := CategoryJsonRepository.NewCategoryRepository(*categoriesFile)
+	if err != nil {
+		log.Fatalf("failed to open %s: %v", *categoriesFile, err)
+	}
+
+	created, err := CategoryJsonRepository.SeedFromProducts(products, categories, *dryRun)
+	if err != nil {
+		log.Fatalf("failed to seed categories: %v", err)
+	}
+
+	for _, c := range created {
+		fmt.Printf("%s\t%s\t%s\n", c.Id, c.Slug, c.Name)
+	}
+	if *dryRun {
+		fmt.Printf("%d categories would be created\n", len(created))
+		return
+	}
+	fmt.Printf("%d categories created\n", len(created))
+}
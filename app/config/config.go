@@ -1,7 +1,12 @@
 package config
 
 import (
+	"fmt"
 	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -15,8 +20,94 @@ type ServerConfig struct {
 	TimeoutIdle  time.Duration
 }
 
+// Storage backends the catalog can be kept in.
+const (
+	StorageJSONL = "jsonl"
+	StorageBolt  = "bolt"
+)
+
+type CatalogConfig struct {
+	// Storage is the backend products and categories are kept in: jsonl or
+	// bolt.
+	Storage string
+	// BoltFile is the database file used by the bolt backend.
+	BoltFile string
+	// PriceBuckets are the ascending bounds of the price range facet.
+	PriceBuckets []float64
+	// WatchFiles reloads the catalog files when they change on disk. JSONL
+	// only.
+	WatchFiles bool
+	// Fsync is when writes to the catalog files are flushed to disk: always,
+	// interval or never. JSONL only; bolt flushes every write.
+	Fsync string
+	// FsyncInterval is how often writes are flushed when Fsync is interval.
+	FsyncInterval time.Duration
+}
+
+// Algorithms the API verifies JWTs with.
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+)
+
+type AuthConfig struct {
+	// Algorithm tokens are signed with: HS256 or RS256.
+	Algorithm string
+	// Secret is the key HS256 tokens are signed with.
+	Secret string
+	// PublicKey is the PEM encoded key RS256 tokens are verified with, or
+	// PublicKeyFile the file holding it.
+	PublicKey     string
+	PublicKeyFile string
+	// Issuer and Audience, when set, must be the iss and aud of tokens.
+	Issuer   string
+	Audience string
+}
+
+// String hides the secret, so the configuration can be logged.
+func (c AuthConfig) String() string {
+	secret := ""
+	if c.Secret != "" {
+		secret = "[redacted]"
+	}
+	return fmt.Sprintf("{Algorithm:%s Secret:%s PublicKeyFile:%s Issuer:%s Audience:%s}", c.Algorithm, secret, c.PublicKeyFile, c.Issuer, c.Audience)
+}
+
+// RateLimit lets a client send Burst requests at once, and Rate requests a
+// second after that. A Rate of 0 does not limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimitGroups are the route groups limited separately, each read from
+// RATE_LIMIT_<GROUP>. The auth group limits the requests of a client that
+// fail to authenticate, whatever route they are sent to.
+var RateLimitGroups = []string{"products", "categories", "carts", "orders", "apikeys", "status", "auth"}
+
+type RateLimitConfig struct {
+	// TrustProxy reads the IP of clients from the X-Forwarded-For and
+	// X-Real-IP headers, for servers behind a reverse proxy.
+	TrustProxy bool
+	// Default is the limit of the route groups missing from Groups.
+	Default RateLimit
+	// Groups are the limits of route groups by name.
+	Groups map[string]RateLimit
+}
+
+// For returns the limit of a route group.
+func (c RateLimitConfig) For(group string) RateLimit {
+	if limit, ok := c.Groups[group]; ok {
+		return limit
+	}
+	return c.Default
+}
+
 type Config struct {
-	Server ServerConfig
+	Server    ServerConfig
+	Catalog   CatalogConfig
+	Auth      AuthConfig
+	RateLimit RateLimitConfig
 }
 
 func LoadConfig() *Config {
@@ -25,12 +116,23 @@ func LoadConfig() *Config {
 	viper.SetDefault("SERVER_TIMEOUT_READ", 5)
 	viper.SetDefault("SERVER_TIMEOUT_WRITE", 5)
 	viper.SetDefault("SERVER_TIMEOUT_IDLE", 5)
+	viper.SetDefault("CATALOG_STORAGE", StorageJSONL)
+	viper.SetDefault("CATALOG_BOLT_FILE", "catalog.db")
+	viper.SetDefault("CATALOG_PRICE_BUCKETS", "50,100,250,500,1000")
+	viper.SetDefault("CATALOG_WATCH_FILES", true)
+	viper.SetDefault("CATALOG_FSYNC", "always")
+	viper.SetDefault("CATALOG_FSYNC_INTERVAL", 1)
+	viper.SetDefault("AUTH_JWT_ALGORITHM", AlgorithmHS256)
+	viper.SetDefault("RATE_LIMIT_DEFAULT", "20:40")
+	viper.SetDefault("RATE_LIMIT_PRODUCTS", "10:20")
+	viper.SetDefault("RATE_LIMIT_AUTH", "1:10")
 
 	viper.AutomaticEnv()
 
 	timeoutRead := viper.GetInt("SERVER_TIMEOUT_READ")
 	timeoutWrite := viper.GetInt("SERVER_TIMEOUT_WRITE")
 	timeoutIdle := viper.GetInt("SERVER_TIMEOUT_IDLE")
+	fsyncInterval := viper.GetInt("CATALOG_FSYNC_INTERVAL")
 
 	cfg := &Config{
 		Server: ServerConfig{
@@ -40,8 +142,74 @@ func LoadConfig() *Config {
 			TimeoutWrite: time.Duration(timeoutWrite) * time.Second,
 			TimeoutIdle:  time.Duration(timeoutIdle) * time.Second,
 		},
+		Catalog: CatalogConfig{
+			Storage:       viper.GetString("CATALOG_STORAGE"),
+			BoltFile:      viper.GetString("CATALOG_BOLT_FILE"),
+			PriceBuckets:  parseBuckets(viper.GetString("CATALOG_PRICE_BUCKETS")),
+			WatchFiles:    viper.GetBool("CATALOG_WATCH_FILES"),
+			Fsync:         viper.GetString("CATALOG_FSYNC"),
+			FsyncInterval: time.Duration(fsyncInterval) * time.Second,
+		},
+		Auth: AuthConfig{
+			Algorithm:     viper.GetString("AUTH_JWT_ALGORITHM"),
+			Secret:        viper.GetString("AUTH_JWT_SECRET"),
+			PublicKey:     viper.GetString("AUTH_JWT_PUBLIC_KEY"),
+			PublicKeyFile: viper.GetString("AUTH_JWT_PUBLIC_KEY_FILE"),
+			Issuer:        viper.GetString("AUTH_JWT_ISSUER"),
+			Audience:      viper.GetString("AUTH_JWT_AUDIENCE"),
+		},
+		RateLimit: RateLimitConfig{
+			TrustProxy: viper.GetBool("RATE_LIMIT_TRUST_PROXY"),
+			Default:    parseRateLimit(viper.GetString("RATE_LIMIT_DEFAULT")),
+			Groups:     map[string]RateLimit{},
+		},
+	}
+	for _, group := range RateLimitGroups {
+		if value := viper.GetString("RATE_LIMIT_" + strings.ToUpper(group)); value != "" {
+			cfg.RateLimit.Groups[group] = parseRateLimit(value)
+		}
 	}
 
 	log.Printf("Config loaded: %+v\n", cfg)
 	return cfg
 }
+
+// parseBuckets reads a comma separated list of bounds, skipping invalid ones.
+func parseBuckets(value string) []float64 {
+	var buckets []float64
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		bound, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			log.Printf("ignoring invalid price bucket %q: %v", field, err)
+			continue
+		}
+		buckets = append(buckets, bound)
+	}
+	sort.Float64s(buckets)
+	return buckets
+}
+
+// parseRateLimit reads a limit written as rate:burst, e.g. 10:20 for 10
+// requests a second in bursts of up to 20. The burst defaults to the rate.
+// Invalid limits and 0 do not limit.
+func parseRateLimit(value string) RateLimit {
+	rate, burst, hasBurst := strings.Cut(strings.TrimSpace(value), ":")
+	var limit RateLimit
+	var err error
+	if limit.Rate, err = strconv.ParseFloat(rate, 64); err != nil || limit.Rate < 0 {
+		log.Printf("ignoring invalid rate limit %q", value)
+		return RateLimit{}
+	}
+	limit.Burst = int(math.Ceil(limit.Rate))
+	if hasBurst {
+		if limit.Burst, err = strconv.Atoi(burst); err != nil || limit.Burst < 1 {
+			log.Printf("ignoring invalid rate limit %q", value)
+			return RateLimit{}
+		}
+	}
+	return limit
+}
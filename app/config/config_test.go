@@ -1,6 +1,7 @@
 package config_test
 
 import (
+	"fmt"
 	"os"
 	"testing"
 	"time"
@@ -23,6 +24,12 @@ func TestLoadConfig_Defaults(t *testing.T) {
 	require.Equal(t, 5*time.Second, cfg.Server.TimeoutRead)
 	require.Equal(t, 5*time.Second, cfg.Server.TimeoutWrite)
 	require.Equal(t, 5*time.Second, cfg.Server.TimeoutIdle)
+	require.Equal(t, config.StorageJSONL, cfg.Catalog.Storage)
+	require.Equal(t, "catalog.db", cfg.Catalog.BoltFile)
+	require.Equal(t, []float64{50, 100, 250, 500, 1000}, cfg.Catalog.PriceBuckets)
+	require.True(t, cfg.Catalog.WatchFiles)
+	require.Equal(t, "always", cfg.Catalog.Fsync)
+	require.Equal(t, time.Second, cfg.Catalog.FsyncInterval)
 }
 
 func TestLoadConfig_FromEnv(t *testing.T) {
@@ -48,3 +55,47 @@ func TestLoadConfig_FromEnv(t *testing.T) {
 	require.Equal(t, 15*time.Second, cfg.Server.TimeoutWrite)
 	require.Equal(t, 20*time.Second, cfg.Server.TimeoutIdle)
 }
+
+func TestLoadConfig_PriceBucketsAreSortedAndSkipInvalid(t *testing.T) {
+	os.Setenv("CATALOG_PRICE_BUCKETS", "200, 20,abc,100")
+	defer os.Unsetenv("CATALOG_PRICE_BUCKETS")
+
+	cfg := config.LoadConfig()
+
+	require.Equal(t, []float64{20, 100, 200}, cfg.Catalog.PriceBuckets)
+}
+
+func TestLoadConfig_AuthSecretIsNotPrinted(t *testing.T) {
+	os.Setenv("AUTH_JWT_SECRET", "s3cr3t")
+	os.Setenv("AUTH_JWT_ISSUER", "meli")
+	defer os.Unsetenv("AUTH_JWT_SECRET")
+	defer os.Unsetenv("AUTH_JWT_ISSUER")
+
+	cfg := config.LoadConfig()
+
+	require.Equal(t, config.AlgorithmHS256, cfg.Auth.Algorithm)
+	require.Equal(t, "s3cr3t", cfg.Auth.Secret)
+	require.Equal(t, "meli", cfg.Auth.Issuer)
+	require.NotContains(t, fmt.Sprintf("%+v", cfg), "s3cr3t")
+}
+
+func TestLoadConfig_RateLimits(t *testing.T) {
+	os.Setenv("RATE_LIMIT_PRODUCTS", "2.5:5")
+	os.Setenv("RATE_LIMIT_CARTS", "4")
+	os.Setenv("RATE_LIMIT_ORDERS", "0")
+	os.Setenv("RATE_LIMIT_STATUS", "fast:5")
+	defer os.Unsetenv("RATE_LIMIT_PRODUCTS")
+	defer os.Unsetenv("RATE_LIMIT_CARTS")
+	defer os.Unsetenv("RATE_LIMIT_ORDERS")
+	defer os.Unsetenv("RATE_LIMIT_STATUS")
+
+	cfg := config.LoadConfig()
+
+	require.False(t, cfg.RateLimit.TrustProxy)
+	require.Equal(t, config.RateLimit{Rate: 20, Burst: 40}, cfg.RateLimit.For("categories"))
+	require.Equal(t, config.RateLimit{Rate: 1, Burst: 10}, cfg.RateLimit.For("auth"))
+	require.Equal(t, config.RateLimit{Rate: 2.5, Burst: 5}, cfg.RateLimit.For("products"))
+	require.Equal(t, config.RateLimit{Rate: 4, Burst: 4}, cfg.RateLimit.For("carts"))
+	require.Zero(t, cfg.RateLimit.For("orders").Rate, "0 does not limit")
+	require.Zero(t, cfg.RateLimit.For("status").Rate, "invalid limits do not limit")
+}
@@ -0,0 +1,249 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator"
+	"github.com/lucasti79/meli-interview/internal/apikey"
+	"github.com/lucasti79/meli-interview/internal/apikey/service"
+	"github.com/lucasti79/meli-interview/internal/auth"
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/lucasti79/meli-interview/pkg/web/request"
+	"github.com/lucasti79/meli-interview/pkg/web/response"
+)
+
+// APIKeyHeader carries the API key partners call the API with.
+const APIKeyHeader = "X-API-Key"
+
+type Handler struct {
+	service   service.Service
+	validator *validator.Validate
+}
+
+func NewHandler(service service.Service) *Handler {
+	return &Handler{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// Authenticate puts the ID and scopes of the API key a request is sent with
+// in its context, as the claims of a token would be. Requests without a key
+// go on as they came; requests with a key that is unknown or revoked, or
+// with both a key and a token, are answered with 401.
+func (h *Handler) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(APIKeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if _, ok := auth.ClaimsFromContext(r.Context()); ok {
+			writeUnauthorized(w, "send either a Bearer token or an API key, not both")
+			return
+		}
+
+		k, err := h.service.AuthenticateWithContext(r.Context(), key)
+		if errors.Is(err, apperrors.ErrUnauthorized) {
+			writeUnauthorized(w, err.Error())
+			return
+		}
+		if err != nil {
+			writeInternalError(w, err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithClaims(r.Context(), auth.KeyClaims(k.Id, k.Scopes))))
+	})
+}
+
+// GetAll godoc
+// @Summary      List API keys
+// @Description  List the API keys newest first, with the time each was last used at. Keys themselves are never returned, only their last characters in hint
+// @Tags         API keys
+// @Produce      json
+// @Param        filters  query  apikey.KeyFilter  false  "API key filters"
+// @Success      200  {object}  APIKeyPaginatedResult
+// @Success      204  "No content"
+// @Failure      400  {object}  httpdto.ErrorResponse
+// @Failure      401  {object}  httpdto.ErrorResponse
+// @Failure      403  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Failure      504  {object}  httpdto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/apikeys [get]
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	filters := apikey.KeyFilter{
+		Page:     1,
+		PageSize: 10,
+	}
+	if active, err := strconv.ParseBool(r.URL.Query().Get("active")); err == nil {
+		filters.Active = active
+	}
+	if page := r.URL.Query().Get("page"); page != "" {
+		if p, err := strconv.Atoi(page); err == nil {
+			filters.Page = p
+		}
+	}
+	if size := r.URL.Query().Get("pageSize"); size != "" {
+		if s, err := strconv.Atoi(size); err == nil {
+			filters.PageSize = s
+		}
+	}
+
+	if err := h.validator.Struct(filters); err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    apperrors.ErrValidation.Error(),
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	keys, total, err := h.service.GetAllWithContext(r.Context(), filters)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	if len(keys) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	data := make([]APIKey, 0, len(keys))
+	for _, k := range keys {
+		data = append(data, toAPIKey(k))
+	}
+	response.JSON(w, http.StatusOK, APIKeyPaginatedResult{
+		Data:       data,
+		TotalCount: total,
+		Page:       filters.Page,
+		PageSize:   filters.PageSize,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get an API key
+// @Description  Get an API key with its scopes and the time it was last used at
+// @Tags         API keys
+// @Produce      json
+// @Param        keyId  path  string  true  "API key ID"
+// @Success      200  {object}  APIKeyResult
+// @Failure      401  {object}  httpdto.ErrorResponse
+// @Failure      403  {object}  httpdto.ErrorResponse
+// @Failure      404  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Failure      504  {object}  httpdto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/apikeys/{keyId} [get]
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	k, err := h.service.GetByIDWithContext(r.Context(), chi.URLParam(r, "keyId"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, APIKeyResult{Data: toAPIKey(*k)})
+}
+
+// Create godoc
+// @Summary      Create an API key
+// @Description  Create an API key for a partner with the scopes it is granted: catalog:read, catalog:write and catalog:export. The key is in the response and cannot be read again, only its hash is stored
+// @Tags         API keys
+// @Accept       json
+// @Produce      json
+// @Param        apikey  body  apikey.CreateRequest  true  "API key"
+// @Success      201  {object}  APIKeyCreatedResult
+// @Failure      400  {object}  httpdto.ErrorResponse
+// @Failure      401  {object}  httpdto.ErrorResponse
+// @Failure      403  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/apikeys [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var req apikey.CreateRequest
+	err := request.JSON(r, &req)
+	if err == nil {
+		err = h.validator.Struct(req)
+	}
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    apikey.ErrAPIKeyInvalidData,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	k, key, err := h.service.CreateWithContext(r.Context(), req)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", r.URL.Path+"/"+k.Id)
+	response.JSON(w, http.StatusCreated, APIKeyCreatedResult{Data: toAPIKey(*k), Key: key})
+}
+
+// Revoke godoc
+// @Summary      Revoke an API key
+// @Description  Stop an API key from being used. The key is still listed, with the time it was revoked at
+// @Tags         API keys
+// @Produce      json
+// @Param        keyId  path  string  true  "API key ID"
+// @Success      204  "No content"
+// @Failure      401  {object}  httpdto.ErrorResponse
+// @Failure      403  {object}  httpdto.ErrorResponse
+// @Failure      404  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/apikeys/{keyId} [delete]
+func (h *Handler) Revoke(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.service.RevokeWithContext(r.Context(), chi.URLParam(r, "keyId")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeError maps an error from the API key service to its response.
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrResourceNotExists):
+		response.JSON(w, http.StatusNotFound, httpdto.ErrorResponse{
+			Code:    apikey.ErrAPIKeyNotFound,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusNotFound),
+		})
+	default:
+		writeInternalError(w, err)
+	}
+}
+
+func writeUnauthorized(w http.ResponseWriter, message string) {
+	response.JSON(w, http.StatusUnauthorized, httpdto.ErrorResponse{
+		Code:    apperrors.ErrUnauthorized.Error(),
+		Message: message,
+		Status:  http.StatusText(http.StatusUnauthorized),
+	})
+}
+
+// writeInternalError answers an unexpected service error, telling apart the
+// requests whose context ended before the keys could be read or written.
+func writeInternalError(w http.ResponseWriter, err error) {
+	if status, body, ok := httpdto.ContextError(err); ok {
+		response.JSON(w, status, body)
+		return
+	}
+	response.JSON(w, http.StatusInternalServerError, httpdto.ErrorResponse{
+		Code:    apperrors.ErrInternalError.Error(),
+		Message: "internal server error",
+		Status:  http.StatusText(http.StatusInternalServerError),
+	})
+}
@@ -0,0 +1,192 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lucasti79/meli-interview/internal/apikey"
+	"github.com/lucasti79/meli-interview/internal/apikey/api"
+	"github.com/lucasti79/meli-interview/internal/apikey/infra/mocks"
+	"github.com/lucasti79/meli-interview/internal/apikey/service"
+	"github.com/lucasti79/meli-interview/internal/auth"
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/lucasti79/meli-interview/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func jsonRequest(t *testing.T, method, target, body string, params map[string]string) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return testutil.WithUrlParamst(t, req, params)
+}
+
+func errorCode(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	var body httpdto.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body.Code
+}
+
+func TestCreate_ReturnsTheKeyButNotItsHash(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	req := apikey.CreateRequest{Name: "Partner", Scopes: []string{apikey.ScopeCatalogRead, apikey.ScopeExport}}
+	mockService.On("CreateWithContext", mock.Anything, req).
+		Return(&apikey.APIKey{Id: "k1", Name: "Partner", Hash: "secret-hash", Hint: "abcd", Scopes: req.Scopes}, "mk_key", nil)
+
+	h := api.NewHandler(mockService)
+
+	rec := httptest.NewRecorder()
+	h.Create(rec, jsonRequest(t, http.MethodPost, "/api/v1/apikeys", `{"name":"Partner","scopes":["catalog:read","catalog:export"]}`, nil))
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Equal(t, "/api/v1/apikeys/k1", rec.Header().Get("Location"))
+	var body api.APIKeyCreatedResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "mk_key", body.Key)
+	assert.Equal(t, "abcd", body.Data.Hint)
+	assert.NotContains(t, rec.Body.String(), "secret-hash")
+	mockService.AssertExpectations(t)
+}
+
+func TestCreate_RejectsInvalidRequests(t *testing.T) {
+	bodies := map[string]string{
+		"malformed body": `{"name":`,
+		"missing name":   `{"scopes":["catalog:read"]}`,
+		"no scopes":      `{"name":"Partner","scopes":[]}`,
+		"unknown scope":  `{"name":"Partner","scopes":["catalog:delete"]}`,
+	}
+
+	for name, body := range bodies {
+		t.Run(name, func(t *testing.T) {
+			h := api.NewHandler(new(mocks.ServiceMock))
+
+			rec := httptest.NewRecorder()
+			h.Create(rec, jsonRequest(t, http.MethodPost, "/api/v1/apikeys", body, nil))
+
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+			require.Equal(t, apikey.ErrAPIKeyInvalidData, errorCode(t, rec))
+		})
+	}
+}
+
+func TestGetAll(t *testing.T) {
+	used := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetAllWithContext", mock.Anything, apikey.KeyFilter{Active: true, Page: 1, PageSize: 10}).
+		Return([]apikey.APIKey{{Id: "k1", Hash: "secret-hash", LastUsedAt: &used}}, 1, nil)
+
+	h := api.NewHandler(mockService)
+
+	rec := httptest.NewRecorder()
+	h.GetAll(rec, jsonRequest(t, http.MethodGet, "/api/v1/apikeys?active=true", "", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body api.APIKeyPaginatedResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, 1, body.TotalCount)
+	require.Equal(t, &used, body.Data[0].LastUsedAt)
+	assert.NotContains(t, rec.Body.String(), "secret-hash")
+}
+
+func TestRevoke(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "revoked", status: http.StatusNoContent},
+		{name: "unknown key", err: apperrors.ErrResourceNotExists, status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ServiceMock)
+			mockService.On("RevokeWithContext", mock.Anything, "k1").Return(&apikey.APIKey{Id: "k1"}, tt.err)
+
+			h := api.NewHandler(mockService)
+
+			rec := httptest.NewRecorder()
+			h.Revoke(rec, jsonRequest(t, http.MethodDelete, "/api/v1/apikeys/k1", "", map[string]string{"keyId": "k1"}))
+
+			require.Equal(t, tt.status, rec.Code)
+			if tt.err != nil {
+				require.Equal(t, apikey.ErrAPIKeyNotFound, errorCode(t, rec))
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    string
+		token  bool
+		err    error
+		status int
+		code   string
+	}{
+		{name: "no key", status: http.StatusNoContent},
+		{name: "valid key", key: "mk_valid", status: http.StatusNoContent},
+		{name: "unknown key", key: "mk_unknown", err: service.ErrInvalidKey, status: http.StatusUnauthorized, code: apperrors.ErrUnauthorized.Error()},
+		{name: "revoked key", key: "mk_revoked", err: service.ErrRevokedKey, status: http.StatusUnauthorized, code: apperrors.ErrUnauthorized.Error()},
+		{name: "key and token", key: "mk_valid", token: true, status: http.StatusUnauthorized, code: apperrors.ErrUnauthorized.Error()},
+		{name: "store failure", key: "mk_valid", err: errors.New("disk failure"), status: http.StatusInternalServerError, code: apperrors.ErrInternalError.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ServiceMock)
+			if tt.key != "" && !tt.token {
+				var k *apikey.APIKey
+				if tt.err == nil {
+					k = &apikey.APIKey{Id: "k1", Scopes: []string{apikey.ScopeCatalogRead}}
+				}
+				mockService.On("AuthenticateWithContext", mock.Anything, tt.key).Return(k, tt.err).Once()
+			}
+
+			var claims *auth.Claims
+			h := api.NewHandler(mockService).Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				claims, _ = auth.ClaimsFromContext(r.Context())
+				w.WriteHeader(http.StatusNoContent)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+			if tt.key != "" {
+				req.Header.Set(api.APIKeyHeader, tt.key)
+			}
+			if tt.token {
+				req = req.WithContext(auth.WithClaims(context.Background(), &auth.Claims{Roles: []string{auth.RoleAdmin}}))
+			}
+			rec := httptest.NewRecorder()
+
+			h.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.status, rec.Code)
+			mockService.AssertExpectations(t)
+			if tt.code != "" {
+				require.Equal(t, tt.code, errorCode(t, rec))
+				return
+			}
+			if tt.key == "" {
+				assert.Nil(t, claims)
+				return
+			}
+			require.NotNil(t, claims)
+			assert.True(t, claims.FromAPIKey())
+			assert.Equal(t, "k1", claims.KeyId)
+			assert.True(t, claims.HasScope(apikey.ScopeCatalogRead))
+			assert.False(t, claims.HasAnyRole(auth.RoleAdmin))
+		})
+	}
+}
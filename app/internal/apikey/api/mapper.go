@@ -0,0 +1,51 @@
+package api
+
+import (
+	"time"
+
+	"github.com/lucasti79/meli-interview/internal/apikey"
+)
+
+// APIKey is an API key as the endpoints return it, without its hash.
+// swagger:model APIKey
+type APIKey struct {
+	Id         string     `json:"keyId"`
+	Name       string     `json:"name"`
+	Hint       string     `json:"hint"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+}
+
+func toAPIKey(k apikey.APIKey) APIKey {
+	return APIKey{
+		Id:         k.Id,
+		Name:       k.Name,
+		Hint:       k.Hint,
+		Scopes:     k.Scopes,
+		CreatedAt:  k.CreatedAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
+
+// swagger:model APIKeyResult
+type APIKeyResult struct {
+	Data APIKey `json:"data"`
+}
+
+// APIKeyCreatedResult carries the key itself, which is only returned once.
+// swagger:model APIKeyCreatedResult
+type APIKeyCreatedResult struct {
+	Data APIKey `json:"data"`
+	Key  string `json:"key"`
+}
+
+// swagger:model APIKeyPaginatedResult
+type APIKeyPaginatedResult struct {
+	Data       []APIKey `json:"data"`
+	TotalCount int      `json:"totalCount"`
+	Page       int      `json:"page,omitempty"`
+	PageSize   int      `json:"pageSize"`
+}
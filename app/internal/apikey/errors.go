@@ -0,0 +1,6 @@
+package apikey
+
+const (
+	ErrAPIKeyNotFound    = "apikey/not-found"
+	ErrAPIKeyInvalidData = "apikey/invalid-data"
+)
@@ -0,0 +1,126 @@
+package jsonstore
+
+import (
+	"context"
+
+	"github.com/lucasti79/meli-interview/internal/apikey"
+	"github.com/lucasti79/meli-interview/internal/apikey/repository"
+	"github.com/lucasti79/meli-interview/internal/infra/jsonstore"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+)
+
+// HashIndex is the secondary index registered by NewAPIKeyStore.
+const HashIndex = "hash"
+
+type apiKeyRepository struct {
+	repo   *jsonstore.JSONRepository[apikey.APIKey]
+	byHash *jsonstore.KeywordIndex[apikey.APIKey]
+}
+
+// NewAPIKeyStore opens the API keys file keyed by key ID. Hashes are unique,
+// which the store enforces on every write, and are what requests are
+// authenticated by.
+func NewAPIKeyStore(fileName string, opts ...jsonstore.Option) (*jsonstore.JSONRepository[apikey.APIKey], error) {
+	getID := func(entity apikey.APIKey) string {
+		return entity.Id
+	}
+	opts = append([]jsonstore.Option{
+		jsonstore.WithIndex(HashIndex, jsonstore.NewUniqueKeywordIndex(func(k apikey.APIKey) string {
+			return k.Hash
+		})),
+	}, opts...)
+	return jsonstore.NewJSONRepository(fileName, getID, opts...)
+}
+
+func NewAPIKeyRepository(fileName string, opts ...jsonstore.Option) (repository.Repository, error) {
+	repo, err := NewAPIKeyStore(fileName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewAPIKeyRepositoryFromStore(repo), nil
+}
+
+// NewAPIKeyRepositoryFromStore wraps a store opened with NewAPIKeyStore.
+func NewAPIKeyRepositoryFromStore(repo *jsonstore.JSONRepository[apikey.APIKey]) repository.Repository {
+	r := &apiKeyRepository{repo: repo}
+	r.byHash, _ = repo.Index(HashIndex).(*jsonstore.KeywordIndex[apikey.APIKey])
+	return r
+}
+
+func (r *apiKeyRepository) GetAll(filters apikey.KeyFilter) ([]apikey.APIKey, int, error) {
+	return r.GetAllWithContext(context.Background(), filters)
+}
+
+func (r *apiKeyRepository) GetAllWithContext(ctx context.Context, filters apikey.KeyFilter) ([]apikey.APIKey, int, error) {
+	keys := make([]apikey.APIKey, 0, filters.PageSize)
+	total, err := r.repo.FindAllIndexedPaginatedWithContext(ctx, nil, filters.Matches, apikey.Newer, filters.Page, filters.PageSize, func(k apikey.APIKey) error {
+		keys = append(keys, k)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return keys, total, nil
+}
+
+func (r *apiKeyRepository) GetByID(keyId string) (*apikey.APIKey, error) {
+	return r.GetByIDWithContext(context.Background(), keyId)
+}
+
+func (r *apiKeyRepository) GetByIDWithContext(ctx context.Context, keyId string) (*apikey.APIKey, error) {
+	k, err := r.repo.FindByIDWithContext(ctx, keyId)
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+func (r *apiKeyRepository) GetByHash(hash string) (*apikey.APIKey, error) {
+	return r.GetByHashWithContext(context.Background(), hash)
+}
+
+func (r *apiKeyRepository) GetByHashWithContext(ctx context.Context, hash string) (*apikey.APIKey, error) {
+	var narrow jsonstore.Narrow
+	if r.byHash != nil {
+		narrow = func() *jsonstore.Bitmap { return r.byHash.Lookup(hash) }
+	}
+
+	var found *apikey.APIKey
+	err := r.repo.FindAllIndexedWithContext(ctx, narrow, func(k apikey.APIKey) bool { return k.Hash == hash }, func(k apikey.APIKey) error {
+		found = &k
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if hash == "" || found == nil {
+		return nil, apperrors.ErrResourceNotExists
+	}
+	return found, nil
+}
+
+func (r *apiKeyRepository) Create(k apikey.APIKey) error {
+	return r.repo.Save(k)
+}
+
+func (r *apiKeyRepository) CreateWithContext(ctx context.Context, k apikey.APIKey) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return r.repo.Save(k)
+}
+
+func (r *apiKeyRepository) Modify(keyId string, modify func(k apikey.APIKey) (apikey.APIKey, error)) (*apikey.APIKey, error) {
+	return r.ModifyWithContext(context.Background(), keyId, modify)
+}
+
+func (r *apiKeyRepository) ModifyWithContext(ctx context.Context, keyId string, modify func(k apikey.APIKey) (apikey.APIKey, error)) (*apikey.APIKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	k, err := r.repo.Modify(keyId, modify)
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
@@ -0,0 +1,58 @@
+package jsonstore_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lucasti79/meli-interview/internal/apikey"
+	"github.com/lucasti79/meli-interview/internal/apikey/infra/jsonstore"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyRepository_WritesAreReadBackAfterReopening(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "apikeys.jsonl")
+	repo, err := jsonstore.NewAPIKeyRepository(fp)
+	require.NoError(t, err)
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Create(apikey.APIKey{Id: "k1", Hash: "h1", Scopes: []string{apikey.ScopeCatalogRead}, CreatedAt: now}))
+	require.NoError(t, repo.Create(apikey.APIKey{Id: "k2", Hash: "h2", CreatedAt: now.Add(time.Minute)}))
+	require.NoError(t, repo.Create(apikey.APIKey{Id: "k3", Hash: "h3", CreatedAt: now.Add(2 * time.Minute)}))
+	// hashes are unique
+	require.ErrorIs(t, repo.Create(apikey.APIKey{Id: "k4", Hash: "h1"}), apperrors.ErrResourceAlreadyExists)
+
+	revoked, err := repo.Modify("k2", func(k apikey.APIKey) (apikey.APIKey, error) {
+		k.RevokedAt = &now
+		return k, nil
+	})
+	require.NoError(t, err)
+
+	reopened, err := jsonstore.NewAPIKeyRepository(fp)
+	require.NoError(t, err)
+
+	got, err := reopened.GetByID("k2")
+	require.NoError(t, err)
+	require.Equal(t, *revoked, *got)
+
+	byHash, err := reopened.GetByHash("h1")
+	require.NoError(t, err)
+	require.Equal(t, "k1", byHash.Id)
+	require.Equal(t, []string{apikey.ScopeCatalogRead}, byHash.Scopes)
+	_, err = reopened.GetByHash("h9")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+	_, err = reopened.GetByHash("")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+
+	all, total, err := reopened.GetAll(apikey.KeyFilter{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Equal(t, "k3", all[0].Id, "newest first")
+
+	active, total, err := reopened.GetAll(apikey.KeyFilter{Active: true, Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	require.Equal(t, "k3", active[0].Id)
+	require.Equal(t, "k1", active[1].Id)
+}
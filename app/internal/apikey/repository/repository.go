@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/lucasti79/meli-interview/internal/apikey"
+)
+
+type Repository interface {
+	// GetAll returns a page of the API keys, newest first, and how many there
+	// are.
+	GetAll(filters apikey.KeyFilter) ([]apikey.APIKey, int, error)
+	GetAllWithContext(ctx context.Context, filters apikey.KeyFilter) ([]apikey.APIKey, int, error)
+	GetByID(keyId string) (*apikey.APIKey, error)
+	GetByIDWithContext(ctx context.Context, keyId string) (*apikey.APIKey, error)
+	// GetByHash returns the API key whose key hashes to hash.
+	GetByHash(hash string) (*apikey.APIKey, error)
+	GetByHashWithContext(ctx context.Context, hash string) (*apikey.APIKey, error)
+	Create(k apikey.APIKey) error
+	CreateWithContext(ctx context.Context, k apikey.APIKey) error
+	// Modify applies modify to the API key atomically; when modify returns an
+	// error nothing is written.
+	Modify(keyId string, modify func(k apikey.APIKey) (apikey.APIKey, error)) (*apikey.APIKey, error)
+	ModifyWithContext(ctx context.Context, keyId string, modify func(k apikey.APIKey) (apikey.APIKey, error)) (*apikey.APIKey, error)
+}
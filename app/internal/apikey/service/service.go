@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lucasti79/meli-interview/internal/apikey"
+	"github.com/lucasti79/meli-interview/internal/apikey/repository"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+)
+
+var (
+	// ErrInvalidKey is returned when authenticating with a key that was never
+	// created.
+	ErrInvalidKey = fmt.Errorf("%w: invalid API key", apperrors.ErrUnauthorized)
+	// ErrRevokedKey is returned when authenticating with a revoked key.
+	ErrRevokedKey = fmt.Errorf("%w: the API key was revoked", apperrors.ErrUnauthorized)
+)
+
+// lastUsedPrecision is how stale the time a key was last used at may get:
+// it is written at most once per key in this interval, not on every request.
+const lastUsedPrecision = time.Minute
+
+type service struct {
+	repo repository.Repository
+	now  func() time.Time
+}
+
+type Option func(*service)
+
+// WithClock sets where the service reads the time keys are created, used and
+// revoked at.
+func WithClock(now func() time.Time) Option {
+	return func(s *service) {
+		s.now = now
+	}
+}
+
+type Service interface {
+	GetAll(filters apikey.KeyFilter) ([]apikey.APIKey, int, error)
+	GetAllWithContext(ctx context.Context, filters apikey.KeyFilter) ([]apikey.APIKey, int, error)
+	GetByID(keyId string) (*apikey.APIKey, error)
+	GetByIDWithContext(ctx context.Context, keyId string) (*apikey.APIKey, error)
+	// Create stores a new API key, returning it along with the key itself,
+	// which is not stored and cannot be read again.
+	Create(req apikey.CreateRequest) (*apikey.APIKey, string, error)
+	CreateWithContext(ctx context.Context, req apikey.CreateRequest) (*apikey.APIKey, string, error)
+	Revoke(keyId string) (*apikey.APIKey, error)
+	RevokeWithContext(ctx context.Context, keyId string) (*apikey.APIKey, error)
+	// Authenticate returns the API key of key, recording it was used.
+	Authenticate(key string) (*apikey.APIKey, error)
+	AuthenticateWithContext(ctx context.Context, key string) (*apikey.APIKey, error)
+}
+
+func NewService(repo repository.Repository, opts ...Option) Service {
+	s := &service{repo: repo, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *service) GetAll(filters apikey.KeyFilter) ([]apikey.APIKey, int, error) {
+	return s.GetAllWithContext(context.Background(), filters)
+}
+
+func (s *service) GetAllWithContext(ctx context.Context, filters apikey.KeyFilter) ([]apikey.APIKey, int, error) {
+	return s.repo.GetAllWithContext(ctx, filters)
+}
+
+func (s *service) GetByID(keyId string) (*apikey.APIKey, error) {
+	return s.GetByIDWithContext(context.Background(), keyId)
+}
+
+func (s *service) GetByIDWithContext(ctx context.Context, keyId string) (*apikey.APIKey, error) {
+	return s.repo.GetByIDWithContext(ctx, keyId)
+}
+
+func (s *service) Create(req apikey.CreateRequest) (*apikey.APIKey, string, error) {
+	return s.CreateWithContext(context.Background(), req)
+}
+
+func (s *service) CreateWithContext(ctx context.Context, req apikey.CreateRequest) (*apikey.APIKey, string, error) {
+	key := apikey.Generate()
+	k := apikey.APIKey{
+		Id:        uuid.NewString(),
+		Name:      req.Name,
+		Hash:      apikey.Hash(key),
+		Hint:      apikey.Hint(key),
+		Scopes:    req.Scopes,
+		CreatedAt: s.now().UTC(),
+	}
+	if err := s.repo.CreateWithContext(ctx, k); err != nil {
+		return nil, "", err
+	}
+	return &k, key, nil
+}
+
+func (s *service) Revoke(keyId string) (*apikey.APIKey, error) {
+	return s.RevokeWithContext(context.Background(), keyId)
+}
+
+// RevokeWithContext stops a key from being used. The key is kept, with the
+// time it was revoked at, so its last use can still be looked up; revoking it
+// again changes nothing.
+func (s *service) RevokeWithContext(ctx context.Context, keyId string) (*apikey.APIKey, error) {
+	now := s.now().UTC()
+	return s.repo.ModifyWithContext(ctx, keyId, func(k apikey.APIKey) (apikey.APIKey, error) {
+		if k.RevokedAt == nil {
+			k.RevokedAt = &now
+		}
+		return k, nil
+	})
+}
+
+func (s *service) Authenticate(key string) (*apikey.APIKey, error) {
+	return s.AuthenticateWithContext(context.Background(), key)
+}
+
+// AuthenticateWithContext looks the key up by its hash. The time it was last
+// used at is updated at most once per lastUsedPrecision; failing to write it
+// does not fail the request.
+func (s *service) AuthenticateWithContext(ctx context.Context, key string) (*apikey.APIKey, error) {
+	k, err := s.repo.GetByHashWithContext(ctx, apikey.Hash(key))
+	if errors.Is(err, apperrors.ErrResourceNotExists) {
+		return nil, ErrInvalidKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	if k.Revoked() {
+		return nil, ErrRevokedKey
+	}
+
+	now := s.now().UTC()
+	if k.LastUsedAt != nil && now.Sub(*k.LastUsedAt) < lastUsedPrecision {
+		return k, nil
+	}
+	used, err := s.repo.ModifyWithContext(ctx, k.Id, func(k apikey.APIKey) (apikey.APIKey, error) {
+		k.LastUsedAt = &now
+		return k, nil
+	})
+	if err != nil {
+		log.Printf("apikey: recording the use of key %s failed: %v", k.Id, err)
+		return k, nil
+	}
+	return used, nil
+}
@@ -0,0 +1,151 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lucasti79/meli-interview/internal/apikey"
+	"github.com/lucasti79/meli-interview/internal/apikey/infra/mocks"
+	"github.com/lucasti79/meli-interview/internal/apikey/service"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newService(repo *mocks.RepositoryMock) service.Service {
+	return service.NewService(repo, service.WithClock(func() time.Time { return now }))
+}
+
+// modifying answers the writes of stored as the repository does.
+func modifying(repo *mocks.RepositoryMock, stored map[string]apikey.APIKey) {
+	repo.On("ModifyWithContext", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, keyId string, modify func(apikey.APIKey) (apikey.APIKey, error)) (*apikey.APIKey, error) {
+			current, ok := stored[keyId]
+			if !ok {
+				return nil, apperrors.ErrResourceNotExists
+			}
+			k, err := modify(current)
+			if err != nil {
+				return nil, err
+			}
+			stored[keyId] = k
+			return &k, nil
+		}).Maybe()
+}
+
+func TestService_CreateWithContext_StoresOnlyTheHashOfTheKey(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := newService(mockRepo)
+
+	var saved apikey.APIKey
+	mockRepo.On("CreateWithContext", mock.Anything, mock.AnythingOfType("apikey.APIKey")).
+		Run(func(args mock.Arguments) { saved = args.Get(1).(apikey.APIKey) }).
+		Return(nil).Twice()
+
+	created, key, err := svc.CreateWithContext(context.Background(), apikey.CreateRequest{Name: "Partner", Scopes: []string{apikey.ScopeCatalogRead}})
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key, apikey.Prefix))
+	assert.NotEmpty(t, created.Id)
+	assert.Equal(t, apikey.Hash(key), saved.Hash)
+	assert.NotContains(t, saved.Hash, key)
+	assert.Equal(t, key[len(key)-4:], saved.Hint)
+	assert.Equal(t, now, saved.CreatedAt)
+	assert.Nil(t, saved.LastUsedAt)
+
+	_, other, err := svc.CreateWithContext(context.Background(), apikey.CreateRequest{Name: "Partner", Scopes: []string{apikey.ScopeCatalogRead}})
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestService_AuthenticateWithContext(t *testing.T) {
+	key := apikey.Generate()
+	recently := now.Add(-30 * time.Second)
+	longAgo := now.Add(-time.Hour)
+
+	tests := []struct {
+		name         string
+		stored       *apikey.APIKey
+		err          error
+		wantErr      error
+		wantLastUsed *time.Time
+	}{
+		{name: "first use", stored: &apikey.APIKey{Id: "k1"}, wantLastUsed: &now},
+		{name: "used long ago", stored: &apikey.APIKey{Id: "k1", LastUsedAt: &longAgo}, wantLastUsed: &now},
+		{name: "used recently is not written", stored: &apikey.APIKey{Id: "k1", LastUsedAt: &recently}, wantLastUsed: &recently},
+		{name: "revoked", stored: &apikey.APIKey{Id: "k1", RevokedAt: &longAgo}, wantErr: service.ErrRevokedKey},
+		{name: "unknown", err: apperrors.ErrResourceNotExists, wantErr: service.ErrInvalidKey},
+		{name: "store failure", err: errors.New("disk failure"), wantErr: errors.New("disk failure")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.RepositoryMock)
+			stored := map[string]apikey.APIKey{}
+			if tt.stored != nil {
+				tt.stored.Hash = apikey.Hash(key)
+				stored["k1"] = *tt.stored
+			}
+			modifying(mockRepo, stored)
+			mockRepo.On("GetByHashWithContext", mock.Anything, apikey.Hash(key)).Return(tt.stored, tt.err).Once()
+			svc := newService(mockRepo)
+
+			got, err := svc.AuthenticateWithContext(context.Background(), key)
+
+			if tt.wantErr != nil {
+				require.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "k1", got.Id)
+			assert.Equal(t, tt.wantLastUsed, got.LastUsedAt)
+			assert.Equal(t, tt.wantLastUsed, stored["k1"].LastUsedAt)
+		})
+	}
+}
+
+func TestService_AuthenticateWithContext_ErrorsAreUnauthorized(t *testing.T) {
+	assert.ErrorIs(t, service.ErrInvalidKey, apperrors.ErrUnauthorized)
+	assert.ErrorIs(t, service.ErrRevokedKey, apperrors.ErrUnauthorized)
+}
+
+func TestService_AuthenticateWithContext_ToleratesFailingToRecordTheUse(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	mockRepo.On("GetByHashWithContext", mock.Anything, mock.Anything).Return(&apikey.APIKey{Id: "k1"}, nil).Once()
+	mockRepo.On("ModifyWithContext", mock.Anything, "k1", mock.Anything).Return(nil, errors.New("disk failure")).Once()
+	svc := newService(mockRepo)
+
+	got, err := svc.AuthenticateWithContext(context.Background(), "mk_key")
+
+	require.NoError(t, err)
+	assert.Equal(t, "k1", got.Id)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_RevokeWithContext_KeepsTheFirstRevocation(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	earlier := now.Add(-time.Hour)
+	stored := map[string]apikey.APIKey{
+		"k1": {Id: "k1"},
+		"k2": {Id: "k2", RevokedAt: &earlier},
+	}
+	modifying(mockRepo, stored)
+	svc := newService(mockRepo)
+
+	revoked, err := svc.RevokeWithContext(context.Background(), "k1")
+	require.NoError(t, err)
+	assert.Equal(t, &now, revoked.RevokedAt)
+
+	again, err := svc.RevokeWithContext(context.Background(), "k2")
+	require.NoError(t, err)
+	assert.Equal(t, &earlier, again.RevokedAt)
+
+	_, err = svc.RevokeWithContext(context.Background(), "k9")
+	assert.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+}
@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lucasti79/meli-interview/config"
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/lucasti79/meli-interview/pkg/web/response"
+)
+
+// ErrNoKey is returned for every token when no key to verify them with is
+// configured.
+var ErrNoKey = errors.New("no key to verify tokens is configured")
+
+// Authenticator verifies the bearer tokens requests are sent with.
+type Authenticator struct {
+	parser *jwt.Parser
+	key    any
+}
+
+// NewAuthenticator reads the key tokens are verified with from cfg. Without
+// a key every token is rejected, so the endpoints that require a role cannot
+// be used until one is configured.
+func NewAuthenticator(cfg config.AuthConfig) (*Authenticator, error) {
+	algorithm := cfg.Algorithm
+	if algorithm == "" {
+		algorithm = config.AlgorithmHS256
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{algorithm}),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	a := &Authenticator{parser: jwt.NewParser(opts...)}
+
+	switch algorithm {
+	case config.AlgorithmHS256:
+		if cfg.Secret != "" {
+			a.key = []byte(cfg.Secret)
+		}
+	case config.AlgorithmRS256:
+		pem := []byte(cfg.PublicKey)
+		if len(pem) == 0 && cfg.PublicKeyFile != "" {
+			var err error
+			if pem, err = os.ReadFile(cfg.PublicKeyFile); err != nil {
+				return nil, fmt.Errorf("reading the JWT public key: %w", err)
+			}
+		}
+		if len(pem) > 0 {
+			key, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+			if err != nil {
+				return nil, fmt.Errorf("parsing the JWT public key: %w", err)
+			}
+			a.key = key
+		}
+	default:
+		return nil, fmt.Errorf("unknown JWT algorithm %q", algorithm)
+	}
+	return a, nil
+}
+
+// Configured reports whether a has a key to verify tokens with.
+func (a *Authenticator) Configured() bool {
+	return a.key != nil
+}
+
+// Verify parses a signed token, returning its claims when it is valid.
+func (a *Authenticator) Verify(token string) (*Claims, error) {
+	if a.key == nil {
+		return nil, ErrNoKey
+	}
+	claims := &Claims{}
+	if _, err := a.parser.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) { return a.key, nil }); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// Authenticate puts the claims of the bearer token a request is sent with in
+// its context. Requests without a token go on anonymous; requests with a
+// token that cannot be verified are answered with 401.
+func (a *Authenticator) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			writeUnauthorized(w, "the Authorization header must be a Bearer token")
+			return
+		}
+		claims, err := a.Verify(strings.TrimSpace(token))
+		if err != nil {
+			writeUnauthorized(w, err.Error())
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithClaims(r.Context(), claims)))
+	})
+}
+
+// RequireRole answers with 401 the requests sent without a valid token, and
+// with 403 the ones whose token has none of roles.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := ClaimsFromContext(r.Context())
+			if !ok {
+				writeUnauthorized(w, "a Bearer token is required")
+				return
+			}
+			if !claims.HasAnyRole(roles...) {
+				writeForbidden(w, fmt.Sprintf("one of the roles %s is required", strings.Join(roles, ", ")))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireUser answers with 401 the requests sent without a valid token, and
+// with 403 the ones sent with an API key or with a token that names no user.
+// Tokens with the admin role are allowed without a subject.
+func RequireUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := ClaimsFromContext(r.Context())
+		if !ok {
+			writeUnauthorized(w, "a Bearer token is required")
+			return
+		}
+		if claims.FromAPIKey() || (claims.Subject == "" && !claims.HasAnyRole(RoleAdmin)) {
+			writeForbidden(w, "a token issued to a user is required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireScope answers with 401 the requests sent without a valid token or
+// API key, and with 403 the ones whose API key was not granted scope. Tokens
+// with the admin role are allowed every scope.
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := ClaimsFromContext(r.Context())
+			if !ok {
+				writeUnauthorized(w, "a Bearer token or an API key is required")
+				return
+			}
+			if !claims.HasAnyRole(RoleAdmin) && !claims.HasScope(scope) {
+				writeForbidden(w, fmt.Sprintf("the scope %s is required", scope))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// LimitScope guards public endpoints: anyone can call them, but a request
+// sent with an API key is answered with 403 unless the key was granted scope.
+func LimitScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if claims, ok := ClaimsFromContext(r.Context()); ok && claims.FromAPIKey() && !claims.HasScope(scope) {
+				writeForbidden(w, fmt.Sprintf("the scope %s is required", scope))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeUnauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+	response.JSON(w, http.StatusUnauthorized, httpdto.ErrorResponse{
+		Code:    apperrors.ErrUnauthorized.Error(),
+		Message: message,
+		Status:  http.StatusText(http.StatusUnauthorized),
+	})
+}
+
+func writeForbidden(w http.ResponseWriter, message string) {
+	response.JSON(w, http.StatusForbidden, httpdto.ErrorResponse{
+		Code:    apperrors.ErrForbidden.Error(),
+		Message: message,
+		Status:  http.StatusText(http.StatusForbidden),
+	})
+}
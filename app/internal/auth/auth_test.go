@@ -0,0 +1,222 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lucasti79/meli-interview/config"
+	"github.com/lucasti79/meli-interview/internal/auth"
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func claims(roles ...string) auth.Claims {
+	return auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "u1",
+			Issuer:    "meli",
+			Audience:  jwt.ClaimStrings{"api"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Roles: roles,
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, c auth.Claims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, c).SignedString(key)
+	require.NoError(t, err)
+	return token
+}
+
+func TestAuthenticator_VerifyHS256(t *testing.T) {
+	a, err := auth.NewAuthenticator(config.AuthConfig{Algorithm: config.AlgorithmHS256, Secret: "secret", Issuer: "meli", Audience: "api"})
+	require.NoError(t, err)
+
+	got, err := a.Verify(sign(t, jwt.SigningMethodHS256, []byte("secret"), claims(auth.RoleAdmin)))
+	require.NoError(t, err)
+	assert.Equal(t, "u1", got.Subject)
+	assert.True(t, got.HasAnyRole("editor", auth.RoleAdmin))
+	assert.False(t, got.HasAnyRole("editor"))
+
+	otherIssuer := claims()
+	otherIssuer.Issuer = "someone-else"
+	noExpiry := claims()
+	noExpiry.ExpiresAt = nil
+
+	rejected := map[string]string{
+		"other secret":   sign(t, jwt.SigningMethodHS256, []byte("other"), claims()),
+		"other issuer":   sign(t, jwt.SigningMethodHS256, []byte("secret"), otherIssuer),
+		"no expiry":      sign(t, jwt.SigningMethodHS256, []byte("secret"), noExpiry),
+		"other method":   sign(t, jwt.SigningMethodHS512, []byte("secret"), claims()),
+		"unsigned token": sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, claims()),
+		"not a token":    "abc",
+	}
+	for name, token := range rejected {
+		_, err := a.Verify(token)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestAuthenticator_VerifyRS256(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "jwt.pub")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+	a, err := auth.NewAuthenticator(config.AuthConfig{Algorithm: config.AlgorithmRS256, PublicKeyFile: keyFile})
+	require.NoError(t, err)
+
+	got, err := a.Verify(sign(t, jwt.SigningMethodRS256, private, claims("customer")))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"customer"}, got.Roles)
+
+	// a token signed with the public key as an HMAC secret is not accepted
+	_, err = a.Verify(sign(t, jwt.SigningMethodHS256, der, claims(auth.RoleAdmin)))
+	assert.Error(t, err)
+}
+
+func TestNewAuthenticator_Errors(t *testing.T) {
+	_, err := auth.NewAuthenticator(config.AuthConfig{Algorithm: "ES256"})
+	assert.Error(t, err)
+	_, err = auth.NewAuthenticator(config.AuthConfig{Algorithm: config.AlgorithmRS256, PublicKey: "not a key"})
+	assert.Error(t, err)
+	_, err = auth.NewAuthenticator(config.AuthConfig{Algorithm: config.AlgorithmRS256, PublicKeyFile: filepath.Join(t.TempDir(), "missing.pub")})
+	assert.Error(t, err)
+
+	a, err := auth.NewAuthenticator(config.AuthConfig{})
+	require.NoError(t, err)
+	assert.False(t, a.Configured())
+	_, err = a.Verify(sign(t, jwt.SigningMethodHS256, []byte("secret"), claims()))
+	assert.ErrorIs(t, err, auth.ErrNoKey)
+}
+
+func TestAuthenticateAndRequireRole(t *testing.T) {
+	a, err := auth.NewAuthenticator(config.AuthConfig{Secret: "secret"})
+	require.NoError(t, err)
+
+	var subject string
+	handler := a.Authenticate(auth.RequireRole(auth.RoleAdmin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, _ := auth.ClaimsFromContext(r.Context())
+		subject = c.Subject
+		w.WriteHeader(http.StatusNoContent)
+	})))
+
+	tests := []struct {
+		name          string
+		authorization string
+		status        int
+		code          string
+	}{
+		{name: "no token", status: http.StatusUnauthorized, code: apperrors.ErrUnauthorized.Error()},
+		{name: "invalid token", authorization: "Bearer abc", status: http.StatusUnauthorized, code: apperrors.ErrUnauthorized.Error()},
+		{name: "missing role", authorization: "Bearer " + sign(t, jwt.SigningMethodHS256, []byte("secret"), claims("customer")), status: http.StatusForbidden, code: apperrors.ErrForbidden.Error()},
+		{name: "admin", authorization: "Bearer " + sign(t, jwt.SigningMethodHS256, []byte("secret"), claims(auth.RoleAdmin)), status: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/products", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.status, rec.Code)
+			if tt.code == "" {
+				assert.Equal(t, "u1", subject)
+				return
+			}
+			var body httpdto.ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.code, body.Code)
+			if tt.status == http.StatusUnauthorized {
+				assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestRequireScopeAndLimitScope(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	required := auth.RequireScope("catalog:write")(ok)
+	limited := auth.LimitScope("catalog:read")(ok)
+
+	tests := []struct {
+		name     string
+		claims   *auth.Claims
+		required int
+		limited  int
+	}{
+		{name: "anonymous", required: http.StatusUnauthorized, limited: http.StatusNoContent},
+		{name: "admin token", claims: &auth.Claims{Roles: []string{auth.RoleAdmin}}, required: http.StatusNoContent, limited: http.StatusNoContent},
+		{name: "other token", claims: &auth.Claims{Roles: []string{"customer"}}, required: http.StatusForbidden, limited: http.StatusNoContent},
+		{name: "key with the scopes", claims: auth.KeyClaims("k1", []string{"catalog:read", "catalog:write"}), required: http.StatusNoContent, limited: http.StatusNoContent},
+		{name: "key without the scopes", claims: auth.KeyClaims("k1", []string{"catalog:export"}), required: http.StatusForbidden, limited: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, c := range []struct {
+				handler http.Handler
+				status  int
+			}{{required, tt.required}, {limited, tt.limited}} {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+				if tt.claims != nil {
+					req = req.WithContext(auth.WithClaims(req.Context(), tt.claims))
+				}
+				rec := httptest.NewRecorder()
+
+				c.handler.ServeHTTP(rec, req)
+
+				assert.Equal(t, c.status, rec.Code)
+			}
+		})
+	}
+}
+
+func TestRequireUser(t *testing.T) {
+	handler := auth.RequireUser(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) }))
+
+	tests := []struct {
+		name   string
+		claims *auth.Claims
+		status int
+	}{
+		{name: "anonymous", status: http.StatusUnauthorized},
+		{name: "user token", claims: &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "u1"}}, status: http.StatusNoContent},
+		{name: "token without subject", claims: &auth.Claims{Roles: []string{"customer"}}, status: http.StatusForbidden},
+		{name: "admin token without subject", claims: &auth.Claims{Roles: []string{auth.RoleAdmin}}, status: http.StatusNoContent},
+		{name: "key", claims: auth.KeyClaims("k1", []string{"catalog:read", "catalog:write"}), status: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/products/p1/reviews", nil)
+			if tt.claims != nil {
+				req = req.WithContext(auth.WithClaims(req.Context(), tt.claims))
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// RoleAdmin is the role allowed to change the catalog and manage orders.
+const RoleAdmin = "admin"
+
+// Claims are the claims of the tokens the API accepts: the registered ones
+// and the roles of the subject. Requests sent with an API key get claims
+// too, carrying the ID and scopes of the key instead of roles.
+type Claims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles,omitempty"`
+	// KeyId is the ID of the API key the request was sent with
+	KeyId  string   `json:"-"`
+	Scopes []string `json:"-"`
+}
+
+// KeyClaims returns the claims of a request sent with an API key.
+func KeyClaims(keyId string, scopes []string) *Claims {
+	return &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: keyId},
+		KeyId:            keyId,
+		Scopes:           scopes,
+	}
+}
+
+// FromAPIKey reports whether the claims are of an API key.
+func (c *Claims) FromAPIKey() bool {
+	return c.KeyId != ""
+}
+
+// HasAnyRole reports whether the subject has one of roles.
+func (c *Claims) HasAnyRole(roles ...string) bool {
+	return slices.ContainsFunc(roles, func(role string) bool {
+		return slices.Contains(c.Roles, role)
+	})
+}
+
+// HasScope reports whether the API key of the claims was granted scope.
+func (c *Claims) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes, scope)
+}
+
+type claimsKey struct{}
+
+// WithClaims returns a copy of ctx carrying claims.
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims of the token the request was
+// authenticated with, if it was.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
@@ -0,0 +1,230 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator"
+	"github.com/lucasti79/meli-interview/internal/cart"
+	"github.com/lucasti79/meli-interview/internal/cart/service"
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/lucasti79/meli-interview/pkg/web/request"
+	"github.com/lucasti79/meli-interview/pkg/web/response"
+)
+
+type Handler struct {
+	service   service.Service
+	validator *validator.Validate
+}
+
+func NewHandler(service service.Service) *Handler {
+	return &Handler{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// Create godoc
+// @Summary      Create a cart
+// @Description  Start an empty cart. Its ID is what the storefront keeps to find it again from any device
+// @Tags         Carts
+// @Produce      json
+// @Success      201  {object}  CartResult
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Router       /api/v1/carts [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	c, err := h.service.CreateWithContext(r.Context())
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", r.URL.Path+"/"+c.Id)
+	response.JSON(w, http.StatusCreated, CartResult{Data: *c})
+}
+
+// GetByID godoc
+// @Summary      Get a cart
+// @Description  Get a cart with its items at the price they were added at
+// @Tags         Carts
+// @Produce      json
+// @Param        cartId  path  string  true  "Cart ID"
+// @Success      200  {object}  CartResult
+// @Failure      404  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Failure      504  {object}  httpdto.ErrorResponse
+// @Router       /api/v1/carts/{cartId} [get]
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	c, err := h.service.GetByIDWithContext(r.Context(), chi.URLParam(r, "cartId"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, CartResult{Data: *c})
+}
+
+// GetTotals godoc
+// @Summary      Get the totals of a cart
+// @Description  Price every item from the product as it is now. Lines whose product is out of stock, has fewer units available than the cart holds or was deleted are flagged in their status and left out of itemCount and subtotal
+// @Tags         Carts
+// @Produce      json
+// @Param        cartId  path  string  true  "Cart ID"
+// @Success      200  {object}  TotalsResult
+// @Failure      404  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Failure      504  {object}  httpdto.ErrorResponse
+// @Router       /api/v1/carts/{cartId}/totals [get]
+func (h *Handler) GetTotals(w http.ResponseWriter, r *http.Request) {
+	totals, err := h.service.GetTotalsWithContext(r.Context(), chi.URLParam(r, "cartId"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, TotalsResult{Data: *totals})
+}
+
+// AddItem godoc
+// @Summary      Add a product to a cart
+// @Description  Add units of a product at its current price. When the product is already in the cart the units are added to the ones it holds. A line holds at most 10000 units
+// @Tags         Carts
+// @Accept       json
+// @Produce      json
+// @Param        cartId  path  string            true  "Cart ID"
+// @Param        item    body  cart.ItemRequest  true  "Product and units"
+// @Success      200  {object}  CartResult
+// @Failure      400  {object}  httpdto.ErrorResponse
+// @Failure      404  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Router       /api/v1/carts/{cartId}/items [post]
+func (h *Handler) AddItem(w http.ResponseWriter, r *http.Request) {
+	var item cart.ItemRequest
+	if !h.decode(w, r, &item) {
+		return
+	}
+
+	c, err := h.service.AddItemWithContext(r.Context(), chi.URLParam(r, "cartId"), item)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, CartResult{Data: *c})
+}
+
+// UpdateItem godoc
+// @Summary      Change the units of a product in a cart
+// @Description  Replace how many units of a product already in the cart it holds, from 1 to 10000
+// @Tags         Carts
+// @Accept       json
+// @Produce      json
+// @Param        cartId     path  string                true  "Cart ID"
+// @Param        productId  path  string                true  "Product ID"
+// @Param        quantity   body  cart.QuantityRequest  true  "Units"
+// @Success      200  {object}  CartResult
+// @Failure      400  {object}  httpdto.ErrorResponse
+// @Failure      404  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Router       /api/v1/carts/{cartId}/items/{productId} [put]
+func (h *Handler) UpdateItem(w http.ResponseWriter, r *http.Request) {
+	var body cart.QuantityRequest
+	if !h.decode(w, r, &body) {
+		return
+	}
+
+	c, err := h.service.UpdateItemWithContext(r.Context(), chi.URLParam(r, "cartId"), chi.URLParam(r, "productId"), body.Quantity)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, CartResult{Data: *c})
+}
+
+// RemoveItem godoc
+// @Summary      Remove a product from a cart
+// @Description  Take every unit of a product out of the cart
+// @Tags         Carts
+// @Produce      json
+// @Param        cartId     path  string  true  "Cart ID"
+// @Param        productId  path  string  true  "Product ID"
+// @Success      200  {object}  CartResult
+// @Failure      404  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Router       /api/v1/carts/{cartId}/items/{productId} [delete]
+func (h *Handler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	c, err := h.service.RemoveItemWithContext(r.Context(), chi.URLParam(r, "cartId"), chi.URLParam(r, "productId"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, CartResult{Data: *c})
+}
+
+// decode reads and validates the JSON body into ptr, writing a 400 response
+// when it cannot.
+func (h *Handler) decode(w http.ResponseWriter, r *http.Request, ptr any) bool {
+	err := request.JSON(r, ptr)
+	if err == nil {
+		err = h.validator.Struct(ptr)
+	}
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    cart.ErrCartInvalidData,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return false
+	}
+	return true
+}
+
+// writeError maps an error from the cart service to its response.
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, cart.ErrItemNotFound):
+		response.JSON(w, http.StatusNotFound, httpdto.ErrorResponse{
+			Code:    cart.ErrCartItemNotFound,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusNotFound),
+		})
+	case errors.Is(err, apperrors.ErrResourceNotExists):
+		response.JSON(w, http.StatusNotFound, httpdto.ErrorResponse{
+			Code:    cart.ErrCartNotFound,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusNotFound),
+		})
+	case errors.Is(err, service.ErrUnknownProduct):
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    cart.ErrCartInvalidProduct,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+	case errors.Is(err, cart.ErrInvalidQuantity):
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    cart.ErrCartInvalidData,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+	default:
+		writeInternalError(w, err)
+	}
+}
+
+// writeInternalError answers an unexpected service error, telling apart the
+// requests whose context ended before the cart could be read.
+func writeInternalError(w http.ResponseWriter, err error) {
+	if status, body, ok := httpdto.ContextError(err); ok {
+		response.JSON(w, status, body)
+		return
+	}
+	response.JSON(w, http.StatusInternalServerError, httpdto.ErrorResponse{
+		Code:    apperrors.ErrInternalError.Error(),
+		Message: "internal server error",
+		Status:  http.StatusText(http.StatusInternalServerError),
+	})
+}
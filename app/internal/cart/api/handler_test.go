@@ -0,0 +1,198 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lucasti79/meli-interview/internal/cart"
+	"github.com/lucasti79/meli-interview/internal/cart/api"
+	"github.com/lucasti79/meli-interview/internal/cart/infra/mocks"
+	"github.com/lucasti79/meli-interview/internal/cart/service"
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/lucasti79/meli-interview/pkg/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func jsonRequest(t *testing.T, method, target, body string, params map[string]string) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return testutil.WithUrlParamst(t, req, params)
+}
+
+func errorCode(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	var body httpdto.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body.Code
+}
+
+func TestCreate_Success(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("CreateWithContext", mock.Anything).Return(&cart.Cart{Id: "c1", Items: []cart.Item{}}, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/carts", nil)
+	rec := httptest.NewRecorder()
+
+	h.Create(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Equal(t, "/api/v1/carts/c1", rec.Header().Get("Location"))
+	require.Contains(t, rec.Body.String(), `"items":[]`)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetByIDWithContext", mock.Anything, "c1").Return(nil, apperrors.ErrResourceNotExists)
+
+	h := api.NewHandler(mockService)
+
+	req := jsonRequest(t, http.MethodGet, "/api/v1/carts/c1", "", map[string]string{"cartId": "c1"})
+	rec := httptest.NewRecorder()
+
+	h.GetByID(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, cart.ErrCartNotFound, errorCode(t, rec))
+}
+
+func TestGetTotals_Success(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetTotalsWithContext", mock.Anything, "c1").Return(&cart.Totals{
+		CartId: "c1",
+		Items: []cart.LineTotal{
+			{ProductId: "p1", Name: "Mouse", Quantity: 2, UnitPrice: 10, Total: 20, Status: cart.ItemAvailable},
+			{ProductId: "p2", Name: "Lamp", Quantity: 1, UnitPrice: 30, Total: 30, Status: cart.ItemOutOfStock},
+		},
+		ItemCount:        2,
+		Subtotal:         20,
+		UnavailableCount: 1,
+	}, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := jsonRequest(t, http.MethodGet, "/api/v1/carts/c1/totals", "", map[string]string{"cartId": "c1"})
+	rec := httptest.NewRecorder()
+
+	h.GetTotals(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body api.TotalsResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, 20.0, body.Data.Subtotal)
+	require.Equal(t, cart.ItemOutOfStock, body.Data.Items[1].Status)
+	mockService.AssertExpectations(t)
+}
+
+func TestAddItem_Success(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("AddItemWithContext", mock.Anything, "c1", cart.ItemRequest{ProductId: "p1", Quantity: 2}).
+		Return(&cart.Cart{Id: "c1", Items: []cart.Item{{ProductId: "p1", Quantity: 2, Price: 10}}}, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := jsonRequest(t, http.MethodPost, "/api/v1/carts/c1/items", `{"productId":"p1","quantity":2}`, map[string]string{"cartId": "c1"})
+	rec := httptest.NewRecorder()
+
+	h.AddItem(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"productId":"p1"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestAddItem_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		err    error
+		status int
+		code   string
+	}{
+		{name: "malformed body", body: `{"productId":`, status: http.StatusBadRequest, code: cart.ErrCartInvalidData},
+		{name: "missing product", body: `{"quantity":1}`, status: http.StatusBadRequest, code: cart.ErrCartInvalidData},
+		{name: "no units", body: `{"productId":"p1","quantity":0}`, status: http.StatusBadRequest, code: cart.ErrCartInvalidData},
+		{name: "too many units", body: `{"productId":"p1","quantity":10001}`, status: http.StatusBadRequest, code: cart.ErrCartInvalidData},
+		{name: "line over the cap", body: `{"productId":"p1","quantity":10}`, err: fmt.Errorf("%w: 10 more units of p1", cart.ErrInvalidQuantity), status: http.StatusBadRequest, code: cart.ErrCartInvalidData},
+		{name: "unknown product", body: `{"productId":"p9","quantity":1}`, err: fmt.Errorf("%w: p9", service.ErrUnknownProduct), status: http.StatusBadRequest, code: cart.ErrCartInvalidProduct},
+		{name: "unknown cart", body: `{"productId":"p1","quantity":1}`, err: apperrors.ErrResourceNotExists, status: http.StatusNotFound, code: cart.ErrCartNotFound},
+		{name: "store failure", body: `{"productId":"p1","quantity":1}`, err: errors.New("disk failure"), status: http.StatusInternalServerError, code: apperrors.ErrInternalError.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ServiceMock)
+			if tt.err != nil {
+				mockService.On("AddItemWithContext", mock.Anything, "c1", mock.AnythingOfType("cart.ItemRequest")).Return(nil, tt.err)
+			}
+
+			h := api.NewHandler(mockService)
+
+			req := jsonRequest(t, http.MethodPost, "/api/v1/carts/c1/items", tt.body, map[string]string{"cartId": "c1"})
+			rec := httptest.NewRecorder()
+
+			h.AddItem(rec, req)
+
+			require.Equal(t, tt.status, rec.Code)
+			require.Equal(t, tt.code, errorCode(t, rec))
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateItem_Success(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("UpdateItemWithContext", mock.Anything, "c1", "p1", 5).
+		Return(&cart.Cart{Id: "c1", Items: []cart.Item{{ProductId: "p1", Quantity: 5, Price: 10}}}, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := jsonRequest(t, http.MethodPut, "/api/v1/carts/c1/items/p1", `{"quantity":5}`, map[string]string{"cartId": "c1", "productId": "p1"})
+	rec := httptest.NewRecorder()
+
+	h.UpdateItem(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateItem_ItemNotInCart(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("UpdateItemWithContext", mock.Anything, "c1", "p9", 1).Return(nil, cart.ErrItemNotFound)
+
+	h := api.NewHandler(mockService)
+
+	req := jsonRequest(t, http.MethodPut, "/api/v1/carts/c1/items/p9", `{"quantity":1}`, map[string]string{"cartId": "c1", "productId": "p9"})
+	rec := httptest.NewRecorder()
+
+	h.UpdateItem(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, cart.ErrCartItemNotFound, errorCode(t, rec))
+}
+
+func TestRemoveItem_Success(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("RemoveItemWithContext", mock.Anything, "c1", "p1").Return(&cart.Cart{Id: "c1", Items: []cart.Item{}}, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := jsonRequest(t, http.MethodDelete, "/api/v1/carts/c1/items/p1", "", map[string]string{"cartId": "c1", "productId": "p1"})
+	rec := httptest.NewRecorder()
+
+	h.RemoveItem(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"items":[]`)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,13 @@
+package api
+
+import "github.com/lucasti79/meli-interview/internal/cart"
+
+// swagger:model CartResult
+type CartResult struct {
+	Data cart.Cart `json:"data"`
+}
+
+// swagger:model TotalsResult
+type TotalsResult struct {
+	Data cart.Totals `json:"data"`
+}
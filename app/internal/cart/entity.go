@@ -0,0 +1,183 @@
+package cart
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+)
+
+var (
+	// ErrItemNotFound is returned by item writes naming a product that is not
+	// in the cart.
+	ErrItemNotFound = errors.New("product is not in the cart")
+	// ErrInvalidQuantity is returned by item writes that would leave a line
+	// with fewer than one or more than product.MaxQuantity units.
+	ErrInvalidQuantity = fmt.Errorf("%w: a cart line holds from 1 to %d units", apperrors.ErrValidation, product.MaxQuantity)
+)
+
+// Cart is stored in its own file, so it follows the shopper across devices.
+// Items keep the order they were first added in.
+type Cart struct {
+	Id        string    `json:"cartId"`
+	Items     []Item    `json:"items"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Item is a product in a cart. Price is the unit price of the product when
+// the item was last added; Totals re-prices it from the catalog.
+type Item struct {
+	ProductId string    `json:"productId"`
+	Quantity  int       `json:"quantity"`
+	Price     float64   `json:"price"`
+	AddedAt   time.Time `json:"addedAt"`
+}
+
+// ItemRequest adds units of a product to a cart.
+type ItemRequest struct {
+	ProductId string `json:"productId" validate:"required"`
+	Quantity  int    `json:"quantity" validate:"min=1,max=10000"`
+}
+
+// QuantityRequest sets how many units of a product a cart holds.
+type QuantityRequest struct {
+	Quantity int `json:"quantity" validate:"min=1,max=10000"`
+}
+
+// AddItem adds quantity units of p to c, or to the units already in it, at
+// the current price of p. It fails with ErrInvalidQuantity, leaving c as it
+// was, when the line would hold more than product.MaxQuantity units.
+func (c *Cart) AddItem(p product.Product, quantity int, now time.Time) error {
+	i := c.find(p.Id)
+	held := 0
+	if i >= 0 {
+		held = c.Items[i].Quantity
+	}
+	// compared by difference, so the sum cannot overflow
+	if quantity < 1 || quantity > product.MaxQuantity-held {
+		return fmt.Errorf("%w: %d more units of %s", ErrInvalidQuantity, quantity, p.Id)
+	}
+
+	c.UpdatedAt = now
+	if i >= 0 {
+		c.Items[i].Quantity += quantity
+		c.Items[i].Price = p.Price
+		c.Items[i].AddedAt = now
+		return nil
+	}
+	c.Items = append(c.Items, Item{ProductId: p.Id, Quantity: quantity, Price: p.Price, AddedAt: now})
+	return nil
+}
+
+// SetQuantity replaces the units of a product already in c.
+func (c *Cart) SetQuantity(productId string, quantity int, now time.Time) error {
+	i := c.find(productId)
+	if i < 0 {
+		return ErrItemNotFound
+	}
+	if quantity < 1 || quantity > product.MaxQuantity {
+		return fmt.Errorf("%w: %d units of %s", ErrInvalidQuantity, quantity, productId)
+	}
+	c.Items[i].Quantity = quantity
+	c.UpdatedAt = now
+	return nil
+}
+
+// RemoveItem takes a product out of c.
+func (c *Cart) RemoveItem(productId string, now time.Time) error {
+	i := c.find(productId)
+	if i < 0 {
+		return ErrItemNotFound
+	}
+	c.Items = slices.Delete(c.Items, i, i+1)
+	c.UpdatedAt = now
+	return nil
+}
+
+func (c *Cart) find(productId string) int {
+	return slices.IndexFunc(c.Items, func(item Item) bool { return item.ProductId == productId })
+}
+
+// Statuses of a cart line, telling whether it can be bought as it is.
+const (
+	ItemAvailable = "available"
+	// ItemOutOfStock lines are of products with no units available.
+	ItemOutOfStock = "out-of-stock"
+	// ItemInsufficientStock lines ask for more units than are available.
+	ItemInsufficientStock = "insufficient-stock"
+	// ItemDiscontinued lines are of products deleted from the catalog.
+	ItemDiscontinued = "discontinued"
+)
+
+// Totals is a cart priced from the catalog as it is now.
+type Totals struct {
+	CartId string      `json:"cartId"`
+	Items  []LineTotal `json:"items"`
+	// ItemCount and Subtotal only count the lines that are available.
+	ItemCount int     `json:"itemCount"`
+	Subtotal  float64 `json:"subtotal"`
+	// UnavailableCount is the number of lines that cannot be bought.
+	UnavailableCount int `json:"unavailableCount"`
+}
+
+// LineTotal is an item of a cart at the current price of its product.
+type LineTotal struct {
+	ProductId string  `json:"productId"`
+	Name      string  `json:"name"`
+	Quantity  int     `json:"quantity"`
+	UnitPrice float64 `json:"unitPrice"`
+	Total     float64 `json:"total"`
+	// PriceChanged tells the price is not the one the item was added at.
+	PriceChanged bool   `json:"priceChanged"`
+	Status       string `json:"status"`
+	// Available is set for products whose stock is tracked.
+	Available *int `json:"available,omitempty"`
+}
+
+// Line prices item at the current price of p, or flags it discontinued when
+// p is nil.
+func Line(item Item, p *product.Product) LineTotal {
+	line := LineTotal{ProductId: item.ProductId, Quantity: item.Quantity, UnitPrice: item.Price}
+	if p == nil {
+		line.Status = ItemDiscontinued
+		return line
+	}
+
+	line.Name = p.Name
+	line.UnitPrice = p.Price
+	line.PriceChanged = p.Price != item.Price
+	line.Total = roundCents(p.Price * float64(item.Quantity))
+	switch {
+	case !p.InStock:
+		line.Status = ItemOutOfStock
+	case p.TracksStock() && p.Available() < item.Quantity:
+		line.Status = ItemInsufficientStock
+	default:
+		line.Status = ItemAvailable
+	}
+	if p.TracksStock() {
+		available := p.Available()
+		line.Available = &available
+	}
+	return line
+}
+
+// Add counts line in the totals.
+func (t *Totals) Add(line LineTotal) {
+	t.Items = append(t.Items, line)
+	if line.Status != ItemAvailable {
+		t.UnavailableCount++
+		return
+	}
+	t.ItemCount += line.Quantity
+	t.Subtotal = roundCents(t.Subtotal + line.Total)
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
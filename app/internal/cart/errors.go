@@ -0,0 +1,9 @@
+package cart
+
+const (
+	ErrCartNotFound       = "cart/not-found"
+	ErrCartInvalidID      = "cart/invalid-id"
+	ErrCartInvalidData    = "cart/invalid-data"
+	ErrCartItemNotFound   = "cart/item-not-found"
+	ErrCartInvalidProduct = "cart/invalid-product"
+)
@@ -0,0 +1,72 @@
+package jsonstore
+
+import (
+	"context"
+
+	"github.com/lucasti79/meli-interview/internal/cart"
+	"github.com/lucasti79/meli-interview/internal/cart/repository"
+	"github.com/lucasti79/meli-interview/internal/infra/jsonstore"
+)
+
+type cartRepository struct {
+	repo *jsonstore.JSONRepository[cart.Cart]
+}
+
+// NewCartStore opens the carts file keyed by cart ID.
+func NewCartStore(fileName string, opts ...jsonstore.Option) (*jsonstore.JSONRepository[cart.Cart], error) {
+	getID := func(entity cart.Cart) string {
+		return entity.Id
+	}
+	return jsonstore.NewJSONRepository(fileName, getID, opts...)
+}
+
+func NewCartRepository(fileName string, opts ...jsonstore.Option) (repository.Repository, error) {
+	repo, err := NewCartStore(fileName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewCartRepositoryFromStore(repo), nil
+}
+
+// NewCartRepositoryFromStore wraps a store opened with NewCartStore.
+func NewCartRepositoryFromStore(repo *jsonstore.JSONRepository[cart.Cart]) repository.Repository {
+	return &cartRepository{repo: repo}
+}
+
+func (r *cartRepository) GetByID(cartId string) (*cart.Cart, error) {
+	return r.GetByIDWithContext(context.Background(), cartId)
+}
+
+func (r *cartRepository) GetByIDWithContext(ctx context.Context, cartId string) (*cart.Cart, error) {
+	c, err := r.repo.FindByIDWithContext(ctx, cartId)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *cartRepository) Create(c cart.Cart) error {
+	return r.repo.Save(c)
+}
+
+func (r *cartRepository) CreateWithContext(ctx context.Context, c cart.Cart) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return r.repo.Save(c)
+}
+
+func (r *cartRepository) Modify(cartId string, modify func(c cart.Cart) (cart.Cart, error)) (*cart.Cart, error) {
+	return r.ModifyWithContext(context.Background(), cartId, modify)
+}
+
+func (r *cartRepository) ModifyWithContext(ctx context.Context, cartId string, modify func(c cart.Cart) (cart.Cart, error)) (*cart.Cart, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c, err := r.repo.Modify(cartId, modify)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,44 @@
+package jsonstore_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lucasti79/meli-interview/internal/cart"
+	"github.com/lucasti79/meli-interview/internal/cart/infra/jsonstore"
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCartRepository_WritesAreReadBackAfterReopening(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "carts.jsonl")
+	repo, err := jsonstore.NewCartRepository(fp)
+	require.NoError(t, err)
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Create(cart.Cart{Id: "c1", Items: []cart.Item{}, CreatedAt: now, UpdatedAt: now}))
+	require.ErrorIs(t, repo.Create(cart.Cart{Id: "c1"}), apperrors.ErrResourceAlreadyExists)
+
+	updated, err := repo.ModifyWithContext(context.Background(), "c1", func(c cart.Cart) (cart.Cart, error) {
+		return c, c.AddItem(product.Product{Id: "p1", Price: 10}, 2, now)
+	})
+	require.NoError(t, err)
+	require.Len(t, updated.Items, 1)
+
+	// errors from modify write nothing
+	_, err = repo.Modify("c1", func(c cart.Cart) (cart.Cart, error) {
+		return c, c.RemoveItem("p9", now)
+	})
+	require.ErrorIs(t, err, cart.ErrItemNotFound)
+	_, err = repo.Modify("missing", func(c cart.Cart) (cart.Cart, error) { return c, nil })
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+
+	reopened, err := jsonstore.NewCartRepository(fp)
+	require.NoError(t, err)
+	got, err := reopened.GetByID("c1")
+	require.NoError(t, err)
+	require.Equal(t, *updated, *got)
+}
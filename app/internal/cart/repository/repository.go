@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/lucasti79/meli-interview/internal/cart"
+)
+
+type Repository interface {
+	GetByID(cartId string) (*cart.Cart, error)
+	GetByIDWithContext(ctx context.Context, cartId string) (*cart.Cart, error)
+	Create(c cart.Cart) error
+	CreateWithContext(ctx context.Context, c cart.Cart) error
+	// Modify replaces a cart with what modify returns from it, atomically, so
+	// concurrent item writes are not lost. Errors from modify are returned as
+	// they are and nothing is written.
+	Modify(cartId string, modify func(c cart.Cart) (cart.Cart, error)) (*cart.Cart, error)
+	ModifyWithContext(ctx context.Context, cartId string, modify func(c cart.Cart) (cart.Cart, error)) (*cart.Cart, error)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lucasti79/meli-interview/internal/cart"
+	"github.com/lucasti79/meli-interview/internal/cart/repository"
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+)
+
+// ErrUnknownProduct is returned when adding a product that is not in the
+// catalog.
+var ErrUnknownProduct = fmt.Errorf("%w: unknown product", apperrors.ErrValidation)
+
+// ProductFinder reads the products carts hold. The product repository
+// satisfies it.
+type ProductFinder interface {
+	GetByIDWithContext(ctx context.Context, productId string) (*product.Product, error)
+}
+
+type service struct {
+	repo     repository.Repository
+	products ProductFinder
+	now      func() time.Time
+}
+
+type Option func(*service)
+
+// WithClock sets where the service reads the time carts are written at.
+func WithClock(now func() time.Time) Option {
+	return func(s *service) {
+		s.now = now
+	}
+}
+
+type Service interface {
+	Create() (*cart.Cart, error)
+	CreateWithContext(ctx context.Context) (*cart.Cart, error)
+	GetByID(cartId string) (*cart.Cart, error)
+	GetByIDWithContext(ctx context.Context, cartId string) (*cart.Cart, error)
+	AddItem(cartId string, item cart.ItemRequest) (*cart.Cart, error)
+	AddItemWithContext(ctx context.Context, cartId string, item cart.ItemRequest) (*cart.Cart, error)
+	UpdateItem(cartId, productId string, quantity int) (*cart.Cart, error)
+	UpdateItemWithContext(ctx context.Context, cartId, productId string, quantity int) (*cart.Cart, error)
+	RemoveItem(cartId, productId string) (*cart.Cart, error)
+	RemoveItemWithContext(ctx context.Context, cartId, productId string) (*cart.Cart, error)
+	GetTotals(cartId string) (*cart.Totals, error)
+	GetTotalsWithContext(ctx context.Context, cartId string) (*cart.Totals, error)
+}
+
+func NewService(repo repository.Repository, products ProductFinder, opts ...Option) Service {
+	s := &service{repo: repo, products: products, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *service) Create() (*cart.Cart, error) {
+	return s.CreateWithContext(context.Background())
+}
+
+// CreateWithContext stores a new empty cart.
+func (s *service) CreateWithContext(ctx context.Context) (*cart.Cart, error) {
+	now := s.now().UTC()
+	c := cart.Cart{Id: uuid.NewString(), Items: []cart.Item{}, CreatedAt: now, UpdatedAt: now}
+	if err := s.repo.CreateWithContext(ctx, c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (s *service) GetByID(cartId string) (*cart.Cart, error) {
+	return s.repo.GetByID(cartId)
+}
+
+func (s *service) GetByIDWithContext(ctx context.Context, cartId string) (*cart.Cart, error) {
+	return s.repo.GetByIDWithContext(ctx, cartId)
+}
+
+func (s *service) AddItem(cartId string, item cart.ItemRequest) (*cart.Cart, error) {
+	return s.AddItemWithContext(context.Background(), cartId, item)
+}
+
+// AddItemWithContext adds the units of item to the cart at the current price
+// of the product. Products out of stock can be added; GetTotals flags them.
+func (s *service) AddItemWithContext(ctx context.Context, cartId string, item cart.ItemRequest) (*cart.Cart, error) {
+	p, err := s.products.GetByIDWithContext(ctx, item.ProductId)
+	if errors.Is(err, apperrors.ErrResourceNotExists) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProduct, item.ProductId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ModifyWithContext(ctx, cartId, func(c cart.Cart) (cart.Cart, error) {
+		return c, c.AddItem(*p, item.Quantity, s.now().UTC())
+	})
+}
+
+func (s *service) UpdateItem(cartId, productId string, quantity int) (*cart.Cart, error) {
+	return s.UpdateItemWithContext(context.Background(), cartId, productId, quantity)
+}
+
+func (s *service) UpdateItemWithContext(ctx context.Context, cartId, productId string, quantity int) (*cart.Cart, error) {
+	return s.repo.ModifyWithContext(ctx, cartId, func(c cart.Cart) (cart.Cart, error) {
+		return c, c.SetQuantity(productId, quantity, s.now().UTC())
+	})
+}
+
+func (s *service) RemoveItem(cartId, productId string) (*cart.Cart, error) {
+	return s.RemoveItemWithContext(context.Background(), cartId, productId)
+}
+
+func (s *service) RemoveItemWithContext(ctx context.Context, cartId, productId string) (*cart.Cart, error) {
+	return s.repo.ModifyWithContext(ctx, cartId, func(c cart.Cart) (cart.Cart, error) {
+		return c, c.RemoveItem(productId, s.now().UTC())
+	})
+}
+
+func (s *service) GetTotals(cartId string) (*cart.Totals, error) {
+	return s.GetTotalsWithContext(context.Background(), cartId)
+}
+
+// GetTotalsWithContext prices every item of the cart from the product as it
+// is now, flagging the ones that cannot be bought.
+func (s *service) GetTotalsWithContext(ctx context.Context, cartId string) (*cart.Totals, error) {
+	c, err := s.repo.GetByIDWithContext(ctx, cartId)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := &cart.Totals{CartId: c.Id, Items: make([]cart.LineTotal, 0, len(c.Items))}
+	for _, item := range c.Items {
+		p, err := s.products.GetByIDWithContext(ctx, item.ProductId)
+		if err != nil && !errors.Is(err, apperrors.ErrResourceNotExists) {
+			return nil, err
+		}
+		totals.Add(cart.Line(item, p))
+	}
+	return totals, nil
+}
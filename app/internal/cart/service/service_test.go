@@ -0,0 +1,231 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/lucasti79/meli-interview/internal/cart"
+	"github.com/lucasti79/meli-interview/internal/cart/infra/mocks"
+	"github.com/lucasti79/meli-interview/internal/cart/service"
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newService(repo *mocks.RepositoryMock, products *mocks.ProductFinderMock) service.Service {
+	return service.NewService(repo, products, service.WithClock(func() time.Time { return now }))
+}
+
+// modifying applies modify to a copy of current, as the store does with the
+// cart it reads.
+func modifying(current cart.Cart) func(context.Context, string, func(cart.Cart) (cart.Cart, error)) (*cart.Cart, error) {
+	return func(_ context.Context, _ string, modify func(cart.Cart) (cart.Cart, error)) (*cart.Cart, error) {
+		stored := current
+		stored.Items = slices.Clone(current.Items)
+		c, err := modify(stored)
+		if err != nil {
+			return nil, err
+		}
+		return &c, nil
+	}
+}
+
+func TestService_CreateWithContext_StoresAnEmptyCart(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := newService(mockRepo, new(mocks.ProductFinderMock))
+
+	ctx := context.Background()
+	mockRepo.On("CreateWithContext", ctx, mock.MatchedBy(func(c cart.Cart) bool {
+		return c.Id != "" && len(c.Items) == 0 && c.CreatedAt.Equal(now) && c.UpdatedAt.Equal(now)
+	})).Return(nil).Once()
+
+	created, err := svc.CreateWithContext(ctx)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.Id)
+	assert.NotNil(t, created.Items)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_AddItemWithContext(t *testing.T) {
+	earlier := now.Add(-time.Hour)
+	current := cart.Cart{Id: "c1", Items: []cart.Item{
+		{ProductId: "p1", Quantity: 1, Price: 10, AddedAt: earlier},
+	}, CreatedAt: earlier, UpdatedAt: earlier}
+
+	tests := []struct {
+		name string
+		item cart.ItemRequest
+		want []cart.Item
+	}{
+		{
+			name: "new product is appended",
+			item: cart.ItemRequest{ProductId: "p2", Quantity: 2},
+			want: []cart.Item{
+				{ProductId: "p1", Quantity: 1, Price: 10, AddedAt: earlier},
+				{ProductId: "p2", Quantity: 2, Price: 25, AddedAt: now},
+			},
+		},
+		{
+			name: "product in the cart gets more units at its current price",
+			item: cart.ItemRequest{ProductId: "p1", Quantity: 3},
+			want: []cart.Item{
+				{ProductId: "p1", Quantity: 4, Price: 12, AddedAt: now},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.RepositoryMock)
+			mockProducts := new(mocks.ProductFinderMock)
+			svc := newService(mockRepo, mockProducts)
+
+			ctx := context.Background()
+			mockProducts.On("GetByIDWithContext", ctx, "p1").Return(&product.Product{Id: "p1", Price: 12}, nil).Maybe()
+			mockProducts.On("GetByIDWithContext", ctx, "p2").Return(&product.Product{Id: "p2", Price: 25}, nil).Maybe()
+			mockRepo.On("ModifyWithContext", ctx, "c1", mock.Anything).Return(modifying(current)).Once()
+
+			updated, err := svc.AddItemWithContext(ctx, "c1", tt.item)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, updated.Items)
+			assert.Equal(t, now, updated.UpdatedAt)
+			assert.Equal(t, earlier, updated.CreatedAt)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_AddItemWithContext_CapsTheUnitsOfALine(t *testing.T) {
+	current := cart.Cart{Id: "c1", Items: []cart.Item{{ProductId: "p1", Quantity: product.MaxQuantity - 1, Price: 10}}}
+
+	mockRepo := new(mocks.RepositoryMock)
+	mockProducts := new(mocks.ProductFinderMock)
+	svc := newService(mockRepo, mockProducts)
+
+	ctx := context.Background()
+	mockProducts.On("GetByIDWithContext", ctx, "p1").Return(&product.Product{Id: "p1", Price: 10}, nil)
+	mockRepo.On("ModifyWithContext", ctx, "c1", mock.Anything).Return(modifying(current))
+
+	updated, err := svc.AddItemWithContext(ctx, "c1", cart.ItemRequest{ProductId: "p1", Quantity: 1})
+	require.NoError(t, err)
+	assert.Equal(t, product.MaxQuantity, updated.Items[0].Quantity)
+
+	for _, quantity := range []int{2, math.MaxInt} {
+		_, err = svc.AddItemWithContext(ctx, "c1", cart.ItemRequest{ProductId: "p1", Quantity: quantity})
+		require.ErrorIs(t, err, cart.ErrInvalidQuantity, "%d units", quantity)
+	}
+
+	_, err = svc.UpdateItemWithContext(ctx, "c1", "p1", product.MaxQuantity+1)
+	require.ErrorIs(t, err, cart.ErrInvalidQuantity)
+}
+
+func TestService_AddItemWithContext_UnknownProduct(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	mockProducts := new(mocks.ProductFinderMock)
+	svc := newService(mockRepo, mockProducts)
+
+	ctx := context.Background()
+	mockProducts.On("GetByIDWithContext", ctx, "missing").Return(nil, apperrors.ErrResourceNotExists).Once()
+
+	_, err := svc.AddItemWithContext(ctx, "c1", cart.ItemRequest{ProductId: "missing", Quantity: 1})
+
+	require.ErrorIs(t, err, service.ErrUnknownProduct)
+	require.ErrorIs(t, err, apperrors.ErrValidation)
+	mockRepo.AssertNotCalled(t, "ModifyWithContext", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_UpdateAndRemoveItemWithContext(t *testing.T) {
+	current := cart.Cart{Id: "c1", Items: []cart.Item{
+		{ProductId: "p1", Quantity: 1, Price: 10},
+		{ProductId: "p2", Quantity: 2, Price: 25},
+	}}
+
+	mockRepo := new(mocks.RepositoryMock)
+	svc := newService(mockRepo, new(mocks.ProductFinderMock))
+
+	ctx := context.Background()
+	mockRepo.On("ModifyWithContext", ctx, "c1", mock.Anything).Return(modifying(current))
+
+	updated, err := svc.UpdateItemWithContext(ctx, "c1", "p2", 5)
+	require.NoError(t, err)
+	assert.Equal(t, []cart.Item{{ProductId: "p1", Quantity: 1, Price: 10}, {ProductId: "p2", Quantity: 5, Price: 25}}, updated.Items)
+
+	updated, err = svc.RemoveItemWithContext(ctx, "c1", "p1")
+	require.NoError(t, err)
+	assert.Equal(t, []cart.Item{{ProductId: "p2", Quantity: 2, Price: 25}}, updated.Items)
+
+	_, err = svc.UpdateItemWithContext(ctx, "c1", "p3", 1)
+	require.ErrorIs(t, err, cart.ErrItemNotFound)
+	_, err = svc.RemoveItemWithContext(ctx, "c1", "p3")
+	require.ErrorIs(t, err, cart.ErrItemNotFound)
+}
+
+func TestService_GetTotalsWithContext_RepricesAndFlagsUnavailableItems(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	mockProducts := new(mocks.ProductFinderMock)
+	svc := newService(mockRepo, mockProducts)
+
+	ctx := context.Background()
+	mockRepo.On("GetByIDWithContext", ctx, "c1").Return(&cart.Cart{Id: "c1", Items: []cart.Item{
+		{ProductId: "repriced", Quantity: 3, Price: 10},
+		{ProductId: "untracked", Quantity: 1, Price: 5.5},
+		{ProductId: "sold-out", Quantity: 1, Price: 20},
+		{ProductId: "scarce", Quantity: 4, Price: 8},
+		{ProductId: "deleted", Quantity: 2, Price: 15},
+	}}, nil).Once()
+	mockProducts.On("GetByIDWithContext", ctx, "repriced").
+		Return(&product.Product{Id: "repriced", Name: "Mouse", Price: 9.99, InStock: true, Stock: product.Int(10)}, nil)
+	mockProducts.On("GetByIDWithContext", ctx, "untracked").
+		Return(&product.Product{Id: "untracked", Name: "Mug", Price: 5.5, InStock: true}, nil)
+	mockProducts.On("GetByIDWithContext", ctx, "sold-out").
+		Return(&product.Product{Id: "sold-out", Name: "Lamp", Price: 20, Stock: product.Int(2), Reserved: 2}, nil)
+	mockProducts.On("GetByIDWithContext", ctx, "scarce").
+		Return(&product.Product{Id: "scarce", Name: "Pen", Price: 8, InStock: true, Stock: product.Int(3)}, nil)
+	mockProducts.On("GetByIDWithContext", ctx, "deleted").Return(nil, apperrors.ErrResourceNotExists)
+
+	totals, err := svc.GetTotalsWithContext(ctx, "c1")
+
+	require.NoError(t, err)
+	assert.Equal(t, &cart.Totals{
+		CartId: "c1",
+		Items: []cart.LineTotal{
+			{ProductId: "repriced", Name: "Mouse", Quantity: 3, UnitPrice: 9.99, Total: 29.97, PriceChanged: true, Status: cart.ItemAvailable, Available: product.Int(10)},
+			{ProductId: "untracked", Name: "Mug", Quantity: 1, UnitPrice: 5.5, Total: 5.5, Status: cart.ItemAvailable},
+			{ProductId: "sold-out", Name: "Lamp", Quantity: 1, UnitPrice: 20, Total: 20, Status: cart.ItemOutOfStock, Available: product.Int(0)},
+			{ProductId: "scarce", Name: "Pen", Quantity: 4, UnitPrice: 8, Total: 32, Status: cart.ItemInsufficientStock, Available: product.Int(3)},
+			{ProductId: "deleted", Quantity: 2, UnitPrice: 15, Status: cart.ItemDiscontinued},
+		},
+		ItemCount:        4,
+		Subtotal:         35.47,
+		UnavailableCount: 3,
+	}, totals)
+	mockProducts.AssertExpectations(t)
+}
+
+func TestService_GetTotalsWithContext_Errors(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	mockProducts := new(mocks.ProductFinderMock)
+	svc := newService(mockRepo, mockProducts)
+
+	ctx := context.Background()
+	mockRepo.On("GetByIDWithContext", ctx, "missing").Return(nil, apperrors.ErrResourceNotExists).Once()
+	_, err := svc.GetTotalsWithContext(ctx, "missing")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+
+	readErr := errors.New("disk failure")
+	mockRepo.On("GetByIDWithContext", ctx, "c1").
+		Return(&cart.Cart{Id: "c1", Items: []cart.Item{{ProductId: "p1", Quantity: 1}}}, nil).Once()
+	mockProducts.On("GetByIDWithContext", ctx, "p1").Return(nil, readErr).Once()
+	_, err = svc.GetTotalsWithContext(ctx, "c1")
+	require.ErrorIs(t, err, readErr)
+}
@@ -6,10 +6,12 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator"
+	"github.com/google/uuid"
 	"github.com/lucasti79/meli-interview/internal/category"
 	"github.com/lucasti79/meli-interview/internal/category/service"
 	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
 	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/lucasti79/meli-interview/pkg/web/request"
 	"github.com/lucasti79/meli-interview/pkg/web/response"
 )
 
@@ -34,16 +36,13 @@ func NewHandler(service service.Service) *Handler {
 // @Success      200  {object}  CategoriesResult
 // @Failure      400  {object}  httpdto.ErrorResponse
 // @Failure      500  {object}  httpdto.ErrorResponse
+// @Failure      504  {object}  httpdto.ErrorResponse
 // @Router       /api/v1/categories [get]
 func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 	categories, err := h.service.GetAllWithContext(r.Context())
 
 	if err != nil {
-		response.JSON(w, http.StatusInternalServerError, httpdto.ErrorResponse{
-			Code:    apperrors.ErrInternalError.Error(),
-			Message: "internal server error",
-			Status:  http.StatusText(http.StatusInternalServerError),
-		})
+		writeInternalError(w, err)
 		return
 	}
 
@@ -61,7 +60,7 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 
 // GetByName godoc
 // @Summary      Get a category by name
-// @Description  Get a category by name
+// @Description  Get a category by its ID, its slug or its name
 // @Tags         Categories
 // @Accept       json
 // @Produce      json
@@ -70,6 +69,7 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Failure      400  {object}  httpdto.ErrorResponse
 // @Failure      404  {object}  httpdto.ErrorResponse
 // @Failure      500  {object}  httpdto.ErrorResponse
+// @Failure      504  {object}  httpdto.ErrorResponse
 // @Router       /api/v1/categories/{categoryName} [get]
 func (h *Handler) GetByName(w http.ResponseWriter, r *http.Request) {
 	categoryName := chi.URLParam(r, "categoryName")
@@ -94,11 +94,7 @@ func (h *Handler) GetByName(w http.ResponseWriter, r *http.Request) {
 				Status:  http.StatusText(http.StatusNotFound),
 			})
 		default:
-			response.JSON(w, http.StatusInternalServerError, httpdto.ErrorResponse{
-				Code:    apperrors.ErrInternalError.Error(),
-				Message: "internal server error",
-				Status:  http.StatusText(http.StatusInternalServerError),
-			})
+			writeInternalError(w, err)
 		}
 		return
 	}
@@ -108,3 +104,271 @@ func (h *Handler) GetByName(w http.ResponseWriter, r *http.Request) {
 	}
 	response.JSON(w, http.StatusOK, result)
 }
+
+// GetTree godoc
+// @Summary      Get the category tree
+// @Description  Get every category nested under its parent. Siblings are ordered by name
+// @Tags         Categories
+// @Produce      json
+// @Success      200  {object}  CategoryTreeResult
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Failure      504  {object}  httpdto.ErrorResponse
+// @Router       /api/v1/categories/tree [get]
+func (h *Handler) GetTree(w http.ResponseWriter, r *http.Request) {
+	tree, err := h.service.GetTreeWithContext(r.Context())
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httpdto.Result[[]*category.Node]{Data: tree})
+}
+
+// GetBreadcrumbs godoc
+// @Summary      Get the breadcrumbs of a category
+// @Description  Get the categories from the root down to the one given by ID, slug or name
+// @Tags         Categories
+// @Produce      json
+// @Param        categoryName   path      string  true  "Category ID, slug or name"
+// @Success      200  {object}  CategoriesResult
+// @Failure      404  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Failure      504  {object}  httpdto.ErrorResponse
+// @Router       /api/v1/categories/{categoryName}/breadcrumbs [get]
+func (h *Handler) GetBreadcrumbs(w http.ResponseWriter, r *http.Request) {
+	categoryName := chi.URLParam(r, "categoryName")
+	if categoryName == "" {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    category.ErrCategoryInvalidID,
+			Message: "category name is required",
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	path, err := h.service.GetBreadcrumbsWithContext(r.Context(), categoryName)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httpdto.Result[[]category.Category]{Data: path})
+}
+
+// Create godoc
+// @Summary      Create a category
+// @Description  Add a new category. The ID is generated when omitted and the slug is derived from the name
+// @Tags         Categories
+// @Accept       json
+// @Produce      json
+// @Param        category  body      category.Category  true  "Category"
+// @Success      201  {object}  CategoryResult
+// @Failure      400  {object}  httpdto.ErrorResponse
+// @Failure      401  {object}  httpdto.ErrorResponse
+// @Failure      403  {object}  httpdto.ErrorResponse
+// @Failure      409  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /api/v1/categories [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var cat category.Category
+	if err := request.JSON(r, &cat); err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    category.ErrCategoryInvalidData,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	if cat.Id == "" {
+		cat.Id = uuid.NewString()
+	}
+	if !h.validate(w, &cat) {
+		return
+	}
+
+	if err := h.service.CreateWithContext(r.Context(), cat); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", r.URL.Path+"/"+cat.Id)
+	response.JSON(w, http.StatusCreated, httpdto.Result[*category.Category]{Data: &cat})
+}
+
+// Update godoc
+// @Summary      Replace a category
+// @Description  Replace every field of an existing category. Categories with products cannot be renamed
+// @Tags         Categories
+// @Accept       json
+// @Produce      json
+// @Param        categoryId  path      string             true  "Category ID"
+// @Param        category    body      category.Category  true  "Category"
+// @Success      200  {object}  CategoryResult
+// @Failure      400  {object}  httpdto.ErrorResponse
+// @Failure      401  {object}  httpdto.ErrorResponse
+// @Failure      403  {object}  httpdto.ErrorResponse
+// @Failure      404  {object}  httpdto.ErrorResponse
+// @Failure      409  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /api/v1/categories/{categoryId} [put]
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	categoryId := chi.URLParam(r, "categoryId")
+	if categoryId == "" {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    category.ErrCategoryInvalidID,
+			Message: "category ID is required",
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	var cat category.Category
+	if err := request.JSON(r, &cat); err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    category.ErrCategoryInvalidData,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	if cat.Id != "" && cat.Id != categoryId {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    category.ErrCategoryInvalidID,
+			Message: "category ID in body does not match the path",
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+	cat.Id = categoryId
+
+	if !h.validate(w, &cat) {
+		return
+	}
+
+	if err := h.service.UpdateWithContext(r.Context(), cat); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httpdto.Result[*category.Category]{Data: &cat})
+}
+
+// Delete godoc
+// @Summary      Delete a category
+// @Description  Remove a category. Categories with subcategories or products cannot be deleted
+// @Tags         Categories
+// @Produce      json
+// @Param        categoryId  path  string  true  "Category ID"
+// @Success      204  "No content"
+// @Failure      400  {object}  httpdto.ErrorResponse
+// @Failure      401  {object}  httpdto.ErrorResponse
+// @Failure      403  {object}  httpdto.ErrorResponse
+// @Failure      404  {object}  httpdto.ErrorResponse
+// @Failure      409  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /api/v1/categories/{categoryId} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	categoryId := chi.URLParam(r, "categoryId")
+	if categoryId == "" {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    category.ErrCategoryInvalidID,
+			Message: "category ID is required",
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	if err := h.service.DeleteWithContext(r.Context(), categoryId); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validate derives a missing slug from the name and checks the category,
+// writing a 400 response when it is invalid.
+func (h *Handler) validate(w http.ResponseWriter, cat *category.Category) bool {
+	if cat.Slug == "" {
+		cat.Slug = category.Slugify(cat.Name)
+	}
+
+	if err := h.validator.Struct(cat); err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    category.ErrCategoryInvalidData,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return false
+	}
+
+	if cat.Slug != category.Slugify(cat.Slug) {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    category.ErrCategoryInvalidData,
+			Message: "slug must only contain lowercase letters, digits and dashes",
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return false
+	}
+	return true
+}
+
+// writeError maps an error from the category service to its response.
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrResourceNotExists):
+		response.JSON(w, http.StatusNotFound, httpdto.ErrorResponse{
+			Code:    category.ErrCategoryNotFound,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusNotFound),
+		})
+	case errors.Is(err, apperrors.ErrResourceAlreadyExists):
+		response.JSON(w, http.StatusConflict, httpdto.ErrorResponse{
+			Code:    category.ErrCategoryAlreadyExists,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusConflict),
+		})
+	case errors.Is(err, service.ErrHasChildren):
+		response.JSON(w, http.StatusConflict, httpdto.ErrorResponse{
+			Code:    category.ErrCategoryHasChildren,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusConflict),
+		})
+	case errors.Is(err, service.ErrInUse):
+		response.JSON(w, http.StatusConflict, httpdto.ErrorResponse{
+			Code:    category.ErrCategoryInUse,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusConflict),
+		})
+	case errors.Is(err, service.ErrInvalidParent):
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    category.ErrCategoryInvalidParent,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+	default:
+		writeInternalError(w, err)
+	}
+}
+
+// writeInternalError answers an unexpected service error, telling apart the
+// requests whose context ended before the catalog could be read.
+func writeInternalError(w http.ResponseWriter, err error) {
+	if status, body, ok := httpdto.ContextError(err); ok {
+		response.JSON(w, status, body)
+		return
+	}
+	response.JSON(w, http.StatusInternalServerError, httpdto.ErrorResponse{
+		Code:    apperrors.ErrInternalError.Error(),
+		Message: "internal server error",
+		Status:  http.StatusText(http.StatusInternalServerError),
+	})
+}
@@ -1,14 +1,18 @@
 package api_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/lucasti79/meli-interview/internal/category"
 	api "github.com/lucasti79/meli-interview/internal/category/api"
 	"github.com/lucasti79/meli-interview/internal/category/infra/mocks"
+	"github.com/lucasti79/meli-interview/internal/category/service"
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
 	"github.com/lucasti79/meli-interview/pkg/apperrors"
 	"github.com/lucasti79/meli-interview/pkg/testutil"
 	"github.com/stretchr/testify/assert"
@@ -129,3 +133,263 @@ func TestHandler_GetAll_InternalError(t *testing.T) {
 	assert.Contains(t, w.Body.String(), apperrors.ErrInternalError.Error())
 	mockSvc.AssertExpectations(t)
 }
+
+func TestHandler_GetAll_ContextErrors(t *testing.T) {
+	tests := map[string]struct {
+		err    error
+		status int
+		code   string
+	}{
+		"canceled": {err: context.Canceled, status: httpdto.StatusClientClosedRequest, code: apperrors.ErrRequestCanceled.Error()},
+		"timeout":  {err: context.DeadlineExceeded, status: http.StatusGatewayTimeout, code: apperrors.ErrRequestTimeout.Error()},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockSvc := new(mocks.ServiceMock)
+			h := api.NewHandler(mockSvc)
+
+			mockSvc.On("GetAllWithContext", mock.Anything).Return(nil, tt.err).Once()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/categories", nil)
+			w := httptest.NewRecorder()
+
+			h.GetAll(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Contains(t, w.Body.String(), tt.code)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_Create_GeneratesIDAndSlug(t *testing.T) {
+	mockSvc := new(mocks.ServiceMock)
+	h := api.NewHandler(mockSvc)
+
+	mockSvc.On("CreateWithContext", mock.Anything, mock.MatchedBy(func(c category.Category) bool {
+		return c.Id != "" && c.Slug == "casa-jardim" && c.Name == "Casa & Jardim"
+	})).Return(nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/categories", strings.NewReader(`{"name":"Casa & Jardim"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	h.Create(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Header().Get("Location"), "/api/v1/categories/")
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHandler_Create_InvalidData(t *testing.T) {
+	tests := map[string]string{
+		"malformed":    `{"name":`,
+		"missing name": `{"slug":"books"}`,
+		"invalid slug": `{"name":"Books","slug":"Books Stuff"}`,
+	}
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockSvc := new(mocks.ServiceMock)
+			h := api.NewHandler(mockSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/categories", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			h.Create(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), category.ErrCategoryInvalidData)
+			mockSvc.AssertNotCalled(t, "CreateWithContext", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestHandler_Create_ServiceErrors(t *testing.T) {
+	tests := map[string]struct {
+		err    error
+		status int
+		code   string
+	}{
+		"conflict":       {err: apperrors.ErrResourceAlreadyExists, status: http.StatusConflict, code: category.ErrCategoryAlreadyExists},
+		"invalid parent": {err: service.ErrInvalidParent, status: http.StatusBadRequest, code: category.ErrCategoryInvalidParent},
+		"internal":       {err: errors.New("some error"), status: http.StatusInternalServerError, code: apperrors.ErrInternalError.Error()},
+		"canceled":       {err: context.Canceled, status: httpdto.StatusClientClosedRequest, code: apperrors.ErrRequestCanceled.Error()},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockSvc := new(mocks.ServiceMock)
+			h := api.NewHandler(mockSvc)
+
+			mockSvc.On("CreateWithContext", mock.Anything, mock.AnythingOfType("category.Category")).Return(tt.err).Once()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/categories", strings.NewReader(`{"name":"Books","parentId":"c1"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			h.Create(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Contains(t, w.Body.String(), tt.code)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_Update_Success(t *testing.T) {
+	mockSvc := new(mocks.ServiceMock)
+	h := api.NewHandler(mockSvc)
+
+	expected := category.Category{Id: "c1", Slug: "livros", Name: "Livros", Description: "Livros e revistas"}
+	mockSvc.On("UpdateWithContext", mock.Anything, expected).Return(nil).Once()
+
+	body := `{"name":"Livros","slug":"livros","description":"Livros e revistas"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/categories/c1", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = testutil.WithUrlParam(t, req, "categoryId", "c1")
+	w := httptest.NewRecorder()
+
+	h.Update(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHandler_Update_IDMismatch(t *testing.T) {
+	mockSvc := new(mocks.ServiceMock)
+	h := api.NewHandler(mockSvc)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/categories/c1", strings.NewReader(`{"categoryId":"c2","name":"Livros"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req = testutil.WithUrlParam(t, req, "categoryId", "c1")
+	w := httptest.NewRecorder()
+
+	h.Update(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), category.ErrCategoryInvalidID)
+}
+
+func TestHandler_Update_NotFound(t *testing.T) {
+	mockSvc := new(mocks.ServiceMock)
+	h := api.NewHandler(mockSvc)
+
+	mockSvc.On("UpdateWithContext", mock.Anything, mock.AnythingOfType("category.Category")).Return(apperrors.ErrResourceNotExists).Once()
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/categories/c1", strings.NewReader(`{"name":"Livros"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req = testutil.WithUrlParam(t, req, "categoryId", "c1")
+	w := httptest.NewRecorder()
+
+	h.Update(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), category.ErrCategoryNotFound)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHandler_Delete(t *testing.T) {
+	mockSvc := new(mocks.ServiceMock)
+	h := api.NewHandler(mockSvc)
+
+	mockSvc.On("DeleteWithContext", mock.Anything, "c1").Return(nil).Once()
+	mockSvc.On("DeleteWithContext", mock.Anything, "c2").Return(apperrors.ErrResourceNotExists).Once()
+
+	for id, status := range map[string]int{"c1": http.StatusNoContent, "c2": http.StatusNotFound} {
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/categories/"+id, nil)
+		req = testutil.WithUrlParam(t, req, "categoryId", id)
+		w := httptest.NewRecorder()
+
+		h.Delete(w, req)
+
+		assert.Equal(t, status, w.Code, id)
+	}
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHandler_GetTree(t *testing.T) {
+	mockSvc := new(mocks.ServiceMock)
+	h := api.NewHandler(mockSvc)
+
+	tree := []*category.Node{{
+		Category: category.Category{Id: "c1", Slug: "electronics", Name: "Electronics"},
+		Children: []*category.Node{{Category: category.Category{Id: "c2", Slug: "audio", Name: "Audio", ParentId: "c1"}, Children: []*category.Node{}}},
+	}}
+	mockSvc.On("GetTreeWithContext", mock.Anything).Return(tree, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/categories/tree", nil)
+	w := httptest.NewRecorder()
+
+	h.GetTree(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data":[{"categoryId":"c1","slug":"electronics","name":"Electronics","children":[
+		{"categoryId":"c2","slug":"audio","name":"Audio","parentId":"c1","children":[]}]}]}`, w.Body.String())
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHandler_GetTree_InternalError(t *testing.T) {
+	mockSvc := new(mocks.ServiceMock)
+	h := api.NewHandler(mockSvc)
+
+	mockSvc.On("GetTreeWithContext", mock.Anything).Return([]*category.Node(nil), errors.New("some error")).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/categories/tree", nil)
+	w := httptest.NewRecorder()
+
+	h.GetTree(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestHandler_GetBreadcrumbs(t *testing.T) {
+	mockSvc := new(mocks.ServiceMock)
+	h := api.NewHandler(mockSvc)
+
+	path := []category.Category{{Id: "c1", Name: "Electronics"}, {Id: "c2", Name: "Audio", ParentId: "c1"}}
+	mockSvc.On("GetBreadcrumbsWithContext", mock.Anything, "audio").Return(path, nil).Once()
+	mockSvc.On("GetBreadcrumbsWithContext", mock.Anything, "missing").Return(nil, apperrors.ErrResourceNotExists).Once()
+
+	for name, status := range map[string]int{"audio": http.StatusOK, "missing": http.StatusNotFound} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/categories/"+name+"/breadcrumbs", nil)
+		req = testutil.WithUrlParam(t, req, "categoryName", name)
+		w := httptest.NewRecorder()
+
+		h.GetBreadcrumbs(w, req)
+
+		assert.Equal(t, status, w.Code, name)
+	}
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHandler_Delete_HasChildren(t *testing.T) {
+	mockSvc := new(mocks.ServiceMock)
+	h := api.NewHandler(mockSvc)
+
+	mockSvc.On("DeleteWithContext", mock.Anything, "c1").Return(service.ErrHasChildren).Once()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/categories/c1", nil)
+	req = testutil.WithUrlParam(t, req, "categoryId", "c1")
+	w := httptest.NewRecorder()
+
+	h.Delete(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), category.ErrCategoryHasChildren)
+}
+
+func TestHandler_Delete_InUse(t *testing.T) {
+	mockSvc := new(mocks.ServiceMock)
+	h := api.NewHandler(mockSvc)
+
+	mockSvc.On("DeleteWithContext", mock.Anything, "c1").Return(service.ErrInUse).Once()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/categories/c1", nil)
+	req = testutil.WithUrlParam(t, req, "categoryId", "c1")
+	w := httptest.NewRecorder()
+
+	h.Delete(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), category.ErrCategoryInUse)
+}
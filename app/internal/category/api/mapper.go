@@ -4,10 +4,15 @@ import "github.com/lucasti79/meli-interview/internal/category"
 
 // swagger:model CategoryResult
 type CategoryResult struct {
-	Data []category.Category `json:"data"`
+	Data category.Category `json:"data"`
 }
 
 // swagger:model CategoriesResult
 type CategoriesResult struct {
-	Data *[]category.Category `json:"data"`
+	Data []category.Category `json:"data"`
+}
+
+// swagger:model CategoryTreeResult
+type CategoryTreeResult struct {
+	Data []category.Node `json:"data"`
 }
@@ -1,5 +1,38 @@
 package category
 
+import (
+	"strings"
+
+	"github.com/lucasti79/meli-interview/pkg/textsearch"
+)
+
+// Category is stored in its own file. Products reference it by Name, which is
+// also what the storefront displays; Slug is the URL friendly form.
 type Category struct {
-	Name string `json:"name"`
+	Id          string `json:"categoryId" validate:"required"`
+	Slug        string `json:"slug" validate:"required"`
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description,omitempty"`
+	ParentId    string `json:"parentId,omitempty"`
+	Image       string `json:"image,omitempty"`
+	// Stats are computed from the products on read and never stored.
+	Stats *Stats `json:"stats,omitempty"`
+}
+
+// Stats summarize the products filed directly under a category. Prices and
+// rating are zero when the category has no products; the rating averages the
+// products that have one.
+type Stats struct {
+	ProductCount int     `json:"productCount"`
+	InStockCount int     `json:"inStockCount"`
+	MinPrice     float64 `json:"minPrice"`
+	MaxPrice     float64 `json:"maxPrice"`
+	AvgPrice     float64 `json:"avgPrice"`
+	AvgRating    float64 `json:"avgRating"`
+}
+
+// Slugify derives a slug from a display name: "Casa & Jardim" becomes
+// "casa-jardim".
+func Slugify(name string) string {
+	return strings.Join(textsearch.Tokenize(name), "-")
 }
@@ -6,4 +6,7 @@ const (
 	ErrCategoryInvalidID     = "category/invalid-id"
 	ErrCategoryInvalidData   = "category/invalid-data"
 	ErrCategoryNotAvailable  = "category/not-available"
+	ErrCategoryInvalidParent = "category/invalid-parent"
+	ErrCategoryHasChildren   = "category/has-children"
+	ErrCategoryInUse         = "category/in-use"
 )
@@ -0,0 +1,163 @@
+package boltstore
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/lucasti79/meli-interview/internal/category"
+	"github.com/lucasti79/meli-interview/internal/category/repository"
+	"github.com/lucasti79/meli-interview/internal/infra/boltstore"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Bucket and index names of the store opened by NewCategoryStore.
+const (
+	Bucket    = "categories"
+	SlugIndex = "slug"
+	NameIndex = "name"
+)
+
+type categoryRepository struct {
+	store *boltstore.Store[category.Category]
+}
+
+// NewCategoryStore opens the categories bucket of db keyed by category ID.
+// Slugs and names are unique regardless of case, which the store enforces on
+// every write.
+func NewCategoryStore(db *bolt.DB) (*boltstore.Store[category.Category], error) {
+	getID := func(entity category.Category) string {
+		return entity.Id
+	}
+	return boltstore.NewStore(db, Bucket, getID,
+		boltstore.WithIndex(boltstore.NewUniqueKeywordIndex(SlugIndex, func(c category.Category) string {
+			return strings.ToLower(c.Slug)
+		})),
+		boltstore.WithIndex(boltstore.NewUniqueKeywordIndex(NameIndex, func(c category.Category) string {
+			return strings.ToLower(c.Name)
+		})),
+	)
+}
+
+// NewCategoryRepository serves categories from a store opened with
+// NewCategoryStore.
+func NewCategoryRepository(store *boltstore.Store[category.Category]) repository.Repository {
+	return &categoryRepository{store: store}
+}
+
+func (r *categoryRepository) GetAll() ([]category.Category, error) {
+	return r.getAll(context.Background())
+}
+
+func (r *categoryRepository) GetAllWithContext(ctx context.Context) ([]category.Category, error) {
+	return r.getAll(ctx)
+}
+
+// getAll returns every category ordered by name.
+func (r *categoryRepository) getAll(ctx context.Context) ([]category.Category, error) {
+	categories := make([]category.Category, 0)
+	err := r.store.View(ctx, func(tx *boltstore.Tx[category.Category]) error {
+		return tx.Scan(func(c category.Category) error {
+			categories = append(categories, c)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		return strings.ToLower(categories[i].Name) < strings.ToLower(categories[j].Name)
+	})
+	return categories, nil
+}
+
+func (r *categoryRepository) GetByID(categoryId string) (*category.Category, error) {
+	return r.GetByIDWithContext(context.Background(), categoryId)
+}
+
+func (r *categoryRepository) GetByIDWithContext(ctx context.Context, categoryId string) (*category.Category, error) {
+	var found category.Category
+	err := r.store.View(ctx, func(tx *boltstore.Tx[category.Category]) error {
+		var err error
+		found, err = tx.Get(categoryId)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
+
+func (r *categoryRepository) GetByName(name string) (*category.Category, error) {
+	return r.getByName(context.Background(), name)
+}
+
+func (r *categoryRepository) GetByNameWithContext(ctx context.Context, name string) (*category.Category, error) {
+	return r.getByName(ctx, name)
+}
+
+// getByName resolves a category from its ID, its slug or its name, the last
+// two ignoring case.
+func (r *categoryRepository) getByName(ctx context.Context, name string) (*category.Category, error) {
+	var found *category.Category
+	err := r.store.View(ctx, func(tx *boltstore.Tx[category.Category]) error {
+		c, err := tx.Get(name)
+		if err == nil {
+			found = &c
+			return nil
+		}
+		if !errors.Is(err, apperrors.ErrResourceNotExists) {
+			return err
+		}
+
+		key := strings.ToLower(name)
+		bySlug, err := tx.Lookup(SlugIndex, key)
+		if err != nil {
+			return err
+		}
+		byName, err := tx.Lookup(NameIndex, key)
+		if err != nil {
+			return err
+		}
+		return tx.Load(boltstore.Union(bySlug, byName), func(c category.Category) error {
+			if found == nil {
+				found = &c
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, apperrors.ErrResourceNotExists
+	}
+	return found, nil
+}
+
+func (r *categoryRepository) Create(c category.Category) error {
+	return r.store.Insert(c)
+}
+
+func (r *categoryRepository) CreateWithContext(ctx context.Context, c category.Category) error {
+	return r.store.Insert(c)
+}
+
+func (r *categoryRepository) Update(c category.Category) error {
+	return r.store.Update(c)
+}
+
+func (r *categoryRepository) UpdateWithContext(ctx context.Context, c category.Category) error {
+	return r.store.Update(c)
+}
+
+func (r *categoryRepository) Delete(categoryId string) error {
+	return r.store.Delete(categoryId)
+}
+
+func (r *categoryRepository) DeleteWithContext(ctx context.Context, categoryId string) error {
+	return r.store.Delete(categoryId)
+}
@@ -0,0 +1,42 @@
+package boltstore_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/lucasti79/meli-interview/internal/category"
+	CategoryBoltRepository "github.com/lucasti79/meli-interview/internal/category/infra/boltstore"
+	"github.com/lucasti79/meli-interview/internal/category/repository"
+	"github.com/lucasti79/meli-interview/internal/category/repository/repositorytest"
+	"github.com/lucasti79/meli-interview/internal/infra/boltstore"
+	"github.com/lucasti79/meli-interview/internal/product"
+	ProductBoltRepository "github.com/lucasti79/meli-interview/internal/product/infra/boltstore"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func openDB(t *testing.T) *bolt.DB {
+	t.Helper()
+	db, err := boltstore.Open(filepath.Join(t.TempDir(), "catalog.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestCategoryRepository_Contract(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T, categories []category.Category) repository.Repository {
+		store, err := CategoryBoltRepository.NewCategoryStore(openDB(t))
+		require.NoError(t, err)
+		require.NoError(t, store.InsertAll(categories))
+		return CategoryBoltRepository.NewCategoryRepository(store)
+	})
+}
+
+func TestStatsRepository_Contract(t *testing.T) {
+	repositorytest.RunStats(t, func(t *testing.T, products []product.Product) repository.StatsRepository {
+		store, err := ProductBoltRepository.NewProductStore(openDB(t))
+		require.NoError(t, err)
+		require.NoError(t, store.InsertAll(products))
+		return CategoryBoltRepository.NewStatsRepository(store)
+	})
+}
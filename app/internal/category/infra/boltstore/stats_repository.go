@@ -0,0 +1,72 @@
+package boltstore
+
+import (
+	"context"
+	"strings"
+
+	"github.com/lucasti79/meli-interview/internal/category"
+	"github.com/lucasti79/meli-interview/internal/category/repository"
+	"github.com/lucasti79/meli-interview/internal/infra/boltstore"
+	"github.com/lucasti79/meli-interview/internal/product"
+	ProductBoltRepository "github.com/lucasti79/meli-interview/internal/product/infra/boltstore"
+)
+
+type statsRepository struct {
+	products *boltstore.Store[product.Product]
+}
+
+// NewStatsRepository computes category stats from a product store opened with
+// ProductBoltRepository.NewProductStore. Stats for a few categories only read
+// their products, found through the category index.
+func NewStatsRepository(products *boltstore.Store[product.Product]) repository.StatsRepository {
+	return &statsRepository{products: products}
+}
+
+func (r *statsRepository) GetStats(names []string) (map[string]category.Stats, error) {
+	return r.getStats(context.Background(), names)
+}
+
+func (r *statsRepository) GetStatsWithContext(ctx context.Context, names []string) (map[string]category.Stats, error) {
+	return r.getStats(ctx, names)
+}
+
+// getStats aggregates the products of names, or of every category when names
+// is empty, in a single transaction.
+func (r *statsRepository) getStats(ctx context.Context, names []string) (map[string]category.Stats, error) {
+	keys := make([]string, len(names))
+	for i, name := range names {
+		keys[i] = strings.ToLower(name)
+	}
+
+	counters := make(map[string]*category.StatsCounter)
+	add := func(p product.Product) error {
+		key := strings.ToLower(p.Category)
+		c, ok := counters[key]
+		if !ok {
+			c = &category.StatsCounter{}
+			counters[key] = c
+		}
+		c.Add(p.Price, p.Rating, p.InStock)
+		return nil
+	}
+
+	err := r.products.View(ctx, func(tx *boltstore.Tx[product.Product]) error {
+		if len(keys) == 0 {
+			return tx.Scan(add)
+		}
+		set, err := tx.Lookup(ProductBoltRepository.CategoryIndex, keys...)
+		if err != nil {
+			return err
+		}
+		return tx.Load(set, add)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]category.Stats, len(counters))
+	for key, c := range counters {
+		stats[key] = c.Stats()
+	}
+	return stats, nil
+}
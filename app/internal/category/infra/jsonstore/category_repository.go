@@ -2,109 +2,168 @@ package jsonstore
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"strings"
 
 	"github.com/lucasti79/meli-interview/internal/category"
 	"github.com/lucasti79/meli-interview/internal/category/repository"
 	"github.com/lucasti79/meli-interview/internal/infra/jsonstore"
-	"github.com/lucasti79/meli-interview/internal/product"
 	"github.com/lucasti79/meli-interview/pkg/apperrors"
 )
 
+// Names of the secondary indexes registered by NewCategoryStore.
+const (
+	SlugIndex = "slug"
+	NameIndex = "name"
+)
+
 type categoryRepository struct {
-	repo *jsonstore.JSONRepository[product.Product]
+	repo   *jsonstore.JSONRepository[category.Category]
+	bySlug *jsonstore.KeywordIndex[category.Category]
+	byName *jsonstore.KeywordIndex[category.Category]
 }
 
-func NewCategoryRepository(fileName string) (repository.Repository, error) {
-	getID := func(entity product.Product) string {
-		return entity.Category
+// NewCategoryStore opens the categories file keyed by category ID. Slugs and
+// names are unique regardless of case, which the store enforces on every write.
+func NewCategoryStore(fileName string, opts ...jsonstore.Option) (*jsonstore.JSONRepository[category.Category], error) {
+	getID := func(entity category.Category) string {
+		return entity.Id
 	}
-	repo, err := jsonstore.NewJSONRepository(fileName, getID)
+	opts = append([]jsonstore.Option{
+		jsonstore.WithIndex(SlugIndex, jsonstore.NewUniqueKeywordIndex(func(c category.Category) string {
+			return strings.ToLower(c.Slug)
+		})),
+		jsonstore.WithIndex(NameIndex, jsonstore.NewUniqueKeywordIndex(func(c category.Category) string {
+			return strings.ToLower(c.Name)
+		})),
+	}, opts...)
+	return jsonstore.NewJSONRepository(fileName, getID, opts...)
+}
+
+func NewCategoryRepository(fileName string, opts ...jsonstore.Option) (repository.Repository, error) {
+	repo, err := NewCategoryStore(fileName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return &categoryRepository{repo: repo}, nil
+	return NewCategoryRepositoryFromStore(repo), nil
+}
+
+// NewCategoryRepositoryFromStore wraps a store opened with NewCategoryStore.
+func NewCategoryRepositoryFromStore(repo *jsonstore.JSONRepository[category.Category]) repository.Repository {
+	r := &categoryRepository{repo: repo}
+	r.bySlug, _ = repo.Index(SlugIndex).(*jsonstore.KeywordIndex[category.Category])
+	r.byName, _ = repo.Index(NameIndex).(*jsonstore.KeywordIndex[category.Category])
+	return r
 }
 
 func (r *categoryRepository) GetAll() ([]category.Category, error) {
-	catMap := make(map[string]struct{})
+	return r.getAll(context.Background())
+}
 
-	err := r.repo.FindAll(func(p product.Product) error {
-		catMap[p.Category] = struct{}{}
+func (r *categoryRepository) GetAllWithContext(ctx context.Context) ([]category.Category, error) {
+	return r.getAll(ctx)
+}
+
+// getAll returns every category ordered by name.
+func (r *categoryRepository) getAll(ctx context.Context) ([]category.Category, error) {
+	categories := make([]category.Category, 0)
+	err := r.repo.FindAllWithContext(ctx, func(c category.Category) error {
+		categories = append(categories, c)
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	categories := make([]category.Category, 0, len(catMap))
-	for c := range catMap {
-		categories = append(categories, category.Category{Name: c})
-	}
-
+	sort.Slice(categories, func(i, j int) bool {
+		return strings.ToLower(categories[i].Name) < strings.ToLower(categories[j].Name)
+	})
 	return categories, nil
 }
 
-func (r *categoryRepository) GetAllWithContext(ctx context.Context) ([]category.Category, error) {
-	catMap := make(map[string]struct{})
+func (r *categoryRepository) GetByID(categoryId string) (*category.Category, error) {
+	return r.GetByIDWithContext(context.Background(), categoryId)
+}
 
-	err := r.repo.FindAll(func(p product.Product) error {
-		catMap[p.Category] = struct{}{}
-		return nil
-	})
+func (r *categoryRepository) GetByIDWithContext(ctx context.Context, categoryId string) (*category.Category, error) {
+	c, err := r.repo.FindByIDWithContext(ctx, categoryId)
 	if err != nil {
 		return nil, err
 	}
+	return &c, nil
+}
 
-	categories := make([]category.Category, 0, len(catMap))
-	for c := range catMap {
-		categories = append(categories, category.Category{Name: c})
-	}
+func (r *categoryRepository) GetByName(name string) (*category.Category, error) {
+	return r.getByName(context.Background(), name)
+}
 
-	return categories, nil
+func (r *categoryRepository) GetByNameWithContext(ctx context.Context, name string) (*category.Category, error) {
+	return r.getByName(ctx, name)
 }
 
-func (r *categoryRepository) GetByName(name string) (*category.Category, error) {
-	var found bool
-	err := r.repo.FindAllWhere(
-		func(p product.Product) bool {
-			if p.Category == name {
-				found = true
-				return true
-			}
-			return false
-		},
-		func(p product.Product) error {
-			return nil
-		},
-	)
-	if err != nil {
+// getByName resolves a category from its ID, its slug or its name, the last
+// two ignoring case.
+func (r *categoryRepository) getByName(ctx context.Context, name string) (*category.Category, error) {
+	c, err := r.GetByIDWithContext(ctx, name)
+	if err == nil {
+		return c, nil
+	}
+	if !errors.Is(err, apperrors.ErrResourceNotExists) {
 		return nil, err
 	}
-	if found {
-		return &category.Category{Name: name}, nil
+
+	key := strings.ToLower(name)
+	narrow := func() *jsonstore.Bitmap {
+		candidates := jsonstore.NewBitmap()
+		if r.bySlug != nil {
+			candidates.Or(r.bySlug.Lookup(key))
+		}
+		if r.byName != nil {
+			candidates.Or(r.byName.Lookup(key))
+		}
+		return candidates
+	}
+	predicate := func(c category.Category) bool {
+		return strings.ToLower(c.Slug) == key || strings.ToLower(c.Name) == key
 	}
-	return nil, apperrors.ErrResourceNotExists
-}
 
-func (r *categoryRepository) GetByNameWithContext(ctx context.Context, name string) (*category.Category, error) {
-	var found bool
-	err := r.repo.FindAllWhere(
-		func(p product.Product) bool {
-			if p.Category == name {
-				found = true
-				return true
-			}
-			return false
-		},
-		func(p product.Product) error {
-			return nil
-		},
-	)
+	var found *category.Category
+	err = r.repo.FindAllIndexedWithContext(ctx, narrow, predicate, func(c category.Category) error {
+		if found == nil {
+			found = &c
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	if found {
-		return &category.Category{Name: name}, nil
+	if found == nil {
+		return nil, apperrors.ErrResourceNotExists
 	}
-	return nil, apperrors.ErrResourceNotExists
+	return found, nil
+}
+
+func (r *categoryRepository) Create(c category.Category) error {
+	return r.repo.Save(c)
+}
+
+func (r *categoryRepository) CreateWithContext(ctx context.Context, c category.Category) error {
+	return r.repo.Save(c)
+}
+
+func (r *categoryRepository) Update(c category.Category) error {
+	return r.repo.Update(c)
+}
+
+func (r *categoryRepository) UpdateWithContext(ctx context.Context, c category.Category) error {
+	return r.repo.Update(c)
+}
+
+func (r *categoryRepository) Delete(categoryId string) error {
+	return r.repo.Delete(categoryId)
+}
+
+func (r *categoryRepository) DeleteWithContext(ctx context.Context, categoryId string) error {
+	return r.repo.Delete(categoryId)
 }
@@ -11,8 +11,9 @@ import (
 	"github.com/lucasti79/meli-interview/internal/category"
 	"github.com/lucasti79/meli-interview/internal/category/infra/jsonstore"
 	"github.com/lucasti79/meli-interview/internal/category/repository"
+	jsonrepo "github.com/lucasti79/meli-interview/internal/infra/jsonstore"
+	"github.com/lucasti79/meli-interview/internal/product"
 	"github.com/lucasti79/meli-interview/pkg/apperrors"
-	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,29 +24,14 @@ func newRepository(t *testing.T, filePath string) repository.Repository {
 	return repo
 }
 
-func newCategoryRepositoryForTest(t *testing.T, filePath string) repository.Repository {
+func writeCategoriesJSONL(t *testing.T, categories []category.Category) string {
 	t.Helper()
-	r, err := jsonstore.NewCategoryRepository(filePath)
-	require.NoError(t, err)
-
-	cr, ok := r.(repository.Repository)
-	require.True(t, ok, "expected repository to be *repository.CategoryRepository")
-	return cr
-}
 
-type DummyMock struct{ mock.Mock }
-
-func writeProductsJSONL(t *testing.T, categories []string) string {
-	t.Helper()
-
-	dir := t.TempDir()
-	fp := filepath.Join(dir, "products.jsonl")
+	fp := filepath.Join(t.TempDir(), "categories.jsonl")
 
 	var lines []string
 	for _, c := range categories {
-		data, err := json.Marshal(map[string]any{
-			"category": c,
-		})
+		data, err := json.Marshal(c)
 		require.NoError(t, err)
 		lines = append(lines, string(data))
 	}
@@ -55,66 +41,57 @@ func writeProductsJSONL(t *testing.T, categories []string) string {
 		content += "\n"
 	}
 
-	err := os.WriteFile(fp, []byte(content), 0o600)
-	require.NoError(t, err)
-
+	require.NoError(t, os.WriteFile(fp, []byte(content), 0o600))
 	return fp
 }
 
-func writeInvalidProductsJSONL(t *testing.T) string {
+func writeProductsJSONL(t *testing.T, categories []string) string {
 	t.Helper()
 
-	dir := t.TempDir()
-	fp := filepath.Join(dir, "products_invalid.jsonl")
+	fp := filepath.Join(t.TempDir(), "products.jsonl")
 
-	content := `{"category":"valid"}
-not-a-json
-`
+	var lines []string
+	for i, c := range categories {
+		data, err := json.Marshal(product.Product{Id: string(rune('a' + i)), Category: c})
+		require.NoError(t, err)
+		lines = append(lines, string(data))
+	}
 
-	err := os.WriteFile(fp, []byte(content), 0o600)
-	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(fp, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
 	return fp
 }
 
-func TestCategoryRepository_GetAll_ReturnsUniqueCategories(t *testing.T) {
-	fp := writeProductsJSONL(t, []string{"electronics", "fashion", "electronics", "home"})
+var sampleCategories = []category.Category{
+	{Id: "c1", Slug: "eletronicos", Name: "Eletrônicos"},
+	{Id: "c2", Slug: "casa-jardim", Name: "Casa & Jardim", Description: "Tudo para a casa"},
+	{Id: "c3", Slug: "celulares", Name: "Celulares", ParentId: "c1"},
+}
 
-	repo := newRepository(t, fp)
+func TestCategoryRepository_GetAll_ReturnsCategoriesOrderedByName(t *testing.T) {
+	repo := newRepository(t, writeCategoriesJSONL(t, sampleCategories))
 
 	got, err := repo.GetAll()
 	require.NoError(t, err)
 
-	expected := []category.Category{
-		{Name: "electronics"},
-		{Name: "fashion"},
-		{Name: "home"},
-	}
-	require.ElementsMatch(t, expected, got)
+	require.Equal(t, []category.Category{sampleCategories[1], sampleCategories[2], sampleCategories[0]}, got)
 }
 
-func TestCategoryRepository_GetByName_ReturnsExistingCategory(t *testing.T) {
-	fp := writeProductsJSONL(t, []string{"A", "B"})
+func TestCategoryRepository_GetAll_EmptyWhenFileDoesNotExist(t *testing.T) {
+	repo := newRepository(t, filepath.Join(t.TempDir(), "categories.jsonl"))
 
-	repo := newRepository(t, fp)
-
-	got, err := repo.GetByName("B")
+	got, err := repo.GetAllWithContext(context.Background())
 	require.NoError(t, err)
-	require.NotNil(t, got)
-	require.Equal(t, "B", got.Name)
+	require.Empty(t, got)
 }
 
-func TestCategoryRepository_GetAllWithContext_ReturnsSameAsGetAll(t *testing.T) {
-	fp := writeProductsJSONL(t, []string{"x", "y", "x"})
+func TestCategoryRepository_GetAll_PropagatesFindAllError(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "categories.jsonl")
+	require.NoError(t, os.WriteFile(fp, []byte("{\"categoryId\":\"c1\",\"slug\":\"a\",\"name\":\"A\"}\nnot-a-json\n"), 0o600))
 
 	repo := newRepository(t, fp)
 
-	got1, err := repo.GetAll()
-	require.NoError(t, err)
-
-	got2, err := repo.GetAllWithContext(context.Background())
-	require.NoError(t, err)
-
-	require.ElementsMatch(t, got1, got2)
+	_, err := repo.GetAll()
+	require.ErrorIs(t, err, apperrors.ErrInvalidDataFormat)
 }
 
 func TestNewCategoryRepository_ReturnsErrorOnJSONRepositoryFailure(t *testing.T) {
@@ -125,79 +102,112 @@ func TestNewCategoryRepository_ReturnsErrorOnJSONRepositoryFailure(t *testing.T)
 	require.Nil(t, r)
 }
 
-func TestCategoryRepository_GetAll_PropagatesFindAllError(t *testing.T) {
-	fp := writeInvalidProductsJSONL(t)
+func TestCategoryRepository_GetByName_ResolvesIDSlugAndNameIgnoringCase(t *testing.T) {
+	repo := newRepository(t, writeCategoriesJSONL(t, sampleCategories))
 
-	repo := newRepository(t, fp)
+	for _, name := range []string{"c2", "casa-jardim", "CASA-JARDIM", "Casa & Jardim", "casa & jardim"} {
+		got, err := repo.GetByNameWithContext(context.Background(), name)
+		require.NoError(t, err, name)
+		require.Equal(t, sampleCategories[1], *got, name)
+	}
+}
 
-	_, err := repo.GetAll()
-	require.Error(t, err)
+func TestCategoryRepository_GetByName_HandlesUnicodeNames(t *testing.T) {
+	repo := newRepository(t, writeCategoriesJSONL(t, sampleCategories))
+
+	got, err := repo.GetByName("eletrônicos")
+	require.NoError(t, err)
+	require.Equal(t, "c1", got.Id)
 }
 
 func TestCategoryRepository_GetByName_ReturnsErrResourceNotExistsWhenMissing(t *testing.T) {
-	fp := writeProductsJSONL(t, []string{"A", "B"})
+	repo := newRepository(t, writeCategoriesJSONL(t, sampleCategories))
 
-	repo := newRepository(t, fp)
-
-	got, err := repo.GetByName("C")
+	got, err := repo.GetByName("brinquedos")
 	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
 	require.Nil(t, got)
 }
 
-func TestGetByNameWithContext_ReturnsCategoryOnExactMatch(t *testing.T) {
-	fp := writeProductsJSONL(t, []string{"home", "garden", "toys"})
-	repo := newCategoryRepositoryForTest(t, fp)
+func TestCategoryRepository_GetByID(t *testing.T) {
+	repo := newRepository(t, writeCategoriesJSONL(t, sampleCategories))
 
-	got, err := repo.GetByNameWithContext(context.Background(), "garden")
+	got, err := repo.GetByID("c3")
 	require.NoError(t, err)
-	require.NotNil(t, got)
-	require.Equal(t, "garden", got.Name)
-}
-
-func TestGetByNameWithContext_SucceedsWhenMultipleMatchesExist(t *testing.T) {
-	fp := writeProductsJSONL(t, []string{"A", "B", "B", "C"})
-	repo := newCategoryRepositoryForTest(t, fp)
+	require.Equal(t, sampleCategories[2], *got)
 
-	got, err := repo.GetByNameWithContext(context.Background(), "B")
-	require.NoError(t, err)
-	require.NotNil(t, got)
-	require.Equal(t, "B", got.Name)
+	_, err = repo.GetByIDWithContext(context.Background(), "celulares")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
 }
 
-func TestGetByNameWithContext_HandlesUnicodeCategoryNames(t *testing.T) {
-	name := "Café-電子📦"
-	fp := writeProductsJSONL(t, []string{"alpha", name, "omega"})
-	repo := newCategoryRepositoryForTest(t, fp)
+func TestCategoryRepository_CreateUpdateDelete(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "categories.jsonl")
+	repo := newRepository(t, fp)
+	ctx := context.Background()
+
+	require.NoError(t, repo.CreateWithContext(ctx, category.Category{Id: "c1", Slug: "livros", Name: "Livros"}))
+	require.NoError(t, repo.UpdateWithContext(ctx, category.Category{Id: "c1", Slug: "livros-e-revistas", Name: "Livros e Revistas"}))
 
-	got, err := repo.GetByNameWithContext(context.Background(), name)
+	_, err := repo.GetByName("livros")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+	got, err := repo.GetByName("livros-e-revistas")
 	require.NoError(t, err)
-	require.NotNil(t, got)
-	require.Equal(t, name, got.Name)
-}
+	require.Equal(t, "Livros e Revistas", got.Name)
 
-func TestGetByNameWithContext_ReturnsErrResourceNotExistsWhenNoMatch(t *testing.T) {
-	fp := writeProductsJSONL(t, []string{"A", "B"})
-	repo := newCategoryRepositoryForTest(t, fp)
+	reopened := newRepository(t, fp)
+	got, err = reopened.GetByID("c1")
+	require.NoError(t, err)
+	require.Equal(t, "livros-e-revistas", got.Slug)
 
-	got, err := repo.GetByNameWithContext(context.Background(), "C")
-	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
-	require.Nil(t, got)
+	require.NoError(t, repo.DeleteWithContext(ctx, "c1"))
+	require.ErrorIs(t, repo.Delete("c1"), apperrors.ErrResourceNotExists)
+	require.ErrorIs(t, repo.Update(category.Category{Id: "c1", Slug: "x", Name: "X"}), apperrors.ErrResourceNotExists)
 }
 
-func TestGetByNameWithContext_PropagatesFindAllWhereError(t *testing.T) {
-	fp := writeInvalidProductsJSONL(t)
-	repo := newCategoryRepositoryForTest(t, fp)
+func TestCategoryRepository_RejectsDuplicateIDSlugOrName(t *testing.T) {
+	repo := newRepository(t, writeCategoriesJSONL(t, sampleCategories))
 
-	got, err := repo.GetByNameWithContext(context.Background(), "valid")
-	require.Error(t, err)
-	require.Nil(t, got)
+	tests := map[string]category.Category{
+		"id":   {Id: "c1", Slug: "outra", Name: "Outra"},
+		"slug": {Id: "c9", Slug: "Celulares", Name: "Telefones"},
+		"name": {Id: "c9", Slug: "telefones", Name: "CELULARES"},
+	}
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, repo.Create(c), apperrors.ErrResourceAlreadyExists)
+		})
+	}
+
+	err := repo.Update(category.Category{Id: "c3", Slug: "eletronicos", Name: "Celulares"})
+	require.ErrorIs(t, err, apperrors.ErrResourceAlreadyExists)
+	require.NoError(t, repo.Update(category.Category{Id: "c3", Slug: "celulares", Name: "Celulares", Description: "Smartphones"}))
 }
 
-func TestGetByNameWithContext_NoMatchWhenCaseDiffers(t *testing.T) {
-	fp := writeProductsJSONL(t, []string{"Books"})
-	repo := newCategoryRepositoryForTest(t, fp)
+func TestSeedFromProducts_CreatesMissingCategoriesOnce(t *testing.T) {
+	products, err := jsonrepo.NewJSONRepository(
+		writeProductsJSONL(t, []string{"Eletrônicos", "Livros", "eletrônicos", "Casa & Jardim", "Livros"}),
+		func(p product.Product) string { return p.Id },
+	)
+	require.NoError(t, err)
+	repo := newRepository(t, writeCategoriesJSONL(t, sampleCategories))
 
-	got, err := repo.GetByNameWithContext(context.Background(), "books")
+	dry, err := jsonstore.SeedFromProducts(products, repo, true)
+	require.NoError(t, err)
+	require.Len(t, dry, 1)
+	_, err = repo.GetByName("Livros")
 	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
-	require.Nil(t, got)
+
+	created, err := jsonstore.SeedFromProducts(products, repo, false)
+	require.NoError(t, err)
+	require.Len(t, created, 1)
+	require.Equal(t, "Livros", created[0].Name)
+	require.Equal(t, "livros", created[0].Slug)
+	require.NotEmpty(t, created[0].Id)
+
+	got, err := repo.GetByName("livros")
+	require.NoError(t, err)
+	require.Equal(t, created[0], *got)
+
+	again, err := jsonstore.SeedFromProducts(products, repo, false)
+	require.NoError(t, err)
+	require.Empty(t, again)
 }
@@ -0,0 +1,31 @@
+package jsonstore_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/lucasti79/meli-interview/internal/category"
+	"github.com/lucasti79/meli-interview/internal/category/infra/jsonstore"
+	"github.com/lucasti79/meli-interview/internal/category/repository"
+	"github.com/lucasti79/meli-interview/internal/category/repository/repositorytest"
+	"github.com/lucasti79/meli-interview/internal/product"
+	ProductJsonRepository "github.com/lucasti79/meli-interview/internal/product/infra/jsonstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryRepository_Contract(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T, categories []category.Category) repository.Repository {
+		return newRepository(t, writeCategoriesJSONL(t, categories))
+	})
+}
+
+func TestStatsRepository_Contract(t *testing.T) {
+	repositorytest.RunStats(t, func(t *testing.T, products []product.Product) repository.StatsRepository {
+		store, err := ProductJsonRepository.NewProductStore(filepath.Join(t.TempDir(), "products.jsonl"))
+		require.NoError(t, err)
+		for _, p := range products {
+			require.NoError(t, store.Save(p))
+		}
+		return jsonstore.NewStatsRepository(store)
+	})
+}
@@ -0,0 +1,58 @@
+package jsonstore
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lucasti79/meli-interview/internal/category"
+	"github.com/lucasti79/meli-interview/internal/category/repository"
+	"github.com/lucasti79/meli-interview/internal/infra/jsonstore"
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+)
+
+// SeedFromProducts creates a category for every distinct product category
+// that is not in repo yet and returns the ones it created. Categories are
+// compared ignoring case and the first spelling found in the file wins, so
+// running it again is a no-op.
+func SeedFromProducts(products *jsonstore.JSONRepository[product.Product], repo repository.Repository, dryRun bool) ([]category.Category, error) {
+	var names []string
+	seen := make(map[string]struct{})
+	err := products.FindAll(func(p product.Product) error {
+		key := strings.ToLower(p.Category)
+		if _, ok := seen[key]; ok || key == "" {
+			return nil
+		}
+		seen[key] = struct{}{}
+		names = append(names, p.Category)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created := make([]category.Category, 0)
+	for _, name := range names {
+		_, err := repo.GetByName(name)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, apperrors.ErrResourceNotExists) {
+			return created, err
+		}
+
+		c := category.Category{
+			Id:   uuid.NewString(),
+			Slug: category.Slugify(name),
+			Name: name,
+		}
+		if !dryRun {
+			if err := repo.Create(c); err != nil {
+				return created, err
+			}
+		}
+		created = append(created, c)
+	}
+	return created, nil
+}
@@ -0,0 +1,78 @@
+package jsonstore
+
+import (
+	"context"
+	"strings"
+
+	"github.com/lucasti79/meli-interview/internal/category"
+	"github.com/lucasti79/meli-interview/internal/category/repository"
+	"github.com/lucasti79/meli-interview/internal/infra/jsonstore"
+	"github.com/lucasti79/meli-interview/internal/product"
+	ProductJsonRepository "github.com/lucasti79/meli-interview/internal/product/infra/jsonstore"
+)
+
+type statsRepository struct {
+	products   *jsonstore.JSONRepository[product.Product]
+	byCategory *jsonstore.KeywordIndex[product.Product]
+}
+
+// NewStatsRepository computes category stats from a product store shared with
+// the product repository, so product writes are reflected immediately. When
+// the store has the product category index, stats for a few categories only
+// read their products.
+func NewStatsRepository(products *jsonstore.JSONRepository[product.Product]) repository.StatsRepository {
+	r := &statsRepository{products: products}
+	r.byCategory, _ = products.Index(ProductJsonRepository.CategoryIndex).(*jsonstore.KeywordIndex[product.Product])
+	return r
+}
+
+func (r *statsRepository) GetStats(names []string) (map[string]category.Stats, error) {
+	return r.getStats(context.Background(), names)
+}
+
+func (r *statsRepository) GetStatsWithContext(ctx context.Context, names []string) (map[string]category.Stats, error) {
+	return r.getStats(ctx, names)
+}
+
+// getStats aggregates the products of names, or of every category when names
+// is empty, in a single pass.
+func (r *statsRepository) getStats(ctx context.Context, names []string) (map[string]category.Stats, error) {
+	wanted := make(map[string]bool, len(names))
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		key := strings.ToLower(name)
+		wanted[key] = true
+		keys = append(keys, key)
+	}
+
+	var narrow jsonstore.Narrow
+	if len(keys) > 0 && r.byCategory != nil {
+		narrow = func() *jsonstore.Bitmap {
+			return r.byCategory.Lookup(keys...)
+		}
+	}
+	predicate := func(p product.Product) bool {
+		return len(wanted) == 0 || wanted[strings.ToLower(p.Category)]
+	}
+
+	counters := make(map[string]*category.StatsCounter)
+	err := r.products.FindAllIndexedWithContext(ctx, narrow, predicate, func(p product.Product) error {
+		key := strings.ToLower(p.Category)
+		c, ok := counters[key]
+		if !ok {
+			c = &category.StatsCounter{}
+			counters[key] = c
+		}
+		c.Add(p.Price, p.Rating, p.InStock)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]category.Stats, len(counters))
+	for key, c := range counters {
+		stats[key] = c.Stats()
+	}
+	return stats, nil
+}
@@ -0,0 +1,62 @@
+package jsonstore_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/lucasti79/meli-interview/internal/category"
+	"github.com/lucasti79/meli-interview/internal/category/infra/jsonstore"
+	jsonrepo "github.com/lucasti79/meli-interview/internal/infra/jsonstore"
+	"github.com/lucasti79/meli-interview/internal/product"
+	ProductJsonRepository "github.com/lucasti79/meli-interview/internal/product/infra/jsonstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsRepository_AggregatesProductsPerCategory(t *testing.T) {
+	store, err := ProductJsonRepository.NewProductStore(filepath.Join(t.TempDir(), "products.jsonl"))
+	require.NoError(t, err)
+	for _, p := range []product.Product{
+		{Id: "1", Category: "Audio", Price: 100, Rating: product.Float(4), InStock: true},
+		{Id: "2", Category: "audio", Price: 50, Rating: product.Float(5)},
+		{Id: "3", Category: "Audio", Price: 30, Rating: product.Float(3.5), InStock: true},
+		{Id: "4", Category: "Books", Price: 20, Rating: product.Float(2), InStock: true},
+	} {
+		require.NoError(t, store.Save(p))
+	}
+
+	repo := jsonstore.NewStatsRepository(store)
+
+	stats, err := repo.GetStats(nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]category.Stats{
+		"audio": {ProductCount: 3, InStockCount: 2, MinPrice: 30, MaxPrice: 100, AvgPrice: 60, AvgRating: 4.17},
+		"books": {ProductCount: 1, InStockCount: 1, MinPrice: 20, MaxPrice: 20, AvgPrice: 20, AvgRating: 2},
+	}, stats)
+
+	require.NoError(t, store.Delete("1"))
+	require.NoError(t, store.Update(product.Product{Id: "2", Category: "Audio", Price: 70, Rating: product.Float(5), InStock: true}))
+
+	stats, err = repo.GetStatsWithContext(context.Background(), []string{"AUDIO", "Games"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]category.Stats{
+		"audio": {ProductCount: 2, InStockCount: 2, MinPrice: 30, MaxPrice: 70, AvgPrice: 50, AvgRating: 4.25},
+	}, stats)
+}
+
+func TestStatsRepository_WithoutCategoryIndexMatchesIndexedResults(t *testing.T) {
+	fp := writeProductsJSONL(t, []string{"Audio", "Books", "audio"})
+	indexed, err := ProductJsonRepository.NewProductStore(fp)
+	require.NoError(t, err)
+	plain, err := jsonrepo.NewJSONRepository(fp, func(p product.Product) string { return p.Id })
+	require.NoError(t, err)
+
+	want, err := jsonstore.NewStatsRepository(indexed).GetStats([]string{"audio"})
+	require.NoError(t, err)
+	got, err := jsonstore.NewStatsRepository(plain).GetStats([]string{"audio"})
+	require.NoError(t, err)
+
+	require.Len(t, want, 1)
+	require.Equal(t, 2, want["audio"].ProductCount)
+	require.Equal(t, want, got)
+}
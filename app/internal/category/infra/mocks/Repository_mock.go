@@ -38,6 +38,222 @@ func (_m *RepositoryMock) EXPECT() *RepositoryMock_Expecter {
 	return &RepositoryMock_Expecter{mock: &_m.Mock}
 }
 
+// Create provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) Create(c category.Category) error {
+	ret := _mock.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(category.Category) error); ok {
+		r0 = returnFunc(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// RepositoryMock_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type RepositoryMock_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - c category.Category
+func (_e *RepositoryMock_Expecter) Create(c interface{}) *RepositoryMock_Create_Call {
+	return &RepositoryMock_Create_Call{Call: _e.mock.On("Create", c)}
+}
+
+func (_c *RepositoryMock_Create_Call) Run(run func(c category.Category)) *RepositoryMock_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 category.Category
+		if args[0] != nil {
+			arg0 = args[0].(category.Category)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_Create_Call) Return(err error) *RepositoryMock_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RepositoryMock_Create_Call) RunAndReturn(run func(c category.Category) error) *RepositoryMock_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWithContext provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) CreateWithContext(ctx context.Context, c category.Category) error {
+	ret := _mock.Called(ctx, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWithContext")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, category.Category) error); ok {
+		r0 = returnFunc(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// RepositoryMock_CreateWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWithContext'
+type RepositoryMock_CreateWithContext_Call struct {
+	*mock.Call
+}
+
+// CreateWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - c category.Category
+func (_e *RepositoryMock_Expecter) CreateWithContext(ctx interface{}, c interface{}) *RepositoryMock_CreateWithContext_Call {
+	return &RepositoryMock_CreateWithContext_Call{Call: _e.mock.On("CreateWithContext", ctx, c)}
+}
+
+func (_c *RepositoryMock_CreateWithContext_Call) Run(run func(ctx context.Context, c category.Category)) *RepositoryMock_CreateWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 category.Category
+		if args[1] != nil {
+			arg1 = args[1].(category.Category)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_CreateWithContext_Call) Return(err error) *RepositoryMock_CreateWithContext_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RepositoryMock_CreateWithContext_Call) RunAndReturn(run func(ctx context.Context, c category.Category) error) *RepositoryMock_CreateWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) Delete(categoryId string) error {
+	ret := _mock.Called(categoryId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(categoryId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// RepositoryMock_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type RepositoryMock_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - categoryId string
+func (_e *RepositoryMock_Expecter) Delete(categoryId interface{}) *RepositoryMock_Delete_Call {
+	return &RepositoryMock_Delete_Call{Call: _e.mock.On("Delete", categoryId)}
+}
+
+func (_c *RepositoryMock_Delete_Call) Run(run func(categoryId string)) *RepositoryMock_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_Delete_Call) Return(err error) *RepositoryMock_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RepositoryMock_Delete_Call) RunAndReturn(run func(categoryId string) error) *RepositoryMock_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWithContext provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) DeleteWithContext(ctx context.Context, categoryId string) error {
+	ret := _mock.Called(ctx, categoryId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWithContext")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, categoryId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// RepositoryMock_DeleteWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWithContext'
+type RepositoryMock_DeleteWithContext_Call struct {
+	*mock.Call
+}
+
+// DeleteWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - categoryId string
+func (_e *RepositoryMock_Expecter) DeleteWithContext(ctx interface{}, categoryId interface{}) *RepositoryMock_DeleteWithContext_Call {
+	return &RepositoryMock_DeleteWithContext_Call{Call: _e.mock.On("DeleteWithContext", ctx, categoryId)}
+}
+
+func (_c *RepositoryMock_DeleteWithContext_Call) Run(run func(ctx context.Context, categoryId string)) *RepositoryMock_DeleteWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_DeleteWithContext_Call) Return(err error) *RepositoryMock_DeleteWithContext_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RepositoryMock_DeleteWithContext_Call) RunAndReturn(run func(ctx context.Context, categoryId string) error) *RepositoryMock_DeleteWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAll provides a mock function for the type RepositoryMock
 func (_mock *RepositoryMock) GetAll() ([]category.Category, error) {
 	ret := _mock.Called()
@@ -155,6 +371,136 @@ func (_c *RepositoryMock_GetAllWithContext_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
+// GetByID provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) GetByID(categoryId string) (*category.Category, error) {
+	ret := _mock.Called(categoryId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *category.Category
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*category.Category, error)); ok {
+		return returnFunc(categoryId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *category.Category); ok {
+		r0 = returnFunc(categoryId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*category.Category)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(categoryId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// RepositoryMock_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type RepositoryMock_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - categoryId string
+func (_e *RepositoryMock_Expecter) GetByID(categoryId interface{}) *RepositoryMock_GetByID_Call {
+	return &RepositoryMock_GetByID_Call{Call: _e.mock.On("GetByID", categoryId)}
+}
+
+func (_c *RepositoryMock_GetByID_Call) Run(run func(categoryId string)) *RepositoryMock_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_GetByID_Call) Return(category1 *category.Category, err error) *RepositoryMock_GetByID_Call {
+	_c.Call.Return(category1, err)
+	return _c
+}
+
+func (_c *RepositoryMock_GetByID_Call) RunAndReturn(run func(categoryId string) (*category.Category, error)) *RepositoryMock_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIDWithContext provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) GetByIDWithContext(ctx context.Context, categoryId string) (*category.Category, error) {
+	ret := _mock.Called(ctx, categoryId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDWithContext")
+	}
+
+	var r0 *category.Category
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*category.Category, error)); ok {
+		return returnFunc(ctx, categoryId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *category.Category); ok {
+		r0 = returnFunc(ctx, categoryId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*category.Category)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, categoryId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// RepositoryMock_GetByIDWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDWithContext'
+type RepositoryMock_GetByIDWithContext_Call struct {
+	*mock.Call
+}
+
+// GetByIDWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - categoryId string
+func (_e *RepositoryMock_Expecter) GetByIDWithContext(ctx interface{}, categoryId interface{}) *RepositoryMock_GetByIDWithContext_Call {
+	return &RepositoryMock_GetByIDWithContext_Call{Call: _e.mock.On("GetByIDWithContext", ctx, categoryId)}
+}
+
+func (_c *RepositoryMock_GetByIDWithContext_Call) Run(run func(ctx context.Context, categoryId string)) *RepositoryMock_GetByIDWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_GetByIDWithContext_Call) Return(category1 *category.Category, err error) *RepositoryMock_GetByIDWithContext_Call {
+	_c.Call.Return(category1, err)
+	return _c
+}
+
+func (_c *RepositoryMock_GetByIDWithContext_Call) RunAndReturn(run func(ctx context.Context, categoryId string) (*category.Category, error)) *RepositoryMock_GetByIDWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByName provides a mock function for the type RepositoryMock
 func (_mock *RepositoryMock) GetByName(name string) (*category.Category, error) {
 	ret := _mock.Called(name)
@@ -284,3 +630,111 @@ func (_c *RepositoryMock_GetByNameWithContext_Call) RunAndReturn(run func(ctx co
 	_c.Call.Return(run)
 	return _c
 }
+
+// Update provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) Update(c category.Category) error {
+	ret := _mock.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(category.Category) error); ok {
+		r0 = returnFunc(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// RepositoryMock_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type RepositoryMock_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - c category.Category
+func (_e *RepositoryMock_Expecter) Update(c interface{}) *RepositoryMock_Update_Call {
+	return &RepositoryMock_Update_Call{Call: _e.mock.On("Update", c)}
+}
+
+func (_c *RepositoryMock_Update_Call) Run(run func(c category.Category)) *RepositoryMock_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 category.Category
+		if args[0] != nil {
+			arg0 = args[0].(category.Category)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_Update_Call) Return(err error) *RepositoryMock_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RepositoryMock_Update_Call) RunAndReturn(run func(c category.Category) error) *RepositoryMock_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateWithContext provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) UpdateWithContext(ctx context.Context, c category.Category) error {
+	ret := _mock.Called(ctx, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWithContext")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, category.Category) error); ok {
+		r0 = returnFunc(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// RepositoryMock_UpdateWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWithContext'
+type RepositoryMock_UpdateWithContext_Call struct {
+	*mock.Call
+}
+
+// UpdateWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - c category.Category
+func (_e *RepositoryMock_Expecter) UpdateWithContext(ctx interface{}, c interface{}) *RepositoryMock_UpdateWithContext_Call {
+	return &RepositoryMock_UpdateWithContext_Call{Call: _e.mock.On("UpdateWithContext", ctx, c)}
+}
+
+func (_c *RepositoryMock_UpdateWithContext_Call) Run(run func(ctx context.Context, c category.Category)) *RepositoryMock_UpdateWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 category.Category
+		if args[1] != nil {
+			arg1 = args[1].(category.Category)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_UpdateWithContext_Call) Return(err error) *RepositoryMock_UpdateWithContext_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RepositoryMock_UpdateWithContext_Call) RunAndReturn(run func(ctx context.Context, c category.Category) error) *RepositoryMock_UpdateWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -38,6 +38,222 @@ func (_m *ServiceMock) EXPECT() *ServiceMock_Expecter {
 	return &ServiceMock_Expecter{mock: &_m.Mock}
 }
 
+// Create provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) Create(c category.Category) error {
+	ret := _mock.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(category.Category) error); ok {
+		r0 = returnFunc(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ServiceMock_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type ServiceMock_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - c category.Category
+func (_e *ServiceMock_Expecter) Create(c interface{}) *ServiceMock_Create_Call {
+	return &ServiceMock_Create_Call{Call: _e.mock.On("Create", c)}
+}
+
+func (_c *ServiceMock_Create_Call) Run(run func(c category.Category)) *ServiceMock_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 category.Category
+		if args[0] != nil {
+			arg0 = args[0].(category.Category)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_Create_Call) Return(err error) *ServiceMock_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceMock_Create_Call) RunAndReturn(run func(c category.Category) error) *ServiceMock_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWithContext provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) CreateWithContext(ctx context.Context, c category.Category) error {
+	ret := _mock.Called(ctx, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWithContext")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, category.Category) error); ok {
+		r0 = returnFunc(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ServiceMock_CreateWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWithContext'
+type ServiceMock_CreateWithContext_Call struct {
+	*mock.Call
+}
+
+// CreateWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - c category.Category
+func (_e *ServiceMock_Expecter) CreateWithContext(ctx interface{}, c interface{}) *ServiceMock_CreateWithContext_Call {
+	return &ServiceMock_CreateWithContext_Call{Call: _e.mock.On("CreateWithContext", ctx, c)}
+}
+
+func (_c *ServiceMock_CreateWithContext_Call) Run(run func(ctx context.Context, c category.Category)) *ServiceMock_CreateWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 category.Category
+		if args[1] != nil {
+			arg1 = args[1].(category.Category)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_CreateWithContext_Call) Return(err error) *ServiceMock_CreateWithContext_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceMock_CreateWithContext_Call) RunAndReturn(run func(ctx context.Context, c category.Category) error) *ServiceMock_CreateWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) Delete(categoryId string) error {
+	ret := _mock.Called(categoryId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(categoryId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ServiceMock_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type ServiceMock_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - categoryId string
+func (_e *ServiceMock_Expecter) Delete(categoryId interface{}) *ServiceMock_Delete_Call {
+	return &ServiceMock_Delete_Call{Call: _e.mock.On("Delete", categoryId)}
+}
+
+func (_c *ServiceMock_Delete_Call) Run(run func(categoryId string)) *ServiceMock_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_Delete_Call) Return(err error) *ServiceMock_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceMock_Delete_Call) RunAndReturn(run func(categoryId string) error) *ServiceMock_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWithContext provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) DeleteWithContext(ctx context.Context, categoryId string) error {
+	ret := _mock.Called(ctx, categoryId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWithContext")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, categoryId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ServiceMock_DeleteWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWithContext'
+type ServiceMock_DeleteWithContext_Call struct {
+	*mock.Call
+}
+
+// DeleteWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - categoryId string
+func (_e *ServiceMock_Expecter) DeleteWithContext(ctx interface{}, categoryId interface{}) *ServiceMock_DeleteWithContext_Call {
+	return &ServiceMock_DeleteWithContext_Call{Call: _e.mock.On("DeleteWithContext", ctx, categoryId)}
+}
+
+func (_c *ServiceMock_DeleteWithContext_Call) Run(run func(ctx context.Context, categoryId string)) *ServiceMock_DeleteWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_DeleteWithContext_Call) Return(err error) *ServiceMock_DeleteWithContext_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceMock_DeleteWithContext_Call) RunAndReturn(run func(ctx context.Context, categoryId string) error) *ServiceMock_DeleteWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAll provides a mock function for the type ServiceMock
 func (_mock *ServiceMock) GetAll() ([]category.Category, error) {
 	ret := _mock.Called()
@@ -155,6 +371,266 @@ func (_c *ServiceMock_GetAllWithContext_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// GetBreadcrumbs provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) GetBreadcrumbs(name string) ([]category.Category, error) {
+	ret := _mock.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBreadcrumbs")
+	}
+
+	var r0 []category.Category
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) ([]category.Category, error)); ok {
+		return returnFunc(name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) []category.Category); ok {
+		r0 = returnFunc(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]category.Category)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ServiceMock_GetBreadcrumbs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBreadcrumbs'
+type ServiceMock_GetBreadcrumbs_Call struct {
+	*mock.Call
+}
+
+// GetBreadcrumbs is a helper method to define mock.On call
+//   - name string
+func (_e *ServiceMock_Expecter) GetBreadcrumbs(name interface{}) *ServiceMock_GetBreadcrumbs_Call {
+	return &ServiceMock_GetBreadcrumbs_Call{Call: _e.mock.On("GetBreadcrumbs", name)}
+}
+
+func (_c *ServiceMock_GetBreadcrumbs_Call) Run(run func(name string)) *ServiceMock_GetBreadcrumbs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_GetBreadcrumbs_Call) Return(categorys []category.Category, err error) *ServiceMock_GetBreadcrumbs_Call {
+	_c.Call.Return(categorys, err)
+	return _c
+}
+
+func (_c *ServiceMock_GetBreadcrumbs_Call) RunAndReturn(run func(name string) ([]category.Category, error)) *ServiceMock_GetBreadcrumbs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBreadcrumbsWithContext provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) GetBreadcrumbsWithContext(ctx context.Context, name string) ([]category.Category, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBreadcrumbsWithContext")
+	}
+
+	var r0 []category.Category
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]category.Category, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []category.Category); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]category.Category)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ServiceMock_GetBreadcrumbsWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBreadcrumbsWithContext'
+type ServiceMock_GetBreadcrumbsWithContext_Call struct {
+	*mock.Call
+}
+
+// GetBreadcrumbsWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *ServiceMock_Expecter) GetBreadcrumbsWithContext(ctx interface{}, name interface{}) *ServiceMock_GetBreadcrumbsWithContext_Call {
+	return &ServiceMock_GetBreadcrumbsWithContext_Call{Call: _e.mock.On("GetBreadcrumbsWithContext", ctx, name)}
+}
+
+func (_c *ServiceMock_GetBreadcrumbsWithContext_Call) Run(run func(ctx context.Context, name string)) *ServiceMock_GetBreadcrumbsWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_GetBreadcrumbsWithContext_Call) Return(categorys []category.Category, err error) *ServiceMock_GetBreadcrumbsWithContext_Call {
+	_c.Call.Return(categorys, err)
+	return _c
+}
+
+func (_c *ServiceMock_GetBreadcrumbsWithContext_Call) RunAndReturn(run func(ctx context.Context, name string) ([]category.Category, error)) *ServiceMock_GetBreadcrumbsWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) GetByID(categoryId string) (*category.Category, error) {
+	ret := _mock.Called(categoryId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *category.Category
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*category.Category, error)); ok {
+		return returnFunc(categoryId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *category.Category); ok {
+		r0 = returnFunc(categoryId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*category.Category)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(categoryId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ServiceMock_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type ServiceMock_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - categoryId string
+func (_e *ServiceMock_Expecter) GetByID(categoryId interface{}) *ServiceMock_GetByID_Call {
+	return &ServiceMock_GetByID_Call{Call: _e.mock.On("GetByID", categoryId)}
+}
+
+func (_c *ServiceMock_GetByID_Call) Run(run func(categoryId string)) *ServiceMock_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_GetByID_Call) Return(category1 *category.Category, err error) *ServiceMock_GetByID_Call {
+	_c.Call.Return(category1, err)
+	return _c
+}
+
+func (_c *ServiceMock_GetByID_Call) RunAndReturn(run func(categoryId string) (*category.Category, error)) *ServiceMock_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIDWithContext provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) GetByIDWithContext(ctx context.Context, categoryId string) (*category.Category, error) {
+	ret := _mock.Called(ctx, categoryId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDWithContext")
+	}
+
+	var r0 *category.Category
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*category.Category, error)); ok {
+		return returnFunc(ctx, categoryId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *category.Category); ok {
+		r0 = returnFunc(ctx, categoryId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*category.Category)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, categoryId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ServiceMock_GetByIDWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDWithContext'
+type ServiceMock_GetByIDWithContext_Call struct {
+	*mock.Call
+}
+
+// GetByIDWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - categoryId string
+func (_e *ServiceMock_Expecter) GetByIDWithContext(ctx interface{}, categoryId interface{}) *ServiceMock_GetByIDWithContext_Call {
+	return &ServiceMock_GetByIDWithContext_Call{Call: _e.mock.On("GetByIDWithContext", ctx, categoryId)}
+}
+
+func (_c *ServiceMock_GetByIDWithContext_Call) Run(run func(ctx context.Context, categoryId string)) *ServiceMock_GetByIDWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_GetByIDWithContext_Call) Return(category1 *category.Category, err error) *ServiceMock_GetByIDWithContext_Call {
+	_c.Call.Return(category1, err)
+	return _c
+}
+
+func (_c *ServiceMock_GetByIDWithContext_Call) RunAndReturn(run func(ctx context.Context, categoryId string) (*category.Category, error)) *ServiceMock_GetByIDWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByName provides a mock function for the type ServiceMock
 func (_mock *ServiceMock) GetByName(name string) (*category.Category, error) {
 	ret := _mock.Called(name)
@@ -284,3 +760,228 @@ func (_c *ServiceMock_GetByNameWithContext_Call) RunAndReturn(run func(ctx conte
 	_c.Call.Return(run)
 	return _c
 }
+
+// GetTree provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) GetTree() ([]*category.Node, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTree")
+	}
+
+	var r0 []*category.Node
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]*category.Node, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []*category.Node); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*category.Node)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ServiceMock_GetTree_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTree'
+type ServiceMock_GetTree_Call struct {
+	*mock.Call
+}
+
+// GetTree is a helper method to define mock.On call
+func (_e *ServiceMock_Expecter) GetTree() *ServiceMock_GetTree_Call {
+	return &ServiceMock_GetTree_Call{Call: _e.mock.On("GetTree")}
+}
+
+func (_c *ServiceMock_GetTree_Call) Run(run func()) *ServiceMock_GetTree_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ServiceMock_GetTree_Call) Return(nodes []*category.Node, err error) *ServiceMock_GetTree_Call {
+	_c.Call.Return(nodes, err)
+	return _c
+}
+
+func (_c *ServiceMock_GetTree_Call) RunAndReturn(run func() ([]*category.Node, error)) *ServiceMock_GetTree_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTreeWithContext provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) GetTreeWithContext(ctx context.Context) ([]*category.Node, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTreeWithContext")
+	}
+
+	var r0 []*category.Node
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*category.Node, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*category.Node); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*category.Node)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ServiceMock_GetTreeWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTreeWithContext'
+type ServiceMock_GetTreeWithContext_Call struct {
+	*mock.Call
+}
+
+// GetTreeWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ServiceMock_Expecter) GetTreeWithContext(ctx interface{}) *ServiceMock_GetTreeWithContext_Call {
+	return &ServiceMock_GetTreeWithContext_Call{Call: _e.mock.On("GetTreeWithContext", ctx)}
+}
+
+func (_c *ServiceMock_GetTreeWithContext_Call) Run(run func(ctx context.Context)) *ServiceMock_GetTreeWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_GetTreeWithContext_Call) Return(nodes []*category.Node, err error) *ServiceMock_GetTreeWithContext_Call {
+	_c.Call.Return(nodes, err)
+	return _c
+}
+
+func (_c *ServiceMock_GetTreeWithContext_Call) RunAndReturn(run func(ctx context.Context) ([]*category.Node, error)) *ServiceMock_GetTreeWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) Update(c category.Category) error {
+	ret := _mock.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(category.Category) error); ok {
+		r0 = returnFunc(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ServiceMock_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type ServiceMock_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - c category.Category
+func (_e *ServiceMock_Expecter) Update(c interface{}) *ServiceMock_Update_Call {
+	return &ServiceMock_Update_Call{Call: _e.mock.On("Update", c)}
+}
+
+func (_c *ServiceMock_Update_Call) Run(run func(c category.Category)) *ServiceMock_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 category.Category
+		if args[0] != nil {
+			arg0 = args[0].(category.Category)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_Update_Call) Return(err error) *ServiceMock_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceMock_Update_Call) RunAndReturn(run func(c category.Category) error) *ServiceMock_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateWithContext provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) UpdateWithContext(ctx context.Context, c category.Category) error {
+	ret := _mock.Called(ctx, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWithContext")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, category.Category) error); ok {
+		r0 = returnFunc(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ServiceMock_UpdateWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWithContext'
+type ServiceMock_UpdateWithContext_Call struct {
+	*mock.Call
+}
+
+// UpdateWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - c category.Category
+func (_e *ServiceMock_Expecter) UpdateWithContext(ctx interface{}, c interface{}) *ServiceMock_UpdateWithContext_Call {
+	return &ServiceMock_UpdateWithContext_Call{Call: _e.mock.On("UpdateWithContext", ctx, c)}
+}
+
+func (_c *ServiceMock_UpdateWithContext_Call) Run(run func(ctx context.Context, c category.Category)) *ServiceMock_UpdateWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 category.Category
+		if args[1] != nil {
+			arg1 = args[1].(category.Category)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_UpdateWithContext_Call) Return(err error) *ServiceMock_UpdateWithContext_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceMock_UpdateWithContext_Call) RunAndReturn(run func(ctx context.Context, c category.Category) error) *ServiceMock_UpdateWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
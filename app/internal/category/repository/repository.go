@@ -9,6 +9,22 @@ import (
 type Repository interface {
 	GetAll() ([]category.Category, error)
 	GetAllWithContext(ctx context.Context) ([]category.Category, error)
+	GetByID(categoryId string) (*category.Category, error)
+	GetByIDWithContext(ctx context.Context, categoryId string) (*category.Category, error)
 	GetByName(name string) (*category.Category, error)
 	GetByNameWithContext(ctx context.Context, name string) (*category.Category, error)
+	Create(c category.Category) error
+	CreateWithContext(ctx context.Context, c category.Category) error
+	Update(c category.Category) error
+	UpdateWithContext(ctx context.Context, c category.Category) error
+	Delete(categoryId string) error
+	DeleteWithContext(ctx context.Context, categoryId string) error
+}
+
+// StatsRepository aggregates the products of the named categories, or of
+// every category when names is empty. Results are keyed by the lowercased
+// category name; categories without products are absent.
+type StatsRepository interface {
+	GetStats(names []string) (map[string]category.Stats, error)
+	GetStatsWithContext(ctx context.Context, names []string) (map[string]category.Stats, error)
 }
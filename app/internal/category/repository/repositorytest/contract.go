@@ -0,0 +1,181 @@
+// Package repositorytest holds the contract every category repository
+// implementation must satisfy, run by the tests of each backend.
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lucasti79/meli-interview/internal/category"
+	"github.com/lucasti79/meli-interview/internal/category/repository"
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Factory returns a repository backed by a new store holding categories.
+type Factory func(t *testing.T, categories []category.Category) repository.Repository
+
+// StatsFactory returns a stats repository over a new store holding products.
+type StatsFactory func(t *testing.T, products []product.Product) repository.StatsRepository
+
+// Categories is the data set the contract runs against.
+func Categories() []category.Category {
+	return []category.Category{
+		{Id: "c1", Slug: "home", Name: "Home"},
+		{Id: "c2", Slug: "electronics", Name: "Electronics", Description: "Gadgets"},
+		{Id: "c3", Slug: "casa-jardim", Name: "Casa & Jardim", ParentId: "c1"},
+	}
+}
+
+// Run checks the repositories made by newRepository against the contract.
+func Run(t *testing.T, newRepository Factory) {
+	t.Run("GetAllByName", func(t *testing.T) { testGetAllByName(t, newRepository) })
+	t.Run("GetByIDAndName", func(t *testing.T) { testGetByIDAndName(t, newRepository) })
+	t.Run("UniqueSlugsAndNames", func(t *testing.T) { testUniqueSlugsAndNames(t, newRepository) })
+	t.Run("Writes", func(t *testing.T) { testWrites(t, newRepository) })
+	t.Run("CanceledContext", func(t *testing.T) { testCanceledContext(t, newRepository) })
+}
+
+func names(categories []category.Category) []string {
+	result := make([]string, len(categories))
+	for i, c := range categories {
+		result[i] = c.Name
+	}
+	return result
+}
+
+func testGetAllByName(t *testing.T, newRepository Factory) {
+	repo := newRepository(t, Categories())
+
+	got, err := repo.GetAll()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Casa & Jardim", "Electronics", "Home"}, names(got))
+
+	empty := newRepository(t, nil)
+	got, err = empty.GetAll()
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+	assert.Empty(t, got)
+}
+
+func testGetByIDAndName(t *testing.T, newRepository Factory) {
+	repo := newRepository(t, Categories())
+
+	got, err := repo.GetByID("c2")
+	require.NoError(t, err)
+	assert.Equal(t, Categories()[1], *got)
+
+	_, err = repo.GetByID("home")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+
+	for _, name := range []string{"c3", "casa-jardim", "CASA-JARDIM", "casa & jardim"} {
+		got, err := repo.GetByName(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, "c3", got.Id, name)
+	}
+
+	_, err = repo.GetByName("garden")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+}
+
+func testUniqueSlugsAndNames(t *testing.T, newRepository Factory) {
+	repo := newRepository(t, Categories())
+
+	for _, c := range []category.Category{
+		{Id: "c1", Slug: "other", Name: "Other"},
+		{Id: "c4", Slug: "HOME", Name: "Other"},
+		{Id: "c4", Slug: "other", Name: "electronics"},
+	} {
+		err := repo.Create(c)
+		require.ErrorIs(t, err, apperrors.ErrResourceAlreadyExists, "%+v", c)
+	}
+	_, err := repo.GetByID("c4")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+
+	err = repo.Update(category.Category{Id: "c1", Slug: "home", Name: "Electronics"})
+	require.ErrorIs(t, err, apperrors.ErrResourceAlreadyExists)
+
+	// a category keeps its own slug and name when updated
+	require.NoError(t, repo.Update(category.Category{Id: "c1", Slug: "home", Name: "HOME"}))
+
+	// and releases them when they change
+	require.NoError(t, repo.Update(category.Category{Id: "c2", Slug: "gadgets", Name: "Gadgets"}))
+	require.NoError(t, repo.Create(category.Category{Id: "c4", Slug: "electronics", Name: "Electronics"}))
+}
+
+func testWrites(t *testing.T, newRepository Factory) {
+	repo := newRepository(t, Categories())
+
+	created := category.Category{Id: "c4", Slug: "garden", Name: "Garden", ParentId: "c1"}
+	require.NoError(t, repo.CreateWithContext(context.Background(), created))
+	got, err := repo.GetByName("Garden")
+	require.NoError(t, err)
+	assert.Equal(t, created, *got)
+
+	updated := Categories()[1]
+	updated.Slug = "gadgets"
+	updated.Name = "Gadgets"
+	require.NoError(t, repo.UpdateWithContext(context.Background(), updated))
+	got, err = repo.GetByName("gadgets")
+	require.NoError(t, err)
+	assert.Equal(t, updated, *got)
+	_, err = repo.GetByName("electronics")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+
+	err = repo.Update(category.Category{Id: "missing", Slug: "missing", Name: "Missing"})
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+
+	require.NoError(t, repo.DeleteWithContext(context.Background(), "c3"))
+	_, err = repo.GetByName("casa-jardim")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+	require.ErrorIs(t, repo.Delete("c3"), apperrors.ErrResourceNotExists)
+
+	all, err := repo.GetAll()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Gadgets", "Garden", "Home"}, names(all))
+}
+
+func testCanceledContext(t *testing.T, newRepository Factory) {
+	repo := newRepository(t, Categories())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := repo.GetAllWithContext(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = repo.GetByIDWithContext(ctx, "c1")
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = repo.GetByNameWithContext(ctx, "home")
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// RunStats checks the stats repositories made by newRepository against the
+// contract.
+func RunStats(t *testing.T, newRepository StatsFactory) {
+	products := []product.Product{
+		{Id: "p1", Name: "Mouse", Category: "Electronics", Price: 25, Rating: product.Float(4.5), InStock: true},
+		{Id: "p2", Name: "Keyboard", Category: "Electronics", Price: 120, Rating: product.Float(4.8), InStock: true},
+		{Id: "p3", Name: "Speaker", Category: "electronics", Price: 80, Rating: product.Float(4.3)},
+		{Id: "p4", Name: "Mug", Category: "Home", Price: 12.5, Rating: product.Float(4.1)},
+		// not rated yet: left out of the average rating
+		{Id: "p5", Name: "Vase", Category: "Home", Price: 20},
+	}
+	repo := newRepository(t, products)
+
+	electronics := category.Stats{ProductCount: 3, InStockCount: 2, MinPrice: 25, MaxPrice: 120, AvgPrice: 75, AvgRating: 4.53}
+	home := category.Stats{ProductCount: 2, MinPrice: 12.5, MaxPrice: 20, AvgPrice: 16.25, AvgRating: 4.1}
+
+	got, err := repo.GetStats(nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]category.Stats{"electronics": electronics, "home": home}, got)
+
+	got, err = repo.GetStats([]string{"ELECTRONICS", "Garden"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]category.Stats{"electronics": electronics}, got)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = repo.GetStatsWithContext(ctx, nil)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -2,48 +2,272 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/lucasti79/meli-interview/internal/category"
 	"github.com/lucasti79/meli-interview/internal/category/repository"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+)
+
+var (
+	// ErrInvalidParent is returned by writes whose ParentId does not name
+	// another existing category, or names one of its own descendants.
+	ErrInvalidParent = fmt.Errorf("%w: invalid parent category", apperrors.ErrValidation)
+	// ErrHasChildren is returned when deleting a category that still has
+	// subcategories.
+	ErrHasChildren = errors.New("category has subcategories")
+	// ErrInUse is returned when deleting or renaming a category that products
+	// still refer to by name.
+	ErrInUse = errors.New("category has products")
 )
 
 type service struct {
-	repo repository.Repository
+	repo  repository.Repository
+	stats repository.StatsRepository
+}
+
+type Option func(*service)
+
+// WithStats attaches product stats to the categories returned by GetAll and
+// GetByName, and keeps categories with products from being deleted or
+// renamed.
+func WithStats(stats repository.StatsRepository) Option {
+	return func(s *service) {
+		s.stats = stats
+	}
 }
 
 type Service interface {
 	GetAll() ([]category.Category, error)
 	GetAllWithContext(ctx context.Context) ([]category.Category, error)
+	GetByID(categoryId string) (*category.Category, error)
+	GetByIDWithContext(ctx context.Context, categoryId string) (*category.Category, error)
 	GetByName(name string) (*category.Category, error)
 	GetByNameWithContext(ctx context.Context, name string) (*category.Category, error)
+	GetTree() ([]*category.Node, error)
+	GetTreeWithContext(ctx context.Context) ([]*category.Node, error)
+	GetBreadcrumbs(name string) ([]category.Category, error)
+	GetBreadcrumbsWithContext(ctx context.Context, name string) ([]category.Category, error)
+	Create(c category.Category) error
+	CreateWithContext(ctx context.Context, c category.Category) error
+	Update(c category.Category) error
+	UpdateWithContext(ctx context.Context, c category.Category) error
+	Delete(categoryId string) error
+	DeleteWithContext(ctx context.Context, categoryId string) error
 }
 
-func NewService(repo repository.Repository) Service {
-	return &service{repo: repo}
+func NewService(repo repository.Repository, opts ...Option) Service {
+	s := &service{repo: repo}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *service) GetAll() ([]category.Category, error) {
-	return s.repo.GetAll()
+	categories, err := s.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return categories, s.attachStats(context.Background(), categories)
 }
 
 func (s *service) GetAllWithContext(ctx context.Context) ([]category.Category, error) {
-	return s.repo.GetAllWithContext(ctx)
+	categories, err := s.repo.GetAllWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return categories, s.attachStats(ctx, categories)
+}
+
+func (s *service) GetByID(categoryId string) (*category.Category, error) {
+	return s.repo.GetByID(categoryId)
+}
+
+func (s *service) GetByIDWithContext(ctx context.Context, categoryId string) (*category.Category, error) {
+	return s.repo.GetByIDWithContext(ctx, categoryId)
 }
 
 func (s *service) GetByName(name string) (*category.Category, error) {
-	categories, err := s.repo.GetByName(name)
+	c, err := s.repo.GetByName(name)
 	if err != nil {
 		return nil, err
 	}
-
-	return categories, nil
+	return s.withStats(context.Background(), c)
 }
 
 func (s *service) GetByNameWithContext(ctx context.Context, name string) (*category.Category, error) {
-	categories, err := s.repo.GetByNameWithContext(ctx, name)
+	c, err := s.repo.GetByNameWithContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return s.withStats(ctx, c)
+}
+
+func (s *service) withStats(ctx context.Context, c *category.Category) (*category.Category, error) {
+	categories := []category.Category{*c}
+	if err := s.attachStats(ctx, categories); err != nil {
+		return nil, err
+	}
+	return &categories[0], nil
+}
+
+// attachStats fills in the stats of categories. A single category only reads
+// its own products; otherwise every product is aggregated in one pass.
+func (s *service) attachStats(ctx context.Context, categories []category.Category) error {
+	if s.stats == nil || len(categories) == 0 {
+		return nil
+	}
+
+	var names []string
+	if len(categories) == 1 {
+		names = []string{categories[0].Name}
+	}
+	stats, err := s.stats.GetStatsWithContext(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	for i := range categories {
+		st := stats[strings.ToLower(categories[i].Name)]
+		categories[i].Stats = &st
+	}
+	return nil
+}
+
+func (s *service) GetTree() ([]*category.Node, error) {
+	return s.GetTreeWithContext(context.Background())
+}
+
+func (s *service) GetTreeWithContext(ctx context.Context) ([]*category.Node, error) {
+	categories, err := s.repo.GetAllWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return category.BuildTree(categories), nil
+}
+
+func (s *service) GetBreadcrumbs(name string) ([]category.Category, error) {
+	return s.GetBreadcrumbsWithContext(context.Background(), name)
+}
+
+// GetBreadcrumbsWithContext returns the path from the root category down to
+// the one resolved from name.
+func (s *service) GetBreadcrumbsWithContext(ctx context.Context, name string) ([]category.Category, error) {
+	c, err := s.repo.GetByNameWithContext(ctx, name)
 	if err != nil {
 		return nil, err
 	}
 
-	return categories, nil
+	categories, err := s.repo.GetAllWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return category.Path(categories, c.Id), nil
+}
+
+func (s *service) Create(c category.Category) error {
+	return s.CreateWithContext(context.Background(), c)
+}
+
+func (s *service) CreateWithContext(ctx context.Context, c category.Category) error {
+	c.Stats = nil
+	if err := s.checkParent(ctx, c); err != nil {
+		return err
+	}
+	return s.repo.CreateWithContext(ctx, c)
+}
+
+func (s *service) Update(c category.Category) error {
+	return s.UpdateWithContext(context.Background(), c)
+}
+
+// UpdateWithContext refuses to rename a category with products, which would
+// otherwise be left pointing at a missing category.
+func (s *service) UpdateWithContext(ctx context.Context, c category.Category) error {
+	c.Stats = nil
+	if err := s.checkParent(ctx, c); err != nil {
+		return err
+	}
+	if s.stats != nil {
+		current, err := s.repo.GetByIDWithContext(ctx, c.Id)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(current.Name, c.Name) {
+			if err := s.checkUnused(ctx, *current); err != nil {
+				return err
+			}
+		}
+	}
+	return s.repo.UpdateWithContext(ctx, c)
+}
+
+func (s *service) Delete(categoryId string) error {
+	return s.DeleteWithContext(context.Background(), categoryId)
+}
+
+// DeleteWithContext refuses to delete a category with subcategories or
+// products, which would otherwise be left pointing at a missing category.
+func (s *service) DeleteWithContext(ctx context.Context, categoryId string) error {
+	categories, err := s.repo.GetAllWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	for _, c := range categories {
+		if c.ParentId == categoryId {
+			return fmt.Errorf("%w: %s is the parent of %s", ErrHasChildren, categoryId, c.Id)
+		}
+	}
+	for _, c := range categories {
+		if c.Id == categoryId {
+			if err := s.checkUnused(ctx, c); err != nil {
+				return err
+			}
+		}
+	}
+	return s.repo.DeleteWithContext(ctx, categoryId)
+}
+
+// checkUnused makes sure no product refers to c by name.
+func (s *service) checkUnused(ctx context.Context, c category.Category) error {
+	if s.stats == nil {
+		return nil
+	}
+	stats, err := s.stats.GetStatsWithContext(ctx, []string{c.Name})
+	if err != nil {
+		return err
+	}
+	if n := stats[strings.ToLower(c.Name)].ProductCount; n > 0 {
+		return fmt.Errorf("%w: %d products are in %s", ErrInUse, n, c.Name)
+	}
+	return nil
+}
+
+// checkParent makes sure a category only points at another existing category
+// that is not below it, so the hierarchy stays a tree.
+func (s *service) checkParent(ctx context.Context, c category.Category) error {
+	if c.ParentId == "" {
+		return nil
+	}
+	if c.ParentId == c.Id {
+		return fmt.Errorf("%w: a category cannot be its own parent", ErrInvalidParent)
+	}
+
+	categories, err := s.repo.GetAllWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	path := category.Path(categories, c.ParentId)
+	if len(path) == 0 {
+		return fmt.Errorf("%w: category %s does not exist", ErrInvalidParent, c.ParentId)
+	}
+	for _, ancestor := range path {
+		if ancestor.Id == c.Id {
+			return fmt.Errorf("%w: %s is a subcategory of %s", ErrInvalidParent, c.ParentId, c.Id)
+		}
+	}
+	return nil
 }
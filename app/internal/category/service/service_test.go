@@ -8,7 +8,9 @@ import (
 	"github.com/lucasti79/meli-interview/internal/category"
 	"github.com/lucasti79/meli-interview/internal/category/infra/mocks"
 	"github.com/lucasti79/meli-interview/internal/category/service"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestCategoryService_GetAll(t *testing.T) {
@@ -105,3 +107,269 @@ func TestCategoryService_GetByNameWithContext_Error(t *testing.T) {
 	assert.Nil(t, result)
 	mockRepo.AssertExpectations(t)
 }
+
+// electronics > audio > headphones, books
+var hierarchy = []category.Category{
+	{Id: "c1", Slug: "electronics", Name: "Electronics"},
+	{Id: "c2", Slug: "audio", Name: "Audio", ParentId: "c1"},
+	{Id: "c3", Slug: "headphones", Name: "Headphones", ParentId: "c2"},
+	{Id: "c4", Slug: "books", Name: "Books"},
+}
+
+func TestCategoryService_CreateWithContext_ChecksParentExists(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	ctx := context.Background()
+	child := category.Category{Id: "c5", Slug: "speakers", Name: "Speakers", ParentId: "c2"}
+
+	mockRepo.On("GetAllWithContext", ctx).Return(hierarchy, nil).Once()
+	mockRepo.On("CreateWithContext", ctx, child).Return(nil).Once()
+
+	assert.NoError(t, svc.CreateWithContext(ctx, child))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCategoryService_CreateWithContext_RejectsMissingParent(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	ctx := context.Background()
+	child := category.Category{Id: "c5", Slug: "speakers", Name: "Speakers", ParentId: "c9"}
+
+	mockRepo.On("GetAllWithContext", ctx).Return(hierarchy, nil).Once()
+
+	err := svc.CreateWithContext(ctx, child)
+
+	assert.ErrorIs(t, err, service.ErrInvalidParent)
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+	mockRepo.AssertNotCalled(t, "CreateWithContext", mock.Anything, mock.Anything)
+}
+
+func TestCategoryService_Update_RejectsCycles(t *testing.T) {
+	for _, parent := range []string{"c1", "c2", "c3"} {
+		t.Run(parent, func(t *testing.T) {
+			mockRepo := new(mocks.RepositoryMock)
+			svc := service.NewService(mockRepo)
+			mockRepo.On("GetAllWithContext", context.Background()).Return(hierarchy, nil).Maybe()
+
+			err := svc.Update(category.Category{Id: "c1", Slug: "electronics", Name: "Electronics", ParentId: parent})
+
+			assert.ErrorIs(t, err, service.ErrInvalidParent)
+			mockRepo.AssertNotCalled(t, "UpdateWithContext", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCategoryService_Update_MovesUnderAnotherBranch(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	c := category.Category{Id: "c2", Slug: "audio", Name: "Audio", ParentId: "c4"}
+	mockRepo.On("GetAllWithContext", context.Background()).Return(hierarchy, nil).Once()
+	mockRepo.On("UpdateWithContext", context.Background(), c).Return(nil).Once()
+
+	assert.NoError(t, svc.Update(c))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCategoryService_Update_WithoutParent(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	c := category.Category{Id: "c1", Slug: "a", Name: "A"}
+	mockRepo.On("UpdateWithContext", context.Background(), c).Return(nil).Once()
+
+	assert.NoError(t, svc.Update(c))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCategoryService_DeleteWithContext(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	ctx := context.Background()
+	mockRepo.On("GetAllWithContext", ctx).Return(hierarchy, nil)
+	mockRepo.On("DeleteWithContext", ctx, "c3").Return(nil).Once()
+
+	assert.NoError(t, svc.DeleteWithContext(ctx, "c3"))
+	assert.ErrorIs(t, svc.DeleteWithContext(ctx, "c2"), service.ErrHasChildren)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "DeleteWithContext", ctx, "c2")
+}
+
+func TestCategoryService_GetTree(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	mockRepo.On("GetAllWithContext", context.Background()).Return(hierarchy, nil).Once()
+
+	tree, err := svc.GetTree()
+
+	assert.NoError(t, err)
+	assert.Len(t, tree, 2)
+	assert.Equal(t, "Books", tree[0].Name)
+	assert.Empty(t, tree[0].Children)
+	assert.Equal(t, "Electronics", tree[1].Name)
+	assert.Equal(t, "Audio", tree[1].Children[0].Name)
+	assert.Equal(t, "Headphones", tree[1].Children[0].Children[0].Name)
+}
+
+func TestCategoryService_GetTree_KeepsOrphansAsRoots(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	categories := []category.Category{{Id: "c1", Name: "Audio", ParentId: "gone"}}
+	mockRepo.On("GetAllWithContext", context.Background()).Return(categories, nil).Once()
+
+	tree, err := svc.GetTree()
+
+	assert.NoError(t, err)
+	assert.Len(t, tree, 1)
+	assert.Equal(t, "c1", tree[0].Id)
+}
+
+func TestCategoryService_GetBreadcrumbsWithContext(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	ctx := context.Background()
+	mockRepo.On("GetByNameWithContext", ctx, "headphones").Return(&hierarchy[2], nil).Once()
+	mockRepo.On("GetAllWithContext", ctx).Return(hierarchy, nil).Once()
+
+	path, err := svc.GetBreadcrumbsWithContext(ctx, "headphones")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []category.Category{hierarchy[0], hierarchy[1], hierarchy[2]}, path)
+}
+
+func TestCategoryService_GetBreadcrumbs_NotFound(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	mockRepo.On("GetByNameWithContext", context.Background(), "x").Return(nil, apperrors.ErrResourceNotExists).Once()
+
+	_, err := svc.GetBreadcrumbs("x")
+
+	assert.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+}
+
+func TestCategoryService_GetAllWithContext_AttachesStats(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	mockStats := new(mocks.StatsRepositoryMock)
+	svc := service.NewService(mockRepo, service.WithStats(mockStats))
+
+	ctx := context.Background()
+	mockRepo.On("GetAllWithContext", ctx).Return([]category.Category{{Id: "c1", Name: "Audio"}, {Id: "c2", Name: "Books"}}, nil).Once()
+	mockStats.On("GetStatsWithContext", ctx, []string(nil)).Return(map[string]category.Stats{
+		"audio": {ProductCount: 2, InStockCount: 1, MinPrice: 10, MaxPrice: 30, AvgPrice: 20, AvgRating: 4},
+	}, nil).Once()
+
+	result, err := svc.GetAllWithContext(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &category.Stats{ProductCount: 2, InStockCount: 1, MinPrice: 10, MaxPrice: 30, AvgPrice: 20, AvgRating: 4}, result[0].Stats)
+	assert.Equal(t, &category.Stats{}, result[1].Stats)
+	mockStats.AssertExpectations(t)
+}
+
+func TestCategoryService_GetByName_AttachesStatsOfThatCategory(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	mockStats := new(mocks.StatsRepositoryMock)
+	svc := service.NewService(mockRepo, service.WithStats(mockStats))
+
+	mockRepo.On("GetByName", "audio").Return(&category.Category{Id: "c1", Name: "Audio"}, nil).Once()
+	mockStats.On("GetStatsWithContext", context.Background(), []string{"Audio"}).Return(map[string]category.Stats{
+		"audio": {ProductCount: 1},
+	}, nil).Once()
+
+	result, err := svc.GetByName("audio")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Stats.ProductCount)
+	mockStats.AssertExpectations(t)
+}
+
+func TestCategoryService_GetAll_PropagatesStatsError(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	mockStats := new(mocks.StatsRepositoryMock)
+	svc := service.NewService(mockRepo, service.WithStats(mockStats))
+
+	mockRepo.On("GetAll").Return([]category.Category{{Id: "c1", Name: "Audio"}}, nil).Once()
+	mockStats.On("GetStatsWithContext", context.Background(), []string{"Audio"}).Return(map[string]category.Stats(nil), errors.New("boom")).Once()
+
+	_, err := svc.GetAll()
+
+	assert.Error(t, err)
+}
+
+func TestCategoryService_Create_DoesNotStoreStats(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	mockRepo.On("CreateWithContext", context.Background(), category.Category{Id: "c1", Slug: "audio", Name: "Audio"}).Return(nil).Once()
+
+	err := svc.Create(category.Category{Id: "c1", Slug: "audio", Name: "Audio", Stats: &category.Stats{ProductCount: 9}})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCategoryService_DeleteWithContext_RejectsCategoriesWithProducts(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	mockStats := new(mocks.StatsRepositoryMock)
+	svc := service.NewService(mockRepo, service.WithStats(mockStats))
+
+	ctx := context.Background()
+	mockRepo.On("GetAllWithContext", ctx).Return(hierarchy, nil)
+	mockStats.On("GetStatsWithContext", ctx, []string{"Headphones"}).Return(map[string]category.Stats{
+		"headphones": {ProductCount: 2},
+	}, nil).Once()
+
+	err := svc.DeleteWithContext(ctx, "c3")
+
+	assert.ErrorIs(t, err, service.ErrInUse)
+	mockRepo.AssertNotCalled(t, "DeleteWithContext", ctx, "c3")
+	mockStats.AssertExpectations(t)
+}
+
+func TestCategoryService_UpdateWithContext_RejectsRenamingCategoriesWithProducts(t *testing.T) {
+	ctx := context.Background()
+	stored := category.Category{Id: "c1", Slug: "audio", Name: "Audio"}
+
+	tests := []struct {
+		name     string
+		newName  string
+		products int
+		wantErr  error
+	}{
+		{name: "renamed with products", newName: "Sound", products: 2, wantErr: service.ErrInUse},
+		{name: "renamed without products", newName: "Sound"},
+		{name: "case changed with products", newName: "AUDIO", products: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.RepositoryMock)
+			mockStats := new(mocks.StatsRepositoryMock)
+			svc := service.NewService(mockRepo, service.WithStats(mockStats))
+
+			c := category.Category{Id: "c1", Slug: "audio", Name: tt.newName}
+			mockRepo.On("GetByIDWithContext", ctx, "c1").Return(&stored, nil).Once()
+			mockStats.On("GetStatsWithContext", ctx, []string{"Audio"}).Return(map[string]category.Stats{
+				"audio": {ProductCount: tt.products},
+			}, nil).Maybe()
+			mockRepo.On("UpdateWithContext", ctx, c).Return(nil).Maybe()
+
+			err := svc.UpdateWithContext(ctx, c)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockRepo.AssertNotCalled(t, "UpdateWithContext", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			mockRepo.AssertCalled(t, "UpdateWithContext", ctx, c)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package category
+
+import "math"
+
+// StatsCounter accumulates the Stats of the products of a category.
+type StatsCounter struct {
+	count, inStock, rated int
+	minPrice, maxPrice    float64
+	sumPrice, sumRatings  float64
+}
+
+// Add counts a product with the given price, rating and availability. A nil
+// rating is a product nobody has rated, left out of the average rating.
+func (c *StatsCounter) Add(price float64, rating *float64, inStock bool) {
+	if c.count == 0 || price < c.minPrice {
+		c.minPrice = price
+	}
+	if c.count == 0 || price > c.maxPrice {
+		c.maxPrice = price
+	}
+	c.count++
+	if inStock {
+		c.inStock++
+	}
+	c.sumPrice += price
+	if rating != nil {
+		c.rated++
+		c.sumRatings += *rating
+	}
+}
+
+// Stats returns the stats of the products added so far, averages rounded to
+// two decimals.
+func (c *StatsCounter) Stats() Stats {
+	if c.count == 0 {
+		return Stats{}
+	}
+	stats := Stats{
+		ProductCount: c.count,
+		InStockCount: c.inStock,
+		MinPrice:     c.minPrice,
+		MaxPrice:     c.maxPrice,
+		AvgPrice:     round2(c.sumPrice / float64(c.count)),
+	}
+	if c.rated > 0 {
+		stats.AvgRating = round2(c.sumRatings / float64(c.rated))
+	}
+	return stats
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,94 @@
+package category
+
+import (
+	"sort"
+	"strings"
+)
+
+// Node is a category with its subcategories, as returned by the tree endpoint.
+type Node struct {
+	Category
+	Children []*Node `json:"children"`
+}
+
+// BuildTree nests categories under their parents. Categories whose parent is
+// missing become roots, so a dangling ParentId never hides a category.
+// Siblings are ordered by name.
+func BuildTree(categories []Category) []*Node {
+	nodes := make(map[string]*Node, len(categories))
+	for _, c := range categories {
+		nodes[c.Id] = &Node{Category: c, Children: []*Node{}}
+	}
+
+	roots := make([]*Node, 0)
+	for _, c := range categories {
+		node := nodes[c.Id]
+		if parent, ok := nodes[c.ParentId]; ok && c.ParentId != c.Id {
+			parent.Children = append(parent.Children, node)
+			continue
+		}
+		roots = append(roots, node)
+	}
+
+	sortNodes(roots)
+	return roots
+}
+
+func sortNodes(nodes []*Node) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return strings.ToLower(nodes[i].Name) < strings.ToLower(nodes[j].Name)
+	})
+	for _, n := range nodes {
+		sortNodes(n.Children)
+	}
+}
+
+// Path returns the ancestors of the category with the given ID followed by
+// the category itself, from the root down, or nil when it does not exist.
+// It stops at the first repeated ID, so a corrupted cycle cannot loop.
+func Path(categories []Category, id string) []Category {
+	byID := make(map[string]Category, len(categories))
+	for _, c := range categories {
+		byID[c.Id] = c
+	}
+
+	var path []Category
+	visited := make(map[string]bool)
+	for current, ok := byID[id]; ok && !visited[current.Id]; current, ok = byID[current.ParentId] {
+		visited[current.Id] = true
+		path = append(path, current)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// Descendants returns every category below the one with the given ID, at any
+// depth, in breadth-first order.
+func Descendants(categories []Category, id string) []Category {
+	children := make(map[string][]Category)
+	for _, c := range categories {
+		if c.ParentId != "" && c.ParentId != c.Id {
+			children[c.ParentId] = append(children[c.ParentId], c)
+		}
+	}
+
+	var result []Category
+	visited := map[string]bool{id: true}
+	queue := []string{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, child := range children[current] {
+			if visited[child.Id] {
+				continue
+			}
+			visited[child.Id] = true
+			result = append(result, child)
+			queue = append(queue, child.Id)
+		}
+	}
+	return result
+}
@@ -1,22 +1,62 @@
 package factory
 
 import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/lucasti79/meli-interview/config"
+	ApiKeyApi "github.com/lucasti79/meli-interview/internal/apikey/api"
+	ApiKeyJsonRepository "github.com/lucasti79/meli-interview/internal/apikey/infra/jsonstore"
+	ApiKeyRepository "github.com/lucasti79/meli-interview/internal/apikey/repository"
+	ApiKeyService "github.com/lucasti79/meli-interview/internal/apikey/service"
+	"github.com/lucasti79/meli-interview/internal/auth"
+	CartApi "github.com/lucasti79/meli-interview/internal/cart/api"
+	CartJsonRepository "github.com/lucasti79/meli-interview/internal/cart/infra/jsonstore"
+	CartRepository "github.com/lucasti79/meli-interview/internal/cart/repository"
+	CartService "github.com/lucasti79/meli-interview/internal/cart/service"
+	"github.com/lucasti79/meli-interview/internal/category"
 	CategoryApi "github.com/lucasti79/meli-interview/internal/category/api"
+	CategoryBoltRepository "github.com/lucasti79/meli-interview/internal/category/infra/boltstore"
 	CategoryJsonRepository "github.com/lucasti79/meli-interview/internal/category/infra/jsonstore"
 	CategoryRepository "github.com/lucasti79/meli-interview/internal/category/repository"
 	CategoryService "github.com/lucasti79/meli-interview/internal/category/service"
+	"github.com/lucasti79/meli-interview/internal/infra/boltstore"
+	"github.com/lucasti79/meli-interview/internal/infra/jsonstore"
+	OrderApi "github.com/lucasti79/meli-interview/internal/order/api"
+	OrderJsonRepository "github.com/lucasti79/meli-interview/internal/order/infra/jsonstore"
+	OrderRepository "github.com/lucasti79/meli-interview/internal/order/repository"
+	OrderService "github.com/lucasti79/meli-interview/internal/order/service"
+	"github.com/lucasti79/meli-interview/internal/product"
 	ProductApi "github.com/lucasti79/meli-interview/internal/product/api"
+	ProductBoltRepository "github.com/lucasti79/meli-interview/internal/product/infra/boltstore"
 	ProductJsonRepository "github.com/lucasti79/meli-interview/internal/product/infra/jsonstore"
 	ProductRepository "github.com/lucasti79/meli-interview/internal/product/repository"
 	ProductService "github.com/lucasti79/meli-interview/internal/product/service"
+	ReviewApi "github.com/lucasti79/meli-interview/internal/review/api"
+	ReviewJsonRepository "github.com/lucasti79/meli-interview/internal/review/infra/jsonstore"
+	ReviewRepository "github.com/lucasti79/meli-interview/internal/review/repository"
+	ReviewService "github.com/lucasti79/meli-interview/internal/review/service"
+	StatusApi "github.com/lucasti79/meli-interview/internal/status/api"
 )
 
 type AppFactory struct {
 	ProductHandler  *ProductApi.Handler
 	CategoryHandler *CategoryApi.Handler
+	CartHandler     *CartApi.Handler
+	OrderHandler    *OrderApi.Handler
+	ReviewHandler   *ReviewApi.Handler
+	StatusHandler   *StatusApi.Handler
+	// APIKeyHandler manages the API keys and authenticates the requests sent
+	// with one.
+	APIKeyHandler *ApiKeyApi.Handler
+	// Authenticator verifies the tokens of the requests to the API.
+	Authenticator *auth.Authenticator
 }
 
-func NewProductHandler(repo ProductRepository.Repository) (*ProductApi.Handler, error) {
+// NewProductHandler builds the product handler. When categories is not nil,
+// product writes are rejected unless their category exists.
+func NewProductHandler(repo ProductRepository.Repository, categories ProductService.CategoryFinder, opts ...ProductApi.Option) (*ProductApi.Handler, error) {
 	if repo == nil {
 		var err error
 		repo, err = ProductJsonRepository.NewProductRepository("products.jsonl")
@@ -25,48 +65,327 @@ func NewProductHandler(repo ProductRepository.Repository) (*ProductApi.Handler,
 		}
 	}
 
-	service := ProductService.NewService(repo)
-	handler := ProductApi.NewHandler(service)
+	var serviceOpts []ProductService.Option
+	if categories != nil {
+		serviceOpts = append(serviceOpts, ProductService.WithCategories(categories))
+	}
+	service := ProductService.NewService(repo, serviceOpts...)
+	handler := ProductApi.NewHandler(service, opts...)
 	return handler, nil
 }
 
-func NewCategoryHandler(repo CategoryRepository.Repository) (*CategoryApi.Handler, error) {
+// NewCategoryHandler builds the category handler. When stats is not nil,
+// categories are returned with the stats of their products, and the ones with
+// products cannot be deleted or renamed.
+func NewCategoryHandler(repo CategoryRepository.Repository, stats CategoryRepository.StatsRepository) (*CategoryApi.Handler, error) {
 	if repo == nil {
 		var err error
-		repo, err = CategoryJsonRepository.NewCategoryRepository("products.jsonl")
+		repo, err = CategoryJsonRepository.NewCategoryRepository("categories.jsonl")
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	service := CategoryService.NewService(repo)
+	var serviceOpts []CategoryService.Option
+	if stats != nil {
+		serviceOpts = append(serviceOpts, CategoryService.WithStats(stats))
+	}
+	service := CategoryService.NewService(repo, serviceOpts...)
 	handler := CategoryApi.NewHandler(service)
 	return handler, nil
 }
 
-func NewAppFactory() (*AppFactory, error) {
-	productHandler, err := NewProductHandler(nil)
+// NewCartHandler builds the cart handler, pricing carts from products.
+func NewCartHandler(repo CartRepository.Repository, products CartService.ProductFinder) (*CartApi.Handler, error) {
+	if repo == nil {
+		var err error
+		repo, err = CartJsonRepository.NewCartRepository("carts.jsonl")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	service := CartService.NewService(repo, products)
+	handler := CartApi.NewHandler(service)
+	return handler, nil
+}
+
+// NewOrderHandler builds the order handler, taking the units ordered out of
+// the stock of products. When carts is not nil, orders can be placed from
+// its carts.
+func NewOrderHandler(repo OrderRepository.Repository, products OrderService.ProductStore, carts OrderService.CartStore) (*OrderApi.Handler, error) {
+	if repo == nil {
+		var err error
+		repo, err = OrderJsonRepository.NewOrderRepository("orders.jsonl")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var serviceOpts []OrderService.Option
+	if carts != nil {
+		serviceOpts = append(serviceOpts, OrderService.WithCarts(carts))
+	}
+	service := OrderService.NewService(repo, products, serviceOpts...)
+	handler := OrderApi.NewHandler(service)
+	return handler, nil
+}
+
+// NewReviewHandler builds the review handler, keeping the rating of products
+// up to date with their reviews.
+func NewReviewHandler(repo ReviewRepository.Repository, products ReviewService.ProductStore) (*ReviewApi.Handler, error) {
+	if repo == nil {
+		var err error
+		repo, err = ReviewJsonRepository.NewReviewRepository("reviews.jsonl")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	service := ReviewService.NewService(repo, products)
+	handler := ReviewApi.NewHandler(service)
+	return handler, nil
+}
+
+// NewAPIKeyHandler builds the API key handler.
+func NewAPIKeyHandler(repo ApiKeyRepository.Repository) (*ApiKeyApi.Handler, error) {
+	if repo == nil {
+		var err error
+		repo, err = ApiKeyJsonRepository.NewAPIKeyRepository("apikeys.jsonl")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	service := ApiKeyService.NewService(repo)
+	handler := ApiKeyApi.NewHandler(service)
+	return handler, nil
+}
+
+// syncOption returns how the JSONL stores flush their writes to disk.
+func syncOption(cfg *config.Config) (jsonstore.Option, error) {
+	syncPolicy, err := jsonstore.ParseSyncPolicy(cfg.Catalog.Fsync)
+	if err != nil {
+		return nil, err
+	}
+	return jsonstore.WithSync(syncPolicy, cfg.Catalog.FsyncInterval), nil
+}
+
+// catalog holds the repositories of the configured storage backend.
+type catalog struct {
+	products   ProductRepository.Repository
+	categories CategoryRepository.Repository
+	stats      CategoryRepository.StatsRepository
+	// stores report their state on the status endpoint
+	stores map[string]StatusApi.Store
+}
+
+func newCatalog(cfg *config.Config) (*catalog, error) {
+	switch cfg.Catalog.Storage {
+	case "", config.StorageJSONL:
+		return newJSONLCatalog(cfg)
+	case config.StorageBolt:
+		return newBoltCatalog(cfg)
+	default:
+		return nil, fmt.Errorf("unknown catalog storage %q", cfg.Catalog.Storage)
+	}
+}
+
+func newJSONLCatalog(cfg *config.Config) (*catalog, error) {
+	sync, err := syncOption(cfg)
+	if err != nil {
+		return nil, err
+	}
 
+	store, err := ProductJsonRepository.NewProductStore("products.jsonl", sync)
 	if err != nil {
 		return nil, err
 	}
 
-	categoryHandler, err := NewCategoryHandler(nil)
+	// product writes are validated against the same category repository the
+	// category endpoints write to, so new categories are usable right away
+	categoryStore, err := CategoryJsonRepository.NewCategoryStore("categories.jsonl", sync)
+	if err != nil {
+		return nil, err
+	}
+
+	// files replaced on disk, e.g. by a catalog import, are picked up without
+	// restarting the server
+	if cfg.Catalog.WatchFiles {
+		if err := store.Watch(context.Background()); err != nil {
+			return nil, err
+		}
+		if err := categoryStore.Watch(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+
+	return &catalog{
+		products:   ProductJsonRepository.NewProductRepositoryFromStore(store),
+		categories: CategoryJsonRepository.NewCategoryRepositoryFromStore(categoryStore),
+		// stats are read from the product store shared with the product
+		// handler, so product writes are reflected without reloading the file
+		stats: CategoryJsonRepository.NewStatsRepository(store),
+		stores: map[string]StatusApi.Store{
+			"products":   store,
+			"categories": categoryStore,
+		},
+	}, nil
+}
+
+func newBoltCatalog(cfg *config.Config) (*catalog, error) {
+	fileName := cfg.Catalog.BoltFile
+	if fileName == "" {
+		fileName = "catalog.db"
+	}
+	db, err := boltstore.Open(fileName)
 	if err != nil {
 		return nil, err
 	}
 
+	store, err := ProductBoltRepository.NewProductStore(db)
+	if err != nil {
+		return nil, err
+	}
+	categoryStore, err := CategoryBoltRepository.NewCategoryStore(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// a new database starts with the catalog of the JSONL files
+	if err := seed(store, "products.jsonl", func(p product.Product) string { return p.Id }); err != nil {
+		return nil, err
+	}
+	if err := seed(categoryStore, "categories.jsonl", func(c category.Category) string { return c.Id }); err != nil {
+		return nil, err
+	}
+
+	return &catalog{
+		products:   ProductBoltRepository.NewProductRepository(store),
+		categories: CategoryBoltRepository.NewCategoryRepository(categoryStore),
+		stats:      CategoryBoltRepository.NewStatsRepository(store),
+		stores:     map[string]StatusApi.Store{},
+	}, nil
+}
+
+// seed copies the records of a JSONL file into store when it is empty.
+func seed[T any](store *boltstore.Store[T], fileName string, getID jsonstore.IDGetter[T]) error {
+	empty, err := store.Empty()
+	if err != nil || !empty {
+		return err
+	}
+
+	source, err := jsonstore.NewJSONRepository(fileName, getID)
+	if err != nil {
+		return err
+	}
+	var entities []T
+	err = source.FindAll(func(entity T) error {
+		entities = append(entities, entity)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := store.InsertAll(entities); err != nil {
+		return fmt.Errorf("seeding from %s: %w", fileName, err)
+	}
+	log.Printf("boltstore: seeded %d records from %s", len(entities), fileName)
+	return nil
+}
+
+func NewAppFactory(cfg *config.Config) (*AppFactory, error) {
+	storage, err := newCatalog(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	productHandler, err := NewProductHandler(
+		storage.products,
+		storage.categories,
+		ProductApi.WithPriceBuckets(cfg.Catalog.PriceBuckets),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryHandler, err := NewCategoryHandler(storage.categories, storage.stats)
+	if err != nil {
+		return nil, err
+	}
+
+	// carts, orders, reviews and API keys are kept in their own JSONL files
+	// whatever the catalog storage
+	sync, err := syncOption(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cartStore, err := CartJsonRepository.NewCartStore("carts.jsonl", sync)
+	if err != nil {
+		return nil, err
+	}
+	storage.stores["carts"] = cartStore
+	carts := CartJsonRepository.NewCartRepositoryFromStore(cartStore)
+	cartHandler, err := NewCartHandler(carts, storage.products)
+	if err != nil {
+		return nil, err
+	}
+
+	orderStore, err := OrderJsonRepository.NewOrderStore("orders.jsonl", sync)
+	if err != nil {
+		return nil, err
+	}
+	storage.stores["orders"] = orderStore
+	orderHandler, err := NewOrderHandler(OrderJsonRepository.NewOrderRepositoryFromStore(orderStore), storage.products, carts)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewStore, err := ReviewJsonRepository.NewReviewStore("reviews.jsonl", sync)
+	if err != nil {
+		return nil, err
+	}
+	storage.stores["reviews"] = reviewStore
+	reviewHandler, err := NewReviewHandler(ReviewJsonRepository.NewReviewRepositoryFromStore(reviewStore), storage.products)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeyStore, err := ApiKeyJsonRepository.NewAPIKeyStore("apikeys.jsonl", sync)
+	if err != nil {
+		return nil, err
+	}
+	storage.stores["apikeys"] = apiKeyStore
+	apiKeyHandler, err := NewAPIKeyHandler(ApiKeyJsonRepository.NewAPIKeyRepositoryFromStore(apiKeyStore))
+	if err != nil {
+		return nil, err
+	}
+
+	authenticator, err := auth.NewAuthenticator(cfg.Auth)
+	if err != nil {
+		return nil, err
+	}
+	if !authenticator.Configured() {
+		log.Printf("auth: no JWT key is configured, endpoints that require a role will answer 401")
+	}
+
 	return &AppFactory{
 		ProductHandler:  productHandler,
 		CategoryHandler: categoryHandler,
+		CartHandler:     cartHandler,
+		OrderHandler:    orderHandler,
+		ReviewHandler:   reviewHandler,
+		StatusHandler:   StatusApi.NewHandler(storage.stores),
+		APIKeyHandler:   apiKeyHandler,
+		Authenticator:   authenticator,
 	}, nil
 }
 
 var appFactory *AppFactory
 
-func InitFactory() error {
+func InitFactory(cfg *config.Config) error {
 	var err error
-	appFactory, err = NewAppFactory()
+	appFactory, err = NewAppFactory(cfg)
 	return err
 }
 
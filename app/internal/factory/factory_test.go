@@ -1,46 +1,87 @@
 package factory_test
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/lucasti79/meli-interview/config"
+	apiKeyMocks "github.com/lucasti79/meli-interview/internal/apikey/infra/mocks"
+	cartMocks "github.com/lucasti79/meli-interview/internal/cart/infra/mocks"
 	categoryMocks "github.com/lucasti79/meli-interview/internal/category/infra/mocks"
 	"github.com/lucasti79/meli-interview/internal/factory"
+	orderMocks "github.com/lucasti79/meli-interview/internal/order/infra/mocks"
 	productMocks "github.com/lucasti79/meli-interview/internal/product/infra/mocks"
+	reviewMocks "github.com/lucasti79/meli-interview/internal/review/infra/mocks"
 	"github.com/stretchr/testify/require"
 )
 
 func TestNewProductHandler_WithNilRepo(t *testing.T) {
-	handler, err := factory.NewProductHandler(nil)
+	handler, err := factory.NewProductHandler(nil, nil)
 	require.NoError(t, err)
 	require.NotNil(t, handler)
 }
 
 func TestNewProductHandler_WithMockRepo(t *testing.T) {
 	mockRepo := new(productMocks.RepositoryMock)
-	handler, err := factory.NewProductHandler(mockRepo)
+	handler, err := factory.NewProductHandler(mockRepo, nil)
 	require.NoError(t, err)
 	require.NotNil(t, handler)
 }
 
 func TestNewCategoryHandler_WithNilRepo(t *testing.T) {
-	handler, err := factory.NewCategoryHandler(nil)
+	handler, err := factory.NewCategoryHandler(nil, nil)
 	require.NoError(t, err)
 	require.NotNil(t, handler)
 }
 
 func TestNewCategoryHandler_WithMockRepo(t *testing.T) {
 	mockRepo := new(categoryMocks.RepositoryMock)
-	handler, err := factory.NewCategoryHandler(mockRepo)
+	handler, err := factory.NewCategoryHandler(mockRepo, nil)
+	require.NoError(t, err)
+	require.NotNil(t, handler)
+}
+
+func TestNewCartHandler_WithMockRepo(t *testing.T) {
+	mockRepo := new(cartMocks.RepositoryMock)
+	handler, err := factory.NewCartHandler(mockRepo, new(productMocks.RepositoryMock))
+	require.NoError(t, err)
+	require.NotNil(t, handler)
+}
+
+func TestNewOrderHandler_WithMockRepo(t *testing.T) {
+	mockRepo := new(orderMocks.RepositoryMock)
+	handler, err := factory.NewOrderHandler(mockRepo, new(productMocks.RepositoryMock), new(cartMocks.RepositoryMock))
+	require.NoError(t, err)
+	require.NotNil(t, handler)
+}
+
+func TestNewReviewHandler_WithMockRepo(t *testing.T) {
+	mockRepo := new(reviewMocks.RepositoryMock)
+	handler, err := factory.NewReviewHandler(mockRepo, new(productMocks.RepositoryMock))
+	require.NoError(t, err)
+	require.NotNil(t, handler)
+}
+
+func TestNewAPIKeyHandler_WithMockRepo(t *testing.T) {
+	handler, err := factory.NewAPIKeyHandler(new(apiKeyMocks.RepositoryMock))
 	require.NoError(t, err)
 	require.NotNil(t, handler)
 }
 
 func TestNewAppFactory(t *testing.T) {
-	appFactory, err := factory.NewAppFactory()
+	appFactory, err := factory.NewAppFactory(&config.Config{})
 	require.NoError(t, err)
 	require.NotNil(t, appFactory)
 	require.NotNil(t, appFactory.ProductHandler)
 	require.NotNil(t, appFactory.CategoryHandler)
+	require.NotNil(t, appFactory.CartHandler)
+	require.NotNil(t, appFactory.OrderHandler)
+	require.NotNil(t, appFactory.ReviewHandler)
+	require.NotNil(t, appFactory.APIKeyHandler)
 }
 
 func TestInitFactoryAndGetFactory(t *testing.T) {
@@ -48,7 +89,7 @@ func TestInitFactoryAndGetFactory(t *testing.T) {
 		factory.GetFactory()
 	})
 
-	err := factory.InitFactory()
+	err := factory.InitFactory(&config.Config{})
 	require.NoError(t, err)
 
 	appFactory := factory.GetFactory()
@@ -56,3 +97,37 @@ func TestInitFactoryAndGetFactory(t *testing.T) {
 	require.NotNil(t, appFactory.ProductHandler)
 	require.NotNil(t, appFactory.CategoryHandler)
 }
+
+func TestNewAppFactory_RejectsUnknownFsyncPolicy(t *testing.T) {
+	_, err := factory.NewAppFactory(&config.Config{Catalog: config.CatalogConfig{Fsync: "sometimes"}})
+	require.Error(t, err)
+}
+
+func TestNewAppFactory_RejectsUnknownStorage(t *testing.T) {
+	_, err := factory.NewAppFactory(&config.Config{Catalog: config.CatalogConfig{Storage: "postgres"}})
+	require.Error(t, err)
+}
+
+func TestNewAppFactory_BoltStorageIsSeededFromTheJSONLFiles(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("PROJECT_ROOT", root)
+	products := `{"productId":"p1","name":"Mouse","category":"Electronics","price":25}` + "\n" +
+		`{"productId":"p2","name":"Mug","category":"Home","price":12.5}` + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(root, "products.jsonl"), []byte(products), 0o600))
+
+	cfg := &config.Config{Catalog: config.CatalogConfig{Storage: config.StorageBolt, BoltFile: "catalog.db"}}
+	appFactory, err := factory.NewAppFactory(cfg)
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(root, "catalog.db"))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+	appFactory.ProductHandler.GetAll(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		TotalCount int `json:"totalCount"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, 2, body.TotalCount)
+}
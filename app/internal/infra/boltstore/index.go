@@ -0,0 +1,167 @@
+package boltstore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	bolt "go.etcd.io/bbolt"
+)
+
+const indexBucketPrefix = "index:"
+
+// Index stores the keys of every record in a bucket of its own, as the key
+// followed by the record number, so the records holding a key are found with
+// a prefix scan and a range of keys with a seek.
+type Index[T any] struct {
+	name   string
+	keys   func(entity T) [][]byte
+	unique bool
+}
+
+// NewKeywordIndex indexes records by an exact key, such as a category.
+func NewKeywordIndex[T any](name string, key func(entity T) string) *Index[T] {
+	return &Index[T]{name: name, keys: func(entity T) [][]byte {
+		return [][]byte{[]byte(key(entity))}
+	}}
+}
+
+// NewUniqueKeywordIndex is a keyword index that rejects writes whose key is
+// already held by another record. Empty keys are not checked.
+func NewUniqueKeywordIndex[T any](name string, key func(entity T) string) *Index[T] {
+	idx := NewKeywordIndex(name, key)
+	idx.unique = true
+	return idx
+}
+
+// NewRangeIndex indexes records by a number, queried with Tx.Range.
+func NewRangeIndex[T any](name string, value func(entity T) float64) *Index[T] {
+	return &Index[T]{name: name, keys: func(entity T) [][]byte {
+		return [][]byte{encodeFloat(value(entity))}
+	}}
+}
+
+func (idx *Index[T]) bucket() []byte {
+	return []byte(indexBucketPrefix + idx.name)
+}
+
+func entryKey(key, record []byte) []byte {
+	entry := make([]byte, 0, len(key)+len(record))
+	return append(append(entry, key...), record...)
+}
+
+func (idx *Index[T]) add(root *bolt.Bucket, record []byte, entity T) error {
+	b := root.Bucket(idx.bucket())
+	for _, key := range idx.keys(entity) {
+		if err := b.Put(entryKey(key, record), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (idx *Index[T]) remove(root *bolt.Bucket, record []byte, entity T) error {
+	b := root.Bucket(idx.bucket())
+	for _, key := range idx.keys(entity) {
+		if err := b.Delete(entryKey(key, record)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (idx *Index[T]) check(root *bolt.Bucket, entity T, replacing []byte) error {
+	if !idx.unique {
+		return nil
+	}
+	b := root.Bucket(idx.bucket())
+	for _, key := range idx.keys(entity) {
+		if len(key) == 0 {
+			continue
+		}
+		taken := false
+		lookup(b, key, func(record []byte) {
+			if !bytes.Equal(record, replacing) {
+				taken = true
+			}
+		})
+		if taken {
+			return fmt.Errorf("%w: key %q is already taken", apperrors.ErrResourceAlreadyExists, key)
+		}
+	}
+	return nil
+}
+
+// lookup calls fn with the number of every record holding key.
+func lookup(b *bolt.Bucket, key []byte, fn func(record []byte)) {
+	c := b.Cursor()
+	for k, _ := c.Seek(key); k != nil && bytes.HasPrefix(k, key); k, _ = c.Next() {
+		if len(k) == len(key)+8 {
+			fn(k[len(key):])
+		}
+	}
+}
+
+// encodeFloat maps v to 8 bytes that sort like the numbers do.
+func encodeFloat(v float64) []byte {
+	bits := math.Float64bits(v)
+	if bits&(1<<63) == 0 {
+		bits ^= 1 << 63
+	} else {
+		bits = ^bits
+	}
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, bits)
+	return key
+}
+
+// Set is a set of record numbers in ascending order, which is the order the
+// records were last written in.
+type Set []uint64
+
+func newSet(seen map[uint64]bool) Set {
+	set := make(Set, 0, len(seen))
+	for seq := range seen {
+		set = append(set, seq)
+	}
+	sort.Slice(set, func(i, j int) bool { return set[i] < set[j] })
+	return set
+}
+
+// Has reports whether seq is in the set.
+func (s Set) Has(seq uint64) bool {
+	i := sort.Search(len(s), func(i int) bool { return s[i] >= seq })
+	return i < len(s) && s[i] == seq
+}
+
+// Intersect returns the records present in every set.
+func Intersect(sets ...Set) Set {
+	if len(sets) == 0 {
+		return nil
+	}
+	result := sets[0]
+	for _, set := range sets[1:] {
+		var next Set
+		for _, seq := range result {
+			if set.Has(seq) {
+				next = append(next, seq)
+			}
+		}
+		result = next
+	}
+	return result
+}
+
+// Union returns the records present in any of the sets.
+func Union(sets ...Set) Set {
+	seen := make(map[uint64]bool)
+	for _, set := range sets {
+		for _, seq := range set {
+			seen[seq] = true
+		}
+	}
+	return newSet(seen)
+}
@@ -0,0 +1,362 @@
+package boltstore
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/lucasti79/meli-interview/pkg/helpers"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	recordsBucket = []byte("records")
+	idsBucket     = []byte("ids")
+)
+
+// openTimeout bounds how long Open waits for another process to release the
+// database file.
+const openTimeout = time.Second
+
+// Open opens the database file, creating it when missing. Relative names are
+// resolved against the project root, like the JSONL files. A database is
+// locked by the process that opened it.
+func Open(fileName string) (*bolt.DB, error) {
+	path := filepath.Clean(fileName)
+	if !filepath.IsAbs(fileName) {
+		path = filepath.Join(helpers.ProjectRoot(), fileName)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path, 0o644, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("boltstore: open %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// Store keeps entities as JSON in a bucket of a bbolt database, with secondary
+// indexes and a text index in nested buckets updated in the same transaction
+// as the records, so queries look up their candidates instead of reading
+// every record.
+//
+// Records are numbered by a sequence that grows with every write. An update
+// stores the new version under a new number, so scans return records in the
+// order they were last written, like the lines of a JSONL file.
+type Store[T any] struct {
+	db      *bolt.DB
+	name    []byte
+	getID   func(entity T) string
+	indexes map[string]*Index[T]
+	text    *TextIndex[T]
+}
+
+// Option customizes a Store.
+type Option[T any] func(*Store[T])
+
+// WithIndex maintains idx for every record of the store.
+func WithIndex[T any](idx *Index[T]) Option[T] {
+	return func(s *Store[T]) {
+		s.indexes[idx.name] = idx
+	}
+}
+
+// WithTextIndex maintains idx for every record of the store, queried by
+// Tx.Search.
+func WithTextIndex[T any](idx *TextIndex[T]) Option[T] {
+	return func(s *Store[T]) {
+		s.text = idx
+	}
+}
+
+// NewStore keeps entities in the bucket called name, creating it and the
+// buckets of its indexes when missing. Indexes added to an existing bucket
+// are built from the records already in it.
+func NewStore[T any](db *bolt.DB, name string, getID func(entity T) string, opts ...Option[T]) (*Store[T], error) {
+	s := &Store[T]{
+		db:      db,
+		name:    []byte(name),
+		getID:   getID,
+		indexes: make(map[string]*Index[T]),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	err := db.Update(func(tx *bolt.Tx) error {
+		root, err := tx.CreateBucketIfNotExists(s.name)
+		if err != nil {
+			return err
+		}
+		var added []*Index[T]
+		for _, idx := range s.indexes {
+			if root.Bucket(idx.bucket()) == nil {
+				added = append(added, idx)
+			}
+		}
+		names := [][]byte{recordsBucket, idsBucket}
+		for _, idx := range s.indexes {
+			names = append(names, idx.bucket())
+		}
+		if s.text != nil {
+			names = append(names, termsBucket, lengthsBucket, textMetaBucket)
+		}
+		for _, n := range names {
+			if _, err := root.CreateBucketIfNotExists(n); err != nil {
+				return err
+			}
+		}
+		return s.build(root, added)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("boltstore: create bucket %s: %w", name, err)
+	}
+	return s, nil
+}
+
+// build adds the records of root to indexes.
+func (s *Store[T]) build(root *bolt.Bucket, indexes []*Index[T]) error {
+	if len(indexes) == 0 {
+		return nil
+	}
+	return root.Bucket(recordsBucket).ForEach(func(key, data []byte) error {
+		entity, err := s.decode(key, data)
+		if err != nil {
+			return err
+		}
+		for _, idx := range indexes {
+			if err := idx.add(root, key, entity); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func seqKey(seq uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+	return key
+}
+
+func (s *Store[T]) typeName(entity T) string {
+	return reflect.TypeOf(entity).Name()
+}
+
+// View runs fn in a read-only transaction, which sees the store as it was
+// when the transaction started however long it runs. It gives up with
+// ctx.Err() when ctx is done before it starts.
+func (s *Store[T]) View(ctx context.Context, fn func(tx *Tx[T]) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.db.View(func(btx *bolt.Tx) error {
+		return fn(&Tx[T]{store: s, root: btx.Bucket(s.name), ctx: ctx})
+	})
+}
+
+// Empty reports whether the store holds no record.
+func (s *Store[T]) Empty() (bool, error) {
+	empty := true
+	err := s.db.View(func(btx *bolt.Tx) error {
+		k, _ := btx.Bucket(s.name).Bucket(recordsBucket).Cursor().First()
+		empty = k == nil
+		return nil
+	})
+	return empty, err
+}
+
+// Insert stores a new entity. It fails with ErrResourceAlreadyExists when its
+// ID is taken or a unique index rejects it.
+func (s *Store[T]) Insert(entity T) error {
+	return s.db.Update(func(btx *bolt.Tx) error {
+		return s.insert(btx.Bucket(s.name), entity)
+	})
+}
+
+// InsertAll stores entities in a single transaction: either all of them are
+// stored or, when one is rejected, none.
+func (s *Store[T]) InsertAll(entities []T) error {
+	return s.db.Update(func(btx *bolt.Tx) error {
+		root := btx.Bucket(s.name)
+		for _, entity := range entities {
+			if err := s.insert(root, entity); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Store[T]) insert(root *bolt.Bucket, entity T) error {
+	id := s.getID(entity)
+	if id != "" && root.Bucket(idsBucket).Get([]byte(id)) != nil {
+		return fmt.Errorf("%w: %s with ID %s already exists", apperrors.ErrResourceAlreadyExists, s.typeName(entity), id)
+	}
+	if err := s.check(root, entity, nil); err != nil {
+		return err
+	}
+	return s.put(root, entity)
+}
+
+// Update replaces the stored version of an entity.
+func (s *Store[T]) Update(entity T) error {
+	return s.db.Update(func(btx *bolt.Tx) error {
+		root := btx.Bucket(s.name)
+		id := s.getID(entity)
+		if id == "" {
+			return apperrors.ErrResourceNotExists
+		}
+		key := root.Bucket(idsBucket).Get([]byte(id))
+		if key == nil {
+			return apperrors.ErrResourceNotExists
+		}
+		key = append([]byte(nil), key...)
+		if err := s.check(root, entity, key); err != nil {
+			return err
+		}
+		if err := s.remove(root, key); err != nil {
+			return err
+		}
+		return s.put(root, entity)
+	})
+}
+
+// Modify replaces the entity stored under id with the one modify returns from
+// it, in a single transaction so no other write can come in between. When
+// modify fails nothing is written and its error is returned.
+func (s *Store[T]) Modify(id string, modify func(entity T) (T, error)) (T, error) {
+	var modified T
+	err := s.db.Update(func(btx *bolt.Tx) error {
+		root := btx.Bucket(s.name)
+		if id == "" {
+			return apperrors.ErrResourceNotExists
+		}
+		key := root.Bucket(idsBucket).Get([]byte(id))
+		if key == nil {
+			return apperrors.ErrResourceNotExists
+		}
+		key = append([]byte(nil), key...)
+		current, err := s.decode(key, root.Bucket(recordsBucket).Get(key))
+		if err != nil {
+			return err
+		}
+		entity, err := modify(current)
+		if err != nil {
+			return err
+		}
+		if s.getID(entity) != id {
+			return fmt.Errorf("boltstore: modify cannot change the ID of %s", id)
+		}
+		if err := s.check(root, entity, key); err != nil {
+			return err
+		}
+		if err := s.remove(root, key); err != nil {
+			return err
+		}
+		modified = entity
+		return s.put(root, entity)
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return modified, nil
+}
+
+// Delete removes the entity with the given ID.
+func (s *Store[T]) Delete(id string) error {
+	return s.db.Update(func(btx *bolt.Tx) error {
+		root := btx.Bucket(s.name)
+		if id == "" {
+			return apperrors.ErrResourceNotExists
+		}
+		key := root.Bucket(idsBucket).Get([]byte(id))
+		if key == nil {
+			return apperrors.ErrResourceNotExists
+		}
+		if err := s.remove(root, append([]byte(nil), key...)); err != nil {
+			return err
+		}
+		return root.Bucket(idsBucket).Delete([]byte(id))
+	})
+}
+
+// check runs the unique indexes against an entity about to be written;
+// replacing is the key of the version being updated, or nil.
+func (s *Store[T]) check(root *bolt.Bucket, entity T, replacing []byte) error {
+	for _, idx := range s.indexes {
+		if err := idx.check(root, entity, replacing); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// put stores entity under the next sequence number and indexes it.
+func (s *Store[T]) put(root *bolt.Bucket, entity T) error {
+	records := root.Bucket(recordsBucket)
+	seq, err := records.NextSequence()
+	if err != nil {
+		return err
+	}
+	key := seqKey(seq)
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+	if err := records.Put(key, data); err != nil {
+		return err
+	}
+	if id := s.getID(entity); id != "" {
+		if err := root.Bucket(idsBucket).Put([]byte(id), key); err != nil {
+			return err
+		}
+	}
+	for _, idx := range s.indexes {
+		if err := idx.add(root, key, entity); err != nil {
+			return err
+		}
+	}
+	if s.text != nil {
+		return s.text.add(root, key, entity)
+	}
+	return nil
+}
+
+// remove deletes the record stored under key and its index entries, but not
+// its ID, which an update points to the new version.
+func (s *Store[T]) remove(root *bolt.Bucket, key []byte) error {
+	records := root.Bucket(recordsBucket)
+	entity, err := s.decode(key, records.Get(key))
+	if err != nil {
+		return err
+	}
+	for _, idx := range s.indexes {
+		if err := idx.remove(root, key, entity); err != nil {
+			return err
+		}
+	}
+	if s.text != nil {
+		if err := s.text.remove(root, key, entity); err != nil {
+			return err
+		}
+	}
+	return records.Delete(key)
+}
+
+func (s *Store[T]) decode(key, data []byte) (T, error) {
+	var entity T
+	if err := json.Unmarshal(data, &entity); err != nil {
+		return entity, fmt.Errorf("%w: bucket %s, record %d: %v", apperrors.ErrInvalidDataFormat, s.name, binary.BigEndian.Uint64(key), err)
+	}
+	return entity, nil
+}
@@ -0,0 +1,195 @@
+package boltstore
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type TestEntity struct {
+	ID    string  `json:"id"`
+	Name  string  `json:"name,omitempty"`
+	Score float64 `json:"score,omitempty"`
+}
+
+func getTestEntityID(e TestEntity) string { return e.ID }
+
+func openTestStore(t *testing.T, path string) *Store[TestEntity] {
+	t.Helper()
+	db, err := Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	store, err := NewStore(db, "entities", getTestEntityID,
+		WithIndex(NewUniqueKeywordIndex("name", func(e TestEntity) string { return e.Name })),
+		WithIndex(NewRangeIndex("score", func(e TestEntity) float64 { return e.Score })),
+	)
+	require.NoError(t, err)
+	return store
+}
+
+func collectIDs(t *testing.T, store *Store[TestEntity]) []string {
+	t.Helper()
+	var ids []string
+	err := store.View(context.Background(), func(tx *Tx[TestEntity]) error {
+		return tx.Scan(func(e TestEntity) error {
+			ids = append(ids, e.ID)
+			return nil
+		})
+	})
+	require.NoError(t, err)
+	return ids
+}
+
+func TestStore_ScansInWriteOrderAndPersistsAcrossReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	store := openTestStore(t, path)
+
+	empty, err := store.Empty()
+	require.NoError(t, err)
+	require.True(t, empty)
+
+	require.NoError(t, store.InsertAll([]TestEntity{{ID: "1"}, {ID: "2"}, {ID: "3"}}))
+	require.NoError(t, store.Update(TestEntity{ID: "1", Name: "one"}))
+	require.NoError(t, store.Delete("2"))
+	require.ErrorIs(t, store.Delete("2"), apperrors.ErrResourceNotExists)
+	require.ErrorIs(t, store.Insert(TestEntity{ID: "3"}), apperrors.ErrResourceAlreadyExists)
+	assert.Equal(t, []string{"3", "1"}, collectIDs(t, store))
+
+	require.NoError(t, store.db.Close())
+	reopened := openTestStore(t, path)
+	assert.Equal(t, []string{"3", "1"}, collectIDs(t, reopened))
+}
+
+func TestStore_InsertAllIsAtomic(t *testing.T) {
+	store := openTestStore(t, filepath.Join(t.TempDir(), "test.db"))
+
+	err := store.InsertAll([]TestEntity{{ID: "1", Name: "a"}, {ID: "2", Name: "a"}})
+	require.ErrorIs(t, err, apperrors.ErrResourceAlreadyExists)
+
+	empty, err := store.Empty()
+	require.NoError(t, err)
+	assert.True(t, empty)
+}
+
+func TestStore_UniqueIndexAllowsKeepingOwnKey(t *testing.T) {
+	store := openTestStore(t, filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, store.InsertAll([]TestEntity{{ID: "1", Name: "a"}, {ID: "2", Name: "b"}}))
+
+	require.NoError(t, store.Update(TestEntity{ID: "1", Name: "a", Score: 1}))
+	require.ErrorIs(t, store.Update(TestEntity{ID: "1", Name: "b"}), apperrors.ErrResourceAlreadyExists)
+	require.NoError(t, store.Update(TestEntity{ID: "2", Name: "c"}))
+	require.NoError(t, store.Insert(TestEntity{ID: "3", Name: "b"}))
+}
+
+func TestStore_ModifyReadsAndWritesInOneTransaction(t *testing.T) {
+	store := openTestStore(t, filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, store.InsertAll([]TestEntity{{ID: "1", Name: "a", Score: 1}, {ID: "2", Name: "b"}}))
+
+	increment := func(e TestEntity) (TestEntity, error) {
+		e.Score++
+		return e, nil
+	}
+	got, err := store.Modify("1", increment)
+	require.NoError(t, err)
+	assert.Equal(t, TestEntity{ID: "1", Name: "a", Score: 2}, got)
+
+	_, err = store.Modify("1", func(e TestEntity) (TestEntity, error) {
+		e.Name = "b"
+		return e, nil
+	})
+	require.ErrorIs(t, err, apperrors.ErrResourceAlreadyExists)
+	_, err = store.Modify("missing", increment)
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+
+	var scored Set
+	require.NoError(t, store.View(context.Background(), func(tx *Tx[TestEntity]) error {
+		var err error
+		scored, err = tx.Range("score", 2, 2)
+		return err
+	}))
+	assert.Len(t, scored, 1)
+	assert.Equal(t, []string{"2", "1"}, collectIDs(t, store))
+}
+
+func TestNewStore_BuildsIndexesAddedToAnExistingBucket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	store := openTestStore(t, path)
+	require.NoError(t, store.InsertAll([]TestEntity{{ID: "1", Name: "a"}, {ID: "2", Name: "b"}}))
+	require.NoError(t, store.db.Close())
+
+	db, err := Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	reopened, err := NewStore(db, "entities", getTestEntityID,
+		WithIndex(NewUniqueKeywordIndex("name", func(e TestEntity) string { return e.Name })),
+		WithIndex(NewRangeIndex("score", func(e TestEntity) float64 { return e.Score })),
+		WithIndex(NewKeywordIndex("first", func(e TestEntity) string { return e.Name[:1] })),
+	)
+	require.NoError(t, err)
+
+	var set Set
+	require.NoError(t, reopened.View(context.Background(), func(tx *Tx[TestEntity]) error {
+		var err error
+		set, err = tx.Lookup("first", "b")
+		return err
+	}))
+	assert.Len(t, set, 1)
+}
+
+func TestTx_RangeOrdersNegativeAndFractionalValues(t *testing.T) {
+	store := openTestStore(t, filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, store.InsertAll([]TestEntity{
+		{ID: "1", Score: -10}, {ID: "2", Score: -0.5}, {ID: "3", Score: 0},
+		{ID: "4", Score: 0.25}, {ID: "5", Score: 7}, {ID: "6", Score: 1e9},
+	}))
+
+	cases := []struct {
+		min, max float64
+		want     Set
+	}{
+		{math.Inf(-1), math.Inf(1), Set{1, 2, 3, 4, 5, 6}},
+		{-1, 0.25, Set{2, 3, 4}},
+		{0.3, 7, Set{5}},
+		{8, 9, Set{}},
+		{5, 1, Set{}},
+	}
+	for _, c := range cases {
+		err := store.View(context.Background(), func(tx *Tx[TestEntity]) error {
+			got, err := tx.Range("score", c.min, c.max)
+			require.NoError(t, err)
+			assert.Equal(t, c.want, got, "[%v, %v]", c.min, c.max)
+			return nil
+		})
+		require.NoError(t, err)
+	}
+}
+
+func TestEncodeFloat_SortsLikeTheNumbers(t *testing.T) {
+	values := []float64{math.Inf(1), 3, -2.5, 0, math.Inf(-1), 1e-9, -1e9, 42}
+	keys := make([][]byte, len(values))
+	for i, v := range values {
+		keys[i] = encodeFloat(v)
+	}
+	sort.Float64s(values)
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+	for i, v := range values {
+		assert.Equal(t, encodeFloat(v), keys[i], "%v", v)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	assert.Equal(t, Set{2, 5}, Intersect(Set{1, 2, 5, 9}, Set{2, 3, 5}, Set{0, 2, 5}))
+	assert.Empty(t, Intersect(Set{1}, Set{2}))
+	assert.Nil(t, Intersect())
+	assert.Equal(t, Set{1, 2, 3, 5}, Union(Set{5, 1}, Set{3, 2}, nil))
+	assert.True(t, Set{1, 4, 7}.Has(4))
+	assert.False(t, Set{1, 4, 7}.Has(5))
+}
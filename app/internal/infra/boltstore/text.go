@@ -0,0 +1,203 @@
+package boltstore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"sort"
+
+	"github.com/lucasti79/meli-interview/pkg/textsearch"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	termsBucket    = []byte("text:terms")
+	lengthsBucket  = []byte("text:lengths")
+	textMetaBucket = []byte("text:meta")
+
+	textDocsKey  = []byte("docs")
+	textTotalKey = []byte("totalLength")
+)
+
+// termSeparator ends the term in the keys of the terms bucket. Analyzers only
+// produce letters and digits, so it never appears in a term.
+const termSeparator = 0
+
+// TextField is a piece of text indexed by a TextIndex. Terms found in fields
+// with a higher Weight count more towards the relevance of a record.
+type TextField[T any] struct {
+	Weight float64
+	Value  func(entity T) string
+}
+
+// Hit is a record matched by a text search.
+type Hit struct {
+	Seq   uint64
+	Score float64
+}
+
+// TextIndex is an inverted index over the text fields of the records, ranked
+// with BM25 like the text index of the JSONL store. The terms bucket holds the
+// weighted frequency of every term in every record, keyed by the term and the
+// record number, so prefixes of a term are found with a seek.
+type TextIndex[T any] struct {
+	fields  []TextField[T]
+	analyze func(text string) []string
+}
+
+// NewTextIndex indexes fields using analyze to split text into terms. The
+// same function is applied to queries.
+func NewTextIndex[T any](analyze func(text string) []string, fields ...TextField[T]) *TextIndex[T] {
+	return &TextIndex[T]{fields: fields, analyze: analyze}
+}
+
+func (idx *TextIndex[T]) frequencies(entity T) (map[string]float64, float64) {
+	freqs := make(map[string]float64)
+	length := 0.0
+	for _, field := range idx.fields {
+		for _, term := range idx.analyze(field.Value(entity)) {
+			freqs[term] += field.Weight
+			length += field.Weight
+		}
+	}
+	return freqs, length
+}
+
+func termKey(term string, record []byte) []byte {
+	key := make([]byte, 0, len(term)+1+len(record))
+	key = append(key, term...)
+	key = append(key, termSeparator)
+	return append(key, record...)
+}
+
+func encodeNumber(v float64) []byte {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, math.Float64bits(v))
+	return data
+}
+
+func decodeNumber(data []byte) float64 {
+	if len(data) != 8 {
+		return 0
+	}
+	return math.Float64frombits(binary.BigEndian.Uint64(data))
+}
+
+func (idx *TextIndex[T]) add(root *bolt.Bucket, record []byte, entity T) error {
+	freqs, length := idx.frequencies(entity)
+	if len(freqs) == 0 {
+		return nil
+	}
+	terms := root.Bucket(termsBucket)
+	for term, freq := range freqs {
+		if err := terms.Put(termKey(term, record), encodeNumber(freq)); err != nil {
+			return err
+		}
+	}
+	if err := root.Bucket(lengthsBucket).Put(record, encodeNumber(length)); err != nil {
+		return err
+	}
+	return idx.count(root, 1, length)
+}
+
+func (idx *TextIndex[T]) remove(root *bolt.Bucket, record []byte, entity T) error {
+	freqs, length := idx.frequencies(entity)
+	if len(freqs) == 0 {
+		return nil
+	}
+	terms := root.Bucket(termsBucket)
+	for term := range freqs {
+		if err := terms.Delete(termKey(term, record)); err != nil {
+			return err
+		}
+	}
+	if err := root.Bucket(lengthsBucket).Delete(record); err != nil {
+		return err
+	}
+	return idx.count(root, -1, -length)
+}
+
+// count adds to the number of indexed records and their total length, which
+// BM25 normalizes lengths by.
+func (idx *TextIndex[T]) count(root *bolt.Bucket, docs, length float64) error {
+	meta := root.Bucket(textMetaBucket)
+	if err := meta.Put(textDocsKey, encodeNumber(decodeNumber(meta.Get(textDocsKey))+docs)); err != nil {
+		return err
+	}
+	return meta.Put(textTotalKey, encodeNumber(decodeNumber(meta.Get(textTotalKey))+length))
+}
+
+func (idx *TextIndex[T]) search(root *bolt.Bucket, query string) []Hit {
+	meta := root.Bucket(textMetaBucket)
+	docs := int(decodeNumber(meta.Get(textDocsKey)))
+	if docs == 0 {
+		return nil
+	}
+	avgLen := decodeNumber(meta.Get(textTotalKey)) / float64(docs)
+	lengths := root.Bucket(lengthsBucket)
+
+	scores := make(map[uint64]float64)
+	seen := make(map[string]bool)
+	for _, queryTerm := range idx.analyze(query) {
+		if seen[queryTerm] {
+			continue
+		}
+		seen[queryTerm] = true
+
+		// a query term contributes its best expansion once per record
+		best := make(map[uint64]float64)
+		for term, posting := range idx.expand(root.Bucket(termsBucket), queryTerm) {
+			weight := 1.0
+			if term != queryTerm {
+				weight = textsearch.PrefixMatchWeight
+			}
+			for record, tf := range posting {
+				docLen := decodeNumber(lengths.Get(seqKey(record)))
+				score := weight * textsearch.BM25(tf, docLen, avgLen, len(posting), docs)
+				if score > best[record] {
+					best[record] = score
+				}
+			}
+		}
+		for record, score := range best {
+			scores[record] += score
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for record, score := range scores {
+		hits = append(hits, Hit{Seq: record, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Seq < hits[j].Seq
+	})
+	return hits
+}
+
+// expand returns the postings of term and, when it is long enough, of every
+// indexed term it is a prefix of.
+func (idx *TextIndex[T]) expand(terms *bolt.Bucket, term string) map[string]map[uint64]float64 {
+	prefix := []byte(term)
+	if len(term) < textsearch.MinPrefixLength {
+		prefix = append(prefix, termSeparator)
+	}
+
+	postings := make(map[string]map[uint64]float64)
+	c := terms.Cursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		if len(k) < 9 {
+			continue
+		}
+		found := string(k[:len(k)-9])
+		posting, ok := postings[found]
+		if !ok {
+			posting = make(map[uint64]float64)
+			postings[found] = posting
+		}
+		posting[binary.BigEndian.Uint64(k[len(k)-8:])] = decodeNumber(v)
+	}
+	return postings
+}
@@ -0,0 +1,136 @@
+package boltstore
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Tx reads a consistent view of a Store. Reads check the context of the
+// transaction before every record and stop with ctx.Err() once it is done.
+type Tx[T any] struct {
+	store *Store[T]
+	root  *bolt.Bucket
+	ctx   context.Context
+}
+
+// Get returns the entity with the given ID.
+func (tx *Tx[T]) Get(id string) (T, error) {
+	var zero T
+	if id == "" {
+		return zero, apperrors.ErrResourceNotExists
+	}
+	key := tx.root.Bucket(idsBucket).Get([]byte(id))
+	if key == nil {
+		return zero, apperrors.ErrResourceNotExists
+	}
+	return tx.store.decode(key, tx.root.Bucket(recordsBucket).Get(key))
+}
+
+// Scan calls handler for every record in the order they were last written.
+func (tx *Tx[T]) Scan(handler func(entity T) error) error {
+	done := tx.ctx.Done()
+	c := tx.root.Bucket(recordsBucket).Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		select {
+		case <-done:
+			return tx.ctx.Err()
+		default:
+		}
+
+		entity, err := tx.store.decode(k, v)
+		if err != nil {
+			return err
+		}
+		if err := handler(entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load calls handler for the given records, in the order given.
+func (tx *Tx[T]) Load(records []uint64, handler func(entity T) error) error {
+	done := tx.ctx.Done()
+	bucket := tx.root.Bucket(recordsBucket)
+	for _, seq := range records {
+		select {
+		case <-done:
+			return tx.ctx.Err()
+		default:
+		}
+
+		key := seqKey(seq)
+		data := bucket.Get(key)
+		if data == nil {
+			continue
+		}
+		entity, err := tx.store.decode(key, data)
+		if err != nil {
+			return err
+		}
+		if err := handler(entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tx *Tx[T]) index(name string) (*bolt.Bucket, error) {
+	idx, ok := tx.store.indexes[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: boltstore: no index %q in %s", apperrors.ErrInternalError, name, tx.store.name)
+	}
+	return tx.root.Bucket(idx.bucket()), nil
+}
+
+// Lookup returns the records holding any of keys in the keyword index name.
+func (tx *Tx[T]) Lookup(name string, keys ...string) (Set, error) {
+	b, err := tx.index(name)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[uint64]bool)
+	for _, key := range keys {
+		lookup(b, []byte(key), func(record []byte) {
+			seen[binary.BigEndian.Uint64(record)] = true
+		})
+	}
+	return newSet(seen), nil
+}
+
+// Range returns the records whose value in the range index name is between
+// min and max, inclusive. Use math.Inf for an open end.
+func (tx *Tx[T]) Range(name string, min, max float64) (Set, error) {
+	b, err := tx.index(name)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(min) || math.IsNaN(max) || min > max {
+		return Set{}, nil
+	}
+	from, to := encodeFloat(min), encodeFloat(max)
+	seen := make(map[uint64]bool)
+	c := b.Cursor()
+	for k, _ := c.Seek(from); k != nil && len(k) == 16 && string(k[:8]) <= string(to); k, _ = c.Next() {
+		seen[binary.BigEndian.Uint64(k[8:])] = true
+	}
+	return newSet(seen), nil
+}
+
+// Search returns the records matching any term of query in the text index,
+// best first. Every query term also matches the indexed terms it is a prefix
+// of, so partially typed words find results.
+func (tx *Tx[T]) Search(query string) ([]Hit, error) {
+	if tx.store.text == nil {
+		return nil, fmt.Errorf("%w: boltstore: no text index in %s", apperrors.ErrInternalError, tx.store.name)
+	}
+	if err := tx.ctx.Err(); err != nil {
+		return nil, err
+	}
+	return tx.store.text.search(tx.root, query), nil
+}
@@ -0,0 +1,34 @@
+package httpdto
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+)
+
+// StatusClientClosedRequest is the non-standard status used when the client
+// went away before the response was ready.
+const StatusClientClosedRequest = 499
+
+// ContextError returns the response for an error caused by the request
+// context ending: 499 when the client cancelled it and 504 when it timed out.
+// ok is false for any other error.
+func ContextError(err error) (status int, body ErrorResponse, ok bool) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return StatusClientClosedRequest, ErrorResponse{
+			Code:    apperrors.ErrRequestCanceled.Error(),
+			Message: err.Error(),
+			Status:  "Client Closed Request",
+		}, true
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, ErrorResponse{
+			Code:    apperrors.ErrRequestTimeout.Error(),
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusGatewayTimeout),
+		}, true
+	}
+	return 0, ErrorResponse{}, false
+}
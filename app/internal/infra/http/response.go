@@ -1,10 +1,11 @@
 package httpdto
 
 type PaginatedResult[T any] struct {
-	Data       []T `json:"data"`
-	TotalCount int `json:"totalCount"`
-	Page       int `json:"page"`
-	PageSize   int `json:"pageSize"`
+	Data       []T    `json:"data"`
+	TotalCount int    `json:"totalCount"`
+	Page       int    `json:"page,omitempty"`
+	PageSize   int    `json:"pageSize"`
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 type ErrorResponse struct {
@@ -0,0 +1,98 @@
+package jsonstore
+
+import "math/bits"
+
+// Bitmap is a growable set of line numbers used by secondary indexes. Query
+// results from different indexes are combined with And and Or.
+type Bitmap struct {
+	words []uint64
+}
+
+func NewBitmap() *Bitmap {
+	return &Bitmap{}
+}
+
+func (b *Bitmap) Set(i int) {
+	w := i >> 6
+	for len(b.words) <= w {
+		b.words = append(b.words, 0)
+	}
+	b.words[w] |= 1 << uint(i&63)
+}
+
+func (b *Bitmap) Clear(i int) {
+	w := i >> 6
+	if w < len(b.words) {
+		b.words[w] &^= 1 << uint(i&63)
+	}
+}
+
+func (b *Bitmap) Has(i int) bool {
+	w := i >> 6
+	return w < len(b.words) && b.words[w]&(1<<uint(i&63)) != 0
+}
+
+// Count returns the number of set bits.
+func (b *Bitmap) Count() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+func (b *Bitmap) Clone() *Bitmap {
+	return &Bitmap{words: append([]uint64(nil), b.words...)}
+}
+
+// And keeps only the bits also set in other.
+func (b *Bitmap) And(other *Bitmap) *Bitmap {
+	if len(b.words) > len(other.words) {
+		b.words = b.words[:len(other.words)]
+	}
+	for i := range b.words {
+		b.words[i] &= other.words[i]
+	}
+	return b
+}
+
+// Or adds every bit set in other.
+func (b *Bitmap) Or(other *Bitmap) *Bitmap {
+	for len(b.words) < len(other.words) {
+		b.words = append(b.words, 0)
+	}
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+	return b
+}
+
+// ForEach calls fn for every set bit in ascending order until fn returns false.
+func (b *Bitmap) ForEach(fn func(i int) bool) {
+	for wi, w := range b.words {
+		for w != 0 {
+			bit := bits.TrailingZeros64(w)
+			if !fn(wi<<6 + bit) {
+				return
+			}
+			w &= w - 1
+		}
+	}
+}
+
+// Intersect returns the intersection of the non-nil bitmaps as a new bitmap,
+// or nil when every argument is nil, meaning "no constraint".
+func Intersect(bitmaps ...*Bitmap) *Bitmap {
+	var result *Bitmap
+	for _, b := range bitmaps {
+		if b == nil {
+			continue
+		}
+		if result == nil {
+			result = b.Clone()
+			continue
+		}
+		result.And(b)
+	}
+	return result
+}
@@ -0,0 +1,151 @@
+package jsonstore
+
+import (
+	"bufio"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Stats describes how much of the file is still referenced by the index and
+// when the file was last indexed, at startup, after a compaction or by Reload.
+type Stats struct {
+	TotalLines     int       `json:"totalLines"`
+	LiveLines      int       `json:"liveLines"`
+	DeadLines      int       `json:"deadLines"`
+	CorruptedLines int       `json:"corruptedLines"`
+	InvalidRecords int       `json:"invalidRecords"`
+	DeadRatio      float64   `json:"deadRatio"`
+	LoadedAt       time.Time `json:"loadedAt"`
+}
+
+func (r *JSONRepository[T]) Stats() Stats {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.stats()
+}
+
+func (r *JSONRepository[T]) stats() Stats {
+	total := len(r.offsets)
+	live := r.live.Count()
+	corrupted := r.corrupted.Count()
+	stats := Stats{
+		TotalLines:     total,
+		LiveLines:      live,
+		DeadLines:      total - live - corrupted,
+		CorruptedLines: corrupted,
+		InvalidRecords: len(r.invalid),
+		LoadedAt:       r.loadedAt,
+	}
+	if total > 0 {
+		stats.DeadRatio = float64(stats.DeadLines) / float64(total)
+	}
+	return stats
+}
+
+// Compact rewrites the file keeping only the latest version of every record.
+// The new content is written to a temporary file in the same directory and
+// renamed over the original, so readers never observe a partial file.
+func (r *JSONRepository[T]) Compact() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.compact()
+}
+
+func (r *JSONRepository[T]) maybeCompact() {
+	ratio := r.options.compactionRatio
+	if ratio <= 0 {
+		return
+	}
+	if stats := r.stats(); stats.DeadLines < r.options.compactionMinDeadLines || stats.DeadRatio < ratio {
+		return
+	}
+	if err := r.compact(); err != nil {
+		log.Printf("jsonstore: automatic compaction of %s failed: %v", r.filePath, err)
+	}
+}
+
+func (r *JSONRepository[T]) compact() error {
+	if r.options.readOnly {
+		return ErrReadOnly
+	}
+	if err := r.refresh(); err != nil {
+		return err
+	}
+	if r.file == nil {
+		return nil
+	}
+
+	info, err := r.file.Stat()
+	if err != nil {
+		return err
+	}
+	src := io.NewSectionReader(r.file, 0, r.size)
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.filePath), filepath.Base(r.filePath)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if err := r.writeLive(src, tmp, info.Mode()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, r.filePath); err != nil {
+		return err
+	}
+	if err := r.syncDir(); err != nil {
+		return err
+	}
+
+	return r.reload()
+}
+
+// writeLive copies live records from src to dst. Lines that cannot be decoded
+// are kept untouched so compaction never loses data it does not understand.
+func (r *JSONRepository[T]) writeLive(src io.Reader, dst *os.File, mode os.FileMode) error {
+	if err := dst.Chmod(mode); err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(dst)
+	scanner := bufio.NewScanner(src)
+	const maxCapacity = 1024 * 102
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, maxCapacity)
+
+	lineNo := 0
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		current := lineNo
+		lineNo++
+
+		if !r.live.Has(current) && !r.corrupted.Has(current) {
+			continue
+		}
+
+		if _, err := writer.Write(line); err != nil {
+			return err
+		}
+		if err := writer.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	return dst.Sync()
+}
@@ -0,0 +1,257 @@
+package jsonstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stressEntity struct {
+	ID    string  `json:"id"`
+	Group string  `json:"group"`
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
+
+var (
+	stressGroups = []string{"red", "green", "blue"}
+	stressWords  = []string{"alpha", "bravo", "charlie", "delta"}
+)
+
+func openStressRepository(t *testing.T, fp string) *JSONRepository[stressEntity] {
+	t.Helper()
+	repo, err := NewJSONRepository(fp, func(e stressEntity) string { return e.ID },
+		WithAutoCompaction(0.3, 50),
+		WithIndex("group", NewKeywordIndex(func(e stressEntity) string { return e.Group })),
+		WithIndex("score", NewRangeIndex(func(e stressEntity) float64 { return e.Score })),
+		WithIndex("name", NewTextIndex(strings.Fields, TextField[stressEntity]{Weight: 1, Value: func(e stressEntity) string { return e.Name }})),
+	)
+	require.NoError(t, err)
+	return repo
+}
+
+func stressVersion(writer, id, version int) stressEntity {
+	return stressEntity{
+		ID:    fmt.Sprintf("w%d-%d", writer, id),
+		Group: stressGroups[(writer+id+version)%len(stressGroups)],
+		Name:  stressWords[(id+version)%len(stressWords)] + " item",
+		Score: float64((writer*7 + id*3 + version) % 100),
+	}
+}
+
+// TestConcurrentReadersAndWriters runs hundreds of readers against writers
+// saving, updating and deleting records (which also triggers compactions).
+// Every read must see indexes that agree with the records they point to, and
+// once the writers are done the in-memory indexes must match the ones rebuilt
+// from the file. Run it with -race.
+func TestConcurrentReadersAndWriters(t *testing.T) {
+	const (
+		readers      = 200
+		writers      = 50
+		idsPerWriter = 10
+		readsEach    = 20
+	)
+
+	var expectedMu sync.Mutex
+	expected := make(map[string]stressEntity)
+	fp := writeStressSeed(t, 100, expected)
+
+	repo := openStressRepository(t, fp)
+	groups := repo.Index("group").(*KeywordIndex[stressEntity])
+	scores := repo.Index("score").(*RangeIndex[stressEntity])
+	names := repo.Index("name").(*TextIndex[stressEntity])
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for rd := 0; rd < readers; rd++ {
+		wg.Add(1)
+		go func(rd int) {
+			defer wg.Done()
+			<-start
+			for i := 0; i < readsEach; i++ {
+				group := stressGroups[(rd+i)%len(stressGroups)]
+				err := repo.FindAllIndexed(func() *Bitmap { return groups.Lookup(group) }, func(stressEntity) bool { return true }, func(e stressEntity) error {
+					if e.Group != group {
+						return fmt.Errorf("group index returned %s of group %s for %s", e.ID, e.Group, group)
+					}
+					return nil
+				})
+				assert.NoError(t, err)
+
+				min := float64((rd + i) % 90)
+				_, err = repo.FindAllIndexedPaginated(func() *Bitmap { return scores.Range(min, min+10) }, func(stressEntity) bool { return true },
+					func(a, b stressEntity) bool { return a.Score < b.Score }, 1, 5, func(e stressEntity) error {
+						if e.Score < min || e.Score > min+10 {
+							return fmt.Errorf("range index returned %s with score %v outside [%v, %v]", e.ID, e.Score, min, min+10)
+						}
+						return nil
+					})
+				assert.NoError(t, err)
+
+				word := stressWords[(rd+i)%len(stressWords)]
+				_, err = repo.FindAllRankedPaginated(func() []int {
+					hits := names.Search(word[:3])
+					lines := make([]int, len(hits))
+					for j, hit := range hits {
+						lines[j] = hit.Line
+					}
+					return lines
+				}, 1, 5, func(e stressEntity) error {
+					if !strings.HasPrefix(e.Name, word) {
+						return fmt.Errorf("text index returned %s named %q for %q", e.ID, e.Name, word)
+					}
+					return nil
+				})
+				assert.NoError(t, err)
+
+				seen := make(map[string]bool)
+				assert.NoError(t, repo.FindAll(func(e stressEntity) error {
+					if seen[e.ID] {
+						return fmt.Errorf("%s returned twice by a scan", e.ID)
+					}
+					seen[e.ID] = true
+					return nil
+				}))
+
+				id := fmt.Sprintf("w%d-%d", (rd+i)%writers, i%idsPerWriter)
+				got, err := repo.FindByID(id)
+				if err != nil {
+					assert.True(t, errors.Is(err, apperrors.ErrResourceNotExists), "FindByID(%s): %v", id, err)
+				} else {
+					assert.Equal(t, id, got.ID)
+				}
+			}
+		}(rd)
+	}
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			<-start
+			for id := 0; id < idsPerWriter; id++ {
+				e := stressVersion(w, id, 0)
+				if !assert.NoError(t, repo.Save(e)) {
+					return
+				}
+				for version := 1; version <= 3; version++ {
+					e = stressVersion(w, id, version)
+					if !assert.NoError(t, repo.Update(e)) {
+						return
+					}
+				}
+				if id%3 == 0 {
+					if !assert.NoError(t, repo.Delete(e.ID)) {
+						return
+					}
+					continue
+				}
+				expectedMu.Lock()
+				expected[e.ID] = e
+				expectedMu.Unlock()
+			}
+		}(w)
+	}
+
+	close(start)
+	wg.Wait()
+	if t.Failed() {
+		return
+	}
+
+	reopened := openStressRepository(t, fp)
+	for _, r := range []*JSONRepository[stressEntity]{repo, reopened} {
+		got := make(map[string]stressEntity)
+		require.NoError(t, r.FindAll(func(e stressEntity) error {
+			got[e.ID] = e
+			return nil
+		}))
+		require.Equal(t, expected, got)
+
+		for _, group := range stressGroups {
+			idx := r.Index("group").(*KeywordIndex[stressEntity])
+			require.ElementsMatch(t, stressIDs(expected, func(e stressEntity) bool { return e.Group == group }), stressLookup(t, r, idx.Lookup(group)), group)
+		}
+		all := r.Index("score").(*RangeIndex[stressEntity]).Range(math.Inf(-1), math.Inf(1))
+		require.ElementsMatch(t, stressIDs(expected, func(stressEntity) bool { return true }), stressLookup(t, r, all))
+	}
+}
+
+// writeStressSeed writes n records that no writer touches and adds them to
+// expected.
+func writeStressSeed(t *testing.T, n int, expected map[string]stressEntity) string {
+	t.Helper()
+	var lines []string
+	for i := 0; i < n; i++ {
+		e := stressVersion(n+i, i, 0)
+		e.ID = fmt.Sprintf("seed-%d", i)
+		expected[e.ID] = e
+		data, err := json.Marshal(e)
+		require.NoError(t, err)
+		lines = append(lines, string(data))
+	}
+	fp := filepath.Join(t.TempDir(), "stress.jsonl")
+	require.NoError(t, os.WriteFile(fp, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+	return fp
+}
+
+// TestConcurrentFirstQueries queries freshly loaded indexes from many readers
+// at once, so the lazy sorting of the range and text indexes happens under
+// the shared read lock.
+func TestConcurrentFirstQueries(t *testing.T) {
+	repo := openStressRepository(t, writeStressSeed(t, 100, make(map[string]stressEntity)))
+	scores := repo.Index("score").(*RangeIndex[stressEntity])
+	names := repo.Index("name").(*TextIndex[stressEntity])
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for rd := 0; rd < 100; rd++ {
+		wg.Add(1)
+		go func(rd int) {
+			defer wg.Done()
+			<-start
+			var lines *Bitmap
+			var hits []Hit
+			err := repo.FindAllIndexed(func() *Bitmap {
+				lines = scores.Range(0, float64(rd))
+				hits = names.Search("ch")
+				return lines
+			}, func(stressEntity) bool { return true }, func(stressEntity) error { return nil })
+			assert.NoError(t, err)
+			assert.NotEmpty(t, hits)
+		}(rd)
+	}
+	close(start)
+	wg.Wait()
+}
+
+func stressIDs(entities map[string]stressEntity, keep func(e stressEntity) bool) []string {
+	ids := make([]string, 0, len(entities))
+	for id, e := range entities {
+		if keep(e) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func stressLookup(t *testing.T, repo *JSONRepository[stressEntity], lines *Bitmap) []string {
+	t.Helper()
+	ids := make([]string, 0)
+	require.NoError(t, repo.FindAllIndexed(func() *Bitmap { return lines }, func(stressEntity) bool { return true }, func(e stressEntity) error {
+		ids = append(ids, e.ID)
+		return nil
+	}))
+	return ids
+}
@@ -0,0 +1,123 @@
+package jsonstore
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CorruptedLine is a line of the file that could not be decoded when it was
+// loaded. It stays in the file, and Compact keeps it, until someone fixes it.
+type CorruptedLine struct {
+	// Line is the 1-based line number.
+	Line   int    `json:"line"`
+	Offset int64  `json:"offset"`
+	Error  string `json:"error"`
+	// Interrupted is set on a last line without its line feed, what a write
+	// interrupted by a crash leaves behind. Only a file opened WithReadOnly
+	// keeps it; otherwise it is cut off when the file is opened.
+	Interrupted bool `json:"interrupted,omitempty"`
+}
+
+// CorruptedLines reports the lines that could not be decoded, in file order.
+// Queries reaching one of them fail with ErrInvalidDataFormat.
+func (r *JSONRepository[T]) CorruptedLines() []CorruptedLine {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return append([]CorruptedLine(nil), r.corruptions...)
+}
+
+func logCorruptions(path string, corruptions []CorruptedLine) {
+	if len(corruptions) == 0 {
+		return
+	}
+	first := corruptions[0]
+	log.Printf("jsonstore: %s has %d corrupted line(s), first at line %d (offset %d): %s", path, len(corruptions), first.Line, first.Offset, first.Error)
+}
+
+// repair fixes the end of a file left behind by a write interrupted by a
+// crash, before s is installed. A last line without a line feed that cannot be
+// decoded is what was written of a record before the crash; that write never
+// succeeded, so the line is cut off. A last line that decodes only misses its
+// line feed, which is added so the next write starts a line of its own.
+func (r *JSONRepository[T]) repair(s *snapshot[T]) error {
+	if !s.unterminated {
+		return nil
+	}
+
+	f, err := os.OpenFile(r.filePath, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	last := len(s.offsets) - 1
+	if s.corrupted.Has(last) {
+		offset := s.offsets[last]
+		if err := f.Truncate(offset); err != nil {
+			return err
+		}
+		log.Printf("jsonstore: %s: removed %d bytes of an interrupted write at line %d", r.filePath, s.size-offset, last+1)
+		s.size = offset
+		s.offsets = s.offsets[:last]
+		s.corrupted.Clear(last)
+		s.corruptions = s.corruptions[:len(s.corruptions)-1]
+	} else {
+		if _, err := f.Write([]byte{'\n'}); err != nil {
+			return err
+		}
+		log.Printf("jsonstore: %s: added the missing line feed after line %d", r.filePath, last+1)
+		s.size++
+	}
+	s.unterminated = false
+
+	if r.options.syncPolicy == SyncNever {
+		return nil
+	}
+	return f.Sync()
+}
+
+// sync flushes a write to f according to the sync policy. The lock must be
+// held.
+func (r *JSONRepository[T]) sync(f *os.File) error {
+	switch r.options.syncPolicy {
+	case SyncAlways:
+		return f.Sync()
+	case SyncInterval:
+		if r.syncScheduled.CompareAndSwap(false, true) {
+			time.AfterFunc(r.options.syncInterval, r.syncPending)
+		}
+	}
+	return nil
+}
+
+// syncPending flushes the writes made since it was scheduled. Writers wait
+// for it, but readers do not.
+func (r *JSONRepository[T]) syncPending() {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	r.syncScheduled.Store(false)
+	if r.file == nil {
+		return
+	}
+	if err := r.file.Sync(); err != nil {
+		log.Printf("jsonstore: sync of %s failed: %v", r.filePath, err)
+	}
+}
+
+// syncDir flushes the directory of the file, so creating or renaming the file
+// survives a crash.
+func (r *JSONRepository[T]) syncDir() error {
+	if r.options.syncPolicy == SyncNever {
+		return nil
+	}
+	dir, err := os.Open(filepath.Dir(r.filePath))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
@@ -0,0 +1,227 @@
+package jsonstore
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// journalEntry is a write made through the repository; each one appends a
+// single line to the file.
+type journalEntry struct {
+	id      string
+	entity  TestEntity
+	deleted bool
+}
+
+// writeJournal saves, updates and deletes records and returns the writes in
+// the order their lines were appended.
+func writeJournal(t *testing.T, fp string) []journalEntry {
+	t.Helper()
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID, WithAutoCompaction(0, 0), WithSync(SyncNever, 0))
+	require.NoError(t, err)
+
+	var journal []journalEntry
+	for i := 0; i < 20; i++ {
+		e := TestEntity{ID: fmt.Sprintf("%d", i), Name: fmt.Sprintf("Entity %d", i), Group: "g"}
+		require.NoError(t, repo.Save(e))
+		journal = append(journal, journalEntry{id: e.ID, entity: e})
+
+		switch {
+		case i%3 == 1:
+			e.Name += " updated"
+			require.NoError(t, repo.Update(e))
+			journal = append(journal, journalEntry{id: e.ID, entity: e})
+		case i%5 == 4:
+			require.NoError(t, repo.Delete(e.ID))
+			journal = append(journal, journalEntry{id: e.ID, deleted: true})
+		}
+	}
+	return journal
+}
+
+// replay returns the records left by the first n writes of journal.
+func replay(journal []journalEntry, n int) map[string]TestEntity {
+	state := make(map[string]TestEntity)
+	for _, entry := range journal[:n] {
+		if entry.deleted {
+			delete(state, entry.id)
+		} else {
+			state[entry.id] = entry.entity
+		}
+	}
+	return state
+}
+
+// TestNewJSONRepository_RecoversFromWritesInterruptedAtRandomOffsets simulates
+// a crash in the middle of a write by truncating the file at random offsets.
+// Opening the file must keep every complete line, drop the partial one and
+// leave a file that further writes append to cleanly.
+func TestNewJSONRepository_RecoversFromWritesInterruptedAtRandomOffsets(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "source.jsonl")
+	journal := writeJournal(t, source)
+	full, err := os.ReadFile(source)
+	require.NoError(t, err)
+	require.Equal(t, len(journal), bytes.Count(full, []byte{'\n'}))
+
+	seed := time.Now().UnixNano()
+	t.Logf("seed %d", seed)
+	rnd := rand.New(rand.NewSource(seed))
+
+	for i := 0; i < 200; i++ {
+		cut := rnd.Intn(len(full) + 1)
+		if i == 0 {
+			// the write stopped right before the line feed
+			cut = bytes.IndexByte(full, '\n')
+		}
+
+		// complete lines survive, and so does a line only missing its line feed
+		kept := bytes.LastIndexByte(full[:cut], '\n') + 1
+		if cut < len(full) && full[cut] == '\n' {
+			kept = cut + 1
+		}
+		lines := bytes.Count(full[:kept], []byte{'\n'})
+
+		fp := filepath.Join(t.TempDir(), "entities.jsonl")
+		require.NoError(t, os.WriteFile(fp, full[:cut], 0o600))
+
+		repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID)
+		require.NoError(t, err, "cut at %d", cut)
+		require.Empty(t, repo.CorruptedLines(), "cut at %d", cut)
+
+		data, err := os.ReadFile(fp)
+		require.NoError(t, err)
+		require.Equal(t, string(full[:kept]), string(data), "cut at %d", cut)
+
+		expected := replay(journal, lines)
+		require.Equal(t, expected, collectAll(t, repo), "cut at %d", cut)
+
+		next := TestEntity{ID: "next", Name: "After the crash"}
+		require.NoError(t, repo.Save(next))
+		expected[next.ID] = next
+		reopened := reopen(t, fp)
+		require.Empty(t, reopened.CorruptedLines(), "cut at %d", cut)
+		require.Equal(t, expected, collectAll(t, reopened), "cut at %d", cut)
+	}
+}
+
+func collectAll(t *testing.T, repo *JSONRepository[TestEntity]) map[string]TestEntity {
+	t.Helper()
+	got := make(map[string]TestEntity)
+	require.NoError(t, repo.FindAll(func(e TestEntity) error {
+		got[e.ID] = e
+		return nil
+	}))
+	return got
+}
+
+func TestNewJSONRepository_ReportsCorruptedLinesWithoutRemovingThem(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "entities.jsonl")
+	content := "{\"id\":\"1\"}\nnot-a-json\n{\"$deleted\":\n{\"id\":\"2\"}\n"
+	require.NoError(t, os.WriteFile(fp, []byte(content), 0o600))
+
+	repo := reopen(t, fp)
+
+	corrupted := repo.CorruptedLines()
+	require.Len(t, corrupted, 2)
+	assert.Equal(t, 2, corrupted[0].Line)
+	assert.Equal(t, int64(11), corrupted[0].Offset)
+	assert.NotEmpty(t, corrupted[0].Error)
+	assert.Equal(t, 3, corrupted[1].Line)
+	assert.Equal(t, 2, repo.Stats().CorruptedLines)
+
+	err := repo.FindAll(func(TestEntity) error { return nil })
+	require.ErrorIs(t, err, apperrors.ErrInvalidDataFormat)
+
+	data, err := os.ReadFile(fp)
+	require.NoError(t, err)
+	require.Equal(t, content, string(data))
+}
+
+func TestWithReadOnly_ReportsAnInterruptedWriteWithoutChangingTheFile(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "entities.jsonl")
+	content := "{\"id\":\"1\"}\n{\"id\":\"2\",\"na"
+	require.NoError(t, os.WriteFile(fp, []byte(content), 0o600))
+
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID, WithReadOnly())
+	require.NoError(t, err)
+
+	corrupted := repo.CorruptedLines()
+	require.Len(t, corrupted, 1)
+	assert.Equal(t, 2, corrupted[0].Line)
+	assert.True(t, corrupted[0].Interrupted)
+	require.ErrorIs(t, repo.Save(TestEntity{ID: "3"}), ErrReadOnly)
+	require.ErrorIs(t, repo.Compact(), ErrReadOnly)
+
+	data, err := os.ReadFile(fp)
+	require.NoError(t, err)
+	require.Equal(t, content, string(data))
+}
+
+func TestSave_StartsANewLineAfterALineWrittenWithoutLineFeed(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{{ID: "1", Name: "Alice"}})
+	repo := reopen(t, fp)
+
+	// another process appends a record without its line feed
+	f, err := os.OpenFile(fp, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"id":"2","name":"Bob"}`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	require.NoError(t, repo.Save(TestEntity{ID: "3", Name: "Carol"}))
+
+	data, err := os.ReadFile(fp)
+	require.NoError(t, err)
+	require.Equal(t, "{\"id\":\"1\",\"name\":\"Alice\"}\n{\"id\":\"2\",\"name\":\"Bob\"}\n{\"id\":\"3\",\"name\":\"Carol\"}\n", string(data))
+	for _, r := range []*JSONRepository[TestEntity]{repo, reopen(t, fp)} {
+		got, err := r.FindByID("3")
+		require.NoError(t, err)
+		require.Equal(t, "Carol", got.Name)
+		require.Empty(t, r.CorruptedLines())
+	}
+}
+
+func TestWithSync_PersistsWritesUnderEveryPolicy(t *testing.T) {
+	for _, policy := range []SyncPolicy{SyncAlways, SyncInterval, SyncNever} {
+		t.Run(policy.String(), func(t *testing.T) {
+			fp := filepath.Join(t.TempDir(), "entities.jsonl")
+			repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID, WithSync(policy, 10*time.Millisecond))
+			require.NoError(t, err)
+
+			require.NoError(t, repo.Save(TestEntity{ID: "1", Name: "Alice"}))
+			require.NoError(t, repo.Update(TestEntity{ID: "1", Name: "Alicia"}))
+
+			require.Eventually(t, func() bool { return !repo.syncScheduled.Load() }, time.Second, 5*time.Millisecond)
+			got, err := reopen(t, fp).FindByID("1")
+			require.NoError(t, err)
+			require.Equal(t, "Alicia", got.Name)
+		})
+	}
+}
+
+func TestParseSyncPolicy(t *testing.T) {
+	for _, policy := range []SyncPolicy{SyncAlways, SyncInterval, SyncNever} {
+		got, err := ParseSyncPolicy(policy.String())
+		require.NoError(t, err)
+		require.Equal(t, policy, got)
+	}
+	got, err := ParseSyncPolicy(" Interval ")
+	require.NoError(t, err)
+	require.Equal(t, SyncInterval, got)
+	got, err = ParseSyncPolicy("")
+	require.NoError(t, err)
+	require.Equal(t, SyncAlways, got)
+
+	_, err = ParseSyncPolicy("sometimes")
+	require.Error(t, err)
+}
@@ -0,0 +1,186 @@
+package jsonstore
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/lucasti79/meli-interview/pkg/textsearch"
+)
+
+// TextField is a piece of text indexed by a TextIndex. Terms found in fields
+// with a higher Weight count more towards the relevance of a record.
+type TextField[T any] struct {
+	Weight float64
+	Value  func(entity T) string
+}
+
+// Hit is a record matched by a TextIndex search.
+type Hit struct {
+	Line  int
+	Score float64
+}
+
+// TextIndex is an inverted index over the text fields of the records, ranked
+// with BM25. Field weights scale the term frequencies (as in BM25F) so a
+// match in a name outranks the same match in a long description.
+type TextIndex[T any] struct {
+	fields   []TextField[T]
+	analyze  func(text string) []string
+	postings map[string]map[int]float64
+	docTerms map[int][]string
+	docLen   map[int]float64
+	totalLen float64
+	terms    []string
+	dirty    bool
+	sorting  sync.Mutex
+}
+
+// NewTextIndex indexes fields using analyze to split text into terms. The
+// same function is applied to queries.
+func NewTextIndex[T any](analyze func(text string) []string, fields ...TextField[T]) *TextIndex[T] {
+	idx := &TextIndex[T]{fields: fields, analyze: analyze}
+	idx.Reset()
+	return idx
+}
+
+func (idx *TextIndex[T]) Add(line int, entity T) {
+	freqs := make(map[string]float64)
+	length := 0.0
+	for _, field := range idx.fields {
+		for _, term := range idx.analyze(field.Value(entity)) {
+			freqs[term] += field.Weight
+			length += field.Weight
+		}
+	}
+	if len(freqs) == 0 {
+		return
+	}
+
+	terms := make([]string, 0, len(freqs))
+	for term, freq := range freqs {
+		posting, ok := idx.postings[term]
+		if !ok {
+			posting = make(map[int]float64)
+			idx.postings[term] = posting
+			idx.dirty = true
+		}
+		posting[line] = freq
+		terms = append(terms, term)
+	}
+	idx.docTerms[line] = terms
+	idx.docLen[line] = length
+	idx.totalLen += length
+}
+
+func (idx *TextIndex[T]) Remove(line int) {
+	terms, ok := idx.docTerms[line]
+	if !ok {
+		return
+	}
+	for _, term := range terms {
+		posting := idx.postings[term]
+		delete(posting, line)
+		if len(posting) == 0 {
+			delete(idx.postings, term)
+			idx.dirty = true
+		}
+	}
+	idx.totalLen -= idx.docLen[line]
+	delete(idx.docTerms, line)
+	delete(idx.docLen, line)
+}
+
+func (idx *TextIndex[T]) Reset() {
+	idx.postings = make(map[string]map[int]float64)
+	idx.docTerms = make(map[int][]string)
+	idx.docLen = make(map[int]float64)
+	idx.totalLen = 0
+	idx.terms = nil
+	idx.dirty = true
+}
+
+// Search returns the records matching any term of query, best first. Every
+// query term also matches the indexed terms it is a prefix of, so partially
+// typed words find results.
+func (idx *TextIndex[T]) Search(query string) []Hit {
+	docs := len(idx.docLen)
+	if docs == 0 {
+		return nil
+	}
+	avgLen := idx.totalLen / float64(docs)
+
+	scores := make(map[int]float64)
+	seen := make(map[string]bool)
+	for _, queryTerm := range idx.analyze(query) {
+		if seen[queryTerm] {
+			continue
+		}
+		seen[queryTerm] = true
+
+		// a query term contributes its best expansion once per record
+		best := make(map[int]float64)
+		for _, term := range idx.expand(queryTerm) {
+			weight := 1.0
+			if term != queryTerm {
+				weight = textsearch.PrefixMatchWeight
+			}
+			posting := idx.postings[term]
+			for line, tf := range posting {
+				score := weight * textsearch.BM25(tf, idx.docLen[line], avgLen, len(posting), docs)
+				if score > best[line] {
+					best[line] = score
+				}
+			}
+		}
+		for line, score := range best {
+			scores[line] += score
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for line, score := range scores {
+		hits = append(hits, Hit{Line: line, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Line < hits[j].Line
+	})
+	return hits
+}
+
+// expand returns term and, when it is long enough, every indexed term it is a prefix of.
+func (idx *TextIndex[T]) expand(term string) []string {
+	if len(term) < textsearch.MinPrefixLength {
+		if _, ok := idx.postings[term]; ok {
+			return []string{term}
+		}
+		return nil
+	}
+
+	idx.sortTerms()
+
+	var expanded []string
+	for i := sort.SearchStrings(idx.terms, term); i < len(idx.terms) && strings.HasPrefix(idx.terms[i], term); i++ {
+		expanded = append(expanded, idx.terms[i])
+	}
+	return expanded
+}
+
+// sortTerms rebuilds the sorted term list after writes. It runs on the first
+// prefix query, possibly from several concurrent queries at once.
+func (idx *TextIndex[T]) sortTerms() {
+	idx.sorting.Lock()
+	defer idx.sorting.Unlock()
+	if !idx.dirty {
+		return
+	}
+	idx.terms = idx.terms[:0]
+	for t := range idx.postings {
+		idx.terms = append(idx.terms, t)
+	}
+	sort.Strings(idx.terms)
+	idx.dirty = false
+}
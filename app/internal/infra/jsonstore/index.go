@@ -0,0 +1,260 @@
+package jsonstore
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+)
+
+// SecondaryIndex is a derived lookup kept in sync with the live records of a
+// JSONRepository. Records are identified by their line number in the file.
+// Add, Remove and Reset run under the exclusive repository lock, while queries
+// run under the shared read lock, concurrently with each other. Query methods
+// must therefore not modify the index without their own synchronization.
+type SecondaryIndex[T any] interface {
+	Add(line int, entity T)
+	Remove(line int)
+	Reset()
+}
+
+// Constraint is implemented by secondary indexes that can reject a write.
+// Check runs under the repository lock before the record is appended;
+// replacing is the line of the version being updated, or -1 for a new record.
+type Constraint[T any] interface {
+	Check(entity T, replacing int) error
+}
+
+// Narrow resolves the candidate lines of a query from secondary indexes. It is
+// called with the repository read lock held; a nil result means every record.
+type Narrow func() *Bitmap
+
+// KeywordIndex maps an exact key to the records holding it. It is meant for
+// low cardinality attributes such as categories.
+type KeywordIndex[T any] struct {
+	key      func(entity T) string
+	unique   bool
+	postings map[string]*Bitmap
+	keyOf    map[int]string
+}
+
+func NewKeywordIndex[T any](key func(entity T) string) *KeywordIndex[T] {
+	idx := &KeywordIndex[T]{key: key}
+	idx.Reset()
+	return idx
+}
+
+// NewUniqueKeywordIndex is a KeywordIndex that rejects writes whose key is
+// already held by another record. Empty keys are not checked.
+func NewUniqueKeywordIndex[T any](key func(entity T) string) *KeywordIndex[T] {
+	idx := NewKeywordIndex(key)
+	idx.unique = true
+	return idx
+}
+
+func (idx *KeywordIndex[T]) Check(entity T, replacing int) error {
+	if !idx.unique {
+		return nil
+	}
+	k := idx.key(entity)
+	if k == "" {
+		return nil
+	}
+	posting, ok := idx.postings[k]
+	if !ok {
+		return nil
+	}
+	taken := false
+	posting.ForEach(func(line int) bool {
+		taken = line != replacing
+		return !taken
+	})
+	if taken {
+		return fmt.Errorf("%w: key %q is already taken", apperrors.ErrResourceAlreadyExists, k)
+	}
+	return nil
+}
+
+func (idx *KeywordIndex[T]) Add(line int, entity T) {
+	k := idx.key(entity)
+	posting, ok := idx.postings[k]
+	if !ok {
+		posting = NewBitmap()
+		idx.postings[k] = posting
+	}
+	posting.Set(line)
+	idx.keyOf[line] = k
+}
+
+func (idx *KeywordIndex[T]) Remove(line int) {
+	k, ok := idx.keyOf[line]
+	if !ok {
+		return
+	}
+	delete(idx.keyOf, line)
+	if posting, ok := idx.postings[k]; ok {
+		posting.Clear(line)
+		if posting.Count() == 0 {
+			delete(idx.postings, k)
+		}
+	}
+}
+
+func (idx *KeywordIndex[T]) Reset() {
+	idx.postings = make(map[string]*Bitmap)
+	idx.keyOf = make(map[int]string)
+}
+
+// Lookup returns the records matching any of keys.
+func (idx *KeywordIndex[T]) Lookup(keys ...string) *Bitmap {
+	result := NewBitmap()
+	for _, k := range keys {
+		if posting, ok := idx.postings[k]; ok {
+			result.Or(posting)
+		}
+	}
+	return result
+}
+
+// Keys returns every key currently held by at least one record.
+func (idx *KeywordIndex[T]) Keys() []string {
+	keys := make([]string, 0, len(idx.postings))
+	for k := range idx.postings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type rangeEntry struct {
+	value float64
+	line  int
+}
+
+func (e rangeEntry) less(other rangeEntry) bool {
+	if e.value != other.value {
+		return e.value < other.value
+	}
+	return e.line < other.line
+}
+
+// RangeIndex keeps records sorted by a numeric attribute to answer range
+// queries. Entries are appended unsorted while the index is being built and
+// sorted on the first query; sorting guards against concurrent queries.
+type RangeIndex[T any] struct {
+	value   func(entity T) float64
+	entries []rangeEntry
+	valueOf map[int]float64
+	sorted  bool
+	sorting sync.Mutex
+}
+
+func NewRangeIndex[T any](value func(entity T) float64) *RangeIndex[T] {
+	idx := &RangeIndex[T]{value: value}
+	idx.Reset()
+	return idx
+}
+
+func (idx *RangeIndex[T]) Add(line int, entity T) {
+	e := rangeEntry{value: idx.value(entity), line: line}
+	idx.valueOf[line] = e.value
+	if !idx.sorted {
+		idx.entries = append(idx.entries, e)
+		return
+	}
+	pos := idx.search(e)
+	idx.entries = append(idx.entries, rangeEntry{})
+	copy(idx.entries[pos+1:], idx.entries[pos:])
+	idx.entries[pos] = e
+}
+
+func (idx *RangeIndex[T]) Remove(line int) {
+	v, ok := idx.valueOf[line]
+	if !ok {
+		return
+	}
+	delete(idx.valueOf, line)
+	idx.ensureSorted()
+	e := rangeEntry{value: v, line: line}
+	pos := idx.search(e)
+	if pos < len(idx.entries) && idx.entries[pos] == e {
+		idx.entries = append(idx.entries[:pos], idx.entries[pos+1:]...)
+	}
+}
+
+func (idx *RangeIndex[T]) Reset() {
+	idx.entries = nil
+	idx.valueOf = make(map[int]float64)
+	idx.sorted = false
+}
+
+// Range returns the records whose value is within [min, max]. Use math.Inf
+// for an open bound.
+func (idx *RangeIndex[T]) Range(min, max float64) *Bitmap {
+	idx.ensureSorted()
+	result := NewBitmap()
+	start := idx.search(rangeEntry{value: min, line: math.MinInt})
+	for _, e := range idx.entries[start:] {
+		if e.value > max {
+			break
+		}
+		result.Set(e.line)
+	}
+	return result
+}
+
+func (idx *RangeIndex[T]) search(e rangeEntry) int {
+	return sort.Search(len(idx.entries), func(i int) bool { return !idx.entries[i].less(e) })
+}
+
+func (idx *RangeIndex[T]) ensureSorted() {
+	idx.sorting.Lock()
+	defer idx.sorting.Unlock()
+	if idx.sorted {
+		return
+	}
+	sort.Slice(idx.entries, func(i, j int) bool { return idx.entries[i].less(idx.entries[j]) })
+	idx.sorted = true
+}
+
+// FlagIndex is a bitmap of the records for which a boolean attribute holds,
+// plus its complement over the live records.
+type FlagIndex[T any] struct {
+	flag  func(entity T) bool
+	set   *Bitmap
+	unset *Bitmap
+}
+
+func NewFlagIndex[T any](flag func(entity T) bool) *FlagIndex[T] {
+	idx := &FlagIndex[T]{flag: flag}
+	idx.Reset()
+	return idx
+}
+
+func (idx *FlagIndex[T]) Add(line int, entity T) {
+	if idx.flag(entity) {
+		idx.set.Set(line)
+	} else {
+		idx.unset.Set(line)
+	}
+}
+
+func (idx *FlagIndex[T]) Remove(line int) {
+	idx.set.Clear(line)
+	idx.unset.Clear(line)
+}
+
+func (idx *FlagIndex[T]) Reset() {
+	idx.set = NewBitmap()
+	idx.unset = NewBitmap()
+}
+
+// Lookup returns the records whose flag equals value.
+func (idx *FlagIndex[T]) Lookup(value bool) *Bitmap {
+	if value {
+		return idx.set.Clone()
+	}
+	return idx.unset.Clone()
+}
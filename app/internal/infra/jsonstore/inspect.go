@@ -0,0 +1,89 @@
+package jsonstore
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// LineState tells what a line of the file holds for the index.
+type LineState string
+
+const (
+	// LineLive is the latest version of a record.
+	LineLive LineState = "live"
+	// LineDead is a version replaced by a later line, or a deleted record.
+	LineDead LineState = "dead"
+	// LineTombstone records the deletion of a record.
+	LineTombstone LineState = "tombstone"
+	// LineCorrupted cannot be decoded.
+	LineCorrupted LineState = "corrupted"
+)
+
+// Line is a line of the file as the index sees it.
+type Line[T any] struct {
+	// Number counts from 1, like editors do.
+	Number int
+	Offset int64
+	State  LineState
+	// ID is set for records and tombstones.
+	ID string
+	// Entity is set for live and dead records.
+	Entity T
+	// Error is why a corrupted line cannot be decoded.
+	Error string
+}
+
+// Lines calls handler for every line of the file in order, dead and corrupted
+// ones included, to inspect what Compact would drop and what the index points
+// to. It stops with ctx.Err() once ctx is done.
+func (r *JSONRepository[T]) Lines(ctx context.Context, handler func(line Line[T]) error) error {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if r.file == nil {
+		return nil
+	}
+
+	reasons := make(map[int]string, len(r.corruptions))
+	for _, c := range r.corruptions {
+		reasons[c.Line-1] = c.Error
+	}
+
+	done := ctx.Done()
+	scanner := bufio.NewScanner(io.NewSectionReader(r.file, 0, r.size))
+	for lineNo := 0; scanner.Scan() && lineNo < len(r.offsets); lineNo++ {
+		select {
+		case <-done:
+			return ctx.Err()
+		default:
+		}
+
+		data := scanner.Bytes()
+		line := Line[T]{Number: lineNo + 1, Offset: r.offsets[lineNo]}
+		switch {
+		case r.corrupted.Has(lineNo):
+			line.State = LineCorrupted
+			line.Error = reasons[lineNo]
+		case isTombstone(data):
+			var t tombstone
+			_ = json.Unmarshal(data, &t)
+			line.State, line.ID = LineTombstone, t.ID
+		default:
+			if err := json.Unmarshal(data, &line.Entity); err != nil {
+				line.State, line.Error = LineCorrupted, err.Error()
+				break
+			}
+			line.ID = r.getID(line.Entity)
+			line.State = LineDead
+			if r.live.Has(lineNo) {
+				line.State = LineLive
+			}
+		}
+		if err := handler(line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -2,18 +2,26 @@ package jsonstore
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"reflect"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/lucasti79/meli-interview/pkg/apperrors"
 	"github.com/lucasti79/meli-interview/pkg/helpers"
 )
 
+// ErrReadOnly is returned by the writes to a repository opened WithReadOnly.
+var ErrReadOnly = errors.New("jsonstore: the file was opened read-only")
+
 type IDGetter[T any] func(entity T) string
 
 type Filter struct {
@@ -22,92 +30,178 @@ type Filter struct {
 	Value    interface{} `json:"value"`
 }
 
+// tombstone is appended in place of a deleted record. Its key cannot collide
+// with a JSON field of the stored entities, so it is recognised by prefix.
+type tombstone struct {
+	ID string `json:"$deleted"`
+}
+
+var tombstonePrefix = []byte(`{"$deleted":`)
+
+func isTombstone(line []byte) bool {
+	return bytes.HasPrefix(line, tombstonePrefix)
+}
+
+// JSONRepository stores entities as JSON lines in an append-only file. Updates
+// append a new version of the record and deletes append a tombstone; index
+// always points to the latest version, so earlier lines for the same ID are
+// dead until Compact rewrites the file without them.
+//
+// Records are addressed by line number: offsets maps a line to its position in
+// the file and live marks the lines holding the latest version of a record.
+// Registered secondary indexes are kept in sync with live.
+//
+// Reads share a read lock and read through their own section of file, the
+// handle opened when the index was built, so any number of queries scan the
+// file at once; writes and compaction take the lock exclusively. Holding on to
+// the handle keeps offsets valid when the file is replaced on disk, until
+// Reload indexes the new one.
+//
+// Writes are flushed to disk according to the SyncPolicy set by WithSync. A
+// write interrupted by a crash leaves part of a line at the end of the file,
+// which is removed the next time the file is opened; lines that cannot be
+// decoded anywhere else are reported by CorruptedLines, and records breaking
+// the rules of WithValidator by InvalidRecords.
 type JSONRepository[T any] struct {
 	filePath string
-	mutex    sync.Mutex
-	index    map[string]int64
+	mutex    sync.RWMutex
+	index    map[string]int
 	getID    IDGetter[T]
+	options  options
+
+	file      *os.File
+	size      int64
+	offsets   []int64
+	live      *Bitmap
+	corrupted *Bitmap
+	indexes   map[string]SecondaryIndex[T]
+
+	corruptions   []CorruptedLine
+	validator     func(entity T) []FieldError
+	invalid       map[int]InvalidRecord
+	unterminated  bool
+	syncScheduled atomic.Bool
+
+	// loadedAt is when the file was last indexed; version changes with every
+	// write and reload.
+	loadedAt time.Time
+	version  uint64
 }
 
-func NewJSONRepository[T any](fileName string, getID IDGetter[T]) (*JSONRepository[T], error) {
-	path := fileName
+func NewJSONRepository[T any](fileName string, getID IDGetter[T], opts ...Option) (*JSONRepository[T], error) {
+	path := filepath.Clean(fileName)
 	if !filepath.IsAbs(fileName) {
 		path = filepath.Join(helpers.ProjectRoot(), fileName)
 	}
 
 	repo := &JSONRepository[T]{
 		filePath: path,
-		index:    make(map[string]int64),
+		index:    make(map[string]int),
 		getID:    getID,
+		options:  defaultOptions(),
+		indexes:  make(map[string]SecondaryIndex[T]),
+		invalid:  make(map[int]InvalidRecord),
 	}
-	if err := repo.buildIndex(); err != nil {
+	for _, opt := range opts {
+		opt(&repo.options)
+	}
+	for name, idx := range repo.options.indexes {
+		typed, ok := idx.(SecondaryIndex[T])
+		if !ok {
+			var zero T
+			return nil, fmt.Errorf("jsonstore: index %q (%T) cannot index %T", name, idx, zero)
+		}
+		repo.indexes[name] = typed
+	}
+	if repo.options.validator != nil {
+		validator, ok := repo.options.validator.(func(entity T) []FieldError)
+		if !ok {
+			var zero T
+			return nil, fmt.Errorf("jsonstore: validator (%T) cannot validate %T", repo.options.validator, zero)
+		}
+		repo.validator = validator
+	}
+	s, err := repo.load()
+	if err != nil {
 		return nil, err
 	}
+	if !repo.options.readOnly {
+		if err := repo.repair(s); err != nil {
+			s.close()
+			return nil, err
+		}
+	}
+	repo.install(s)
 	return repo, nil
 }
 
-func (r *JSONRepository[T]) buildIndex() error {
-	r.index = make(map[string]int64)
+// Index returns the secondary index registered under name, or nil.
+func (r *JSONRepository[T]) Index(name string) SecondaryIndex[T] {
+	return r.indexes[name]
+}
 
-	f, err := os.Open(r.filePath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil
-		}
-		return err
+func (r *JSONRepository[T]) markLive(line int, entity T) {
+	r.live.Set(line)
+	for _, idx := range r.indexes {
+		idx.Add(line, entity)
 	}
-	defer f.Close()
-
-	var offset int64 = 0
-	scanner := bufio.NewScanner(f)
-	const maxCapacity = 1024 * 102
-	buf := make([]byte, 0, 64*1024)
-	scanner.Buffer(buf, maxCapacity)
+	r.validate(line, entity)
+}
 
-	for scanner.Scan() {
-		line := scanner.Bytes()
+func (r *JSONRepository[T]) markDead(line int) {
+	r.live.Clear(line)
+	for _, idx := range r.indexes {
+		idx.Remove(line)
+	}
+	delete(r.invalid, line)
+}
 
-		var entity T
-		if err := json.Unmarshal(line, &entity); err == nil {
-			id := r.getID(entity)
-			if id != "" {
-				r.index[id] = offset
+// check runs the registered constraints against a record about to be written.
+func (r *JSONRepository[T]) check(entity T, replacing int) error {
+	for _, idx := range r.indexes {
+		if c, ok := idx.(Constraint[T]); ok {
+			if err := c.Check(entity, replacing); err != nil {
+				return err
 			}
 		}
-
-		offset += int64(len(line) + 1)
 	}
-	return scanner.Err()
+	return nil
 }
 
 func (r *JSONRepository[T]) FindByID(id string) (T, error) {
-	var zero T
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
+	return r.FindByIDWithContext(context.Background(), id)
+}
 
-	offset, ok := r.index[id]
-	if !ok {
-		return zero, apperrors.ErrResourceNotExists
-	}
+// FindByIDWithContext is FindByID giving up with ctx.Err() when ctx is done
+// before the lock is acquired.
+func (r *JSONRepository[T]) FindByIDWithContext(ctx context.Context, id string) (T, error) {
+	var zero T
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
 
-	f, err := os.Open(r.filePath)
-	if err != nil {
+	if err := ctx.Err(); err != nil {
 		return zero, err
 	}
-	defer f.Close()
 
-	if _, err := f.Seek(offset, io.SeekStart); err != nil {
-		return zero, err
+	line, ok := r.index[id]
+	if !ok {
+		return zero, apperrors.ErrResourceNotExists
 	}
 
-	reader := bufio.NewReader(f)
-	line, err := reader.ReadBytes('\n')
+	return r.readAt(r.offsets[line])
+}
+
+// readAt decodes the record stored at offset.
+func (r *JSONRepository[T]) readAt(offset int64) (T, error) {
+	var zero T
+	reader := bufio.NewReader(io.NewSectionReader(r.file, offset, r.size-offset))
+	data, err := reader.ReadBytes('\n')
 	if err != nil && err != io.EOF {
 		return zero, err
 	}
 
 	var entity T
-	if err := json.Unmarshal(line, &entity); err != nil {
+	if err := json.Unmarshal(data, &entity); err != nil {
 		return zero, fmt.Errorf("%w: file %s, offset %d: %v", apperrors.ErrInvalidDataFormat, r.filePath, offset, err)
 	}
 
@@ -118,53 +212,228 @@ func (r *JSONRepository[T]) Save(entity T) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
+	if err := r.refresh(); err != nil {
+		return err
+	}
+
 	id := r.getID(entity)
 	if _, exists := r.index[id]; exists {
-		return fmt.Errorf("%s with ID %s already exists", reflect.TypeOf(entity).Name(), id)
+		return fmt.Errorf("%w: %s with ID %s already exists", apperrors.ErrResourceAlreadyExists, reflect.TypeOf(entity).Name(), id)
 	}
-
-	dir := filepath.Dir(r.filePath)
-	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+	if err := r.check(entity, -1); err != nil {
 		return err
 	}
 
-	f, err := os.OpenFile(r.filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	line, err := r.appendLine(entity)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
 
-	offset, _ := f.Seek(0, io.SeekEnd)
-	enc := json.NewEncoder(f)
-	if err := enc.Encode(entity); err != nil {
+	if id != "" {
+		r.index[id] = line
+	}
+	r.markLive(line, entity)
+	return nil
+}
+
+// Update appends a new version of an existing entity and points the index at it.
+func (r *JSONRepository[T]) Update(entity T) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := r.refresh(); err != nil {
+		return err
+	}
+
+	id := r.getID(entity)
+	previous, exists := r.index[id]
+	if !exists {
+		return apperrors.ErrResourceNotExists
+	}
+	if err := r.check(entity, previous); err != nil {
 		return err
 	}
 
-	r.index[id] = offset
+	line, err := r.appendLine(entity)
+	if err != nil {
+		return err
+	}
+
+	r.markDead(previous)
+	r.index[id] = line
+	r.markLive(line, entity)
+	r.maybeCompact()
 	return nil
 }
 
-func (r *JSONRepository[T]) FindAll(handler func(entity T) error) error {
+// Modify replaces the entity stored under id with the one modify returns from
+// it. The entity is read and written under the same lock, so no other write
+// can come in between: this is how read-modify-write updates, such as
+// counters, stay atomic. When modify fails nothing is written and its error
+// is returned.
+func (r *JSONRepository[T]) Modify(id string, modify func(entity T) (T, error)) (T, error) {
+	var zero T
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	f, err := os.Open(r.filePath)
+	if err := r.refresh(); err != nil {
+		return zero, err
+	}
+
+	previous, exists := r.index[id]
+	if !exists {
+		return zero, apperrors.ErrResourceNotExists
+	}
+	current, err := r.readAt(r.offsets[previous])
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil
-		}
+		return zero, err
+	}
+	entity, err := modify(current)
+	if err != nil {
+		return zero, err
+	}
+	if r.getID(entity) != id {
+		return zero, fmt.Errorf("jsonstore: modify cannot change the ID of %s", id)
+	}
+	if err := r.check(entity, previous); err != nil {
+		return zero, err
+	}
+
+	line, err := r.appendLine(entity)
+	if err != nil {
+		return zero, err
+	}
+
+	r.markDead(previous)
+	r.index[id] = line
+	r.markLive(line, entity)
+	r.maybeCompact()
+	return entity, nil
+}
+
+// Delete appends a tombstone for id and removes it from the index.
+func (r *JSONRepository[T]) Delete(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := r.refresh(); err != nil {
 		return err
 	}
+
+	previous, exists := r.index[id]
+	if !exists {
+		return apperrors.ErrResourceNotExists
+	}
+
+	if _, err := r.appendLine(tombstone{ID: id}); err != nil {
+		return err
+	}
+
+	r.markDead(previous)
+	delete(r.index, id)
+	r.maybeCompact()
+	return nil
+}
+
+// appendLine encodes v as a new line at the end of the file and returns its line number.
+func (r *JSONRepository[T]) appendLine(v any) (int, error) {
+	if r.options.readOnly {
+		return 0, ErrReadOnly
+	}
+	dir := filepath.Dir(r.filePath)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return 0, err
+	}
+
+	f, err := os.OpenFile(r.filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return 0, err
+	}
 	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0, err
+	}
+	line := append(data, '\n')
+	offset := r.size
+	if r.unterminated {
+		// another process left the last line without its line feed
+		line = append([]byte{'\n'}, line...)
+		offset++
+	}
+	if _, err := f.Write(line); err != nil {
+		// do not leave part of the line behind for the next write to follow
+		f.Truncate(r.size)
+		return 0, err
+	}
+	if err := r.sync(f); err != nil {
+		f.Truncate(r.size)
+		return 0, err
+	}
+
+	if r.file == nil {
+		if err := r.syncDir(); err != nil {
+			return 0, err
+		}
+		if r.file, err = os.Open(r.filePath); err != nil {
+			return 0, err
+		}
+	}
+	r.size += int64(len(line))
+	r.unterminated = false
+	r.offsets = append(r.offsets, offset)
+	r.version++
+	return len(r.offsets) - 1, nil
+}
+
+// scan calls handler for every live record in file order, skipping tombstones
+// and versions that were superseded by a later line. Records without an ID
+// cannot be superseded and are always live. A line that could not be decoded
+// when the index was built aborts the scan with ErrInvalidDataFormat.
+//
+// When candidates is not nil only those lines are read, seeking over the rest
+// of the file. ctx is checked before every line, so a cancelled request stops
+// a long scan with ctx.Err().
+func (r *JSONRepository[T]) scan(ctx context.Context, candidates *Bitmap, handler func(entity T) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if r.file == nil {
+		return nil
+	}
+	if candidates != nil {
+		return r.scanLines(ctx, candidates, handler)
+	}
+
+	done := ctx.Done()
+	scanner := bufio.NewScanner(io.NewSectionReader(r.file, 0, r.size))
 	lineNo := 0
 	for scanner.Scan() {
+		select {
+		case <-done:
+			return ctx.Err()
+		default:
+		}
+
+		line := scanner.Bytes()
+		current := lineNo
 		lineNo++
+
+		if !r.live.Has(current) {
+			if !r.corrupted.Has(current) {
+				continue
+			}
+			var entity T
+			err := json.Unmarshal(line, &entity)
+			return fmt.Errorf("%w: file %s, line %d: %v", apperrors.ErrInvalidDataFormat, r.filePath, lineNo, err)
+		}
+
 		var entity T
-		if err := json.Unmarshal(scanner.Bytes(), &entity); err != nil {
+		if err := json.Unmarshal(line, &entity); err != nil {
 			return fmt.Errorf("%w: file %s, line %d: %v", apperrors.ErrInvalidDataFormat, r.filePath, lineNo, err)
 		}
+
 		if err := handler(entity); err != nil {
 			return err
 		}
@@ -173,36 +442,89 @@ func (r *JSONRepository[T]) FindAll(handler func(entity T) error) error {
 	return scanner.Err()
 }
 
-func (r *JSONRepository[T]) FindAllWhere(predicate func(entity T) bool, handler func(entity T) error) error {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
+// scanLines reads the live lines in candidates in ascending order. Nearby lines
+// are reached by discarding buffered bytes, distant ones with a seek.
+func (r *JSONRepository[T]) scanLines(ctx context.Context, candidates *Bitmap, handler func(entity T) error) error {
+	lines := candidates.Clone().And(r.live)
+	if lines.Count() == 0 {
+		return nil
+	}
 
-	f, err := os.Open(r.filePath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil
+	f := io.NewSectionReader(r.file, 0, r.size)
+	const bufferSize = 64 * 1024
+	reader := bufio.NewReaderSize(f, bufferSize)
+	var position int64 = 0
+	var scanErr error
+	done := ctx.Done()
+
+	lines.ForEach(func(line int) bool {
+		select {
+		case <-done:
+			scanErr = ctx.Err()
+			return false
+		default:
 		}
-		return err
-	}
-	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
-	lineNo := 0
-	for scanner.Scan() {
-		lineNo++
+		offset := r.offsets[line]
+		if gap := offset - position; gap != 0 {
+			if gap > 0 && gap < bufferSize {
+				if _, scanErr = reader.Discard(int(gap)); scanErr != nil {
+					return false
+				}
+			} else {
+				if _, scanErr = f.Seek(offset, io.SeekStart); scanErr != nil {
+					return false
+				}
+				reader.Reset(f)
+			}
+		}
+
+		data, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			scanErr = err
+			return false
+		}
+		position = offset + int64(len(data))
+
 		var entity T
-		if err := json.Unmarshal(scanner.Bytes(), &entity); err != nil {
-			return fmt.Errorf("%w: file %s, line %d: %v", apperrors.ErrInvalidDataFormat, r.filePath, lineNo, err)
+		if err := json.Unmarshal(data, &entity); err != nil {
+			scanErr = fmt.Errorf("%w: file %s, line %d: %v", apperrors.ErrInvalidDataFormat, r.filePath, line+1, err)
+			return false
 		}
+		scanErr = handler(entity)
+		return scanErr == nil
+	})
+
+	return scanErr
+}
+
+func (r *JSONRepository[T]) FindAll(handler func(entity T) error) error {
+	return r.FindAllWithContext(context.Background(), handler)
+}
+
+// FindAllWithContext is FindAll stopping with ctx.Err() once ctx is done.
+func (r *JSONRepository[T]) FindAllWithContext(ctx context.Context, handler func(entity T) error) error {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.scan(ctx, nil, handler)
+}
+
+func (r *JSONRepository[T]) FindAllWhere(predicate func(entity T) bool, handler func(entity T) error) error {
+	return r.FindAllWhereWithContext(context.Background(), predicate, handler)
+}
+
+// FindAllWhereWithContext is FindAllWhere stopping with ctx.Err() once ctx is done.
+func (r *JSONRepository[T]) FindAllWhereWithContext(ctx context.Context, predicate func(entity T) bool, handler func(entity T) error) error {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
 
+	return r.scan(ctx, nil, func(entity T) error {
 		if predicate(entity) {
-			if err := handler(entity); err != nil {
-				return err
-			}
+			return handler(entity)
 		}
-	}
-
-	return scanner.Err()
+		return nil
+	})
 }
 
 func (r *JSONRepository[T]) FindAllWherePaginated(
@@ -210,49 +532,114 @@ func (r *JSONRepository[T]) FindAllWherePaginated(
 	page, pageSize int,
 	handler func(entity T) error,
 ) (int, error) {
-	r.mutex.Lock()
-	defer r.mutex.Unlock()
+	return r.FindAllWherePaginatedWithContext(context.Background(), predicate, page, pageSize, handler)
+}
 
-	f, err := os.Open(r.filePath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return 0, nil
-		}
-		return 0, err
+// FindAllWherePaginatedWithContext is FindAllWherePaginated stopping with
+// ctx.Err() once ctx is done.
+func (r *JSONRepository[T]) FindAllWherePaginatedWithContext(
+	ctx context.Context,
+	predicate func(entity T) bool,
+	page, pageSize int,
+	handler func(entity T) error,
+) (int, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.paginate(ctx, nil, predicate, page, pageSize, handler)
+}
+
+// FindAllIndexedPaginated is FindAllWherePaginated restricted to the records
+// selected by narrow, ordered by less when it is not nil. predicate is still
+// applied to every candidate, so narrow may return a superset of the matches.
+func (r *JSONRepository[T]) FindAllIndexedPaginated(
+	narrow Narrow,
+	predicate func(entity T) bool,
+	less func(a, b T) bool,
+	page, pageSize int,
+	handler func(entity T) error,
+) (int, error) {
+	return r.FindAllIndexedPaginatedWithContext(context.Background(), narrow, predicate, less, page, pageSize, handler)
+}
+
+// FindAllIndexedPaginatedWithContext is FindAllIndexedPaginated stopping with
+// ctx.Err() once ctx is done.
+func (r *JSONRepository[T]) FindAllIndexedPaginatedWithContext(
+	ctx context.Context,
+	narrow Narrow,
+	predicate func(entity T) bool,
+	less func(a, b T) bool,
+	page, pageSize int,
+	handler func(entity T) error,
+) (int, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var candidates *Bitmap
+	if narrow != nil {
+		candidates = narrow()
 	}
-	defer f.Close()
+	if less != nil {
+		return r.sortedPage(ctx, candidates, predicate, less, nil, page, pageSize, handler)
+	}
+	return r.paginate(ctx, candidates, predicate, page, pageSize, handler)
+}
+
+// FindAllIndexed calls handler for every record selected by narrow that
+// satisfies predicate, in file order.
+func (r *JSONRepository[T]) FindAllIndexed(narrow Narrow, predicate func(entity T) bool, handler func(entity T) error) error {
+	return r.FindAllIndexedWithContext(context.Background(), narrow, predicate, handler)
+}
 
-	scanner := bufio.NewScanner(f)
+// FindAllIndexedWithContext is FindAllIndexed stopping with ctx.Err() once
+// ctx is done.
+func (r *JSONRepository[T]) FindAllIndexedWithContext(ctx context.Context, narrow Narrow, predicate func(entity T) bool, handler func(entity T) error) error {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
 
+	var candidates *Bitmap
+	if narrow != nil {
+		candidates = narrow()
+	}
+	return r.scan(ctx, candidates, func(entity T) error {
+		if predicate(entity) {
+			return handler(entity)
+		}
+		return nil
+	})
+}
+
+func (r *JSONRepository[T]) paginate(
+	ctx context.Context,
+	candidates *Bitmap,
+	predicate func(entity T) bool,
+	page, pageSize int,
+	handler func(entity T) error,
+) (int, error) {
 	skipped := 0
 	collected := 0
 	total := 0
 	start := (page - 1) * pageSize
-	lineNo := 0
 
-	for scanner.Scan() {
-		lineNo++
-		var entity T
-		if err := json.Unmarshal(scanner.Bytes(), &entity); err != nil {
-			return total, fmt.Errorf("%w: file %s, line %d: %v", apperrors.ErrInvalidDataFormat, r.filePath, lineNo, err)
+	err := r.scan(ctx, candidates, func(entity T) error {
+		if !predicate(entity) {
+			return nil
 		}
+		total++
 
-		if predicate(entity) {
-			total++
-
-			if skipped < start {
-				skipped++
-				continue
-			}
+		if skipped < start {
+			skipped++
+			return nil
+		}
 
-			if collected < pageSize {
-				if err := handler(entity); err != nil {
-					return total, err
-				}
-				collected++
+		if collected < pageSize {
+			if err := handler(entity); err != nil {
+				return err
 			}
+			collected++
 		}
-	}
+		return nil
+	})
 
-	return total, scanner.Err()
+	return total, err
 }
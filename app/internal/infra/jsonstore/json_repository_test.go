@@ -2,11 +2,16 @@ package jsonstore
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
+	"math"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/lucasti79/meli-interview/pkg/apperrors"
 	"github.com/stretchr/testify/assert"
@@ -342,3 +347,732 @@ func TestFindAllWhere_ReturnsScannerErrOnScanFailure(t *testing.T) {
 	require.ErrorIs(t, err, bufio.ErrTooLong)
 	require.False(t, called, "handler must not be called when scanner fails")
 }
+
+func TestSave_DuplicateID_WrapsErrResourceAlreadyExists(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{{ID: "dup"}})
+
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID)
+	require.NoError(t, err)
+
+	err = repo.Save(TestEntity{ID: "dup"})
+	require.ErrorIs(t, err, apperrors.ErrResourceAlreadyExists)
+}
+
+func TestUpdate_AppendsNewVersionAndRepointsIndex(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{
+		{ID: "1", Name: "Alice"},
+		{ID: "2", Name: "Bob"},
+		{ID: "3", Name: "Carol"},
+	})
+
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Update(TestEntity{ID: "2", Name: "Robert, a much longer name"}))
+
+	got, err := repo.FindByID("2")
+	require.NoError(t, err)
+	require.Equal(t, "Robert, a much longer name", got.Name)
+
+	got, err = repo.FindByID("3")
+	require.NoError(t, err)
+	require.Equal(t, "Carol", got.Name)
+
+	var visited []string
+	require.NoError(t, repo.FindAll(func(e TestEntity) error {
+		visited = append(visited, e.ID)
+		return nil
+	}))
+	require.Equal(t, []string{"1", "3", "2"}, visited, "superseded version must be skipped")
+	require.Equal(t, 4, countFileLines(t, fp))
+	require.Equal(t, 1, repo.Stats().DeadLines)
+}
+
+func TestUpdate_WhenNotFound_ReturnsErrResourceNotExists(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{{ID: "1"}})
+
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID)
+	require.NoError(t, err)
+
+	err = repo.Update(TestEntity{ID: "missing"})
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+	require.Equal(t, 1, countFileLines(t, fp))
+}
+
+func TestModify_AppliesConcurrentModificationsOneAtATime(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{{ID: "1", Name: "0"}, {ID: "2", Name: "Bob"}})
+
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID)
+	require.NoError(t, err)
+
+	increment := func(e TestEntity) (TestEntity, error) {
+		n, err := strconv.Atoi(e.Name)
+		if err != nil {
+			return e, err
+		}
+		e.Name = strconv.Itoa(n + 1)
+		return e, nil
+	}
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.Modify("1", increment)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	got, err := repo.FindByID("1")
+	require.NoError(t, err)
+	require.Equal(t, "20", got.Name)
+	require.Equal(t, 22, countFileLines(t, fp))
+
+	// errors from modify, and changing the ID, write nothing
+	_, err = repo.Modify("2", increment)
+	require.Error(t, err)
+	_, err = repo.Modify("2", func(e TestEntity) (TestEntity, error) {
+		e.ID = "3"
+		return e, nil
+	})
+	require.Error(t, err)
+	_, err = repo.Modify("missing", increment)
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+	require.Equal(t, 22, countFileLines(t, fp))
+}
+
+func TestDelete_AppendsTombstoneAndRemovesIndexEntry(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{
+		{ID: "1", Name: "Alice"},
+		{ID: "2", Name: "Bob"},
+		{ID: "3", Name: "Carol"},
+	})
+
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Delete("1"))
+
+	_, err = repo.FindByID("1")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+
+	got, err := repo.FindByID("3")
+	require.NoError(t, err)
+	require.Equal(t, "Carol", got.Name)
+	require.Equal(t, 4, countFileLines(t, fp))
+
+	var visited []string
+	require.NoError(t, repo.FindAll(func(e TestEntity) error {
+		visited = append(visited, e.ID)
+		return nil
+	}))
+	require.Equal(t, []string{"2", "3"}, visited)
+
+	err = repo.Delete("1")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+}
+
+func TestNewJSONRepository_ReplaysUpdatesAndTombstones(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{
+		{ID: "1", Name: "Alice"},
+		{ID: "2", Name: "Bob"},
+	})
+
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID, WithAutoCompaction(0, 0))
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(TestEntity{ID: "1", Name: "Alicia"}))
+	require.NoError(t, repo.Delete("2"))
+	require.NoError(t, repo.Save(TestEntity{ID: "2", Name: "Bobby"}))
+
+	reopened, err := NewJSONRepository[TestEntity](fp, getTestEntityID)
+	require.NoError(t, err)
+
+	got, err := reopened.FindByID("1")
+	require.NoError(t, err)
+	require.Equal(t, "Alicia", got.Name)
+
+	got, err = reopened.FindByID("2")
+	require.NoError(t, err)
+	require.Equal(t, "Bobby", got.Name)
+
+	stats := reopened.Stats()
+	require.False(t, stats.LoadedAt.IsZero())
+	stats.LoadedAt = time.Time{}
+	require.Equal(t, Stats{TotalLines: 5, LiveLines: 2, DeadLines: 3, DeadRatio: 0.6}, stats)
+}
+
+func TestCompact_DropsDeadLinesAndKeepsLatestVersions(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{
+		{ID: "1", Name: "Alice"},
+		{ID: "2", Name: "Bob"},
+		{ID: "3", Name: "Carol"},
+	})
+	require.NoError(t, os.Chmod(fp, 0o640))
+
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID, WithAutoCompaction(0, 0))
+	require.NoError(t, err)
+	require.NoError(t, repo.Update(TestEntity{ID: "1", Name: "Alicia"}))
+	require.NoError(t, repo.Update(TestEntity{ID: "1", Name: "Ali"}))
+	require.NoError(t, repo.Delete("2"))
+	require.Equal(t, 6, countFileLines(t, fp))
+
+	require.NoError(t, repo.Compact())
+
+	require.Equal(t, 2, countFileLines(t, fp))
+	stats := repo.Stats()
+	stats.LoadedAt = time.Time{}
+	require.Equal(t, Stats{TotalLines: 2, LiveLines: 2}, stats)
+
+	got, err := repo.FindByID("1")
+	require.NoError(t, err)
+	require.Equal(t, "Ali", got.Name)
+
+	got, err = repo.FindByID("3")
+	require.NoError(t, err)
+	require.Equal(t, "Carol", got.Name)
+
+	info, err := os.Stat(fp)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o640), info.Mode().Perm(), "compaction must preserve file permissions")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary file must not be left behind")
+}
+
+func TestCompact_KeepsUndecodableLines(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "entities.jsonl")
+	content := `{"id":"1","name":"Old"}
+not-a-json
+{"id":"1","name":"New"}
+`
+	require.NoError(t, os.WriteFile(fp, []byte(content), 0o600))
+
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Compact())
+
+	data, err := os.ReadFile(fp)
+	require.NoError(t, err)
+	require.Equal(t, "not-a-json\n{\"id\":\"1\",\"name\":\"New\"}\n", string(data))
+}
+
+func TestUpdate_AutoCompactsOnceDeadRatioIsReached(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{
+		{ID: "1", Name: "Alice"},
+		{ID: "2", Name: "Bob"},
+	})
+
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID, WithAutoCompaction(0.5, 2))
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Update(TestEntity{ID: "1", Name: "v2"}))
+	require.Equal(t, 3, countFileLines(t, fp), "a single dead line is below the minimum")
+
+	require.NoError(t, repo.Update(TestEntity{ID: "1", Name: "v3"}))
+	require.Equal(t, 2, countFileLines(t, fp), "2 of 4 lines dead must trigger compaction")
+	require.Equal(t, 0, repo.Stats().DeadLines)
+
+	got, err := repo.FindByID("1")
+	require.NoError(t, err)
+	require.Equal(t, "v3", got.Name)
+}
+
+func TestFindAllWhereSortedPaginated_ReturnsPagesInOrder(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{
+		{ID: "1", Name: "delta", Group: "A"},
+		{ID: "2", Name: "alpha", Group: "A"},
+		{ID: "3", Name: "echo", Group: "B"},
+		{ID: "4", Name: "charlie", Group: "A"},
+		{ID: "5", Name: "bravo", Group: "A"},
+		{ID: "6", Name: "bravo", Group: "A"},
+	})
+
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID)
+	require.NoError(t, err)
+
+	byName := func(a, b TestEntity) bool {
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	}
+	onlyA := func(e TestEntity) bool { return e.Group == "A" }
+
+	var pages [][]string
+	for page := 1; page <= 3; page++ {
+		var collected []string
+		total, err := repo.FindAllWhereSortedPaginated(onlyA, byName, page, 2, func(e TestEntity) error {
+			collected = append(collected, e.ID)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 5, total)
+		pages = append(pages, collected)
+	}
+
+	require.Equal(t, [][]string{{"2", "5"}, {"6", "4"}, {"1"}}, pages)
+}
+
+func TestFindAllWhereSortedPaginated_PageBeyondResults(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{{ID: "1"}, {ID: "2"}})
+
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID)
+	require.NoError(t, err)
+
+	called := false
+	total, err := repo.FindAllWhereSortedPaginated(
+		func(e TestEntity) bool { return true },
+		func(a, b TestEntity) bool { return a.ID < b.ID },
+		3, 2,
+		func(e TestEntity) error {
+			called = true
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	require.False(t, called)
+}
+
+func newGroupIndexedRepository(t *testing.T, fp string) (*JSONRepository[TestEntity], *KeywordIndex[TestEntity]) {
+	t.Helper()
+	byGroup := NewKeywordIndex(func(e TestEntity) string { return e.Group })
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID, WithIndex("group", byGroup))
+	require.NoError(t, err)
+	require.Same(t, byGroup, repo.Index("group"))
+	return repo, byGroup
+}
+
+func collectIndexed(t *testing.T, repo *JSONRepository[TestEntity], narrow Narrow) []string {
+	t.Helper()
+	var ids []string
+	_, err := repo.FindAllIndexedPaginated(narrow, func(TestEntity) bool { return true }, nil, 1, 100, func(e TestEntity) error {
+		ids = append(ids, e.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	return ids
+}
+
+func TestWithIndex_IsPopulatedOnLoadAndKeptInSyncWithWrites(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{
+		{ID: "1", Group: "A"},
+		{ID: "2", Group: "B"},
+		{ID: "1", Group: "B"},
+		{ID: "3", Group: "A"},
+	})
+
+	repo, byGroup := newGroupIndexedRepository(t, fp)
+	groupA := func() *Bitmap { return byGroup.Lookup("A") }
+	groupB := func() *Bitmap { return byGroup.Lookup("B") }
+
+	require.Equal(t, []string{"3"}, collectIndexed(t, repo, groupA))
+	require.Equal(t, []string{"2", "1"}, collectIndexed(t, repo, groupB))
+
+	require.NoError(t, repo.Save(TestEntity{ID: "4", Group: "A"}))
+	require.NoError(t, repo.Update(TestEntity{ID: "2", Group: "A"}))
+	require.NoError(t, repo.Delete("3"))
+
+	require.Equal(t, []string{"4", "2"}, collectIndexed(t, repo, groupA))
+	require.Equal(t, []string{"1"}, collectIndexed(t, repo, groupB))
+
+	require.NoError(t, repo.Compact())
+	require.Equal(t, []string{"4", "2"}, collectIndexed(t, repo, groupA))
+	require.Equal(t, []string{"A", "B"}, byGroup.Keys())
+}
+
+func TestWithIndex_RejectsIndexOfAnotherType(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "entities.jsonl")
+	idx := NewFlagIndex(func(s string) bool { return s != "" })
+
+	_, err := NewJSONRepository[TestEntity](fp, getTestEntityID, WithIndex[string]("flag", idx))
+	require.Error(t, err)
+}
+
+func TestUniqueKeywordIndex_RejectsTakenKeysButAllowsUpdatingTheHolder(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{{ID: "1", Name: "a"}})
+	byName := NewUniqueKeywordIndex(func(e TestEntity) string { return e.Name })
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID, WithIndex("name", byName))
+	require.NoError(t, err)
+
+	err = repo.Save(TestEntity{ID: "2", Name: "a"})
+	require.ErrorIs(t, err, apperrors.ErrResourceAlreadyExists)
+	require.Equal(t, 1, countFileLines(t, fp))
+
+	require.NoError(t, repo.Save(TestEntity{ID: "2", Name: "b"}))
+	require.NoError(t, repo.Update(TestEntity{ID: "1", Name: "a", Group: "changed"}))
+	require.ErrorIs(t, repo.Update(TestEntity{ID: "2", Name: "a"}), apperrors.ErrResourceAlreadyExists)
+
+	require.NoError(t, repo.Delete("1"))
+	require.NoError(t, repo.Update(TestEntity{ID: "2", Name: "a"}))
+	require.Equal(t, []string{"2"}, collectIndexed(t, repo, func() *Bitmap { return byName.Lookup("a") }))
+}
+
+func TestFindAllIndexedPaginated_AppliesPredicateAndSortToCandidates(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{
+		{ID: "1", Name: "delta", Group: "A"},
+		{ID: "2", Name: "alpha", Group: "B"},
+		{ID: "3", Name: "charlie", Group: "A"},
+		{ID: "4", Name: "bravo", Group: "A"},
+		{ID: "5", Name: "x", Group: "A"},
+	})
+	repo, byGroup := newGroupIndexedRepository(t, fp)
+
+	var ids []string
+	total, err := repo.FindAllIndexedPaginated(
+		func() *Bitmap { return byGroup.Lookup("A") },
+		func(e TestEntity) bool { return e.Name != "x" },
+		func(a, b TestEntity) bool { return a.Name < b.Name },
+		1, 2,
+		func(e TestEntity) error {
+			ids = append(ids, e.ID)
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Equal(t, []string{"4", "3"}, ids)
+}
+
+func TestFindAllIndexedAfter_ResumesAfterPositionAndCountsAllMatches(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{
+		{ID: "4", Group: "A"},
+		{ID: "1", Group: "A"},
+		{ID: "3", Group: "B"},
+		{ID: "5", Group: "A"},
+		{ID: "2", Group: "A"},
+	})
+	repo, byGroup := newGroupIndexedRepository(t, fp)
+
+	var ids []string
+	total, err := repo.FindAllIndexedAfter(
+		func() *Bitmap { return byGroup.Lookup("A") },
+		func(TestEntity) bool { return true },
+		func(a, b TestEntity) bool { return a.ID < b.ID },
+		func(e TestEntity) bool { return e.ID > "1" },
+		2,
+		func(e TestEntity) error {
+			ids = append(ids, e.ID)
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, 4, total)
+	require.Equal(t, []string{"2", "4"}, ids)
+}
+
+func TestTextIndex_RanksWithBM25AndExpandsPrefixes(t *testing.T) {
+	idx := NewTextIndex(strings.Fields,
+		TextField[TestEntity]{Weight: 2, Value: func(e TestEntity) string { return e.Name }},
+		TextField[TestEntity]{Weight: 1, Value: func(e TestEntity) string { return e.Group }},
+	)
+	idx.Add(0, TestEntity{Name: "red apple", Group: "fruit"})
+	idx.Add(1, TestEntity{Name: "green pear", Group: "apple family fruit"})
+	idx.Add(2, TestEntity{Name: "blue car"})
+
+	lines := func(hits []Hit) []int {
+		var out []int
+		for _, h := range hits {
+			out = append(out, h.Line)
+		}
+		return out
+	}
+
+	require.Equal(t, []int{0, 1}, lines(idx.Search("apple")), "a name match outweighs a group match")
+	require.Equal(t, []int{0, 1}, lines(idx.Search("app")))
+	require.Empty(t, idx.Search("a"), "single letters are not expanded")
+	require.Equal(t, []int{2}, lines(idx.Search("blue blue")))
+
+	idx.Remove(0)
+	idx.Add(3, TestEntity{Name: "apple pie"})
+	require.Equal(t, []int{3, 1}, lines(idx.Search("apple")))
+	require.Empty(t, idx.Search("red"))
+}
+
+func TestFindAllRankedPaginated_ReadsPageInRankOrder(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{{ID: "1"}, {ID: "2"}, {ID: "3"}, {ID: "4"}})
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID)
+	require.NoError(t, err)
+
+	rank := func() []int { return []int{3, 0, 2} }
+	var ids []string
+	total, err := repo.FindAllRankedPaginated(rank, 1, 2, func(e TestEntity) error {
+		ids = append(ids, e.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Equal(t, []string{"4", "1"}, ids)
+
+	ids = nil
+	total, err = repo.FindAllRankedPaginated(rank, 3, 2, func(e TestEntity) error {
+		ids = append(ids, e.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Empty(t, ids)
+}
+
+func TestRangeIndex_RangeIsInclusiveAndTracksUpdates(t *testing.T) {
+	idx := NewRangeIndex(func(v float64) float64 { return v })
+	for line, v := range []float64{30, 10, 20, 10} {
+		idx.Add(line, v)
+	}
+
+	lines := func(b *Bitmap) []int {
+		var out []int
+		b.ForEach(func(i int) bool {
+			out = append(out, i)
+			return true
+		})
+		return out
+	}
+
+	require.Equal(t, []int{1, 2, 3}, lines(idx.Range(10, 20)))
+	require.Equal(t, []int{0}, lines(idx.Range(25, math.Inf(1))))
+
+	idx.Remove(1)
+	idx.Add(4, 15)
+	require.Equal(t, []int{2, 3, 4}, lines(idx.Range(math.Inf(-1), 20)))
+}
+
+func TestBitmap_SetOperations(t *testing.T) {
+	a, b := NewBitmap(), NewBitmap()
+	for _, i := range []int{1, 64, 130} {
+		a.Set(i)
+	}
+	for _, i := range []int{64, 130, 200} {
+		b.Set(i)
+	}
+
+	require.Equal(t, 2, Intersect(a, nil, b).Count())
+	require.Nil(t, Intersect(nil, nil))
+	require.Equal(t, 4, a.Clone().Or(b).Count())
+
+	a.Clear(64)
+	require.False(t, a.Has(64))
+	require.True(t, a.Has(130))
+	require.Equal(t, 2, a.Count())
+}
+
+type benchEntity struct {
+	ID    string  `json:"id"`
+	Group string  `json:"group"`
+	Price float64 `json:"price"`
+}
+
+const benchLines = 100_000
+
+func TestWithContext_ReturnsContextErrorOnceDone(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{{ID: "1", Group: "a"}, {ID: "2", Group: "b"}, {ID: "3", Group: "a"}})
+	repo, _ := newGroupIndexedRepository(t, fp)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	all := func(TestEntity) bool { return true }
+	noop := func(TestEntity) error { return nil }
+
+	for ctx, want := range map[context.Context]error{canceled: context.Canceled, expired: context.DeadlineExceeded} {
+		_, err := repo.FindByIDWithContext(ctx, "1")
+		require.ErrorIs(t, err, want)
+		require.ErrorIs(t, repo.FindAllWithContext(ctx, noop), want)
+		require.ErrorIs(t, repo.FindAllWhereWithContext(ctx, all, noop), want)
+		_, err = repo.FindAllWherePaginatedWithContext(ctx, all, 1, 10, noop)
+		require.ErrorIs(t, err, want)
+		require.ErrorIs(t, repo.FindAllIndexedWithContext(ctx, nil, all, noop), want)
+		_, err = repo.FindAllRankedPaginatedWithContext(ctx, func() []int { return []int{0} }, 1, 10, noop)
+		require.ErrorIs(t, err, want)
+	}
+}
+
+func TestFindAllWithContext_StopsBetweenLinesWhenCanceled(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{{ID: "1", Group: "a"}, {ID: "2", Group: "b"}, {ID: "3", Group: "a"}})
+	repo, groups := newGroupIndexedRepository(t, fp)
+
+	for name, narrow := range map[string]Narrow{
+		"scan":    nil,
+		"indexed": func() *Bitmap { return groups.Lookup("a", "b") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var seen []string
+			err := repo.FindAllIndexedWithContext(ctx, narrow, func(TestEntity) bool { return true }, func(e TestEntity) error {
+				seen = append(seen, e.ID)
+				cancel()
+				return nil
+			})
+			require.ErrorIs(t, err, context.Canceled)
+			require.Equal(t, []string{"1"}, seen)
+		})
+	}
+}
+
+func writeBenchJSONL(b *testing.B) string {
+	b.Helper()
+	fp := filepath.Join(b.TempDir(), "bench.jsonl")
+	f, err := os.Create(fp)
+	require.NoError(b, err)
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for i := 0; i < benchLines; i++ {
+		require.NoError(b, enc.Encode(benchEntity{
+			ID:    strconv.Itoa(i),
+			Group: "group-" + strconv.Itoa(i%50),
+			Price: float64(i % 1000),
+		}))
+	}
+	require.NoError(b, w.Flush())
+	return fp
+}
+
+func benchMatch(e benchEntity) bool {
+	return e.Group == "group-7" && e.Price >= 100 && e.Price <= 200
+}
+
+func BenchmarkFindAllWherePaginated_Scan100k(b *testing.B) {
+	fp := writeBenchJSONL(b)
+	repo, err := NewJSONRepository(fp, func(e benchEntity) string { return e.ID })
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.FindAllWherePaginated(benchMatch, 1, 20, func(benchEntity) error { return nil }); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFindAllIndexedPaginated_Indexed100k(b *testing.B) {
+	fp := writeBenchJSONL(b)
+	byGroup := NewKeywordIndex(func(e benchEntity) string { return e.Group })
+	byPrice := NewRangeIndex(func(e benchEntity) float64 { return e.Price })
+	repo, err := NewJSONRepository(fp, func(e benchEntity) string { return e.ID },
+		WithIndex("group", byGroup), WithIndex("price", byPrice))
+	require.NoError(b, err)
+
+	narrow := func() *Bitmap {
+		return Intersect(byGroup.Lookup("group-7"), byPrice.Range(100, 200))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.FindAllIndexedPaginated(narrow, benchMatch, nil, 1, 20, func(benchEntity) error { return nil }); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestLines_ReportsTheStateOfEveryLine(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "entities.jsonl")
+	content := `{"id":"1","name":"a"}` + "\n" +
+		`{"id":"2","name":"b"}` + "\n" +
+		`not json` + "\n" +
+		`{"id":"1","name":"c"}` + "\n" +
+		`{"$deleted":"2"}` + "\n"
+	require.NoError(t, os.WriteFile(fp, []byte(content), 0o600))
+
+	repo, err := NewJSONRepository(fp, getTestEntityID)
+	require.NoError(t, err)
+
+	var got []Line[TestEntity]
+	require.NoError(t, repo.Lines(context.Background(), func(line Line[TestEntity]) error {
+		got = append(got, line)
+		return nil
+	}))
+
+	require.Len(t, got, 5)
+	assert.Equal(t, Line[TestEntity]{Number: 1, Offset: 0, State: LineDead, ID: "1", Entity: TestEntity{ID: "1", Name: "a"}}, got[0])
+	assert.Equal(t, LineDead, got[1].State)
+	assert.Equal(t, LineCorrupted, got[2].State)
+	assert.Equal(t, int64(44), got[2].Offset)
+	assert.NotEmpty(t, got[2].Error)
+	assert.Equal(t, Line[TestEntity]{Number: 4, Offset: 53, State: LineLive, ID: "1", Entity: TestEntity{ID: "1", Name: "c"}}, got[3])
+	assert.Equal(t, Line[TestEntity]{Number: 5, Offset: 75, State: LineTombstone, ID: "2"}, got[4])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, repo.Lines(ctx, func(Line[TestEntity]) error { return nil }), context.Canceled)
+}
+
+func TestWithValidator_ReportsInvalidLiveRecords(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "entities.jsonl")
+	content := `{"id":"1"}` + "\n" +
+		`{"id":"2","name":"b"}` + "\n" +
+		`not json` + "\n" +
+		`{"id":"3"}` + "\n" +
+		`{"id":"3","name":"c"}` + "\n"
+	require.NoError(t, os.WriteFile(fp, []byte(content), 0o600))
+
+	requireName := func(e TestEntity) []FieldError {
+		if e.Name == "" {
+			return []FieldError{{Field: "name", Message: "is required"}}
+		}
+		return nil
+	}
+	repo, err := NewJSONRepository(fp, getTestEntityID, WithValidator(requireName))
+	require.NoError(t, err)
+
+	// invalid records are still served
+	_, err = repo.FindByID("1")
+	require.NoError(t, err)
+	report := repo.Report()
+	require.Len(t, report.CorruptedLines, 1)
+	assert.Equal(t, []InvalidRecord{{Line: 1, Offset: 0, ID: "1", Errors: []FieldError{{Field: "name", Message: "is required"}}}}, report.InvalidRecords)
+	assert.Equal(t, 1, repo.Stats().InvalidRecords)
+
+	require.NoError(t, repo.Update(TestEntity{ID: "2"}))
+	require.NoError(t, repo.Update(TestEntity{ID: "1", Name: "a"}))
+	invalid := repo.InvalidRecords()
+	require.Len(t, invalid, 1)
+	assert.Equal(t, "2", invalid[0].ID)
+	assert.Equal(t, 6, invalid[0].Line)
+
+	require.NoError(t, repo.Delete("2"))
+	assert.Empty(t, repo.InvalidRecords())
+
+	_, err = NewJSONRepository(fp, getTestEntityID, WithValidator(func(string) []FieldError { return nil }))
+	require.Error(t, err)
+}
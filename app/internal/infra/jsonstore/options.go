@@ -0,0 +1,145 @@
+package jsonstore
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultCompactionRatio is the share of dead lines that triggers an automatic compaction.
+	DefaultCompactionRatio = 0.5
+	// DefaultCompactionMinDeadLines avoids rewriting small files on every change.
+	DefaultCompactionMinDeadLines = 100
+	// DefaultReloadDelay is how long Watch waits for changes to a file to
+	// settle before reloading it.
+	DefaultReloadDelay = 500 * time.Millisecond
+	// DefaultSyncInterval is how often SyncInterval flushes writes.
+	DefaultSyncInterval = time.Second
+)
+
+// SyncPolicy decides when writes are flushed to stable storage with fsync.
+type SyncPolicy int
+
+const (
+	// SyncAlways flushes the file before a write returns, so a write that
+	// succeeded survives a crash of the process or the machine.
+	SyncAlways SyncPolicy = iota
+	// SyncInterval flushes the file in the background at most once per
+	// interval; a crash of the machine loses at most the writes of the last
+	// interval.
+	SyncInterval
+	// SyncNever leaves flushing to the operating system.
+	SyncNever
+)
+
+var syncPolicyNames = map[SyncPolicy]string{
+	SyncAlways:   "always",
+	SyncInterval: "interval",
+	SyncNever:    "never",
+}
+
+func (p SyncPolicy) String() string {
+	if name, ok := syncPolicyNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("SyncPolicy(%d)", int(p))
+}
+
+// ParseSyncPolicy reads a policy by name: always, interval or never. An empty
+// name is the default, SyncAlways.
+func ParseSyncPolicy(name string) (SyncPolicy, error) {
+	if strings.TrimSpace(name) == "" {
+		return SyncAlways, nil
+	}
+	for p, n := range syncPolicyNames {
+		if strings.EqualFold(strings.TrimSpace(name), n) {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("jsonstore: unknown sync policy %q (want always, interval or never)", name)
+}
+
+type options struct {
+	compactionRatio        float64
+	compactionMinDeadLines int
+	reloadDelay            time.Duration
+	syncPolicy             SyncPolicy
+	syncInterval           time.Duration
+	indexes                map[string]any
+	validator              any
+	readOnly               bool
+}
+
+func defaultOptions() options {
+	return options{
+		compactionRatio:        DefaultCompactionRatio,
+		compactionMinDeadLines: DefaultCompactionMinDeadLines,
+		reloadDelay:            DefaultReloadDelay,
+		syncPolicy:             SyncAlways,
+		syncInterval:           DefaultSyncInterval,
+	}
+}
+
+// Option customizes a JSONRepository.
+type Option func(*options)
+
+// WithAutoCompaction compacts the file after a write once at least minDeadLines
+// lines are dead and they make up ratio (0 to 1) of the file. A ratio <= 0
+// disables automatic compaction; Compact can still be called manually.
+func WithAutoCompaction(ratio float64, minDeadLines int) Option {
+	return func(o *options) {
+		o.compactionRatio = ratio
+		o.compactionMinDeadLines = minDeadLines
+	}
+}
+
+// WithReloadDelay sets how long Watch waits after the last change to the file
+// before reloading it, so a file being written is not indexed half way.
+func WithReloadDelay(delay time.Duration) Option {
+	return func(o *options) {
+		o.reloadDelay = delay
+	}
+}
+
+// WithSync sets when writes are flushed to disk. interval is only used by
+// SyncInterval; a non positive one means DefaultSyncInterval.
+func WithSync(policy SyncPolicy, interval time.Duration) Option {
+	return func(o *options) {
+		o.syncPolicy = policy
+		o.syncInterval = interval
+		if interval <= 0 {
+			o.syncInterval = DefaultSyncInterval
+		}
+	}
+}
+
+// WithIndex registers a secondary index under name. It is populated while the
+// file is loaded and kept up to date on every write; queries reach it through
+// JSONRepository.Index.
+func WithIndex[T any](name string, idx SecondaryIndex[T]) Option {
+	return func(o *options) {
+		if o.indexes == nil {
+			o.indexes = make(map[string]any)
+		}
+		o.indexes[name] = idx
+	}
+}
+
+// WithValidator checks every live record against validate while the file is
+// loaded and on every write. Records with errors are still served; they are
+// reported by InvalidRecords and Report.
+func WithValidator[T any](validate func(entity T) []FieldError) Option {
+	return func(o *options) {
+		o.validator = validate
+	}
+}
+
+// WithReadOnly opens the file without ever changing it: a last line left by an
+// interrupted write is reported among the corrupted lines instead of being
+// cut off, and writes and compactions fail with ErrReadOnly.
+func WithReadOnly() Option {
+	return func(o *options) {
+		o.readOnly = true
+	}
+}
@@ -0,0 +1,54 @@
+package jsonstore
+
+import "context"
+
+// Rank returns the lines of the records matching a query, most relevant
+// first. It is called with the repository read lock held.
+type Rank func() []int
+
+// FindAllRankedPaginated returns the requested page of the records ranked by
+// rank, and how many records it matched. Only the records of the page are
+// read from the file.
+func (r *JSONRepository[T]) FindAllRankedPaginated(
+	rank Rank,
+	page, pageSize int,
+	handler func(entity T) error,
+) (int, error) {
+	return r.FindAllRankedPaginatedWithContext(context.Background(), rank, page, pageSize, handler)
+}
+
+// FindAllRankedPaginatedWithContext is FindAllRankedPaginated stopping with
+// ctx.Err() once ctx is done.
+func (r *JSONRepository[T]) FindAllRankedPaginatedWithContext(
+	ctx context.Context,
+	rank Rank,
+	page, pageSize int,
+	handler func(entity T) error,
+) (int, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	lines := rank()
+	start := max(page-1, 0) * pageSize
+	if start >= len(lines) {
+		return len(lines), nil
+	}
+	end := min(start+pageSize, len(lines))
+
+	for _, line := range lines[start:end] {
+		if err := ctx.Err(); err != nil {
+			return len(lines), err
+		}
+		entity, err := r.readAt(r.offsets[line])
+		if err != nil {
+			return len(lines), err
+		}
+		if err := handler(entity); err != nil {
+			return len(lines), err
+		}
+	}
+	return len(lines), nil
+}
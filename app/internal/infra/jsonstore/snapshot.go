@@ -0,0 +1,228 @@
+package jsonstore
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+)
+
+// maxReloadAttempts bounds how many times Reload reads the file without the
+// lock before giving up on concurrent writes and reading it under the lock.
+const maxReloadAttempts = 3
+
+// snapshot is the index of the file as it was read by load. It keeps the file
+// open, so its offsets stay valid after the file is replaced on disk until a
+// newer snapshot is installed.
+type snapshot[T any] struct {
+	file      *os.File
+	size      int64
+	index     map[string]int
+	offsets   []int64
+	live      *Bitmap
+	corrupted *Bitmap
+	// corruptions describes the lines in corrupted
+	corruptions []CorruptedLine
+	// unterminated is set when the last line has no line feed
+	unterminated bool
+	// entities holds the live records, to populate the secondary indexes
+	entities map[int]T
+}
+
+func (s *snapshot[T]) close() {
+	if s.file != nil {
+		s.file.Close()
+	}
+}
+
+// load reads the file into a new snapshot. It only uses the fields of r that
+// never change, so it does not need the lock. A missing file is an empty
+// snapshot.
+func (r *JSONRepository[T]) load() (*snapshot[T], error) {
+	s := &snapshot[T]{
+		index:     make(map[string]int),
+		live:      NewBitmap(),
+		corrupted: NewBitmap(),
+		entities:  make(map[int]T),
+	}
+
+	f, err := os.Open(r.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	// lines appended after the Stat are left for the next reload
+	s.file, s.size = f, info.Size()
+
+	var offset int64 = 0
+	scanner := bufio.NewScanner(io.NewSectionReader(f, 0, s.size))
+	const maxCapacity = 1024 * 102
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, maxCapacity)
+
+	kill := func(line int) {
+		s.live.Clear(line)
+		delete(s.entities, line)
+	}
+	corrupt := func(line int, err error) {
+		s.corrupted.Set(line)
+		s.corruptions = append(s.corruptions, CorruptedLine{Line: line + 1, Offset: s.offsets[line], Error: err.Error()})
+	}
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		lineNo := len(s.offsets)
+		s.offsets = append(s.offsets, offset)
+		offset += int64(len(line) + 1)
+
+		if isTombstone(line) {
+			var t tombstone
+			if err := json.Unmarshal(line, &t); err != nil {
+				corrupt(lineNo, err)
+				continue
+			}
+			if previous, exists := s.index[t.ID]; exists {
+				kill(previous)
+			}
+			delete(s.index, t.ID)
+			continue
+		}
+
+		var entity T
+		if err := json.Unmarshal(line, &entity); err != nil {
+			corrupt(lineNo, err)
+			continue
+		}
+		if id := r.getID(entity); id != "" {
+			if previous, exists := s.index[id]; exists {
+				kill(previous)
+			}
+			s.index[id] = lineNo
+		}
+		s.live.Set(lineNo)
+		s.entities[lineNo] = entity
+	}
+	if err := scanner.Err(); err != nil {
+		s.close()
+		return nil, err
+	}
+	// offset counts a line feed after every line, including the last one
+	s.unterminated = offset > s.size
+	if last := len(s.offsets) - 1; s.unterminated && s.corrupted.Has(last) {
+		s.corruptions[len(s.corruptions)-1].Interrupted = true
+	}
+	return s, nil
+}
+
+// install replaces the state of r with s and rebuilds the secondary indexes
+// and the invalid records.
+// The exclusive lock must be held.
+func (r *JSONRepository[T]) install(s *snapshot[T]) {
+	if r.file != nil {
+		r.file.Close()
+	}
+	r.file, r.size = s.file, s.size
+	r.index = s.index
+	r.offsets = s.offsets
+	r.live = s.live
+	r.corrupted = s.corrupted
+	r.corruptions = s.corruptions
+	r.unterminated = s.unterminated
+	logCorruptions(r.filePath, s.corruptions)
+
+	for _, idx := range r.indexes {
+		idx.Reset()
+	}
+	clear(r.invalid)
+	s.live.ForEach(func(line int) bool {
+		entity := s.entities[line]
+		for _, idx := range r.indexes {
+			idx.Add(line, entity)
+		}
+		r.validate(line, entity)
+		return true
+	})
+	logInvalidRecords(r.filePath, r.invalidRecords())
+
+	r.loadedAt = time.Now()
+	r.version++
+}
+
+// reload reads the file again while holding the exclusive lock.
+func (r *JSONRepository[T]) reload() error {
+	s, err := r.load()
+	if err != nil {
+		return err
+	}
+	r.install(s)
+	return nil
+}
+
+// Reload rebuilds the index from the file on disk, for when it was replaced or
+// edited by another process. The file is read without holding the lock, so
+// queries keep being answered from the previous snapshot until the new one is
+// swapped in. A snapshot that raced with a write is read again.
+func (r *JSONRepository[T]) Reload() error {
+	for attempt := 0; attempt < maxReloadAttempts; attempt++ {
+		r.mutex.RLock()
+		version := r.version
+		r.mutex.RUnlock()
+
+		s, err := r.load()
+		if err != nil {
+			return err
+		}
+
+		r.mutex.Lock()
+		if r.version == version {
+			r.install(s)
+			r.mutex.Unlock()
+			return nil
+		}
+		r.mutex.Unlock()
+		s.close()
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.reload()
+}
+
+// stale reports whether the file on disk is no longer the one the index was
+// built from, or was written to by another process. The lock must be held.
+func (r *JSONRepository[T]) stale() (bool, error) {
+	info, err := os.Stat(r.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return r.file != nil, nil
+		}
+		return false, err
+	}
+	if r.file == nil {
+		return true, nil
+	}
+	current, err := r.file.Stat()
+	if err != nil {
+		return false, err
+	}
+	return !os.SameFile(info, current) || info.Size() != r.size, nil
+}
+
+// refresh reloads the index before a write when the file changed behind the
+// repository's back, so the write is appended to the file the index
+// describes. The exclusive lock must be held.
+func (r *JSONRepository[T]) refresh() error {
+	stale, err := r.stale()
+	if err != nil || !stale {
+		return err
+	}
+	return r.reload()
+}
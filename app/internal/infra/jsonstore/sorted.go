@@ -0,0 +1,138 @@
+package jsonstore
+
+import (
+	"container/heap"
+	"context"
+	"sort"
+)
+
+// FindAllWhereSortedPaginated returns the requested page of matching entities
+// ordered by less. Only the best page*pageSize matches are kept in memory while
+// scanning, so deep pages cost more memory but the file is never loaded whole.
+// less must define a total order for pages to be stable between requests.
+func (r *JSONRepository[T]) FindAllWhereSortedPaginated(
+	predicate func(entity T) bool,
+	less func(a, b T) bool,
+	page, pageSize int,
+	handler func(entity T) error,
+) (int, error) {
+	return r.FindAllWhereSortedPaginatedWithContext(context.Background(), predicate, less, page, pageSize, handler)
+}
+
+// FindAllWhereSortedPaginatedWithContext is FindAllWhereSortedPaginated
+// stopping with ctx.Err() once ctx is done.
+func (r *JSONRepository[T]) FindAllWhereSortedPaginatedWithContext(
+	ctx context.Context,
+	predicate func(entity T) bool,
+	less func(a, b T) bool,
+	page, pageSize int,
+	handler func(entity T) error,
+) (int, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.sortedPage(ctx, nil, predicate, less, nil, page, pageSize, handler)
+}
+
+// FindAllIndexedAfter returns up to limit matches ordered by less, starting
+// after the position of a previous page: only entities for which after holds
+// are returned, but every match counts towards the total. Unlike deep offset
+// pages, memory stays bounded by limit however far the client has scrolled.
+func (r *JSONRepository[T]) FindAllIndexedAfter(
+	narrow Narrow,
+	predicate func(entity T) bool,
+	less func(a, b T) bool,
+	after func(entity T) bool,
+	limit int,
+	handler func(entity T) error,
+) (int, error) {
+	return r.FindAllIndexedAfterWithContext(context.Background(), narrow, predicate, less, after, limit, handler)
+}
+
+// FindAllIndexedAfterWithContext is FindAllIndexedAfter stopping with
+// ctx.Err() once ctx is done.
+func (r *JSONRepository[T]) FindAllIndexedAfterWithContext(
+	ctx context.Context,
+	narrow Narrow,
+	predicate func(entity T) bool,
+	less func(a, b T) bool,
+	after func(entity T) bool,
+	limit int,
+	handler func(entity T) error,
+) (int, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var candidates *Bitmap
+	if narrow != nil {
+		candidates = narrow()
+	}
+	return r.sortedPage(ctx, candidates, predicate, less, after, 1, limit, handler)
+}
+
+func (r *JSONRepository[T]) sortedPage(
+	ctx context.Context,
+	candidates *Bitmap,
+	predicate func(entity T) bool,
+	less func(a, b T) bool,
+	after func(entity T) bool,
+	page, pageSize int,
+	handler func(entity T) error,
+) (int, error) {
+	start := max(page-1, 0) * pageSize
+	limit := start + pageSize
+	top := &boundedHeap[T]{less: less}
+	total := 0
+
+	err := r.scan(ctx, candidates, func(entity T) error {
+		if !predicate(entity) {
+			return nil
+		}
+		total++
+
+		if limit <= 0 || (after != nil && !after(entity)) {
+			return nil
+		}
+		if top.Len() < limit {
+			heap.Push(top, entity)
+			return nil
+		}
+		if less(entity, top.items[0]) {
+			top.items[0] = entity
+			heap.Fix(top, 0)
+		}
+		return nil
+	})
+	if err != nil {
+		return total, err
+	}
+
+	items := top.items
+	sort.Slice(items, func(i, j int) bool { return less(items[i], items[j]) })
+	if start >= len(items) {
+		return total, nil
+	}
+	for _, entity := range items[start:] {
+		if err := handler(entity); err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// boundedHeap is a max-heap by less: the root is the worst entity kept so far,
+// which is the one evicted when a better match shows up.
+type boundedHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *boundedHeap[T]) Len() int           { return len(h.items) }
+func (h *boundedHeap[T]) Less(i, j int) bool { return h.less(h.items[j], h.items[i]) }
+func (h *boundedHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *boundedHeap[T]) Push(x any)         { h.items = append(h.items, x.(T)) }
+func (h *boundedHeap[T]) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
@@ -0,0 +1,87 @@
+package jsonstore
+
+import (
+	"log"
+	"sort"
+)
+
+// FieldError is a field of a record that breaks a rule of the validator set
+// by WithValidator.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// InvalidRecord is the latest version of a record that decodes but breaks the
+// rules of the validator. It is still served: the report tells someone what
+// to fix rather than hiding the record.
+type InvalidRecord struct {
+	// Line is the 1-based line number.
+	Line   int          `json:"line"`
+	Offset int64        `json:"offset"`
+	ID     string       `json:"id"`
+	Errors []FieldError `json:"errors"`
+}
+
+// Report lists what is wrong with the file: the lines that cannot be decoded
+// and the live records that break the rules of the validator, both in file
+// order.
+type Report struct {
+	CorruptedLines []CorruptedLine `json:"corruptedLines"`
+	InvalidRecords []InvalidRecord `json:"invalidRecords"`
+}
+
+// Report returns what is wrong with the file. It is built while the file is
+// loaded and kept up to date on every write, like the secondary indexes.
+func (r *JSONRepository[T]) Report() Report {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return Report{
+		CorruptedLines: append([]CorruptedLine{}, r.corruptions...),
+		InvalidRecords: r.invalidRecords(),
+	}
+}
+
+// InvalidRecords reports the live records that break the rules of the
+// validator, in file order.
+func (r *JSONRepository[T]) InvalidRecords() []InvalidRecord {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.invalidRecords()
+}
+
+func (r *JSONRepository[T]) invalidRecords() []InvalidRecord {
+	records := make([]InvalidRecord, 0, len(r.invalid))
+	for _, record := range r.invalid {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Line < records[j].Line
+	})
+	return records
+}
+
+// validate records the errors of the record at line, or forgets the ones of
+// a previous record there.
+func (r *JSONRepository[T]) validate(line int, entity T) {
+	if r.validator == nil {
+		return
+	}
+	errs := r.validator(entity)
+	if len(errs) == 0 {
+		delete(r.invalid, line)
+		return
+	}
+	r.invalid[line] = InvalidRecord{Line: line + 1, Offset: r.offsets[line], ID: r.getID(entity), Errors: errs}
+}
+
+func logInvalidRecords(path string, records []InvalidRecord) {
+	if len(records) == 0 {
+		return
+	}
+	first := records[0]
+	log.Printf("jsonstore: %s has %d invalid record(s), first %q at line %d: %s %s",
+		path, len(records), first.ID, first.Line, first.Errors[0].Field, first.Errors[0].Message)
+}
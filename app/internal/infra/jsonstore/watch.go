@@ -0,0 +1,78 @@
+package jsonstore
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch reloads the repository whenever its file is replaced or modified by
+// another process, until ctx is done. The directory is watched rather than the
+// file, so replacing the file with a rename is noticed too. Writes made through
+// the repository itself are recognised and do not trigger a reload.
+func (r *JSONRepository[T]) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(r.filePath)); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go r.watch(ctx, watcher)
+	return nil
+}
+
+func (r *JSONRepository[T]) watch(ctx context.Context, watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+
+	settled := time.NewTimer(r.options.reloadDelay)
+	settled.Stop()
+	defer settled.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != r.filePath || event.Op == fsnotify.Chmod {
+				continue
+			}
+			settled.Reset(r.options.reloadDelay)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("jsonstore: watching %s: %v", r.filePath, err)
+		case <-settled.C:
+			if err := r.reloadIfChanged(); err != nil {
+				log.Printf("jsonstore: reloading %s failed: %v", r.filePath, err)
+			}
+		}
+	}
+}
+
+// reloadIfChanged reloads the file when it differs from the indexed one. A
+// missing file is assumed to be in the middle of being replaced, so the
+// current snapshot keeps being served.
+func (r *JSONRepository[T]) reloadIfChanged() error {
+	if _, err := os.Stat(r.filePath); os.IsNotExist(err) {
+		return nil
+	}
+
+	r.mutex.RLock()
+	stale, err := r.stale()
+	r.mutex.RUnlock()
+	if err != nil || !stale {
+		return err
+	}
+	return r.Reload()
+}
@@ -0,0 +1,116 @@
+package jsonstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/stretchr/testify/require"
+)
+
+// replaceFile writes entities to a new file renamed over path, the way a feed
+// publishes a new catalog.
+func replaceFile(t *testing.T, path string, entities []TestEntity) {
+	t.Helper()
+	tmp := path + ".new"
+	writeJSONL(t, tmp, entities)
+	require.NoError(t, os.Rename(tmp, path))
+}
+
+func TestReload_ServesPreviousSnapshotUntilReloaded(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{{ID: "1", Name: "Alice", Group: "a"}, {ID: "2", Name: "Bob", Group: "b"}})
+	repo, byGroup := newGroupIndexedRepository(t, fp)
+	loadedAt := repo.Stats().LoadedAt
+
+	replaceFile(t, fp, []TestEntity{{ID: "3", Name: "Carol", Group: "a"}, {ID: "2", Name: "Bobby", Group: "a"}, {ID: "4", Name: "Dave", Group: "b"}})
+
+	got, err := repo.FindByID("2")
+	require.NoError(t, err)
+	require.Equal(t, "Bob", got.Name)
+	require.Equal(t, []string{"1"}, collectIndexed(t, repo, func() *Bitmap { return byGroup.Lookup("a") }))
+
+	require.NoError(t, repo.Reload())
+
+	got, err = repo.FindByID("2")
+	require.NoError(t, err)
+	require.Equal(t, "Bobby", got.Name)
+	_, err = repo.FindByID("1")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+	require.Equal(t, []string{"3", "2"}, collectIndexed(t, repo, func() *Bitmap { return byGroup.Lookup("a") }))
+
+	stats := repo.Stats()
+	require.Equal(t, 3, stats.TotalLines)
+	require.True(t, stats.LoadedAt.After(loadedAt))
+}
+
+func TestSave_ReloadsReplacedFileBeforeAppending(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{{ID: "1", Name: "Alice"}})
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID)
+	require.NoError(t, err)
+
+	replaceFile(t, fp, []TestEntity{{ID: "2", Name: "Bob"}, {ID: "3", Name: "Carol"}})
+	require.NoError(t, repo.Save(TestEntity{ID: "1", Name: "Alice"}))
+	require.NoError(t, repo.Update(TestEntity{ID: "3", Name: "Caroline"}))
+
+	for _, r := range []*JSONRepository[TestEntity]{repo, reopen(t, fp)} {
+		var names []string
+		require.NoError(t, r.FindAll(func(e TestEntity) error {
+			names = append(names, e.Name)
+			return nil
+		}))
+		require.Equal(t, []string{"Bob", "Alice", "Caroline"}, names)
+	}
+}
+
+func TestWatch_ReloadsWhenTheFileIsReplaced(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{{ID: "1", Name: "Alice"}})
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID, WithReloadDelay(10*time.Millisecond))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, repo.Watch(ctx))
+
+	replaceFile(t, fp, []TestEntity{{ID: "1", Name: "Alicia"}, {ID: "2", Name: "Bob"}})
+
+	require.Eventually(t, func() bool {
+		got, err := repo.FindByID("2")
+		return err == nil && got.Name == "Bob"
+	}, 5*time.Second, 10*time.Millisecond)
+	got, err := repo.FindByID("1")
+	require.NoError(t, err)
+	require.Equal(t, "Alicia", got.Name)
+	require.Equal(t, 2, repo.Stats().TotalLines)
+}
+
+func TestWatch_IgnoresWritesMadeThroughTheRepository(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "entities.jsonl")
+	writeJSONL(t, fp, []TestEntity{{ID: "1", Name: "Alice"}})
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID, WithReloadDelay(10*time.Millisecond), WithAutoCompaction(0, 0))
+	require.NoError(t, err)
+	loadedAt := repo.Stats().LoadedAt
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, repo.Watch(ctx))
+
+	require.NoError(t, repo.Save(TestEntity{ID: "2", Name: "Bob"}))
+	require.NoError(t, repo.Update(TestEntity{ID: "1", Name: "Alicia"}))
+	time.Sleep(100 * time.Millisecond)
+
+	require.Equal(t, loadedAt, repo.Stats().LoadedAt)
+	require.Equal(t, 3, repo.Stats().TotalLines)
+}
+
+func reopen(t *testing.T, fp string) *JSONRepository[TestEntity] {
+	t.Helper()
+	repo, err := NewJSONRepository[TestEntity](fp, getTestEntityID)
+	require.NoError(t, err)
+	return repo
+}
@@ -0,0 +1,277 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator"
+	"github.com/lucasti79/meli-interview/internal/auth"
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
+	"github.com/lucasti79/meli-interview/internal/order"
+	"github.com/lucasti79/meli-interview/internal/order/service"
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/lucasti79/meli-interview/pkg/web/request"
+	"github.com/lucasti79/meli-interview/pkg/web/response"
+)
+
+// IdempotencyKeyHeader carries the key a client places an order with, so
+// retrying the request returns the order instead of placing another.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type Handler struct {
+	service   service.Service
+	validator *validator.Validate
+}
+
+func NewHandler(service service.Service) *Handler {
+	return &Handler{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// Create godoc
+// @Summary      Place an order
+// @Description  Order the items of a cart, or the given items, at the prices their products have now, taking the units out of stock. Ordering a cart takes its items out of it, so it cannot be ordered twice. Nothing is ordered when any product is out of stock, has fewer units available than ordered or is no longer in the catalog. Sending the Idempotency-Key of an order already placed returns that order with 200 instead of placing another. Orders placed with a user token belong to its user, who can read them back
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string               true  "Key identifying this order across retries"
+// @Param        order            body    order.CreateRequest  true  "Cart or items to order"
+// @Success      200  {object}  OrderResult
+// @Success      201  {object}  OrderResult
+// @Failure      400  {object}  httpdto.ErrorResponse
+// @Failure      409  {object}  httpdto.ErrorResponse
+// @Failure      422  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Router       /api/v1/orders [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get(IdempotencyKeyHeader)
+	if key == "" {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    order.ErrOrderIdempotencyKeyRequired,
+			Message: "the " + IdempotencyKeyHeader + " header is required",
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	var req order.CreateRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+	req.IdempotencyKey = key
+	if claims, ok := auth.ClaimsFromContext(r.Context()); ok && !claims.FromAPIKey() {
+		req.UserId = claims.Subject
+	}
+
+	o, created, err := h.service.CreateWithContext(r.Context(), req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", r.URL.Path+"/"+o.Id)
+	status := http.StatusCreated
+	if !created {
+		status = http.StatusOK
+	}
+	response.JSON(w, status, OrderResult{Data: *o})
+}
+
+// GetAll godoc
+// @Summary      List orders
+// @Description  List orders newest first, optionally only the ones in a status
+// @Tags         Orders
+// @Produce      json
+// @Param        filters  query  order.OrderFilter  false  "Order filters"
+// @Success      200  {object}  OrderPaginatedResult
+// @Success      204  "No content"
+// @Failure      400  {object}  httpdto.ErrorResponse
+// @Failure      401  {object}  httpdto.ErrorResponse
+// @Failure      403  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Failure      504  {object}  httpdto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/orders [get]
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	filters := order.OrderFilter{
+		Status:   r.URL.Query().Get("status"),
+		Page:     1,
+		PageSize: 10,
+	}
+	if page := r.URL.Query().Get("page"); page != "" {
+		if p, err := strconv.Atoi(page); err == nil {
+			filters.Page = p
+		}
+	}
+	if size := r.URL.Query().Get("pageSize"); size != "" {
+		if s, err := strconv.Atoi(size); err == nil {
+			filters.PageSize = s
+		}
+	}
+
+	if err := h.validator.Struct(filters); err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    apperrors.ErrValidation.Error(),
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	orders, total, err := h.service.GetAllWithContext(r.Context(), filters)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	if len(orders) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, OrderPaginatedResult{
+		Data:       orders,
+		TotalCount: total,
+		Page:       filters.Page,
+		PageSize:   filters.PageSize,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get an order
+// @Description  Get an order with its items at the prices they were ordered at and the history of its status. Users only get the orders they placed; other orders are not found for them. Admins get every order
+// @Tags         Orders
+// @Produce      json
+// @Param        orderId  path  string  true  "Order ID"
+// @Success      200  {object}  OrderResult
+// @Failure      401  {object}  httpdto.ErrorResponse
+// @Failure      403  {object}  httpdto.ErrorResponse
+// @Failure      404  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Failure      504  {object}  httpdto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/orders/{orderId} [get]
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+	orderId := chi.URLParam(r, "orderId")
+	o, err := h.service.GetByIDWithContext(r.Context(), orderId)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	// the orders of other users are answered as missing, so their IDs cannot
+	// be probed
+	if claims, ok := auth.ClaimsFromContext(r.Context()); !ok || (!claims.HasAnyRole(auth.RoleAdmin) && !o.PlacedBy(claims.Subject)) {
+		writeError(w, fmt.Errorf("%w: order %s", apperrors.ErrResourceNotExists, orderId))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, OrderResult{Data: *o})
+}
+
+// UpdateStatus godoc
+// @Summary      Change the status of an order
+// @Description  Move an order along pending, paid and shipped, or cancel it before it ships. Cancelled orders put their units back in stock
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        orderId  path  string               true  "Order ID"
+// @Param        status   body  order.StatusRequest  true  "New status"
+// @Success      200  {object}  OrderResult
+// @Failure      400  {object}  httpdto.ErrorResponse
+// @Failure      401  {object}  httpdto.ErrorResponse
+// @Failure      403  {object}  httpdto.ErrorResponse
+// @Failure      404  {object}  httpdto.ErrorResponse
+// @Failure      409  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/orders/{orderId}/status [put]
+func (h *Handler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	var body order.StatusRequest
+	if !h.decode(w, r, &body) {
+		return
+	}
+
+	o, err := h.service.UpdateStatusWithContext(r.Context(), chi.URLParam(r, "orderId"), body.Status)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, OrderResult{Data: *o})
+}
+
+// decode reads and validates the JSON body into ptr, writing a 400 response
+// when it cannot.
+func (h *Handler) decode(w http.ResponseWriter, r *http.Request, ptr any) bool {
+	err := request.JSON(r, ptr)
+	if err == nil {
+		err = h.validator.Struct(ptr)
+	}
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    order.ErrOrderInvalidData,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return false
+	}
+	return true
+}
+
+// writeError maps an error from the order service to its response.
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrResourceNotExists):
+		response.JSON(w, http.StatusNotFound, httpdto.ErrorResponse{
+			Code:    order.ErrOrderNotFound,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusNotFound),
+		})
+	case errors.Is(err, product.ErrNotAvailable):
+		response.JSON(w, http.StatusConflict, httpdto.ErrorResponse{
+			Code:    product.ErrProductNotAvailable,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusConflict),
+		})
+	case errors.Is(err, order.ErrInvalidTransition):
+		response.JSON(w, http.StatusConflict, httpdto.ErrorResponse{
+			Code:    order.ErrOrderInvalidTransition,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusConflict),
+		})
+	case errors.Is(err, order.ErrIdempotencyMismatch):
+		response.JSON(w, http.StatusUnprocessableEntity, httpdto.ErrorResponse{
+			Code:    order.ErrOrderIdempotencyMismatch,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusUnprocessableEntity),
+		})
+	case errors.Is(err, apperrors.ErrValidation):
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    order.ErrOrderInvalidData,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+	default:
+		writeInternalError(w, err)
+	}
+}
+
+// writeInternalError answers an unexpected service error, telling apart the
+// requests whose context ended before the order could be read or written.
+func writeInternalError(w http.ResponseWriter, err error) {
+	if status, body, ok := httpdto.ContextError(err); ok {
+		response.JSON(w, status, body)
+		return
+	}
+	response.JSON(w, http.StatusInternalServerError, httpdto.ErrorResponse{
+		Code:    apperrors.ErrInternalError.Error(),
+		Message: "internal server error",
+		Status:  http.StatusText(http.StatusInternalServerError),
+	})
+}
@@ -0,0 +1,287 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lucasti79/meli-interview/internal/auth"
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
+	"github.com/lucasti79/meli-interview/internal/order"
+	"github.com/lucasti79/meli-interview/internal/order/api"
+	"github.com/lucasti79/meli-interview/internal/order/infra/mocks"
+	"github.com/lucasti79/meli-interview/internal/order/service"
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/lucasti79/meli-interview/pkg/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func jsonRequest(t *testing.T, method, target, body string, params map[string]string) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return testutil.WithUrlParamst(t, req, params)
+}
+
+func user(subject string) *auth.Claims {
+	return &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: subject}}
+}
+
+func errorCode(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	var body httpdto.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body.Code
+}
+
+func TestCreate_Success(t *testing.T) {
+	tests := []struct {
+		name    string
+		created bool
+		status  int
+	}{
+		{name: "new order", created: true, status: http.StatusCreated},
+		{name: "replayed key", created: false, status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ServiceMock)
+			mockService.On("CreateWithContext", mock.Anything, order.CreateRequest{
+				Items:          []order.ItemRequest{{ProductId: "p1", Quantity: 2}},
+				IdempotencyKey: "k1",
+			}).Return(&order.Order{Id: "o1", Status: order.StatusPending}, tt.created, nil)
+
+			h := api.NewHandler(mockService)
+
+			req := jsonRequest(t, http.MethodPost, "/api/v1/orders", `{"items":[{"productId":"p1","quantity":2}]}`, nil)
+			req.Header.Set(api.IdempotencyKeyHeader, "k1")
+			rec := httptest.NewRecorder()
+
+			h.Create(rec, req)
+
+			require.Equal(t, tt.status, rec.Code)
+			require.Equal(t, "/api/v1/orders/o1", rec.Header().Get("Location"))
+			require.Contains(t, rec.Body.String(), `"orderId":"o1"`)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreate_PlacesTheOrderForTheUserOfTheToken(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("CreateWithContext", mock.Anything, order.CreateRequest{
+		Items:          []order.ItemRequest{{ProductId: "p1", Quantity: 2}},
+		IdempotencyKey: "k1",
+		UserId:         "u1",
+	}).Return(&order.Order{Id: "o1", Status: order.StatusPending, UserId: "u1"}, true, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := jsonRequest(t, http.MethodPost, "/api/v1/orders", `{"items":[{"productId":"p1","quantity":2}]}`, nil)
+	req.Header.Set(api.IdempotencyKeyHeader, "k1")
+	req = req.WithContext(auth.WithClaims(req.Context(), user("u1")))
+	rec := httptest.NewRecorder()
+
+	h.Create(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestCreate_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    string
+		body   string
+		err    error
+		status int
+		code   string
+	}{
+		{name: "missing idempotency key", body: `{"cartId":"c1"}`, status: http.StatusBadRequest, code: order.ErrOrderIdempotencyKeyRequired},
+		{name: "malformed body", key: "k1", body: `{"items":`, status: http.StatusBadRequest, code: order.ErrOrderInvalidData},
+		{name: "item without units", key: "k1", body: `{"items":[{"productId":"p1","quantity":0}]}`, status: http.StatusBadRequest, code: order.ErrOrderInvalidData},
+		{name: "item over the cap", key: "k1", body: `{"items":[{"productId":"p1","quantity":5000000000000000000}]}`, status: http.StatusBadRequest, code: order.ErrOrderInvalidData},
+		{name: "units adding up past the cap", key: "k1", body: `{"items":[{"productId":"p1","quantity":6000},{"productId":"p1","quantity":6000}]}`, err: order.ErrInvalidQuantity, status: http.StatusBadRequest, code: order.ErrOrderInvalidData},
+		{name: "empty order", key: "k1", body: `{}`, err: order.ErrEmptyOrder, status: http.StatusBadRequest, code: order.ErrOrderInvalidData},
+		{name: "unknown cart", key: "k1", body: `{"cartId":"c9"}`, err: fmt.Errorf("%w: c9", service.ErrUnknownCart), status: http.StatusBadRequest, code: order.ErrOrderInvalidData},
+		{name: "product not available", key: "k1", body: `{"cartId":"c1"}`, err: fmt.Errorf("%w: p1", product.ErrNotAvailable), status: http.StatusConflict, code: product.ErrProductNotAvailable},
+		{name: "key used for another order", key: "k1", body: `{"cartId":"c1"}`, err: order.ErrIdempotencyMismatch, status: http.StatusUnprocessableEntity, code: order.ErrOrderIdempotencyMismatch},
+		{name: "store failure", key: "k1", body: `{"cartId":"c1"}`, err: errors.New("disk failure"), status: http.StatusInternalServerError, code: apperrors.ErrInternalError.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ServiceMock)
+			if tt.err != nil {
+				mockService.On("CreateWithContext", mock.Anything, mock.AnythingOfType("order.CreateRequest")).Return(nil, false, tt.err)
+			}
+
+			h := api.NewHandler(mockService)
+
+			req := jsonRequest(t, http.MethodPost, "/api/v1/orders", tt.body, nil)
+			if tt.key != "" {
+				req.Header.Set(api.IdempotencyKeyHeader, tt.key)
+			}
+			rec := httptest.NewRecorder()
+
+			h.Create(rec, req)
+
+			require.Equal(t, tt.status, rec.Code)
+			require.Equal(t, tt.code, errorCode(t, rec))
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetAll_Success(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetAllWithContext", mock.Anything, order.OrderFilter{Status: order.StatusPaid, Page: 2, PageSize: 5}).
+		Return([]order.Order{{Id: "o1", Status: order.StatusPaid}}, 6, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders?status=paid&page=2&pageSize=5", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAll(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body api.OrderPaginatedResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, 6, body.TotalCount)
+	require.Equal(t, "o1", body.Data[0].Id)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAll_NoContent(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetAllWithContext", mock.Anything, order.OrderFilter{Page: 1, PageSize: 10}).Return([]order.Order{}, 0, nil)
+
+	h := api.NewHandler(mockService)
+
+	rec := httptest.NewRecorder()
+	h.GetAll(rec, httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil))
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestGetAll_InvalidStatus(t *testing.T) {
+	h := api.NewHandler(new(mocks.ServiceMock))
+
+	rec := httptest.NewRecorder()
+	h.GetAll(rec, httptest.NewRequest(http.MethodGet, "/api/v1/orders?status=lost", nil))
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, apperrors.ErrValidation.Error(), errorCode(t, rec))
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetByIDWithContext", mock.Anything, "o1").Return(nil, apperrors.ErrResourceNotExists)
+
+	h := api.NewHandler(mockService)
+
+	req := jsonRequest(t, http.MethodGet, "/api/v1/orders/o1", "", map[string]string{"orderId": "o1"})
+	rec := httptest.NewRecorder()
+
+	h.GetByID(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, order.ErrOrderNotFound, errorCode(t, rec))
+}
+
+func TestGetByID_OnlyForItsUser(t *testing.T) {
+	tests := []struct {
+		name   string
+		order  order.Order
+		claims *auth.Claims
+		status int
+	}{
+		{name: "owner", order: order.Order{Id: "o1", UserId: "u1"}, claims: user("u1"), status: http.StatusOK},
+		{name: "other user", order: order.Order{Id: "o1", UserId: "u1"}, claims: user("u2"), status: http.StatusNotFound},
+		{name: "guest order", order: order.Order{Id: "o1"}, claims: &auth.Claims{Roles: []string{"customer"}}, status: http.StatusNotFound},
+		{name: "admin", order: order.Order{Id: "o1", UserId: "u1"}, claims: &auth.Claims{Roles: []string{auth.RoleAdmin}}, status: http.StatusOK},
+		{name: "anonymous", order: order.Order{Id: "o1", UserId: "u1"}, status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ServiceMock)
+			mockService.On("GetByIDWithContext", mock.Anything, "o1").Return(&tt.order, nil)
+
+			h := api.NewHandler(mockService)
+
+			req := jsonRequest(t, http.MethodGet, "/api/v1/orders/o1", "", map[string]string{"orderId": "o1"})
+			if tt.claims != nil {
+				req = req.WithContext(auth.WithClaims(req.Context(), tt.claims))
+			}
+			rec := httptest.NewRecorder()
+
+			h.GetByID(rec, req)
+
+			require.Equal(t, tt.status, rec.Code)
+			if tt.status == http.StatusNotFound {
+				require.Equal(t, order.ErrOrderNotFound, errorCode(t, rec))
+			}
+		})
+	}
+}
+
+func TestUpdateStatus_Success(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("UpdateStatusWithContext", mock.Anything, "o1", order.StatusPaid).
+		Return(&order.Order{Id: "o1", Status: order.StatusPaid}, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := jsonRequest(t, http.MethodPut, "/api/v1/orders/o1/status", `{"status":"paid"}`, map[string]string{"orderId": "o1"})
+	rec := httptest.NewRecorder()
+
+	h.UpdateStatus(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"status":"paid"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateStatus_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		err    error
+		status int
+		code   string
+	}{
+		{name: "unknown status", body: `{"status":"lost"}`, status: http.StatusBadRequest, code: order.ErrOrderInvalidData},
+		{name: "invalid transition", body: `{"status":"pending"}`, err: order.ErrInvalidTransition, status: http.StatusConflict, code: order.ErrOrderInvalidTransition},
+		{name: "unknown order", body: `{"status":"paid"}`, err: apperrors.ErrResourceNotExists, status: http.StatusNotFound, code: order.ErrOrderNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ServiceMock)
+			if tt.err != nil {
+				mockService.On("UpdateStatusWithContext", mock.Anything, "o1", mock.AnythingOfType("string")).Return(nil, tt.err)
+			}
+
+			h := api.NewHandler(mockService)
+
+			req := jsonRequest(t, http.MethodPut, "/api/v1/orders/o1/status", tt.body, map[string]string{"orderId": "o1"})
+			rec := httptest.NewRecorder()
+
+			h.UpdateStatus(rec, req)
+
+			require.Equal(t, tt.status, rec.Code)
+			require.Equal(t, tt.code, errorCode(t, rec))
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package api
+
+import "github.com/lucasti79/meli-interview/internal/order"
+
+// swagger:model OrderResult
+type OrderResult struct {
+	Data order.Order `json:"data"`
+}
+
+// swagger:model OrderPaginatedResult
+type OrderPaginatedResult struct {
+	Data       []order.Order `json:"data"`
+	TotalCount int           `json:"totalCount"`
+	Page       int           `json:"page,omitempty"`
+	PageSize   int           `json:"pageSize"`
+}
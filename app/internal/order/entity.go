@@ -0,0 +1,214 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+)
+
+// Statuses an order moves through. Orders start pending; shipped and
+// cancelled orders are final.
+const (
+	StatusPending   = "pending"
+	StatusPaid      = "paid"
+	StatusShipped   = "shipped"
+	StatusCancelled = "cancelled"
+)
+
+// transitions lists the statuses each status can move to.
+var transitions = map[string][]string{
+	StatusPending: {StatusPaid, StatusCancelled},
+	StatusPaid:    {StatusShipped, StatusCancelled},
+}
+
+var (
+	// ErrInvalidTransition is returned when moving an order to a status its
+	// current status cannot move to.
+	ErrInvalidTransition = errors.New("invalid order status transition")
+	// ErrEmptyOrder is returned when creating an order without items.
+	ErrEmptyOrder = fmt.Errorf("%w: an order needs at least one item", apperrors.ErrValidation)
+	// ErrInvalidQuantity is returned when an order asks for fewer than one or,
+	// adding up the lines of a product, more than product.MaxQuantity units.
+	ErrInvalidQuantity = fmt.Errorf("%w: an order line holds from 1 to %d units", apperrors.ErrValidation, product.MaxQuantity)
+	// ErrIdempotencyMismatch is returned when an idempotency key is sent
+	// again with a different request than the one that created its order.
+	ErrIdempotencyMismatch = errors.New("idempotency key was already used for a different order")
+)
+
+// Order is stored in its own file. Items keep the name and price their
+// product had when the order was placed, so later catalog changes do not
+// rewrite it.
+type Order struct {
+	Id     string  `json:"orderId"`
+	Status string  `json:"status"`
+	Items  []Item  `json:"items"`
+	Total  float64 `json:"total"`
+	// CartId is the cart the order was placed from, if any.
+	CartId string `json:"cartId,omitempty"`
+	// UserId is the user who placed the order. Orders placed without a user
+	// token have none and can only be read by admins.
+	UserId string `json:"userId,omitempty"`
+	// IdempotencyKey is the key the client created the order with.
+	IdempotencyKey string         `json:"idempotencyKey,omitempty"`
+	History        []StatusChange `json:"history"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
+}
+
+// Item is a product of an order at the price it was bought at.
+type Item struct {
+	ProductId string  `json:"productId"`
+	Name      string  `json:"name"`
+	Quantity  int     `json:"quantity"`
+	UnitPrice float64 `json:"unitPrice"`
+	Total     float64 `json:"total"`
+}
+
+// StatusChange records when an order entered a status.
+type StatusChange struct {
+	Status string    `json:"status"`
+	At     time.Time `json:"at"`
+}
+
+// CreateRequest places an order for the items of a cart, or for the given
+// items.
+type CreateRequest struct {
+	CartId string        `json:"cartId,omitempty"`
+	Items  []ItemRequest `json:"items,omitempty" validate:"omitempty,dive"`
+	// IdempotencyKey is read from the Idempotency-Key header.
+	IdempotencyKey string `json:"-"`
+	// UserId is the subject of the token the order is placed with.
+	UserId string `json:"-"`
+}
+
+// ItemRequest asks for units of a product.
+type ItemRequest struct {
+	ProductId string `json:"productId" validate:"required"`
+	Quantity  int    `json:"quantity" validate:"min=1,max=10000"`
+}
+
+// StatusRequest moves an order to another status.
+type StatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=pending paid shipped cancelled"`
+}
+
+// OrderFilter selects a page of orders, newest first.
+type OrderFilter struct {
+	// Status only lists orders in this status.
+	Status   string `json:"status,omitempty" validate:"omitempty,oneof=pending paid shipped cancelled"`
+	Page     int    `json:"page,omitempty" validate:"omitempty,min=1"`
+	PageSize int    `json:"pageSize,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// Matches reports whether o passes the filters.
+func (f OrderFilter) Matches(o Order) bool {
+	return f.Status == "" || o.Status == f.Status
+}
+
+// ScopedKey is the idempotency key of userId, so two users sending the same
+// key do not share it. The empty key stays empty.
+func ScopedKey(userId, key string) string {
+	if key == "" {
+		return ""
+	}
+	return fmt.Sprintf("%d:%s:%s", len(userId), userId, key)
+}
+
+// PlacedBy reports whether userId placed o. Orders placed without a user were
+// placed by no one.
+func (o Order) PlacedBy(userId string) bool {
+	return o.UserId != "" && o.UserId == userId
+}
+
+// Newer orders o before other: newest first, by ID when created at once.
+func Newer(o, other Order) bool {
+	if !o.CreatedAt.Equal(other.CreatedAt) {
+		return o.CreatedAt.After(other.CreatedAt)
+	}
+	return o.Id < other.Id
+}
+
+// NewItem snapshots the units of a product at its price.
+func NewItem(productId, name string, quantity int, unitPrice float64) Item {
+	return Item{
+		ProductId: productId,
+		Name:      name,
+		Quantity:  quantity,
+		UnitPrice: unitPrice,
+		Total:     roundCents(unitPrice * float64(quantity)),
+	}
+}
+
+// Sum sets the total of o from its items.
+func (o *Order) Sum() {
+	o.Total = 0
+	for _, item := range o.Items {
+		o.Total = roundCents(o.Total + item.Total)
+	}
+}
+
+// Transition moves o to status, recording when it did.
+func (o *Order) Transition(status string, now time.Time) error {
+	if !slices.Contains(transitions[o.Status], status) {
+		return fmt.Errorf("%w: order %s is %s and cannot become %s", ErrInvalidTransition, o.Id, o.Status, status)
+	}
+	o.Status = status
+	o.UpdatedAt = now
+	o.History = append(o.History, StatusChange{Status: status, At: now})
+	return nil
+}
+
+// Merge returns the requested items with the units of repeated products
+// added together, in the order products first appear. It fails with
+// ErrInvalidQuantity when a product ends up with fewer than one or more than
+// product.MaxQuantity units.
+func Merge(items []ItemRequest) ([]ItemRequest, error) {
+	merged := make([]ItemRequest, 0, len(items))
+	for _, item := range items {
+		i := slices.IndexFunc(merged, func(m ItemRequest) bool { return m.ProductId == item.ProductId })
+		held := 0
+		if i >= 0 {
+			held = merged[i].Quantity
+		}
+		// compared by difference, so the sum cannot overflow
+		if item.Quantity < 1 || item.Quantity > product.MaxQuantity-held {
+			return nil, fmt.Errorf("%w: %d more units of %s", ErrInvalidQuantity, item.Quantity, item.ProductId)
+		}
+		if i < 0 {
+			merged = append(merged, item)
+			continue
+		}
+		merged[i].Quantity += item.Quantity
+	}
+	return merged, nil
+}
+
+// SameRequest reports whether req asks for what created o: for the same user,
+// the same cart or the same units of the same products.
+func (o Order) SameRequest(req CreateRequest) bool {
+	if req.UserId != o.UserId {
+		return false
+	}
+	if req.CartId != "" || o.CartId != "" {
+		return req.CartId == o.CartId
+	}
+	items, err := Merge(req.Items)
+	if err != nil || len(items) != len(o.Items) {
+		return false
+	}
+	for i, item := range items {
+		if item.ProductId != o.Items[i].ProductId || item.Quantity != o.Items[i].Quantity {
+			return false
+		}
+	}
+	return true
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,9 @@
+package order
+
+const (
+	ErrOrderNotFound               = "order/not-found"
+	ErrOrderInvalidData            = "order/invalid-data"
+	ErrOrderInvalidTransition      = "order/invalid-transition"
+	ErrOrderIdempotencyKeyRequired = "order/idempotency-key-required"
+	ErrOrderIdempotencyMismatch    = "order/idempotency-mismatch"
+)
@@ -0,0 +1,143 @@
+package jsonstore
+
+import (
+	"context"
+
+	"github.com/lucasti79/meli-interview/internal/infra/jsonstore"
+	"github.com/lucasti79/meli-interview/internal/order"
+	"github.com/lucasti79/meli-interview/internal/order/repository"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+)
+
+// Names of the secondary indexes registered by NewOrderStore.
+const (
+	StatusIndex         = "status"
+	IdempotencyKeyIndex = "idempotencyKey"
+)
+
+type orderRepository struct {
+	repo     *jsonstore.JSONRepository[order.Order]
+	byStatus *jsonstore.KeywordIndex[order.Order]
+	byKey    *jsonstore.KeywordIndex[order.Order]
+}
+
+// NewOrderStore opens the orders file keyed by order ID. Idempotency keys are
+// unique per user, which the store enforces on every write.
+func NewOrderStore(fileName string, opts ...jsonstore.Option) (*jsonstore.JSONRepository[order.Order], error) {
+	getID := func(entity order.Order) string {
+		return entity.Id
+	}
+	opts = append([]jsonstore.Option{
+		jsonstore.WithIndex(StatusIndex, jsonstore.NewKeywordIndex(func(o order.Order) string {
+			return o.Status
+		})),
+		jsonstore.WithIndex(IdempotencyKeyIndex, jsonstore.NewUniqueKeywordIndex(func(o order.Order) string {
+			return order.ScopedKey(o.UserId, o.IdempotencyKey)
+		})),
+	}, opts...)
+	return jsonstore.NewJSONRepository(fileName, getID, opts...)
+}
+
+func NewOrderRepository(fileName string, opts ...jsonstore.Option) (repository.Repository, error) {
+	repo, err := NewOrderStore(fileName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewOrderRepositoryFromStore(repo), nil
+}
+
+// NewOrderRepositoryFromStore wraps a store opened with NewOrderStore.
+func NewOrderRepositoryFromStore(repo *jsonstore.JSONRepository[order.Order]) repository.Repository {
+	r := &orderRepository{repo: repo}
+	r.byStatus, _ = repo.Index(StatusIndex).(*jsonstore.KeywordIndex[order.Order])
+	r.byKey, _ = repo.Index(IdempotencyKeyIndex).(*jsonstore.KeywordIndex[order.Order])
+	return r
+}
+
+func (r *orderRepository) GetAll(filters order.OrderFilter) ([]order.Order, int, error) {
+	return r.GetAllWithContext(context.Background(), filters)
+}
+
+// GetAllWithContext returns a page of the orders matching filters, newest
+// first.
+func (r *orderRepository) GetAllWithContext(ctx context.Context, filters order.OrderFilter) ([]order.Order, int, error) {
+	var narrow jsonstore.Narrow
+	if r.byStatus != nil && filters.Status != "" {
+		narrow = func() *jsonstore.Bitmap { return r.byStatus.Lookup(filters.Status) }
+	}
+
+	orders := make([]order.Order, 0, filters.PageSize)
+	total, err := r.repo.FindAllIndexedPaginatedWithContext(ctx, narrow, filters.Matches, order.Newer, filters.Page, filters.PageSize, func(o order.Order) error {
+		orders = append(orders, o)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
+
+func (r *orderRepository) GetByID(orderId string) (*order.Order, error) {
+	return r.GetByIDWithContext(context.Background(), orderId)
+}
+
+func (r *orderRepository) GetByIDWithContext(ctx context.Context, orderId string) (*order.Order, error) {
+	o, err := r.repo.FindByIDWithContext(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (r *orderRepository) GetByIdempotencyKey(userId, key string) (*order.Order, error) {
+	return r.GetByIdempotencyKeyWithContext(context.Background(), userId, key)
+}
+
+func (r *orderRepository) GetByIdempotencyKeyWithContext(ctx context.Context, userId, key string) (*order.Order, error) {
+	scoped := order.ScopedKey(userId, key)
+	var narrow jsonstore.Narrow
+	if r.byKey != nil {
+		narrow = func() *jsonstore.Bitmap { return r.byKey.Lookup(scoped) }
+	}
+
+	var found *order.Order
+	err := r.repo.FindAllIndexedWithContext(ctx, narrow, func(o order.Order) bool {
+		return order.ScopedKey(o.UserId, o.IdempotencyKey) == scoped
+	}, func(o order.Order) error {
+		found = &o
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if key == "" || found == nil {
+		return nil, apperrors.ErrResourceNotExists
+	}
+	return found, nil
+}
+
+func (r *orderRepository) Create(o order.Order) error {
+	return r.repo.Save(o)
+}
+
+func (r *orderRepository) CreateWithContext(ctx context.Context, o order.Order) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return r.repo.Save(o)
+}
+
+func (r *orderRepository) Modify(orderId string, modify func(o order.Order) (order.Order, error)) (*order.Order, error) {
+	return r.ModifyWithContext(context.Background(), orderId, modify)
+}
+
+func (r *orderRepository) ModifyWithContext(ctx context.Context, orderId string, modify func(o order.Order) (order.Order, error)) (*order.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	o, err := r.repo.Modify(orderId, modify)
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
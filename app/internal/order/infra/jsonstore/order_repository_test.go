@@ -0,0 +1,70 @@
+package jsonstore_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lucasti79/meli-interview/internal/order"
+	"github.com/lucasti79/meli-interview/internal/order/infra/jsonstore"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderRepository_WritesAreReadBackAfterReopening(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "orders.jsonl")
+	repo, err := jsonstore.NewOrderRepository(fp)
+	require.NoError(t, err)
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Create(order.Order{Id: "o1", Status: order.StatusPending, IdempotencyKey: "k1", CreatedAt: now}))
+	require.NoError(t, repo.Create(order.Order{Id: "o2", Status: order.StatusPending, CreatedAt: now.Add(time.Minute)}))
+	require.NoError(t, repo.Create(order.Order{Id: "o3", Status: order.StatusPending, CreatedAt: now.Add(2 * time.Minute)}))
+	// idempotency keys are unique per user, orders without one are not checked
+	require.ErrorIs(t, repo.Create(order.Order{Id: "o4", IdempotencyKey: "k1"}), apperrors.ErrResourceAlreadyExists)
+	require.NoError(t, repo.Create(order.Order{Id: "o5", Status: order.StatusPaid, UserId: "u1", IdempotencyKey: "k1", CreatedAt: now.Add(-time.Minute)}))
+
+	updated, err := repo.Modify("o2", func(o order.Order) (order.Order, error) {
+		err := o.Transition(order.StatusPaid, now)
+		return o, err
+	})
+	require.NoError(t, err)
+	_, err = repo.Modify("o2", func(o order.Order) (order.Order, error) {
+		err := o.Transition(order.StatusPending, now)
+		return o, err
+	})
+	require.ErrorIs(t, err, order.ErrInvalidTransition)
+
+	reopened, err := jsonstore.NewOrderRepository(fp)
+	require.NoError(t, err)
+
+	got, err := reopened.GetByID("o2")
+	require.NoError(t, err)
+	require.Equal(t, *updated, *got)
+
+	byKey, err := reopened.GetByIdempotencyKey("", "k1")
+	require.NoError(t, err)
+	require.Equal(t, "o1", byKey.Id)
+	byKey, err = reopened.GetByIdempotencyKey("u1", "k1")
+	require.NoError(t, err)
+	require.Equal(t, "o5", byKey.Id)
+	_, err = reopened.GetByIdempotencyKey("u2", "k1")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+	_, err = reopened.GetByIdempotencyKey("", "k9")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+	_, err = reopened.GetByIdempotencyKey("", "")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+
+	pending, total, err := reopened.GetAll(order.OrderFilter{Status: order.StatusPending, Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	require.Equal(t, "o3", pending[0].Id, "newest first")
+	require.Equal(t, "o1", pending[1].Id)
+
+	all, total, err := reopened.GetAll(order.OrderFilter{Page: 2, PageSize: 2})
+	require.NoError(t, err)
+	require.Equal(t, 4, total)
+	require.Len(t, all, 2)
+	require.Equal(t, "o1", all[0].Id)
+	require.Equal(t, "o5", all[1].Id)
+}
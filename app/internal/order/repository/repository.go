@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/lucasti79/meli-interview/internal/order"
+)
+
+type Repository interface {
+	GetAll(filters order.OrderFilter) ([]order.Order, int, error)
+	GetAllWithContext(ctx context.Context, filters order.OrderFilter) ([]order.Order, int, error)
+	GetByID(orderId string) (*order.Order, error)
+	GetByIDWithContext(ctx context.Context, orderId string) (*order.Order, error)
+	// GetByIdempotencyKey returns the order userId placed with key. Keys are
+	// scoped to the user, so the orders of other users are never returned.
+	GetByIdempotencyKey(userId, key string) (*order.Order, error)
+	GetByIdempotencyKeyWithContext(ctx context.Context, userId, key string) (*order.Order, error)
+	// Create fails with apperrors.ErrResourceAlreadyExists when another order
+	// of the same user holds the same idempotency key.
+	Create(o order.Order) error
+	CreateWithContext(ctx context.Context, o order.Order) error
+	// Modify replaces an order with what modify returns from it, atomically.
+	// Errors from modify are returned as they are and nothing is written.
+	Modify(orderId string, modify func(o order.Order) (order.Order, error)) (*order.Order, error)
+	ModifyWithContext(ctx context.Context, orderId string, modify func(o order.Order) (order.Order, error)) (*order.Order, error)
+}
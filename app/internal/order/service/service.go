@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lucasti79/meli-interview/internal/cart"
+	"github.com/lucasti79/meli-interview/internal/order"
+	"github.com/lucasti79/meli-interview/internal/order/repository"
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+)
+
+var (
+	// ErrUnknownCart is returned when ordering a cart that does not exist.
+	ErrUnknownCart = fmt.Errorf("%w: unknown cart", apperrors.ErrValidation)
+	// ErrCartAndItems is returned when an order names both a cart and items.
+	ErrCartAndItems = fmt.Errorf("%w: order either a cart or items, not both", apperrors.ErrValidation)
+)
+
+// errUntracked stops the write of a product that does not track its stock:
+// there is nothing to decrement, only its price to read.
+var errUntracked = errors.New("product does not track its stock")
+
+// ProductStore decrements the stock of the products ordered. The product
+// repository satisfies it.
+type ProductStore interface {
+	ModifyWithContext(ctx context.Context, productId string, modify func(p product.Product) (product.Product, error)) (*product.Product, error)
+}
+
+// CartStore empties the carts orders are placed from. The cart repository
+// satisfies it.
+type CartStore interface {
+	ModifyWithContext(ctx context.Context, cartId string, modify func(c cart.Cart) (cart.Cart, error)) (*cart.Cart, error)
+}
+
+type service struct {
+	repo     repository.Repository
+	products ProductStore
+	carts    CartStore
+	now      func() time.Time
+}
+
+type Option func(*service)
+
+// WithCarts lets orders be placed from the carts of store.
+func WithCarts(store CartStore) Option {
+	return func(s *service) {
+		s.carts = store
+	}
+}
+
+// WithClock sets where the service reads the time orders are written at.
+func WithClock(now func() time.Time) Option {
+	return func(s *service) {
+		s.now = now
+	}
+}
+
+type Service interface {
+	// Create places an order, reporting false when req.IdempotencyKey already
+	// placed it and the existing order is returned instead.
+	Create(req order.CreateRequest) (*order.Order, bool, error)
+	CreateWithContext(ctx context.Context, req order.CreateRequest) (*order.Order, bool, error)
+	GetAll(filters order.OrderFilter) ([]order.Order, int, error)
+	GetAllWithContext(ctx context.Context, filters order.OrderFilter) ([]order.Order, int, error)
+	GetByID(orderId string) (*order.Order, error)
+	GetByIDWithContext(ctx context.Context, orderId string) (*order.Order, error)
+	UpdateStatus(orderId, status string) (*order.Order, error)
+	UpdateStatusWithContext(ctx context.Context, orderId, status string) (*order.Order, error)
+}
+
+func NewService(repo repository.Repository, products ProductStore, opts ...Option) Service {
+	s := &service{repo: repo, products: products, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *service) Create(req order.CreateRequest) (*order.Order, bool, error) {
+	return s.CreateWithContext(context.Background(), req)
+}
+
+// CreateWithContext takes the ordered units out of stock and stores the order
+// at the prices the products have now. An order placed from a cart takes its
+// items out of it, so the cart cannot be ordered twice. When any product
+// cannot be bought the units already taken are put back, as are the items of
+// the cart, and nothing is ordered.
+func (s *service) CreateWithContext(ctx context.Context, req order.CreateRequest) (*order.Order, bool, error) {
+	if existing, err := s.replay(ctx, req); existing != nil || err != nil {
+		return existing, false, err
+	}
+
+	requested, checkedOut, err := s.requestedItems(ctx, req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := s.now().UTC()
+	o := order.Order{
+		Id:             uuid.NewString(),
+		Status:         order.StatusPending,
+		Items:          make([]order.Item, 0, len(requested)),
+		CartId:         req.CartId,
+		UserId:         req.UserId,
+		IdempotencyKey: req.IdempotencyKey,
+		History:        []order.StatusChange{{Status: order.StatusPending, At: now}},
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	for _, item := range requested {
+		ordered, err := s.take(ctx, item)
+		if err != nil {
+			s.restock(o.Items)
+			s.refill(req.CartId, checkedOut)
+			return nil, false, err
+		}
+		o.Items = append(o.Items, ordered)
+	}
+	o.Sum()
+
+	if err := s.repo.CreateWithContext(ctx, o); err != nil {
+		s.restock(o.Items)
+		s.refill(req.CartId, checkedOut)
+		if errors.Is(err, apperrors.ErrResourceAlreadyExists) && req.IdempotencyKey != "" {
+			// a retry placed the same order while this one was taking stock
+			existing, err := s.replay(ctx, req)
+			return existing, false, err
+		}
+		return nil, false, err
+	}
+	return &o, true, nil
+}
+
+// replay returns the order req.IdempotencyKey already placed for the user of
+// req, if any. Keys are scoped to the user, so the orders of others are never
+// replayed.
+func (s *service) replay(ctx context.Context, req order.CreateRequest) (*order.Order, error) {
+	if req.IdempotencyKey == "" {
+		return nil, nil
+	}
+	existing, err := s.repo.GetByIdempotencyKeyWithContext(ctx, req.UserId, req.IdempotencyKey)
+	if errors.Is(err, apperrors.ErrResourceNotExists) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !existing.SameRequest(req) {
+		// the order is not named: the key is all the caller sent
+		return nil, fmt.Errorf("%w: %s", order.ErrIdempotencyMismatch, req.IdempotencyKey)
+	}
+	return existing, nil
+}
+
+// requestedItems returns the units req orders. When req names a cart they are
+// its items, which are taken out of it in the same write they are read in so
+// two orders cannot both get them; the cart items are returned too, to be put
+// back if the order fails.
+func (s *service) requestedItems(ctx context.Context, req order.CreateRequest) ([]order.ItemRequest, []cart.Item, error) {
+	items := req.Items
+	var checkedOut []cart.Item
+	if req.CartId != "" {
+		if len(req.Items) > 0 {
+			return nil, nil, ErrCartAndItems
+		}
+		if s.carts == nil {
+			return nil, nil, fmt.Errorf("%w: %s", ErrUnknownCart, req.CartId)
+		}
+		_, err := s.carts.ModifyWithContext(ctx, req.CartId, func(c cart.Cart) (cart.Cart, error) {
+			if len(c.Items) == 0 {
+				return c, order.ErrEmptyOrder
+			}
+			checkedOut = c.Items
+			c.Items = []cart.Item{}
+			c.UpdatedAt = s.now().UTC()
+			return c, nil
+		})
+		if errors.Is(err, apperrors.ErrResourceNotExists) {
+			return nil, nil, fmt.Errorf("%w: %s", ErrUnknownCart, req.CartId)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		items = make([]order.ItemRequest, 0, len(checkedOut))
+		for _, item := range checkedOut {
+			items = append(items, order.ItemRequest{ProductId: item.ProductId, Quantity: item.Quantity})
+		}
+	}
+	if len(items) == 0 {
+		return nil, nil, order.ErrEmptyOrder
+	}
+	merged, err := order.Merge(items)
+	if err != nil {
+		s.refill(req.CartId, checkedOut)
+		return nil, nil, err
+	}
+	return merged, checkedOut, nil
+}
+
+// refill puts the items taken out of a cart by an order that failed back in
+// it. Products added to the cart again meanwhile keep their newer item. Like
+// restock it does not use the context of the request.
+func (s *service) refill(cartId string, items []cart.Item) {
+	if len(items) == 0 {
+		return
+	}
+	_, err := s.carts.ModifyWithContext(context.Background(), cartId, func(c cart.Cart) (cart.Cart, error) {
+		for _, item := range items {
+			if !slices.ContainsFunc(c.Items, func(i cart.Item) bool { return i.ProductId == item.ProductId }) {
+				c.Items = append(c.Items, item)
+			}
+		}
+		c.UpdatedAt = s.now().UTC()
+		return c, nil
+	})
+	if err != nil {
+		log.Printf("order: putting back the items of cart %s failed: %v", cartId, err)
+	}
+}
+
+// take decrements the stock of the requested product and returns the item
+// at its current price. Products out of stock, with fewer units available
+// than requested or no longer in the catalog fail with
+// product.ErrNotAvailable. A quantity that is not positive fails with
+// order.ErrInvalidQuantity before the stock is touched, as taking it would add
+// units instead.
+func (s *service) take(ctx context.Context, item order.ItemRequest) (order.Item, error) {
+	if item.Quantity < 1 || item.Quantity > product.MaxQuantity {
+		return order.Item{}, fmt.Errorf("%w: %d units of %s", order.ErrInvalidQuantity, item.Quantity, item.ProductId)
+	}
+	var ordered order.Item
+	_, err := s.products.ModifyWithContext(ctx, item.ProductId, func(p product.Product) (product.Product, error) {
+		if !p.InStock {
+			return p, fmt.Errorf("%w: product %s is out of stock", product.ErrNotAvailable, p.Id)
+		}
+		ordered = order.NewItem(p.Id, p.Name, item.Quantity, p.Price)
+		if !p.TracksStock() {
+			return p, errUntracked
+		}
+		err := p.AdjustStock(product.StockAdjustment{Quantity: -item.Quantity})
+		return p, err
+	})
+	switch {
+	case err == nil, errors.Is(err, errUntracked):
+		return ordered, nil
+	case errors.Is(err, apperrors.ErrResourceNotExists):
+		return order.Item{}, fmt.Errorf("%w: product %s is no longer in the catalog", product.ErrNotAvailable, item.ProductId)
+	default:
+		return order.Item{}, err
+	}
+}
+
+// restock puts the units of items back in stock. It runs after the request
+// that took them failed or was cancelled, so it does not use its context.
+func (s *service) restock(items []order.Item) {
+	for _, item := range items {
+		_, err := s.products.ModifyWithContext(context.Background(), item.ProductId, func(p product.Product) (product.Product, error) {
+			if !p.TracksStock() {
+				return p, errUntracked
+			}
+			err := p.AdjustStock(product.StockAdjustment{Quantity: item.Quantity})
+			return p, err
+		})
+		if err != nil && !errors.Is(err, errUntracked) && !errors.Is(err, apperrors.ErrResourceNotExists) {
+			log.Printf("order: putting back %d units of product %s failed: %v", item.Quantity, item.ProductId, err)
+		}
+	}
+}
+
+func (s *service) GetAll(filters order.OrderFilter) ([]order.Order, int, error) {
+	return s.repo.GetAll(filters)
+}
+
+func (s *service) GetAllWithContext(ctx context.Context, filters order.OrderFilter) ([]order.Order, int, error) {
+	return s.repo.GetAllWithContext(ctx, filters)
+}
+
+func (s *service) GetByID(orderId string) (*order.Order, error) {
+	return s.repo.GetByID(orderId)
+}
+
+func (s *service) GetByIDWithContext(ctx context.Context, orderId string) (*order.Order, error) {
+	return s.repo.GetByIDWithContext(ctx, orderId)
+}
+
+func (s *service) UpdateStatus(orderId, status string) (*order.Order, error) {
+	return s.UpdateStatusWithContext(context.Background(), orderId, status)
+}
+
+// UpdateStatusWithContext moves the order to status. Cancelled orders put
+// their units back in stock.
+func (s *service) UpdateStatusWithContext(ctx context.Context, orderId, status string) (*order.Order, error) {
+	o, err := s.repo.ModifyWithContext(ctx, orderId, func(o order.Order) (order.Order, error) {
+		err := o.Transition(status, s.now().UTC())
+		return o, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if o.Status == order.StatusCancelled {
+		s.restock(o.Items)
+	}
+	return o, nil
+}
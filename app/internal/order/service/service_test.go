@@ -0,0 +1,375 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/lucasti79/meli-interview/internal/cart"
+	"github.com/lucasti79/meli-interview/internal/order"
+	"github.com/lucasti79/meli-interview/internal/order/infra/mocks"
+	"github.com/lucasti79/meli-interview/internal/order/service"
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// catalog answers product writes from products as the product store does:
+// errors from modify leave the product as it was.
+func catalog(products ...product.Product) (*mocks.ProductStoreMock, map[string]product.Product) {
+	stored := make(map[string]product.Product, len(products))
+	for _, p := range products {
+		stored[p.Id] = p
+	}
+	store := new(mocks.ProductStoreMock)
+	store.On("ModifyWithContext", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, productId string, modify func(product.Product) (product.Product, error)) (*product.Product, error) {
+			current, ok := stored[productId]
+			if !ok {
+				return nil, apperrors.ErrResourceNotExists
+			}
+			if current.Stock != nil {
+				current.Stock = product.Int(*current.Stock)
+			}
+			p, err := modify(current)
+			if err != nil {
+				return nil, err
+			}
+			stored[productId] = p
+			return &p, nil
+		}).Maybe()
+	return store, stored
+}
+
+// carts answers cart writes from carts as the cart store does: errors from
+// modify leave the cart as it was.
+func carts(stored map[string]cart.Cart) *mocks.CartStoreMock {
+	store := new(mocks.CartStoreMock)
+	store.On("ModifyWithContext", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, cartId string, modify func(cart.Cart) (cart.Cart, error)) (*cart.Cart, error) {
+			current, ok := stored[cartId]
+			if !ok {
+				return nil, apperrors.ErrResourceNotExists
+			}
+			current.Items = slices.Clone(current.Items)
+			c, err := modify(current)
+			if err != nil {
+				return nil, err
+			}
+			stored[cartId] = c
+			return &c, nil
+		}).Maybe()
+	return store
+}
+
+func stockOf(t *testing.T, stored map[string]product.Product, productId string) int {
+	t.Helper()
+	require.NotNil(t, stored[productId].Stock)
+	return *stored[productId].Stock
+}
+
+func newService(repo *mocks.RepositoryMock, products *mocks.ProductStoreMock, opts ...service.Option) service.Service {
+	opts = append(opts, service.WithClock(func() time.Time { return now }))
+	return service.NewService(repo, products, opts...)
+}
+
+func TestService_CreateWithContext_TakesStockAtCurrentPrices(t *testing.T) {
+	products, stored := catalog(
+		product.Product{Id: "p1", Name: "Mouse", Price: 10.5, InStock: true, Stock: product.Int(5)},
+		product.Product{Id: "p2", Name: "Lamp", Price: 30, InStock: true},
+	)
+	mockRepo := new(mocks.RepositoryMock)
+	svc := newService(mockRepo, products)
+
+	ctx := context.Background()
+	mockRepo.On("GetByIdempotencyKeyWithContext", ctx, "", "k1").Return(nil, apperrors.ErrResourceNotExists).Once()
+	mockRepo.On("CreateWithContext", ctx, mock.AnythingOfType("order.Order")).Return(nil).Once()
+
+	created, isNew, err := svc.CreateWithContext(ctx, order.CreateRequest{
+		Items: []order.ItemRequest{
+			{ProductId: "p1", Quantity: 1},
+			{ProductId: "p2", Quantity: 1},
+			{ProductId: "p1", Quantity: 2},
+		},
+		IdempotencyKey: "k1",
+	})
+
+	require.NoError(t, err)
+	assert.True(t, isNew)
+	assert.NotEmpty(t, created.Id)
+	assert.Equal(t, order.StatusPending, created.Status)
+	assert.Equal(t, []order.Item{
+		{ProductId: "p1", Name: "Mouse", Quantity: 3, UnitPrice: 10.5, Total: 31.5},
+		{ProductId: "p2", Name: "Lamp", Quantity: 1, UnitPrice: 30, Total: 30},
+	}, created.Items)
+	assert.Equal(t, 61.5, created.Total)
+	assert.Equal(t, []order.StatusChange{{Status: order.StatusPending, At: now}}, created.History)
+	assert.Equal(t, 2, stockOf(t, stored, "p1"))
+	assert.Nil(t, stored["p2"].Stock, "products that do not track their stock are left untouched")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_CreateWithContext_UnavailableProductsOrderNothing(t *testing.T) {
+	tests := []struct {
+		name string
+		item order.ItemRequest
+	}{
+		{name: "out of stock", item: order.ItemRequest{ProductId: "p2", Quantity: 1}},
+		{name: "not enough units", item: order.ItemRequest{ProductId: "p3", Quantity: 3}},
+		{name: "not in the catalog", item: order.ItemRequest{ProductId: "p9", Quantity: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products, stored := catalog(
+				product.Product{Id: "p1", Price: 10, InStock: true, Stock: product.Int(5)},
+				product.Product{Id: "p2", Price: 10, InStock: false},
+				product.Product{Id: "p3", Price: 10, InStock: true, Stock: product.Int(4), Reserved: 2},
+			)
+			mockRepo := new(mocks.RepositoryMock)
+			svc := newService(mockRepo, products)
+
+			_, _, err := svc.CreateWithContext(context.Background(), order.CreateRequest{
+				Items: []order.ItemRequest{{ProductId: "p1", Quantity: 2}, tt.item},
+			})
+
+			require.ErrorIs(t, err, product.ErrNotAvailable)
+			assert.Equal(t, 5, stockOf(t, stored, "p1"), "units taken before the failure are put back")
+			assert.Equal(t, 4, stockOf(t, stored, "p3"))
+			mockRepo.AssertNotCalled(t, "CreateWithContext", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestService_CreateWithContext_ReplaysIdempotencyKeys(t *testing.T) {
+	existing := &order.Order{Id: "o1", Status: order.StatusPaid, IdempotencyKey: "k1", Items: []order.Item{
+		{ProductId: "p1", Quantity: 2, UnitPrice: 10, Total: 20},
+	}}
+
+	t.Run("same request returns the order", func(t *testing.T) {
+		products, stored := catalog(product.Product{Id: "p1", Price: 10, InStock: true, Stock: product.Int(5)})
+		mockRepo := new(mocks.RepositoryMock)
+		svc := newService(mockRepo, products)
+
+		ctx := context.Background()
+		mockRepo.On("GetByIdempotencyKeyWithContext", ctx, "", "k1").Return(existing, nil).Once()
+
+		got, isNew, err := svc.CreateWithContext(ctx, order.CreateRequest{
+			Items:          []order.ItemRequest{{ProductId: "p1", Quantity: 1}, {ProductId: "p1", Quantity: 1}},
+			IdempotencyKey: "k1",
+		})
+
+		require.NoError(t, err)
+		assert.False(t, isNew)
+		assert.Equal(t, existing, got)
+		assert.Equal(t, 5, stockOf(t, stored, "p1"))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("different request is rejected", func(t *testing.T) {
+		products, _ := catalog()
+		mockRepo := new(mocks.RepositoryMock)
+		svc := newService(mockRepo, products)
+
+		ctx := context.Background()
+		mockRepo.On("GetByIdempotencyKeyWithContext", ctx, "", "k1").Return(existing, nil).Once()
+
+		_, _, err := svc.CreateWithContext(ctx, order.CreateRequest{
+			Items:          []order.ItemRequest{{ProductId: "p1", Quantity: 3}},
+			IdempotencyKey: "k1",
+		})
+
+		require.ErrorIs(t, err, order.ErrIdempotencyMismatch)
+		assert.NotContains(t, err.Error(), existing.Id, "does not tell the order placed with the key")
+	})
+
+	t.Run("same key of another user places a new order", func(t *testing.T) {
+		products, _ := catalog(product.Product{Id: "p1", Price: 10, InStock: true, Stock: product.Int(5)})
+		mockRepo := new(mocks.RepositoryMock)
+		svc := newService(mockRepo, products)
+
+		ctx := context.Background()
+		mockRepo.On("GetByIdempotencyKeyWithContext", ctx, "u2", "k1").Return(nil, apperrors.ErrResourceNotExists).Once()
+		mockRepo.On("CreateWithContext", ctx, mock.MatchedBy(func(o order.Order) bool { return o.UserId == "u2" })).Return(nil).Once()
+
+		got, isNew, err := svc.CreateWithContext(ctx, order.CreateRequest{
+			Items:          []order.ItemRequest{{ProductId: "p1", Quantity: 2}},
+			IdempotencyKey: "k1",
+			UserId:         "u2",
+		})
+
+		require.NoError(t, err)
+		assert.True(t, isNew)
+		assert.NotEqual(t, existing.Id, got.Id)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("retry placed concurrently returns its order", func(t *testing.T) {
+		products, stored := catalog(product.Product{Id: "p1", Price: 10, InStock: true, Stock: product.Int(5)})
+		mockRepo := new(mocks.RepositoryMock)
+		svc := newService(mockRepo, products)
+
+		ctx := context.Background()
+		mockRepo.On("GetByIdempotencyKeyWithContext", ctx, "", "k1").Return(nil, apperrors.ErrResourceNotExists).Once()
+		mockRepo.On("CreateWithContext", ctx, mock.AnythingOfType("order.Order")).Return(fmt.Errorf("%w: k1", apperrors.ErrResourceAlreadyExists)).Once()
+		mockRepo.On("GetByIdempotencyKeyWithContext", ctx, "", "k1").Return(existing, nil).Once()
+
+		got, isNew, err := svc.CreateWithContext(ctx, order.CreateRequest{
+			Items:          []order.ItemRequest{{ProductId: "p1", Quantity: 2}},
+			IdempotencyKey: "k1",
+		})
+
+		require.NoError(t, err)
+		assert.False(t, isNew)
+		assert.Equal(t, existing, got)
+		assert.Equal(t, 5, stockOf(t, stored, "p1"))
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestService_CreateWithContext_FromCart(t *testing.T) {
+	products, stored := catalog(product.Product{Id: "p1", Name: "Mouse", Price: 12, InStock: true, Stock: product.Int(5)})
+	mockRepo := new(mocks.RepositoryMock)
+	// the cart holds the price the product had when it was added
+	storedCarts := map[string]cart.Cart{"c1": {Id: "c1", Items: []cart.Item{{ProductId: "p1", Quantity: 2, Price: 10}}}}
+	svc := newService(mockRepo, products, service.WithCarts(carts(storedCarts)))
+
+	ctx := context.Background()
+	mockRepo.On("CreateWithContext", ctx, mock.MatchedBy(func(o order.Order) bool { return o.CartId == "c1" })).Return(nil).Once()
+
+	created, isNew, err := svc.CreateWithContext(ctx, order.CreateRequest{CartId: "c1"})
+
+	require.NoError(t, err)
+	assert.True(t, isNew)
+	assert.Equal(t, []order.Item{{ProductId: "p1", Name: "Mouse", Quantity: 2, UnitPrice: 12, Total: 24}}, created.Items)
+	assert.Equal(t, 3, stockOf(t, stored, "p1"))
+	assert.Empty(t, storedCarts["c1"].Items, "the ordered items are taken out of the cart")
+	assert.Equal(t, now, storedCarts["c1"].UpdatedAt)
+
+	// the same cart cannot be ordered again
+	_, _, err = svc.CreateWithContext(ctx, order.CreateRequest{CartId: "c1"})
+	require.ErrorIs(t, err, order.ErrEmptyOrder)
+	assert.Equal(t, 3, stockOf(t, stored, "p1"))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_CreateWithContext_FailedOrderPutsTheCartItemsBack(t *testing.T) {
+	products, stored := catalog(
+		product.Product{Id: "p1", Price: 10, InStock: true, Stock: product.Int(5)},
+		product.Product{Id: "p2", Price: 10, InStock: false},
+	)
+	items := []cart.Item{{ProductId: "p1", Quantity: 2, Price: 10}, {ProductId: "p2", Quantity: 1, Price: 10}}
+	storedCarts := map[string]cart.Cart{"c1": {Id: "c1", Items: items}}
+	svc := newService(new(mocks.RepositoryMock), products, service.WithCarts(carts(storedCarts)))
+
+	_, _, err := svc.CreateWithContext(context.Background(), order.CreateRequest{CartId: "c1"})
+
+	require.ErrorIs(t, err, product.ErrNotAvailable)
+	assert.Equal(t, items, storedCarts["c1"].Items)
+	assert.Equal(t, 5, stockOf(t, stored, "p1"))
+}
+
+func TestService_CreateWithContext_InvalidRequests(t *testing.T) {
+	tests := []struct {
+		name string
+		req  order.CreateRequest
+		want error
+	}{
+		{name: "no items", req: order.CreateRequest{}, want: order.ErrEmptyOrder},
+		{name: "empty cart", req: order.CreateRequest{CartId: "empty"}, want: order.ErrEmptyOrder},
+		{name: "unknown cart", req: order.CreateRequest{CartId: "c9"}, want: service.ErrUnknownCart},
+		{
+			name: "units adding up past the cap",
+			req:  order.CreateRequest{Items: []order.ItemRequest{{ProductId: "p1", Quantity: math.MaxInt/2 + 1}, {ProductId: "p1", Quantity: math.MaxInt/2 + 1}}},
+			want: order.ErrInvalidQuantity,
+		},
+		{name: "negative units", req: order.CreateRequest{Items: []order.ItemRequest{{ProductId: "p1", Quantity: -5}}}, want: order.ErrInvalidQuantity},
+		{name: "cart line over the cap", req: order.CreateRequest{CartId: "big"}, want: order.ErrInvalidQuantity},
+		{
+			name: "cart and items",
+			req:  order.CreateRequest{CartId: "c1", Items: []order.ItemRequest{{ProductId: "p1", Quantity: 1}}},
+			want: service.ErrCartAndItems,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products, _ := catalog()
+			mockCarts := carts(map[string]cart.Cart{
+				"empty": {Id: "empty", Items: []cart.Item{}},
+				"big":   {Id: "big", Items: []cart.Item{{ProductId: "p1", Quantity: product.MaxQuantity + 1}}},
+			})
+			svc := newService(new(mocks.RepositoryMock), products, service.WithCarts(mockCarts))
+
+			_, _, err := svc.CreateWithContext(context.Background(), tt.req)
+
+			require.ErrorIs(t, err, tt.want)
+			require.ErrorIs(t, err, apperrors.ErrValidation)
+		})
+	}
+}
+
+func TestService_UpdateStatusWithContext(t *testing.T) {
+	placed := order.Order{Id: "o1", Status: order.StatusPaid, Items: []order.Item{
+		{ProductId: "p1", Quantity: 2},
+		{ProductId: "p2", Quantity: 1},
+	}, History: []order.StatusChange{{Status: order.StatusPending}, {Status: order.StatusPaid}}}
+	modifying := func(_ context.Context, _ string, modify func(order.Order) (order.Order, error)) (*order.Order, error) {
+		stored := placed
+		stored.History = slices.Clone(placed.History)
+		o, err := modify(stored)
+		if err != nil {
+			return nil, err
+		}
+		return &o, nil
+	}
+
+	t.Run("shipping keeps the stock", func(t *testing.T) {
+		products, stored := catalog(product.Product{Id: "p1", InStock: true, Stock: product.Int(3)})
+		mockRepo := new(mocks.RepositoryMock)
+		svc := newService(mockRepo, products)
+		mockRepo.On("ModifyWithContext", mock.Anything, "o1", mock.Anything).Return(modifying).Once()
+
+		updated, err := svc.UpdateStatusWithContext(context.Background(), "o1", order.StatusShipped)
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusShipped, updated.Status)
+		assert.Equal(t, order.StatusChange{Status: order.StatusShipped, At: now}, updated.History[2])
+		assert.Equal(t, 3, stockOf(t, stored, "p1"))
+	})
+
+	t.Run("cancelling puts the units back", func(t *testing.T) {
+		products, stored := catalog(
+			product.Product{Id: "p1", InStock: true, Stock: product.Int(3)},
+			product.Product{Id: "p2", InStock: true},
+		)
+		mockRepo := new(mocks.RepositoryMock)
+		svc := newService(mockRepo, products)
+		mockRepo.On("ModifyWithContext", mock.Anything, "o1", mock.Anything).Return(modifying).Once()
+
+		updated, err := svc.UpdateStatusWithContext(context.Background(), "o1", order.StatusCancelled)
+
+		require.NoError(t, err)
+		assert.Equal(t, order.StatusCancelled, updated.Status)
+		assert.Equal(t, 5, stockOf(t, stored, "p1"))
+		assert.Nil(t, stored["p2"].Stock)
+	})
+
+	t.Run("invalid transition", func(t *testing.T) {
+		products, _ := catalog()
+		mockRepo := new(mocks.RepositoryMock)
+		svc := newService(mockRepo, products)
+		mockRepo.On("ModifyWithContext", mock.Anything, "o1", mock.Anything).Return(modifying).Once()
+
+		_, err := svc.UpdateStatusWithContext(context.Background(), "o1", order.StatusPending)
+
+		require.ErrorIs(t, err, order.ErrInvalidTransition)
+	})
+}
@@ -2,34 +2,57 @@ package api
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
 	chi "github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator"
+	"github.com/google/uuid"
 	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
 	"github.com/lucasti79/meli-interview/internal/product"
 	"github.com/lucasti79/meli-interview/internal/product/service"
 	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/lucasti79/meli-interview/pkg/web/request"
 	"github.com/lucasti79/meli-interview/pkg/web/response"
 )
 
 type Handler struct {
-	service   service.Service
-	validator *validator.Validate
+	service      service.Service
+	validator    *validator.Validate
+	priceBuckets []float64
 }
 
-func NewHandler(service service.Service) *Handler {
-	return &Handler{
-		service:   service,
-		validator: validator.New(),
+// Option customizes a Handler.
+type Option func(*Handler)
+
+// WithPriceBuckets sets the bounds of the priceRange facet.
+func WithPriceBuckets(bounds []float64) Option {
+	return func(h *Handler) {
+		h.priceBuckets = bounds
+	}
+}
+
+func NewHandler(service service.Service, opts ...Option) *Handler {
+	h := &Handler{
+		service:      service,
+		validator:    validator.New(),
+		priceBuckets: product.DefaultPriceBuckets,
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
 
 // GetAll godoc
 // @Summary List all products
-// @Description Get a list of all available products with optional filters
+// @Description Get a list of all available products with optional filters.
+// @Description Pages are addressed either by page or by the cursor returned as nextCursor.
+// @Description Without sort products are ordered by productId.
+// @Description facets adds bucket counts over every filtered product, not only the returned page.
 // @Tags products
 // @Accept  json
 // @Produce json
@@ -38,27 +61,152 @@ func NewHandler(service service.Service) *Handler {
 // @Success 204 "No content"
 // @Failure 400 {object} httpdto.ErrorResponse
 // @Failure 500 {object} httpdto.ErrorResponse
+// @Failure 504 {object} httpdto.ErrorResponse
 // @Router  /api/v1/products [get]
 func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	filters := filtersFromQuery(r.URL.Query())
+
+	if facets := r.URL.Query().Get("facets"); facets != "" {
+		filters.Facets = strings.Split(facets, ",")
+		filters.PriceBuckets = h.priceBuckets
+	}
+
+	// paginação default
+	filters.Page = 1
+	filters.PageSize = 10
+
+	if page := r.URL.Query().Get("page"); page != "" {
+		if p, err := strconv.Atoi(page); err == nil {
+			filters.Page = p
+		}
+	}
+	if size := r.URL.Query().Get("pageSize"); size != "" {
+		if s, err := strconv.Atoi(size); err == nil {
+			filters.PageSize = s
+		}
+	}
+
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		after, err := product.DecodeCursor(cursor)
+		if err == nil && after.Sort != filters.Sort {
+			err = fmt.Errorf("%w: issued for sort %q", product.ErrInvalidCursor, after.Sort)
+		}
+		if err == nil && r.URL.Query().Has("page") {
+			err = fmt.Errorf("%w: cannot be combined with page", product.ErrInvalidCursor)
+		}
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+				Code:    product.ErrProductInvalidCursor,
+				Message: err.Error(),
+				Status:  http.StatusText(http.StatusBadRequest),
+			})
+			return
+		}
+		filters.Cursor = cursor
+		filters.After = &after
+		filters.Page = 0
+	}
+
+	if err := h.validator.Struct(filters); err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    apperrors.ErrValidation.Error(),
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	pageSize := filters.PageSize
+	if filters.After != nil {
+		// one extra product tells whether another page follows
+		filters.PageSize++
+	}
+
+	products, total, err := h.service.GetAllWithContext(r.Context(), filters)
+	if err != nil {
+		writeInternalError(w, err)
+		return
+	}
+
+	if len(products) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	hasMore := filters.Page*pageSize < total
+	if filters.After != nil {
+		hasMore = len(products) > pageSize
+		products = products[:min(len(products), pageSize)]
+	}
+
+	result := ProductPaginatedResult{
+		Data:       products,
+		TotalCount: total,
+		Page:       filters.Page,
+		PageSize:   pageSize,
+	}
+	if len(filters.Facets) > 0 {
+		result.Facets, err = h.service.GetFacetsWithContext(r.Context(), filters)
+		if err != nil {
+			writeInternalError(w, err)
+			return
+		}
+	}
+	if hasMore {
+		result.NextCursor = product.NewCursor(products[len(products)-1], filters.Sort).Encode()
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+// filtersFromQuery reads the name, category, price, stock and sort filters
+// shared by GetAll and Export.
+func filtersFromQuery(query url.Values) product.ProductFilter {
 	filters := product.ProductFilter{
-		Name: r.URL.Query().Get("name"),
+		Name: query.Get("name"),
+		Sort: query.Get("sort"),
 	}
 
-	if cats := r.URL.Query().Get("categories"); cats != "" {
+	if cats := query.Get("categories"); cats != "" {
 		filters.Categories = strings.Split(cats, ",")
 	}
+	filters.IncludeSubcategories, _ = strconv.ParseBool(query.Get("includeSubcategories"))
 
-	if min := r.URL.Query().Get("minPrice"); min != "" {
+	if min := query.Get("minPrice"); min != "" {
 		filters.MinPrice, _ = strconv.ParseFloat(min, 64)
 	}
-	if max := r.URL.Query().Get("maxPrice"); max != "" {
+	if max := query.Get("maxPrice"); max != "" {
 		filters.MaxPrice, _ = strconv.ParseFloat(max, 64)
 	}
+	if inStock, err := strconv.ParseBool(query.Get("inStock")); err == nil {
+		filters.InStock = &inStock
+	}
+	return filters
+}
 
-	// paginação default
-	filters.Page = 1
-	filters.PageSize = 10
+// Search godoc
+// @Summary Search products
+// @Description Full text search over product name, description and category, ranked by relevance.
+// @Description Accents and case are ignored and partially typed words match by prefix.
+// @Tags products
+// @Produce json
+// @Param filters query product.SearchFilter true "Search filters"
+// @Success 200 {object} ProductPaginatedResult
+// @Success 204 "No content"
+// @Failure 400 {object} httpdto.ErrorResponse
+// @Failure 500 {object} httpdto.ErrorResponse
+// @Failure 504 {object} httpdto.ErrorResponse
+// @Router  /api/v1/products/search [get]
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	filters := product.SearchFilter{
+		Query:    strings.TrimSpace(r.URL.Query().Get("q")),
+		Page:     1,
+		PageSize: 10,
+	}
 
+	if cats := r.URL.Query().Get("categories"); cats != "" {
+		filters.Categories = strings.Split(cats, ",")
+	}
 	if page := r.URL.Query().Get("page"); page != "" {
 		if p, err := strconv.Atoi(page); err == nil {
 			filters.Page = p
@@ -79,13 +227,9 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	products, total, err := h.service.GetAllWithContext(r.Context(), filters)
+	products, total, err := h.service.SearchWithContext(r.Context(), filters)
 	if err != nil {
-		response.JSON(w, http.StatusInternalServerError, httpdto.ErrorResponse{
-			Code:    apperrors.ErrInternalError.Error(),
-			Message: "internal server error",
-			Status:  http.StatusText(http.StatusInternalServerError),
-		})
+		writeInternalError(w, err)
 		return
 	}
 
@@ -94,14 +238,12 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result := httpdto.PaginatedResult[product.Product]{
+	response.JSON(w, http.StatusOK, httpdto.PaginatedResult[product.Product]{
 		Data:       products,
 		TotalCount: total,
 		Page:       filters.Page,
 		PageSize:   filters.PageSize,
-	}
-
-	response.JSON(w, http.StatusOK, result)
+	})
 }
 
 // GetByID godoc
@@ -114,6 +256,7 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} httpdto.ErrorResponse
 // @Failure 404 {object} httpdto.ErrorResponse
 // @Failure 500 {object} httpdto.ErrorResponse
+// @Failure 504 {object} httpdto.ErrorResponse
 // @Router /api/v1/products/{productId} [get]
 func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 	productId := chi.URLParam(r, "productId")
@@ -137,14 +280,286 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 				Status:  http.StatusText(http.StatusNotFound),
 			})
 		default:
-			response.JSON(w, http.StatusInternalServerError, httpdto.ErrorResponse{
-				Code:    apperrors.ErrInternalError.Error(),
-				Message: "internal server error",
-				Status:  http.StatusText(http.StatusInternalServerError),
-			})
+			writeInternalError(w, err)
 		}
 		return
 	}
 
 	response.JSON(w, http.StatusOK, httpdto.Result[*product.Product]{Data: pr})
 }
+
+// Create godoc
+// @Summary Create a product
+// @Description Add a new product to the catalog. The product ID is generated when omitted
+// @Tags products
+// @Accept  json
+// @Produce json
+// @Param product body product.Product true "Product"
+// @Success 201 {object} ProductResult
+// @Failure 400 {object} httpdto.ErrorResponse
+// @Failure 401 {object} httpdto.ErrorResponse
+// @Failure 403 {object} httpdto.ErrorResponse
+// @Failure 409 {object} httpdto.ErrorResponse
+// @Failure 500 {object} httpdto.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router  /api/v1/products [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var pr product.Product
+	if err := request.JSON(r, &pr); err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    product.ErrProductInvalidData,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	if pr.Id == "" {
+		pr.Id = uuid.NewString()
+	}
+
+	if err := h.validator.Struct(pr); err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    product.ErrProductInvalidData,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	created, err := h.service.CreateWithContext(r.Context(), pr)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnknownCategory):
+			response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+				Code:    product.ErrProductInvalidCategory,
+				Message: err.Error(),
+				Status:  http.StatusText(http.StatusBadRequest),
+			})
+		case errors.Is(err, apperrors.ErrResourceAlreadyExists):
+			response.JSON(w, http.StatusConflict, httpdto.ErrorResponse{
+				Code:    product.ErrProductAlreadyExists,
+				Message: err.Error(),
+				Status:  http.StatusText(http.StatusConflict),
+			})
+		default:
+			writeInternalError(w, err)
+		}
+		return
+	}
+
+	w.Header().Set("Location", r.URL.Path+"/"+created.Id)
+	response.JSON(w, http.StatusCreated, httpdto.Result[*product.Product]{Data: created})
+}
+
+// Update godoc
+// @Summary Replace a product
+// @Description Replace every field of an existing product. A version other than 0 must be the current one.
+// @Description The reserved units are kept, and stock cannot drop below them.
+// @Tags products
+// @Accept  json
+// @Produce json
+// @Param productId path string true "Product ID"
+// @Param product body product.Product true "Product"
+// @Success 200 {object} ProductResult
+// @Failure 400 {object} httpdto.ErrorResponse
+// @Failure 401 {object} httpdto.ErrorResponse
+// @Failure 403 {object} httpdto.ErrorResponse
+// @Failure 404 {object} httpdto.ErrorResponse
+// @Failure 409 {object} httpdto.ErrorResponse
+// @Failure 500 {object} httpdto.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router  /api/v1/products/{productId} [put]
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	productId := chi.URLParam(r, "productId")
+	if productId == "" {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    product.ErrProductInvalidID,
+			Message: "product ID is required",
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	var pr product.Product
+	if err := request.JSON(r, &pr); err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    product.ErrProductInvalidData,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	if pr.Id != "" && pr.Id != productId {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    product.ErrProductInvalidID,
+			Message: "product ID in body does not match the path",
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+	pr.Id = productId
+
+	h.save(w, r, pr)
+}
+
+// Patch godoc
+// @Summary Partially update a product
+// @Description Apply a JSON Merge Patch (RFC 7396) to an existing product
+// @Tags products
+// @Accept  application/merge-patch+json
+// @Produce json
+// @Param productId path string true "Product ID"
+// @Param patch body object true "JSON Merge Patch document"
+// @Success 200 {object} ProductResult
+// @Failure 400 {object} httpdto.ErrorResponse
+// @Failure 401 {object} httpdto.ErrorResponse
+// @Failure 403 {object} httpdto.ErrorResponse
+// @Failure 404 {object} httpdto.ErrorResponse
+// @Failure 409 {object} httpdto.ErrorResponse
+// @Failure 500 {object} httpdto.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router  /api/v1/products/{productId} [patch]
+func (h *Handler) Patch(w http.ResponseWriter, r *http.Request) {
+	productId := chi.URLParam(r, "productId")
+	if productId == "" {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    product.ErrProductInvalidID,
+			Message: "product ID is required",
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	pr, err := h.service.GetByIDWithContext(r.Context(), productId)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrResourceNotExists):
+			response.JSON(w, http.StatusNotFound, httpdto.ErrorResponse{
+				Code:    product.ErrProductNotFound,
+				Message: err.Error(),
+				Status:  http.StatusText(http.StatusNotFound),
+			})
+		default:
+			writeInternalError(w, err)
+		}
+		return
+	}
+
+	if err := request.MergePatch(r, pr); err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    product.ErrProductInvalidData,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	if pr.Id != productId {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    product.ErrProductInvalidID,
+			Message: "product ID cannot be changed",
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	h.save(w, r, *pr)
+}
+
+// save validates and persists a full replacement of an existing product,
+// shared by Update and Patch.
+func (h *Handler) save(w http.ResponseWriter, r *http.Request, pr product.Product) {
+	if err := h.validator.Struct(pr); err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    product.ErrProductInvalidData,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	updated, err := h.service.UpdateWithContext(r.Context(), pr)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnknownCategory):
+			response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+				Code:    product.ErrProductInvalidCategory,
+				Message: err.Error(),
+				Status:  http.StatusText(http.StatusBadRequest),
+			})
+		case errors.Is(err, apperrors.ErrResourceNotExists):
+			response.JSON(w, http.StatusNotFound, httpdto.ErrorResponse{
+				Code:    product.ErrProductNotFound,
+				Message: err.Error(),
+				Status:  http.StatusText(http.StatusNotFound),
+			})
+		default:
+			writeStockError(w, err)
+		}
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httpdto.Result[*product.Product]{Data: updated})
+}
+
+// Delete godoc
+// @Summary Delete a product
+// @Description Remove a product from the catalog
+// @Tags products
+// @Produce json
+// @Param productId path string true "Product ID"
+// @Success 204 "No content"
+// @Failure 400 {object} httpdto.ErrorResponse
+// @Failure 401 {object} httpdto.ErrorResponse
+// @Failure 403 {object} httpdto.ErrorResponse
+// @Failure 404 {object} httpdto.ErrorResponse
+// @Failure 500 {object} httpdto.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router  /api/v1/products/{productId} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	productId := chi.URLParam(r, "productId")
+	if productId == "" {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    product.ErrProductInvalidID,
+			Message: "product ID is required",
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	if err := h.service.DeleteWithContext(r.Context(), productId); err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrResourceNotExists):
+			response.JSON(w, http.StatusNotFound, httpdto.ErrorResponse{
+				Code:    product.ErrProductNotFound,
+				Message: err.Error(),
+				Status:  http.StatusText(http.StatusNotFound),
+			})
+		default:
+			writeInternalError(w, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeInternalError answers an unexpected service error, telling apart the
+// requests whose context ended before the catalog could be read.
+func writeInternalError(w http.ResponseWriter, err error) {
+	if status, body, ok := httpdto.ContextError(err); ok {
+		response.JSON(w, status, body)
+		return
+	}
+	response.JSON(w, http.StatusInternalServerError, httpdto.ErrorResponse{
+		Code:    apperrors.ErrInternalError.Error(),
+		Message: "internal server error",
+		Status:  http.StatusText(http.StatusInternalServerError),
+	})
+}
@@ -1,14 +1,20 @@
 package api_test
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
 	"github.com/lucasti79/meli-interview/internal/product"
 	"github.com/lucasti79/meli-interview/internal/product/api"
 	"github.com/lucasti79/meli-interview/internal/product/infra/mocks"
+	"github.com/lucasti79/meli-interview/internal/product/service"
 	"github.com/lucasti79/meli-interview/pkg/apperrors"
 	"github.com/lucasti79/meli-interview/pkg/testutil"
 	"github.com/stretchr/testify/mock"
@@ -70,6 +76,309 @@ func TestGetAll_ValidationError(t *testing.T) {
 	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
 }
 
+func TestGetAll_PassesSortToService(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetAllWithContext", mock.Anything, mock.MatchedBy(func(f product.ProductFilter) bool {
+		return f.Sort == "-price"
+	})).Return([]product.Product{{Id: "1", Name: "Prod1", Category: "Cat1", Price: 10}}, 1, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?sort=-price", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAll(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAll_PassesIncludeSubcategoriesToService(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetAllWithContext", mock.Anything, mock.MatchedBy(func(f product.ProductFilter) bool {
+		return f.IncludeSubcategories && len(f.Categories) == 1 && f.Categories[0] == "Audio"
+	})).Return([]product.Product{{Id: "1", Name: "Prod1", Category: "Headphones", Price: 10}}, 1, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?categories=Audio&includeSubcategories=true", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAll(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAll_PassesInStockToService(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetAllWithContext", mock.Anything, mock.MatchedBy(func(f product.ProductFilter) bool {
+		return f.InStock != nil && !*f.InStock
+	})).Return([]product.Product{{Id: "1", Name: "Prod1", Category: "Cat1", Price: 10}}, 1, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?inStock=false", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAll(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAll_InvalidSort(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?sort=color", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAll(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetAll_OffsetPageReturnsNextCursor(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetAllWithContext", mock.Anything, mock.AnythingOfType("product.ProductFilter")).
+		Return([]product.Product{{Id: "1", Name: "Prod1", Category: "Cat1", Price: 10}}, 3, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?sort=price&pageSize=1", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAll(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body api.ProductPaginatedResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, 1, body.Page)
+
+	cursor, err := product.DecodeCursor(body.NextCursor)
+	require.NoError(t, err)
+	require.Equal(t, product.Cursor{Sort: "price", Num: 10, Id: "1"}, cursor)
+}
+
+func TestGetAll_UnsortedOffsetPageReturnsCursorByID(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetAllWithContext", mock.Anything, mock.MatchedBy(func(f product.ProductFilter) bool {
+		return f.Sort == "" && f.Page == 1 && f.After == nil
+	})).Return([]product.Product{{Id: "a1", Name: "Prod1", Category: "Cat1", Price: 10}}, 3, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?pageSize=1", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAll(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body api.ProductPaginatedResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+
+	// unsorted pages are ordered by ID, the order the cursor resumes in
+	cursor, err := product.DecodeCursor(body.NextCursor)
+	require.NoError(t, err)
+	require.Equal(t, product.Cursor{Id: "a1"}, cursor)
+	require.True(t, product.ProductFilter{}.IsAfter(product.Product{Id: "b2"}, cursor))
+	require.False(t, product.ProductFilter{}.IsAfter(product.Product{Id: "a0"}, cursor))
+}
+
+func TestGetAll_CursorFetchesOneExtraToDetectNextPage(t *testing.T) {
+	after := product.Cursor{Sort: "-price", Num: 50, Id: "1"}
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetAllWithContext", mock.Anything, mock.MatchedBy(func(f product.ProductFilter) bool {
+		return f.PageSize == 3 && f.After != nil && *f.After == after
+	})).Return([]product.Product{
+		{Id: "2", Name: "Prod2", Category: "Cat1", Price: 40},
+		{Id: "3", Name: "Prod3", Category: "Cat1", Price: 30},
+		{Id: "4", Name: "Prod4", Category: "Cat1", Price: 20},
+	}, 4, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?sort=-price&pageSize=2&cursor="+after.Encode(), nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAll(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body api.ProductPaginatedResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Data, 2)
+	require.Equal(t, 0, body.Page)
+	require.Equal(t, 2, body.PageSize)
+	require.Equal(t, product.NewCursor(body.Data[1], "-price").Encode(), body.NextCursor)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAll_CursorOnLastPageHasNoNextCursor(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetAllWithContext", mock.Anything, mock.AnythingOfType("product.ProductFilter")).
+		Return([]product.Product{{Id: "2", Name: "Prod2", Category: "Cat1", Price: 40}}, 2, nil)
+
+	h := api.NewHandler(mockService)
+
+	cursor := product.Cursor{Id: "1"}.Encode()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?pageSize=2&cursor="+cursor, nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAll(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotContains(t, rec.Body.String(), "nextCursor")
+}
+
+func TestGetAll_InvalidCursor(t *testing.T) {
+	sorted := product.Cursor{Sort: "price", Id: "1"}.Encode()
+	cases := map[string]string{
+		"malformed":      "cursor=not-a-cursor",
+		"different sort": "sort=-price&cursor=" + sorted,
+		"with page":      "sort=price&page=2&cursor=" + sorted,
+	}
+
+	for name, query := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := api.NewHandler(new(mocks.ServiceMock))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/products?"+query, nil)
+			rec := httptest.NewRecorder()
+
+			h.GetAll(rec, req)
+
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+			require.Contains(t, rec.Body.String(), product.ErrProductInvalidCursor)
+		})
+	}
+}
+
+func TestGetAll_WithFacets(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetAllWithContext", mock.Anything, mock.AnythingOfType("product.ProductFilter")).
+		Return([]product.Product{{Id: "1", Name: "Prod1", Category: "Cat1", Price: 10}}, 1, nil)
+	mockService.On("GetFacetsWithContext", mock.Anything, mock.MatchedBy(func(f product.ProductFilter) bool {
+		return len(f.Facets) == 2 && f.Name == "Prod" && len(f.PriceBuckets) == 1 && f.PriceBuckets[0] == 25
+	})).Return(product.Facets{product.FacetInStock: {{Value: "true", Count: 1}, {Value: "false", Count: 0}}}, nil)
+
+	h := api.NewHandler(mockService, api.WithPriceBuckets([]float64{25}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?name=Prod&facets=inStock,priceRange", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAll(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body api.ProductPaginatedResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, 1, body.Facets[product.FacetInStock][0].Count)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAll_WithoutFacetsDoesNotCountThem(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetAllWithContext", mock.Anything, mock.AnythingOfType("product.ProductFilter")).
+		Return([]product.Product{{Id: "1", Name: "Prod1", Category: "Cat1", Price: 10}}, 1, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAll(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotContains(t, rec.Body.String(), "facets")
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAll_InvalidFacet(t *testing.T) {
+	h := api.NewHandler(new(mocks.ServiceMock))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?facets=category,color", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAll(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetAll_FacetsError(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetAllWithContext", mock.Anything, mock.AnythingOfType("product.ProductFilter")).
+		Return([]product.Product{{Id: "1", Name: "Prod1", Category: "Cat1", Price: 10}}, 1, nil)
+	mockService.On("GetFacetsWithContext", mock.Anything, mock.AnythingOfType("product.ProductFilter")).
+		Return(product.Facets(nil), errors.New("internal error"))
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?facets=category", nil)
+	rec := httptest.NewRecorder()
+
+	h.GetAll(rec, req)
+
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestSearch_Success(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("SearchWithContext", mock.Anything, product.SearchFilter{
+		Query: "fone", Categories: []string{"Eletrônicos"}, Page: 2, PageSize: 5,
+	}).Return([]product.Product{{Id: "1", Name: "Fone", Category: "Eletrônicos", Price: 10}}, 6, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/search?q=+fone+&categories=Eletr%C3%B4nicos&page=2&pageSize=5", nil)
+	rec := httptest.NewRecorder()
+
+	h.Search(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSearch_MissingQuery(t *testing.T) {
+	h := api.NewHandler(new(mocks.ServiceMock))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/search?q=%20", nil)
+	rec := httptest.NewRecorder()
+
+	h.Search(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSearch_NoResults(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("SearchWithContext", mock.Anything, mock.AnythingOfType("product.SearchFilter")).
+		Return([]product.Product{}, 0, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/search?q=nada", nil)
+	rec := httptest.NewRecorder()
+
+	h.Search(rec, req)
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestSearch_ServiceError(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("SearchWithContext", mock.Anything, mock.AnythingOfType("product.SearchFilter")).
+		Return(nil, 0, errors.New("internal error"))
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/search?q=fone", nil)
+	rec := httptest.NewRecorder()
+
+	h.Search(rec, req)
+
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
 func TestGetAll_ServiceError(t *testing.T) {
 	mockService := new(mocks.ServiceMock)
 	mockService.On("GetAllWithContext", mock.Anything, mock.AnythingOfType("product.ProductFilter")).
@@ -89,6 +398,52 @@ func TestGetAll_ServiceError(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestGetAll_ContextErrors(t *testing.T) {
+	tests := map[string]struct {
+		err    error
+		status int
+		code   string
+	}{
+		"canceled": {err: context.Canceled, status: httpdto.StatusClientClosedRequest, code: apperrors.ErrRequestCanceled.Error()},
+		"timeout":  {err: fmt.Errorf("scan: %w", context.DeadlineExceeded), status: http.StatusGatewayTimeout, code: apperrors.ErrRequestTimeout.Error()},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockService := new(mocks.ServiceMock)
+			mockService.On("GetAllWithContext", mock.Anything, mock.AnythingOfType("product.ProductFilter")).
+				Return(nil, 0, tt.err)
+
+			h := api.NewHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+			rec := httptest.NewRecorder()
+
+			h.GetAll(rec, req)
+
+			require.Equal(t, tt.status, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetByID_Timeout(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetByIDWithContext", mock.Anything, "123").
+		Return(nil, context.DeadlineExceeded)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/123", nil)
+	req = testutil.WithUrlParam(t, req, "productId", "123")
+	rec := httptest.NewRecorder()
+
+	h.GetByID(rec, req)
+
+	require.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
 func TestGetByID_Success(t *testing.T) {
 	mockService := new(mocks.ServiceMock)
 	mockService.On("GetByIDWithContext", mock.Anything, "123").
@@ -163,3 +518,523 @@ func TestGetByID_MissingID(t *testing.T) {
 
 	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
 }
+
+func TestCreate_Success(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("CreateWithContext", mock.Anything, mock.MatchedBy(func(p product.Product) bool {
+		return p.Id != "" && p.Name == "Prod1"
+	})).Return(func(_ context.Context, p product.Product) (*product.Product, error) {
+		return &p, nil
+	})
+
+	h := api.NewHandler(mockService)
+
+	body := `{"name":"Prod1","category":"Cat1","price":10,"rating":4.5}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	h.Create(rec, req)
+
+	resp := rec.Result()
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.Contains(t, resp.Header.Get("Location"), "/api/v1/products/")
+	mockService.AssertExpectations(t)
+}
+
+func TestCreate_InvalidData(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	h := api.NewHandler(mockService)
+
+	body := `{"name":"","category":"Cat1","price":-1,"rating":7}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	h.Create(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), product.ErrProductInvalidData)
+	mockService.AssertNotCalled(t, "CreateWithContext", mock.Anything, mock.Anything)
+}
+
+func TestCreate_MalformedBody(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products", strings.NewReader(`{"name":`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	h.Create(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), product.ErrProductInvalidData)
+}
+
+func TestCreate_AlreadyExists(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("CreateWithContext", mock.Anything, mock.AnythingOfType("product.Product")).
+		Return(nil, apperrors.ErrResourceAlreadyExists)
+
+	h := api.NewHandler(mockService)
+
+	body := `{"productId":"1","name":"Prod1","category":"Cat1","price":10}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	h.Create(rec, req)
+
+	require.Equal(t, http.StatusConflict, rec.Code)
+	require.Contains(t, rec.Body.String(), product.ErrProductAlreadyExists)
+	mockService.AssertExpectations(t)
+}
+
+func TestCreate_UnknownCategory(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("CreateWithContext", mock.Anything, mock.AnythingOfType("product.Product")).
+		Return(nil, fmt.Errorf("%w: Cat1", service.ErrUnknownCategory))
+
+	h := api.NewHandler(mockService)
+
+	body := `{"productId":"1","name":"Prod1","category":"Cat1","price":10}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	h.Create(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), product.ErrProductInvalidCategory)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdate_Success(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	expected := product.Product{Id: "123", Name: "Prod123", Category: "Cat1", Price: 10}
+	stored := expected
+	stored.Version = 1
+	mockService.On("UpdateWithContext", mock.Anything, expected).Return(&stored, nil)
+
+	h := api.NewHandler(mockService)
+
+	body := `{"name":"Prod123","category":"Cat1","price":10}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/products/123", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = testutil.WithUrlParam(t, req, "productId", "123")
+	rec := httptest.NewRecorder()
+
+	h.Update(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"version":1`)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdate_MismatchedID(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	h := api.NewHandler(mockService)
+
+	body := `{"productId":"456","name":"Prod123","category":"Cat1","price":10}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/products/123", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = testutil.WithUrlParam(t, req, "productId", "123")
+	rec := httptest.NewRecorder()
+
+	h.Update(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), product.ErrProductInvalidID)
+}
+
+func TestUpdate_NotFound(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("UpdateWithContext", mock.Anything, mock.AnythingOfType("product.Product")).
+		Return(nil, apperrors.ErrResourceNotExists)
+
+	h := api.NewHandler(mockService)
+
+	body := `{"name":"Prod123","category":"Cat1","price":10}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/products/123", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = testutil.WithUrlParam(t, req, "productId", "123")
+	rec := httptest.NewRecorder()
+
+	h.Update(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestPatch_Success(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetByIDWithContext", mock.Anything, "123").
+		Return(&product.Product{Id: "123", Name: "Prod123", Category: "Cat1", Price: 10, InStock: true}, nil)
+	patched := product.Product{Id: "123", Name: "Prod123", Category: "Cat1", Price: 8.5, InStock: false}
+	mockService.On("UpdateWithContext", mock.Anything, patched).Return(&patched, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/products/123", strings.NewReader(`{"price":8.5,"inStock":false}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req = testutil.WithUrlParam(t, req, "productId", "123")
+	rec := httptest.NewRecorder()
+
+	h.Patch(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"price":8.5`)
+	mockService.AssertExpectations(t)
+}
+
+func TestPatch_CannotChangeID(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetByIDWithContext", mock.Anything, "123").
+		Return(&product.Product{Id: "123", Name: "Prod123", Category: "Cat1", Price: 10}, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/products/123", strings.NewReader(`{"productId":"999"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req = testutil.WithUrlParam(t, req, "productId", "123")
+	rec := httptest.NewRecorder()
+
+	h.Patch(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), product.ErrProductInvalidID)
+	mockService.AssertNotCalled(t, "UpdateWithContext", mock.Anything, mock.Anything)
+}
+
+func TestPatch_InvalidResult(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetByIDWithContext", mock.Anything, "123").
+		Return(&product.Product{Id: "123", Name: "Prod123", Category: "Cat1", Price: 10}, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/products/123", strings.NewReader(`{"name":null}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req = testutil.WithUrlParam(t, req, "productId", "123")
+	rec := httptest.NewRecorder()
+
+	h.Patch(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), product.ErrProductInvalidData)
+}
+
+func TestPatch_NotFound(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetByIDWithContext", mock.Anything, "123").
+		Return(nil, apperrors.ErrResourceNotExists)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/products/123", strings.NewReader(`{"price":1}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req = testutil.WithUrlParam(t, req, "productId", "123")
+	rec := httptest.NewRecorder()
+
+	h.Patch(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestDelete_Success(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("DeleteWithContext", mock.Anything, "123").Return(nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/products/123", nil)
+	req = testutil.WithUrlParam(t, req, "productId", "123")
+	rec := httptest.NewRecorder()
+
+	h.Delete(rec, req)
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestDelete_NotFound(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("DeleteWithContext", mock.Anything, "123").Return(apperrors.ErrResourceNotExists)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/products/123", nil)
+	req = testutil.WithUrlParam(t, req, "productId", "123")
+	rec := httptest.NewRecorder()
+
+	h.Delete(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Contains(t, rec.Body.String(), product.ErrProductNotFound)
+	mockService.AssertExpectations(t)
+}
+
+func TestExport_StreamsCSVWithHeader(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("ExportWithContext", mock.Anything, mock.MatchedBy(func(f product.ProductFilter) bool {
+		return f.Sort == "-price" && len(f.Categories) == 1 && f.Categories[0] == "Books"
+	}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			handler := args.Get(2).(func(product.Product) error)
+			_ = handler(product.Product{Id: "1", Name: "Go, the book", Category: "Books", Price: 39.9, InStock: true, Stock: product.Int(10), Reserved: 2, Rating: product.Float(4.5), Reviews: 3})
+			_ = handler(product.Product{Id: "2", Name: "Notebook", Category: "Books", Price: 5, OriginalPrice: product.Float(7.5)})
+		}).
+		Return(nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/export?categories=Books&sort=-price", nil)
+	rec := httptest.NewRecorder()
+
+	h.Export(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="products.csv"`, rec.Header().Get("Content-Disposition"))
+	require.Equal(t, "productId,name,description,price,originalPrice,category,image,inStock,stock,reserved,rating,reviews\n"+
+		"1,\"Go, the book\",,39.9,,Books,,true,10,2,4.5,3\n"+
+		"2,Notebook,,5,7.5,Books,,false,,0,,0\n", rec.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestExport_JSONLWithoutProductsIsEmpty(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("ExportWithContext", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/export?format=jsonl", nil)
+	rec := httptest.NewRecorder()
+
+	h.Export(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+	require.Empty(t, rec.Body.String())
+}
+
+func TestExport_ErrorBeforeFirstProductIsReported(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("ExportWithContext", mock.Anything, mock.Anything, mock.Anything).Return(context.DeadlineExceeded)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/export", nil)
+	rec := httptest.NewRecorder()
+
+	h.Export(rec, req)
+
+	require.Equal(t, http.StatusGatewayTimeout, rec.Code)
+}
+
+func TestExport_RejectsUnknownFormatAndSort(t *testing.T) {
+	h := api.NewHandler(new(mocks.ServiceMock))
+
+	for _, target := range []string{"/api/v1/products/export?format=xlsx", "/api/v1/products/export?sort=color"} {
+		rec := httptest.NewRecorder()
+		h.Export(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+}
+
+func TestImport_DecodesCSVRowsWithLineNumbers(t *testing.T) {
+	body := "name,price,category,inStock,productId\n" +
+		"Mouse,25.5,Electronics,true,p1\n" +
+		"\"Multi\nline\",abc,Electronics,maybe,p2\n"
+
+	mockService := new(mocks.ServiceMock)
+	mockService.On("ImportWithContext", mock.Anything, mock.Anything, product.ImportOptions{Mode: product.ImportUpsert, DryRun: true}).
+		Return(product.ImportReport{Mode: product.ImportUpsert, DryRun: true, Rows: 2, Created: 1, Failed: 1,
+			Errors: []product.ImportError{{Line: 3, Field: "price", Message: "must be a number"}}}, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/import?mode=upsert&dryRun=true", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	rec := httptest.NewRecorder()
+
+	h.Import(rec, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	var result api.ImportResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	require.Equal(t, 1, result.Data.Failed)
+	require.Equal(t, 3, result.Data.Errors[0].Line)
+
+	rows := mockService.Calls[0].Arguments.Get(1).([]product.ImportRow)
+	require.Equal(t, []product.ImportRow{
+		{Line: 2, Product: product.Product{Id: "p1", Name: "Mouse", Price: 25.5, Category: "Electronics", InStock: true}},
+		{Line: 3, Product: product.Product{Id: "p2", Name: "Multi\nline", Category: "Electronics"}, Errors: []product.ImportError{
+			{Line: 3, Field: "price", Message: "must be a number"},
+			{Line: 3, Field: "inStock", Message: "must be true or false"},
+		}},
+	}, rows)
+}
+
+func TestImport_DecodesJSONLAndAppliesValidFiles(t *testing.T) {
+	body := `{"productId":"p1","name":"Mouse","price":25,"category":"Electronics"}` + "\n\n" +
+		`{"productId":"p2","name":"Mug","price":"cheap","category":"Home"}` + "\n" +
+		`not json`
+
+	mockService := new(mocks.ServiceMock)
+	mockService.On("ImportWithContext", mock.Anything, mock.Anything, product.ImportOptions{Mode: product.ImportInsert}).
+		Return(product.ImportReport{Mode: product.ImportInsert, Rows: 1, Created: 1, Errors: []product.ImportError{}}, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/import?format=jsonl", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	h.Import(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	rows := mockService.Calls[0].Arguments.Get(1).([]product.ImportRow)
+	require.Len(t, rows, 3)
+	require.Equal(t, 1, rows[0].Line)
+	require.Empty(t, rows[0].Errors)
+	require.Equal(t, []product.ImportError{{Line: 3, Field: "price", Message: "must be a number"}}, rows[1].Errors)
+	require.Equal(t, 4, rows[2].Line)
+	require.Len(t, rows[2].Errors, 1)
+	require.Empty(t, rows[2].Errors[0].Field)
+}
+
+func TestImport_RejectsUnreadableFiles(t *testing.T) {
+	cases := []struct {
+		name        string
+		target      string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{"unknown format", "/api/v1/products/import", "application/json", "{}", http.StatusBadRequest},
+		{"unknown mode", "/api/v1/products/import?mode=merge", "text/csv", "name\n", http.StatusBadRequest},
+		{"unknown column", "/api/v1/products/import", "text/csv", "name,colour\nMouse,red\n", http.StatusBadRequest},
+		{"too large", "/api/v1/products/import", "text/csv", "name\n" + strings.Repeat("x", 11<<20), http.StatusRequestEntityTooLarge},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			h := api.NewHandler(new(mocks.ServiceMock))
+
+			req := httptest.NewRequest(http.MethodPost, c.target, strings.NewReader(c.body))
+			req.Header.Set("Content-Type", c.contentType)
+			rec := httptest.NewRecorder()
+
+			h.Import(rec, req)
+
+			require.Equal(t, c.wantStatus, rec.Code)
+			var body httpdto.ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			require.NotEmpty(t, body.Code)
+		})
+	}
+}
+
+func TestGetStock_Success(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetByIDWithContext", mock.Anything, "123").
+		Return(&product.Product{Id: "123", Name: "Prod123", InStock: true, Stock: product.Int(10), Reserved: 3, Version: 4}, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/123/stock", nil)
+	req = testutil.WithUrlParam(t, req, "productId", "123")
+	rec := httptest.NewRecorder()
+
+	h.GetStock(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body api.StockResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, product.StockLevel{
+		ProductId: "123", Stock: product.Int(10), Reserved: 3, Available: 7, InStock: true, Version: 4,
+	}, body.Data)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetStock_NotFound(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetByIDWithContext", mock.Anything, "123").
+		Return(nil, apperrors.ErrResourceNotExists)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/123/stock", nil)
+	req = testutil.WithUrlParam(t, req, "productId", "123")
+	rec := httptest.NewRecorder()
+
+	h.GetStock(rec, req)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAdjustStock_Success(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("AdjustStockWithContext", mock.Anything, "123", product.StockAdjustment{Quantity: -2, Version: product.Int(4)}).
+		Return(&product.Product{Id: "123", InStock: true, Stock: product.Int(8), Version: 5}, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products/123/stock", strings.NewReader(`{"quantity":-2,"version":4}`))
+	req.Header.Set("Content-Type", "application/json")
+	req = testutil.WithUrlParam(t, req, "productId", "123")
+	rec := httptest.NewRecorder()
+
+	h.AdjustStock(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"available":8`)
+	require.Contains(t, rec.Body.String(), `"version":5`)
+	mockService.AssertExpectations(t)
+}
+
+func TestAdjustStock_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		err    error
+		status int
+		code   string
+	}{
+		{name: "malformed body", body: `{"quantity":`, status: http.StatusBadRequest, code: product.ErrProductInvalidData},
+		{name: "empty adjustment", body: `{"version":4}`, status: http.StatusBadRequest, code: product.ErrProductInvalidData},
+		{name: "not found", body: `{"quantity":1}`, err: apperrors.ErrResourceNotExists, status: http.StatusNotFound, code: product.ErrProductNotFound},
+		{name: "stale version", body: `{"quantity":1,"version":3}`, err: product.ErrVersionConflict, status: http.StatusConflict, code: product.ErrProductVersionConflict},
+		{name: "not available", body: `{"reserved":5}`, err: product.ErrNotAvailable, status: http.StatusConflict, code: product.ErrProductNotAvailable},
+		{name: "negative reserved", body: `{"reserved":-5}`, err: fmt.Errorf("%w: reserved cannot be negative", apperrors.ErrValidation), status: http.StatusBadRequest, code: product.ErrProductInvalidData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ServiceMock)
+			if tt.err != nil {
+				mockService.On("AdjustStockWithContext", mock.Anything, "123", mock.AnythingOfType("product.StockAdjustment")).
+					Return(nil, tt.err)
+			}
+
+			h := api.NewHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/products/123/stock", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req = testutil.WithUrlParam(t, req, "productId", "123")
+			rec := httptest.NewRecorder()
+
+			h.AdjustStock(rec, req)
+
+			require.Equal(t, tt.status, rec.Code)
+			var body httpdto.ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			require.Equal(t, tt.code, body.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
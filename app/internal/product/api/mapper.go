@@ -6,11 +6,23 @@ import "github.com/lucasti79/meli-interview/internal/product"
 type ProductPaginatedResult struct {
 	Data       []product.Product `json:"data"`
 	TotalCount int               `json:"totalCount"`
-	Page       int               `json:"page"`
+	Page       int               `json:"page,omitempty"`
 	PageSize   int               `json:"pageSize"`
+	NextCursor string            `json:"nextCursor,omitempty"`
+	Facets     product.Facets    `json:"facets,omitempty"`
 }
 
 // swagger:model ProductResult
 type ProductResult struct {
 	Data *product.Product `json:"data"`
 }
+
+// swagger:model ImportResult
+type ImportResult struct {
+	Data product.ImportReport `json:"data"`
+}
+
+// swagger:model StockResult
+type StockResult struct {
+	Data product.StockLevel `json:"data"`
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	chi "github.com/go-chi/chi/v5"
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/lucasti79/meli-interview/pkg/web/request"
+	"github.com/lucasti79/meli-interview/pkg/web/response"
+)
+
+// GetStock godoc
+// @Summary Get the stock of a product
+// @Description Units in stock, reserved and available to sell, and the version to send back with an adjustment.
+// @Description stock is null for products whose stock is not tracked.
+// @Tags products
+// @Produce json
+// @Param productId path string true "Product ID"
+// @Success 200 {object} StockResult
+// @Failure 404 {object} httpdto.ErrorResponse
+// @Failure 500 {object} httpdto.ErrorResponse
+// @Failure 504 {object} httpdto.ErrorResponse
+// @Router /api/v1/products/{productId}/stock [get]
+func (h *Handler) GetStock(w http.ResponseWriter, r *http.Request) {
+	pr, err := h.service.GetByIDWithContext(r.Context(), chi.URLParam(r, "productId"))
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrResourceNotExists):
+			response.JSON(w, http.StatusNotFound, httpdto.ErrorResponse{
+				Code:    product.ErrProductNotFound,
+				Message: err.Error(),
+				Status:  http.StatusText(http.StatusNotFound),
+			})
+		default:
+			writeInternalError(w, err)
+		}
+		return
+	}
+
+	response.JSON(w, http.StatusOK, StockResult{Data: pr.StockLevel()})
+}
+
+// AdjustStock godoc
+// @Summary Adjust the stock of a product
+// @Description Add quantity to the units in stock and reserved to the units reserved; negative values remove units.
+// @Description The adjustment is applied atomically. When version is set it must be the current version of the product, or the adjustment is rejected with product/version-conflict.
+// @Description Adjustments leaving fewer units in stock than reserved are rejected with product/not-available. Adjusting a product whose stock is not tracked starts tracking it from 0.
+// @Tags products
+// @Accept  json
+// @Produce json
+// @Param productId path string true "Product ID"
+// @Param adjustment body product.StockAdjustment true "Stock adjustment"
+// @Success 200 {object} StockResult
+// @Failure 400 {object} httpdto.ErrorResponse
+// @Failure 401 {object} httpdto.ErrorResponse
+// @Failure 403 {object} httpdto.ErrorResponse
+// @Failure 404 {object} httpdto.ErrorResponse
+// @Failure 409 {object} httpdto.ErrorResponse
+// @Failure 500 {object} httpdto.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /api/v1/products/{productId}/stock [post]
+func (h *Handler) AdjustStock(w http.ResponseWriter, r *http.Request) {
+	var adj product.StockAdjustment
+	if err := request.JSON(r, &adj); err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    product.ErrProductInvalidData,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+	if adj.Quantity == 0 && adj.Reserved == 0 {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    product.ErrProductInvalidData,
+			Message: "quantity or reserved is required",
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	pr, err := h.service.AdjustStockWithContext(r.Context(), chi.URLParam(r, "productId"), adj)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrResourceNotExists):
+			response.JSON(w, http.StatusNotFound, httpdto.ErrorResponse{
+				Code:    product.ErrProductNotFound,
+				Message: err.Error(),
+				Status:  http.StatusText(http.StatusNotFound),
+			})
+		default:
+			writeStockError(w, err)
+		}
+		return
+	}
+
+	response.JSON(w, http.StatusOK, StockResult{Data: pr.StockLevel()})
+}
+
+// writeStockError answers the errors of writes that change the stock or
+// version of a product, and any other error as writeInternalError does.
+func writeStockError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, product.ErrVersionConflict):
+		response.JSON(w, http.StatusConflict, httpdto.ErrorResponse{
+			Code:    product.ErrProductVersionConflict,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusConflict),
+		})
+	case errors.Is(err, product.ErrNotAvailable):
+		response.JSON(w, http.StatusConflict, httpdto.ErrorResponse{
+			Code:    product.ErrProductNotAvailable,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusConflict),
+		})
+	case errors.Is(err, apperrors.ErrValidation):
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    product.ErrProductInvalidData,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+	default:
+		writeInternalError(w, err)
+	}
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/lucasti79/meli-interview/pkg/web/response"
+)
+
+// maxImportSize bounds the size of an import file, which is read whole
+// before any product is written.
+const maxImportSize = 10 << 20
+
+// exportFlushEvery is how many products are written between flushes of an
+// export, so clients receive it as it is read.
+const exportFlushEvery = 100
+
+var contentTypes = map[string]string{
+	product.FormatCSV:   "text/csv; charset=utf-8",
+	product.FormatJSONL: "application/x-ndjson",
+}
+
+// formatFromContentType maps the media type of an import file to its format.
+func formatFromContentType(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return product.FormatCSV
+	case "application/x-ndjson", "application/jsonl", "application/jsonlines":
+		return product.FormatJSONL
+	}
+	return ""
+}
+
+// Export godoc
+// @Summary Export products
+// @Description Stream every product matching the filters as CSV or JSON Lines, ordered by sort or by product ID.
+// @Description CSV files start with a header naming the columns. Pagination parameters are ignored.
+// @Tags products
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "csv (default) or jsonl"
+// @Param filters query product.ProductFilter false "Product filters"
+// @Success 200 {file} file
+// @Failure 400 {object} httpdto.ErrorResponse
+// @Failure 401 {object} httpdto.ErrorResponse
+// @Failure 403 {object} httpdto.ErrorResponse
+// @Failure 500 {object} httpdto.ErrorResponse
+// @Failure 504 {object} httpdto.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router  /api/v1/products/export [get]
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = product.FormatCSV
+	}
+	contentType, ok := contentTypes[format]
+	if !ok {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    apperrors.ErrValidation.Error(),
+			Message: fmt.Sprintf("unknown export format %q: use csv or jsonl", format),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	filters := filtersFromQuery(r.URL.Query())
+	if err := h.validator.Struct(filters); err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    apperrors.ErrValidation.Error(),
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	// the status is only sent with the first product, so errors reading the
+	// first page can still be answered as such
+	var out product.Writer
+	begin := func() error {
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="products.%s"`, format))
+		w.WriteHeader(http.StatusOK)
+		var err error
+		out, err = product.NewWriter(w, format)
+		return err
+	}
+	flusher, _ := w.(http.Flusher)
+
+	written := 0
+	err := h.service.ExportWithContext(r.Context(), filters, func(p product.Product) error {
+		if out == nil {
+			if err := begin(); err != nil {
+				return err
+			}
+		}
+		if err := out.Write(p); err != nil {
+			return err
+		}
+		if written++; written%exportFlushEvery == 0 {
+			if err := out.Flush(); err != nil {
+				return err
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		return nil
+	})
+	if err == nil && out == nil {
+		err = begin()
+	}
+	if err != nil {
+		if out == nil {
+			writeInternalError(w, err)
+			return
+		}
+		// the client sees the export end early
+		log.Printf("export: stopped after %d products: %v", written, err)
+		return
+	}
+	if err := out.Flush(); err != nil {
+		log.Printf("export: %v", err)
+	}
+}
+
+// Import godoc
+// @Summary Import products
+// @Description Create or replace products from a CSV or JSON Lines file, in the columns and fields of the export.
+// @Description Every row is checked before any is written: when a row has errors none is written and they are reported by line and field. When writing a row fails the rows written before it are rolled back.
+// @Description In insert mode products whose ID exists are rejected; in upsert mode they are replaced, keeping their reserved units, and the stock of a row cannot be below them. Products without an ID get a new one.
+// @Description A product written to while the import runs fails it with 409.
+// @Tags products
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param format query string false "csv or jsonl, taken from the Content-Type when omitted"
+// @Param mode query string false "insert (default) or upsert"
+// @Param dryRun query bool false "Check the file without writing any product"
+// @Param file body string true "CSV or JSON Lines file"
+// @Success 200 {object} ImportResult
+// @Failure 400 {object} httpdto.ErrorResponse
+// @Failure 401 {object} httpdto.ErrorResponse
+// @Failure 403 {object} httpdto.ErrorResponse
+// @Failure 409 {object} httpdto.ErrorResponse
+// @Failure 413 {object} httpdto.ErrorResponse
+// @Failure 422 {object} ImportResult
+// @Failure 500 {object} httpdto.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router  /api/v1/products/import [post]
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
+	opts := product.ImportOptions{Mode: r.URL.Query().Get("mode")}
+	if opts.Mode == "" {
+		opts.Mode = product.ImportInsert
+	}
+	if opts.Mode != product.ImportInsert && opts.Mode != product.ImportUpsert {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    apperrors.ErrValidation.Error(),
+			Message: fmt.Sprintf("unknown import mode %q: use insert or upsert", opts.Mode),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+	opts.DryRun, _ = strconv.ParseBool(r.URL.Query().Get("dryRun"))
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = formatFromContentType(r.Header.Get("Content-Type"))
+	}
+	if _, ok := contentTypes[format]; !ok {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    product.ErrProductInvalidImport,
+			Message: "unknown import format: send text/csv or application/x-ndjson, or set format to csv or jsonl",
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	rows, err := product.DecodeImport(http.MaxBytesReader(w, r.Body, maxImportSize), format)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			response.JSON(w, http.StatusRequestEntityTooLarge, httpdto.ErrorResponse{
+				Code:    product.ErrProductInvalidImport,
+				Message: fmt.Sprintf("import files are limited to %d bytes", tooLarge.Limit),
+				Status:  http.StatusText(http.StatusRequestEntityTooLarge),
+			})
+		default:
+			response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+				Code:    product.ErrProductInvalidImport,
+				Message: err.Error(),
+				Status:  http.StatusText(http.StatusBadRequest),
+			})
+		}
+		return
+	}
+
+	report, err := h.service.ImportWithContext(r.Context(), rows, opts)
+	if err != nil {
+		writeStockError(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	if report.Failed > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+	response.JSON(w, status, ImportResult{Data: report})
+}
@@ -0,0 +1,213 @@
+package product
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSVColumns are the columns of a product CSV file, in the order they are
+// exported. Imports match them by name in any order, ignoring case.
+var CSVColumns = []string{
+	"productId", "name", "description", "price", "originalPrice",
+	"category", "image", "inStock", "stock", "reserved", "rating", "reviews",
+}
+
+// CSVRecord returns the fields of p in the order of CSVColumns. A missing
+// originalPrice, stock or rating is left empty.
+func (p Product) CSVRecord() []string {
+	stock := ""
+	if p.Stock != nil {
+		stock = strconv.Itoa(*p.Stock)
+	}
+	return []string{
+		p.Id, p.Name, p.Description, formatFloat(p.Price), formatOptional(p.OriginalPrice),
+		p.Category, p.Image, strconv.FormatBool(p.InStock), stock, strconv.Itoa(p.Reserved),
+		formatOptional(p.Rating), strconv.Itoa(p.Reviews),
+	}
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func formatOptional(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return formatFloat(*v)
+}
+
+// setCSVField parses value into the field of p named by column. Empty values
+// leave the field at its zero value, or unset for optional fields.
+func setCSVField(p *Product, column, value string) error {
+	value = strings.TrimSpace(value)
+	var err error
+	switch column {
+	case "productId":
+		p.Id = value
+	case "name":
+		p.Name = value
+	case "description":
+		p.Description = value
+	case "category":
+		p.Category = value
+	case "image":
+		p.Image = value
+	case "price":
+		p.Price, err = parseFloat(value)
+	case "originalPrice":
+		p.OriginalPrice, err = parseOptional(value)
+	case "rating":
+		p.Rating, err = parseOptional(value)
+	case "reviews":
+		p.Reviews, err = parseInt(value)
+	case "reserved":
+		p.Reserved, err = parseInt(value)
+	case "stock":
+		if value != "" {
+			var stock int
+			stock, err = parseInt(value)
+			p.Stock = &stock
+		}
+	case "inStock":
+		if value != "" {
+			p.InStock, err = strconv.ParseBool(value)
+			if err != nil {
+				err = errors.New("must be true or false")
+			}
+		}
+	}
+	return err
+}
+
+func parseFloat(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, errors.New("must be a number")
+	}
+	return v, nil
+}
+
+func parseInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.New("must be an integer")
+	}
+	return v, nil
+}
+
+func parseOptional(value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	v, err := parseFloat(value)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// DecodeCSV reads the products of a CSV file whose first line names its
+// columns. Fields that cannot be parsed are reported in the Errors of their
+// row; the error returned is for files that cannot be read at all, such as a
+// header naming an unknown column.
+func DecodeCSV(r io.Reader) ([]ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+
+	columns := make([]string, len(header))
+	seen := make(map[string]bool)
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		for _, column := range CSVColumns {
+			if strings.EqualFold(column, name) {
+				columns[i] = column
+			}
+		}
+		if columns[i] == "" {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidImport, name)
+		}
+		if seen[columns[i]] {
+			return nil, fmt.Errorf("%w: duplicated column %q", ErrInvalidImport, name)
+		}
+		seen[columns[i]] = true
+	}
+
+	var rows []ImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, ImportRow{Line: parseErr.StartLine, Errors: []ImportError{
+				{Line: parseErr.StartLine, Message: parseErr.Err.Error()},
+			}})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := ImportRow{Line: line}
+		if len(record) != len(columns) {
+			row.Errors = append(row.Errors, ImportError{
+				Line:    line,
+				Message: fmt.Sprintf("has %d fields, the header has %d", len(record), len(columns)),
+			})
+			rows = append(rows, row)
+			continue
+		}
+		for i, value := range record {
+			if err := setCSVField(&row.Product, columns[i], value); err != nil {
+				row.Errors = append(row.Errors, ImportError{Line: line, Field: columns[i], Message: err.Error()})
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+// CSVWriter writes products as CSV, starting with the CSVColumns header.
+type CSVWriter struct {
+	w *csv.Writer
+}
+
+func NewCSVWriter(w io.Writer) (*CSVWriter, error) {
+	cw := &CSVWriter{w: csv.NewWriter(w)}
+	if err := cw.w.Write(CSVColumns); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (cw *CSVWriter) Write(p Product) error {
+	return cw.w.Write(p.CSVRecord())
+}
+
+// Flush writes any buffered products to the underlying writer.
+func (cw *CSVWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
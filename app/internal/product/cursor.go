@@ -0,0 +1,73 @@
+package product
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the position of the last product of a page in the order given by
+// Sort. Clients receive it as an opaque string and send it back to get the
+// products that follow, which stays stable while the catalog changes.
+type Cursor struct {
+	Sort string  `json:"o,omitempty"`
+	Num  float64 `json:"n,omitempty"`
+	Text string  `json:"t,omitempty"`
+	Id   string  `json:"id"`
+}
+
+// NewCursor returns the position of p in the given sort order.
+func NewCursor(p Product, sort string) Cursor {
+	field, _ := ProductFilter{Sort: sort}.SortField()
+	num, text := p.SortKey(field)
+	return Cursor{Sort: sort, Num: num, Text: text, Id: p.Id}
+}
+
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(s string) (Cursor, error) {
+	var c Cursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &c); err != nil || c.Id == "" {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// Less orders products by the sort field of f, breaking ties by product ID so
+// pagination stays stable across requests.
+func (f ProductFilter) Less(a, b Product) bool {
+	return f.compare(NewCursor(a, f.Sort), NewCursor(b, f.Sort)) < 0
+}
+
+// IsAfter reports whether p comes after the cursor in the order of f, so a
+// listing resumes where the previous page stopped.
+func (f ProductFilter) IsAfter(p Product, cursor Cursor) bool {
+	return f.compare(NewCursor(p, f.Sort), cursor) > 0
+}
+
+func (f ProductFilter) compare(a, b Cursor) int {
+	field, desc := f.SortField()
+	var c int
+	if field == SortByName {
+		c = cmp.Compare(a.Text, b.Text)
+	} else {
+		c = cmp.Compare(a.Num, b.Num)
+	}
+	if desc {
+		c = -c
+	}
+	if c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Id, b.Id)
+}
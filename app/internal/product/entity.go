@@ -1,16 +1,81 @@
 package product
 
+import "strings"
+
+const (
+	SortByPrice    = "price"
+	SortByRating   = "rating"
+	SortByReviews  = "reviews"
+	SortByName     = "name"
+	SortByDiscount = "discount"
+)
+
+// Product is an item of the catalog. OriginalPrice and Rating are null for
+// products that are not discounted and have not been rated, which tells them
+// apart from a real zero.
+//
+// Stock is null for products whose stock is not tracked, which keep the
+// InStock they are written with; otherwise InStock is derived from the units
+// in Stock that are not Reserved. Version counts the writes to the product.
+//
+// Rating is rounded to two decimals; RatingSum keeps the stars of the reviews
+// added up, which Rating is derived from on every review written.
 type Product struct {
-	Id            string  `json:"productId"`
-	Description   string  `json:"description"`
-	Name          string  `json:"name"`
-	OriginalPrice float64 `json:"originalPrice"`
-	Price         float64 `json:"price"`
-	Category      string  `json:"category"`
-	Image         string  `json:"image"`
-	InStock       bool    `json:"inStock"`
-	Rating        float64 `json:"rating"`
-	Reviews       int     `json:"reviews"`
+	Id            string   `json:"productId" validate:"required"`
+	Description   string   `json:"description"`
+	Name          string   `json:"name" validate:"required"`
+	OriginalPrice *float64 `json:"originalPrice" validate:"omitempty,gtfield=Price"`
+	Price         float64  `json:"price" validate:"gt=0"`
+	Category      string   `json:"category" validate:"required"`
+	Image         string   `json:"image" validate:"omitempty,url"`
+	InStock       bool     `json:"inStock"`
+	Stock         *int     `json:"stock" validate:"omitempty,min=0"`
+	Reserved      int      `json:"reserved" validate:"omitempty,min=0,ltefield=Stock"`
+	Rating        *float64 `json:"rating" validate:"omitempty,min=0,max=5"`
+	Reviews       int      `json:"reviews" validate:"min=0"`
+	RatingSum     *float64 `json:"ratingSum,omitempty" validate:"omitempty,min=0"`
+	Version       int      `json:"version"`
+}
+
+// Float returns a pointer to v, to set the optional fields of a Product.
+func Float(v float64) *float64 {
+	return &v
+}
+
+// Int returns a pointer to v, to set the optional fields of a Product.
+func Int(v int) *int {
+	return &v
+}
+
+// DiscountPercentage returns how much cheaper Price is than OriginalPrice, from 0 to 100.
+func (p Product) DiscountPercentage() float64 {
+	if p.OriginalPrice == nil || *p.OriginalPrice <= 0 || p.Price >= *p.OriginalPrice {
+		return 0
+	}
+	return (*p.OriginalPrice - p.Price) / *p.OriginalPrice * 100
+}
+
+// SortKey returns the value products are ordered by for a sort field: a number
+// for numeric fields and the lowercased name for SortByName. Products without
+// a rating sort as rated 0.
+func (p Product) SortKey(field string) (float64, string) {
+	switch field {
+	case SortByPrice:
+		return p.Price, ""
+	case SortByRating:
+		if p.Rating == nil {
+			return 0, ""
+		}
+		return *p.Rating, ""
+	case SortByReviews:
+		return float64(p.Reviews), ""
+	case SortByDiscount:
+		return p.DiscountPercentage(), ""
+	case SortByName:
+		return 0, strings.ToLower(p.Name)
+	default:
+		return 0, ""
+	}
 }
 
 // swagger:parameters GetAll
@@ -19,12 +84,101 @@ type ProductFilter struct {
 	Name string `json:"name,omitempty" validate:"omitempty"`
 	// in: query
 	Categories []string `json:"categories,omitempty" validate:"omitempty"`
+	// Also match products in any subcategory of categories
+	// in: query
+	IncludeSubcategories bool `json:"includeSubcategories,omitempty"`
 	// in: query
 	MinPrice float64 `json:"minPrice,omitempty" validate:"omitempty"`
 	// in: query
 	MaxPrice float64 `json:"maxPrice,omitempty" validate:"omitempty"`
+	// Only products in stock (true) or out of stock (false)
+	// in: query
+	InStock *bool `json:"inStock,omitempty" validate:"omitempty"`
+	// in: query
+	Page int `json:"page,omitempty" validate:"omitempty,min=1"`
+	// in: query
+	PageSize int `json:"pageSize,omitempty" validate:"omitempty,min=1,max=100"`
+	// Sort field, prefixed with "-" for descending order: price, rating, reviews, name or discount
+	// in: query
+	Sort string `json:"sort,omitempty" validate:"omitempty,oneof=price -price rating -rating reviews -reviews name -name discount -discount"`
+	// Opaque position returned as nextCursor by a previous request. Replaces page;
+	// results are ordered by sort, or by product ID when sort is empty.
+	// in: query
+	Cursor string `json:"cursor,omitempty" validate:"omitempty"`
+	// After is the decoded Cursor.
+	After *Cursor `json:"-"`
+	// Facets to count over the filtered products: category, priceRange, inStock or rating
+	// in: query
+	Facets []string `json:"facets,omitempty" validate:"omitempty,dive,oneof=category priceRange inStock rating"`
+	// PriceBuckets are the bounds of the priceRange facet.
+	PriceBuckets []float64 `json:"-"`
+}
+
+// SortField splits Sort into the field name and whether the order is descending.
+func (f ProductFilter) SortField() (string, bool) {
+	if field, ok := strings.CutPrefix(f.Sort, "-"); ok {
+		return field, true
+	}
+	return f.Sort, false
+}
+
+// SearchField is a text field of a product matched by Search. Matches in
+// fields with a higher Weight rank higher.
+type SearchField struct {
+	Weight float64
+	Value  func(p Product) string
+}
+
+// SearchFields are the fields matched by Search: a match in the name outranks
+// one in the category, which outranks one in the description.
+var SearchFields = []SearchField{
+	{Weight: 3, Value: func(p Product) string { return p.Name }},
+	{Weight: 2, Value: func(p Product) string { return p.Category }},
+	{Weight: 1, Value: func(p Product) string { return p.Description }},
+}
+
+// swagger:parameters Search
+type SearchFilter struct {
+	// Free text matched against name, description and category, ignoring accents
+	// in: query
+	Query string `json:"q" validate:"required"`
+	// in: query
+	Categories []string `json:"categories,omitempty" validate:"omitempty"`
 	// in: query
 	Page int `json:"page,omitempty" validate:"omitempty,min=1"`
 	// in: query
 	PageSize int `json:"pageSize,omitempty" validate:"omitempty,min=1,max=100"`
 }
+
+// Matches reports whether p satisfies the name, category, price and stock
+// filters.
+// Names match by substring and categories by name, both ignoring case.
+func (f ProductFilter) Matches(p Product) bool {
+	if f.Name != "" && !strings.Contains(strings.ToLower(p.Name), strings.ToLower(f.Name)) {
+		return false
+	}
+
+	if len(f.Categories) > 0 {
+		found := false
+		for _, cat := range f.Categories {
+			if strings.EqualFold(cat, p.Category) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.MinPrice > 0 && p.Price < f.MinPrice {
+		return false
+	}
+	if f.MaxPrice > 0 && p.Price > f.MaxPrice {
+		return false
+	}
+	if f.InStock != nil && p.InStock != *f.InStock {
+		return false
+	}
+	return true
+}
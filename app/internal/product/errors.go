@@ -1,9 +1,13 @@
 package product
 
 const (
-	ErrProductNotFound      = "product/not-found"
-	ErrProductAlreadyExists = "product/already-exists"
-	ErrProductInvalidID     = "product/invalid-id"
-	ErrProductInvalidData   = "product/invalid-data"
-	ErrProductNotAvailable  = "product/not-available"
+	ErrProductNotFound        = "product/not-found"
+	ErrProductAlreadyExists   = "product/already-exists"
+	ErrProductInvalidID       = "product/invalid-id"
+	ErrProductInvalidData     = "product/invalid-data"
+	ErrProductNotAvailable    = "product/not-available"
+	ErrProductVersionConflict = "product/version-conflict"
+	ErrProductInvalidCursor   = "product/invalid-cursor"
+	ErrProductInvalidCategory = "product/invalid-category"
+	ErrProductInvalidImport   = "product/invalid-import"
 )
@@ -0,0 +1,132 @@
+package product
+
+import (
+	"math"
+	"sort"
+	"strconv"
+)
+
+const (
+	FacetCategory   = "category"
+	FacetPriceRange = "priceRange"
+	FacetInStock    = "inStock"
+	FacetRating     = "rating"
+)
+
+// DefaultPriceBuckets are the bounds of the price range facet when none are configured.
+var DefaultPriceBuckets = []float64{50, 100, 250, 500, 1000}
+
+// FacetBucket counts the products holding a facet value. Range buckets set
+// From (inclusive) and To (exclusive); an open end is left unset.
+type FacetBucket struct {
+	Value string   `json:"value"`
+	From  *float64 `json:"from,omitempty"`
+	To    *float64 `json:"to,omitempty"`
+	Count int      `json:"count"`
+}
+
+// Facets maps each requested facet to its buckets.
+type Facets map[string][]FacetBucket
+
+// FacetCounter accumulates the requested facets over a set of products.
+type FacetCounter struct {
+	fields       map[string]bool
+	priceBuckets []float64
+	categories   map[string]int
+	prices       []int
+	inStock      [2]int
+	ratings      [5]int
+}
+
+func NewFacetCounter(fields []string, priceBuckets []float64) *FacetCounter {
+	if len(priceBuckets) == 0 {
+		priceBuckets = DefaultPriceBuckets
+	}
+	c := &FacetCounter{
+		fields:       make(map[string]bool, len(fields)),
+		priceBuckets: priceBuckets,
+		categories:   make(map[string]int),
+		prices:       make([]int, len(priceBuckets)+1),
+	}
+	for _, f := range fields {
+		c.fields[f] = true
+	}
+	return c
+}
+
+func (c *FacetCounter) Add(p Product) {
+	c.categories[p.Category]++
+	c.prices[sort.Search(len(c.priceBuckets), func(i int) bool { return c.priceBuckets[i] > p.Price })]++
+	if p.InStock {
+		c.inStock[0]++
+	} else {
+		c.inStock[1]++
+	}
+	// a perfect 5 falls in the last bucket; products without a rating are in
+	// none
+	if p.Rating != nil {
+		c.ratings[min(max(int(math.Floor(*p.Rating)), 0), len(c.ratings)-1)]++
+	}
+}
+
+// Facets returns the buckets of the requested facets. Categories are ordered
+// by count; range buckets are always all present, in ascending order.
+func (c *FacetCounter) Facets() Facets {
+	facets := make(Facets, len(c.fields))
+
+	if c.fields[FacetCategory] {
+		buckets := make([]FacetBucket, 0, len(c.categories))
+		for name, count := range c.categories {
+			buckets = append(buckets, FacetBucket{Value: name, Count: count})
+		}
+		sort.Slice(buckets, func(i, j int) bool {
+			if buckets[i].Count != buckets[j].Count {
+				return buckets[i].Count > buckets[j].Count
+			}
+			return buckets[i].Value < buckets[j].Value
+		})
+		facets[FacetCategory] = buckets
+	}
+
+	if c.fields[FacetPriceRange] {
+		bounds := append(append([]float64{math.Inf(-1)}, c.priceBuckets...), math.Inf(1))
+		facets[FacetPriceRange] = rangeBuckets(bounds, c.prices)
+	}
+
+	if c.fields[FacetInStock] {
+		facets[FacetInStock] = []FacetBucket{
+			{Value: "true", Count: c.inStock[0]},
+			{Value: "false", Count: c.inStock[1]},
+		}
+	}
+
+	if c.fields[FacetRating] {
+		facets[FacetRating] = rangeBuckets([]float64{0, 1, 2, 3, 4, 5}, c.ratings[:])
+	}
+
+	return facets
+}
+
+// rangeBuckets labels counts[i] with the range from bounds[i] to bounds[i+1].
+// Infinite bounds are open ends.
+func rangeBuckets(bounds []float64, counts []int) []FacetBucket {
+	buckets := make([]FacetBucket, len(counts))
+	for i := range counts {
+		bucket := FacetBucket{Value: rangeBound(bounds[i]) + "-" + rangeBound(bounds[i+1]), Count: counts[i]}
+		if !math.IsInf(bounds[i], 0) {
+			bucket.From = &bounds[i]
+		}
+		if !math.IsInf(bounds[i+1], 0) {
+			bucket.To = &bounds[i+1]
+		}
+		buckets[i] = bucket
+	}
+	return buckets
+}
+
+func rangeBound(v float64) string {
+	if math.IsInf(v, 0) {
+		return "*"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,26 @@
+package boltstore_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/lucasti79/meli-interview/internal/infra/boltstore"
+	"github.com/lucasti79/meli-interview/internal/product"
+	ProductBoltRepository "github.com/lucasti79/meli-interview/internal/product/infra/boltstore"
+	"github.com/lucasti79/meli-interview/internal/product/repository"
+	"github.com/lucasti79/meli-interview/internal/product/repository/repositorytest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductRepository_Contract(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T, products []product.Product) repository.Repository {
+		db, err := boltstore.Open(filepath.Join(t.TempDir(), "catalog.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		store, err := ProductBoltRepository.NewProductStore(db)
+		require.NoError(t, err)
+		require.NoError(t, store.InsertAll(products))
+		return ProductBoltRepository.NewProductRepository(store)
+	})
+}
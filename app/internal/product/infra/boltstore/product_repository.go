@@ -0,0 +1,284 @@
+package boltstore
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lucasti79/meli-interview/internal/infra/boltstore"
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/internal/product/repository"
+	"github.com/lucasti79/meli-interview/pkg/textsearch"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Bucket and index names of the store opened by NewProductStore.
+const (
+	Bucket        = "products"
+	CategoryIndex = "category"
+	PriceIndex    = "price"
+	InStockIndex  = "inStock"
+)
+
+type productRepository struct {
+	store *boltstore.Store[product.Product]
+}
+
+// NewProductStore opens the products bucket of db, indexed by product ID,
+// category, price and stock, with a text index for Search.
+func NewProductStore(db *bolt.DB) (*boltstore.Store[product.Product], error) {
+	getID := func(entity product.Product) string {
+		return entity.Id
+	}
+	textFields := make([]boltstore.TextField[product.Product], len(product.SearchFields))
+	for i, field := range product.SearchFields {
+		textFields[i] = boltstore.TextField[product.Product]{Weight: field.Weight, Value: field.Value}
+	}
+	return boltstore.NewStore(db, Bucket, getID,
+		boltstore.WithIndex(boltstore.NewKeywordIndex(CategoryIndex, func(p product.Product) string {
+			return strings.ToLower(p.Category)
+		})),
+		boltstore.WithIndex(boltstore.NewRangeIndex(PriceIndex, func(p product.Product) float64 {
+			return p.Price
+		})),
+		boltstore.WithIndex(boltstore.NewKeywordIndex(InStockIndex, func(p product.Product) string {
+			return strconv.FormatBool(p.InStock)
+		})),
+		boltstore.WithTextIndex(boltstore.NewTextIndex(textsearch.Tokenize, textFields...)),
+	)
+}
+
+// NewProductRepository serves products from a store opened with
+// NewProductStore. It returns the same results as the JSONL repository, in the
+// same order.
+func NewProductRepository(store *boltstore.Store[product.Product]) repository.Repository {
+	return &productRepository{store: store}
+}
+
+func (r *productRepository) GetAll(filters product.ProductFilter) ([]product.Product, int, error) {
+	return r.findAll(context.Background(), filters)
+}
+
+func (r *productRepository) GetAllWithContext(ctx context.Context, filters product.ProductFilter) ([]product.Product, int, error) {
+	return r.findAll(ctx, filters)
+}
+
+func (r *productRepository) findAll(ctx context.Context, filters product.ProductFilter) ([]product.Product, int, error) {
+	var result []product.Product
+	var total int
+	err := r.store.View(ctx, func(tx *boltstore.Tx[product.Product]) error {
+		// every match is kept to be sorted: without a sort products are
+		// ordered by ID, the order the cursor of the page resumes in
+		start := max(filters.Page-1, 0) * filters.PageSize
+		var matches []product.Product
+
+		err := r.each(tx, filters, func(p product.Product) error {
+			if !filters.Matches(p) {
+				return nil
+			}
+			total++
+			if filters.After == nil || filters.IsAfter(p, *filters.After) {
+				matches = append(matches, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		sort.Slice(matches, func(i, j int) bool { return filters.Less(matches[i], matches[j]) })
+		if filters.After != nil {
+			start = 0
+		}
+		if start < len(matches) {
+			result = matches[start:min(start+filters.PageSize, len(matches))]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return result, total, nil
+}
+
+// each calls handler for the products the indexes covering filters select,
+// or for every product when no index applies. filters.Matches still has to be
+// checked, as the indexes do not cover the name filter.
+func (r *productRepository) each(tx *boltstore.Tx[product.Product], f product.ProductFilter, handler func(p product.Product) error) error {
+	var sets []boltstore.Set
+
+	if len(f.Categories) > 0 {
+		keys := make([]string, len(f.Categories))
+		for i, cat := range f.Categories {
+			keys[i] = strings.ToLower(cat)
+		}
+		set, err := tx.Lookup(CategoryIndex, keys...)
+		if err != nil {
+			return err
+		}
+		sets = append(sets, set)
+	}
+
+	if f.MinPrice > 0 || f.MaxPrice > 0 {
+		min, max := math.Inf(-1), math.Inf(1)
+		if f.MinPrice > 0 {
+			min = f.MinPrice
+		}
+		if f.MaxPrice > 0 {
+			max = f.MaxPrice
+		}
+		set, err := tx.Range(PriceIndex, min, max)
+		if err != nil {
+			return err
+		}
+		sets = append(sets, set)
+	}
+
+	if f.InStock != nil {
+		set, err := tx.Lookup(InStockIndex, strconv.FormatBool(*f.InStock))
+		if err != nil {
+			return err
+		}
+		sets = append(sets, set)
+	}
+
+	if len(sets) == 0 {
+		return tx.Scan(handler)
+	}
+	return tx.Load(boltstore.Intersect(sets...), handler)
+}
+
+func (r *productRepository) GetByID(productId string) (*product.Product, error) {
+	return r.GetByIDWithContext(context.Background(), productId)
+}
+
+func (r *productRepository) GetByIDWithContext(ctx context.Context, productId string) (*product.Product, error) {
+	var found product.Product
+	err := r.store.View(ctx, func(tx *boltstore.Tx[product.Product]) error {
+		var err error
+		found, err = tx.Get(productId)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
+
+func (r *productRepository) GetFacets(filters product.ProductFilter) (product.Facets, error) {
+	return r.facets(context.Background(), filters)
+}
+
+func (r *productRepository) GetFacetsWithContext(ctx context.Context, filters product.ProductFilter) (product.Facets, error) {
+	return r.facets(ctx, filters)
+}
+
+// facets counts the requested facets over every product matching filters,
+// regardless of pagination.
+func (r *productRepository) facets(ctx context.Context, filters product.ProductFilter) (product.Facets, error) {
+	counter := product.NewFacetCounter(filters.Facets, filters.PriceBuckets)
+	err := r.store.View(ctx, func(tx *boltstore.Tx[product.Product]) error {
+		return r.each(tx, filters, func(p product.Product) error {
+			if filters.Matches(p) {
+				counter.Add(p)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counter.Facets(), nil
+}
+
+func (r *productRepository) Search(filters product.SearchFilter) ([]product.Product, int, error) {
+	return r.search(context.Background(), filters)
+}
+
+func (r *productRepository) SearchWithContext(ctx context.Context, filters product.SearchFilter) ([]product.Product, int, error) {
+	return r.search(ctx, filters)
+}
+
+func (r *productRepository) search(ctx context.Context, filters product.SearchFilter) ([]product.Product, int, error) {
+	var result []product.Product
+	var total int
+	err := r.store.View(ctx, func(tx *boltstore.Tx[product.Product]) error {
+		hits, err := tx.Search(filters.Query)
+		if err != nil {
+			return err
+		}
+
+		var categories boltstore.Set
+		if len(filters.Categories) > 0 {
+			keys := make([]string, len(filters.Categories))
+			for i, cat := range filters.Categories {
+				keys[i] = strings.ToLower(cat)
+			}
+			if categories, err = tx.Lookup(CategoryIndex, keys...); err != nil {
+				return err
+			}
+		}
+
+		ranked := make([]uint64, 0, len(hits))
+		for _, hit := range hits {
+			if categories == nil || categories.Has(hit.Seq) {
+				ranked = append(ranked, hit.Seq)
+			}
+		}
+		total = len(ranked)
+
+		start := max(filters.Page-1, 0) * filters.PageSize
+		if start >= len(ranked) {
+			return nil
+		}
+		return tx.Load(ranked[start:min(start+filters.PageSize, len(ranked))], func(p product.Product) error {
+			result = append(result, p)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return result, total, nil
+}
+
+func (r *productRepository) Create(p product.Product) error {
+	return r.store.Insert(p)
+}
+
+func (r *productRepository) CreateWithContext(ctx context.Context, p product.Product) error {
+	return r.store.Insert(p)
+}
+
+func (r *productRepository) Update(p product.Product) error {
+	return r.store.Update(p)
+}
+
+func (r *productRepository) UpdateWithContext(ctx context.Context, p product.Product) error {
+	return r.store.Update(p)
+}
+
+func (r *productRepository) Modify(productId string, modify func(p product.Product) (product.Product, error)) (*product.Product, error) {
+	return r.ModifyWithContext(context.Background(), productId, modify)
+}
+
+func (r *productRepository) ModifyWithContext(ctx context.Context, productId string, modify func(p product.Product) (product.Product, error)) (*product.Product, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	p, err := r.store.Modify(productId, modify)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *productRepository) Delete(productId string) error {
+	return r.store.Delete(productId)
+}
+
+func (r *productRepository) DeleteWithContext(ctx context.Context, productId string) error {
+	return r.store.Delete(productId)
+}
@@ -0,0 +1,15 @@
+package jsonstore_test
+
+import (
+	"testing"
+
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/internal/product/repository"
+	"github.com/lucasti79/meli-interview/internal/product/repository/repositorytest"
+)
+
+func TestProductRepository_Contract(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T, products []product.Product) repository.Repository {
+		return newRepository(t, writeProductsJSONL(t, products))
+	})
+}
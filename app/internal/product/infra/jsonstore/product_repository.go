@@ -2,43 +2,126 @@ package jsonstore
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"strings"
 
 	"github.com/lucasti79/meli-interview/internal/infra/jsonstore"
 	"github.com/lucasti79/meli-interview/internal/product"
 	"github.com/lucasti79/meli-interview/internal/product/repository"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/lucasti79/meli-interview/pkg/textsearch"
+)
+
+// Names of the secondary indexes registered by NewProductStore.
+const (
+	CategoryIndex = "category"
+	PriceIndex    = "price"
+	InStockIndex  = "inStock"
+	TextIndex     = "text"
 )
 
 type productRepository struct {
-	repo *jsonstore.JSONRepository[product.Product]
+	repo       *jsonstore.JSONRepository[product.Product]
+	byCategory *jsonstore.KeywordIndex[product.Product]
+	byPrice    *jsonstore.RangeIndex[product.Product]
+	byStock    *jsonstore.FlagIndex[product.Product]
+	byText     *jsonstore.TextIndex[product.Product]
 }
 
-func NewProductRepository(fileName string) (repository.Repository, error) {
+// NewProductStore opens the JSONL file holding the product catalog, indexed by
+// product ID, with secondary indexes on category, price and stock and a full
+// text index for Search. Products breaking the rules of product.Validate are
+// reported by the store's InvalidRecords.
+func NewProductStore(fileName string, opts ...jsonstore.Option) (*jsonstore.JSONRepository[product.Product], error) {
 	getID := func(entity product.Product) string {
 		return entity.Id
 	}
-	repo, err := jsonstore.NewJSONRepository(fileName, getID)
+	textFields := make([]jsonstore.TextField[product.Product], len(product.SearchFields))
+	for i, field := range product.SearchFields {
+		textFields[i] = jsonstore.TextField[product.Product]{Weight: field.Weight, Value: field.Value}
+	}
+	indexes := []jsonstore.Option{
+		jsonstore.WithIndex(CategoryIndex, jsonstore.NewKeywordIndex(func(p product.Product) string {
+			return strings.ToLower(p.Category)
+		})),
+		jsonstore.WithIndex(PriceIndex, jsonstore.NewRangeIndex(func(p product.Product) float64 {
+			return p.Price
+		})),
+		jsonstore.WithIndex(InStockIndex, jsonstore.NewFlagIndex(func(p product.Product) bool {
+			return p.InStock
+		})),
+		jsonstore.WithIndex(TextIndex, jsonstore.NewTextIndex(textsearch.Tokenize, textFields...)),
+		jsonstore.WithValidator(validate),
+	}
+	return jsonstore.NewJSONRepository(fileName, getID, append(indexes, opts...)...)
+}
+
+func validate(p product.Product) []jsonstore.FieldError {
+	fieldErrors := product.Validate(p)
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+	result := make([]jsonstore.FieldError, len(fieldErrors))
+	for i, fe := range fieldErrors {
+		result[i] = jsonstore.FieldError(fe)
+	}
+	return result
+}
+
+func NewProductRepository(fileName string, opts ...jsonstore.Option) (repository.Repository, error) {
+	repo, err := NewProductStore(fileName, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return &productRepository{repo: repo}, nil
+	return NewProductRepositoryFromStore(repo), nil
+}
+
+// NewProductRepositoryFromStore builds the repository on top of a store that
+// may be shared with other read models of the catalog. Filters are resolved
+// through whichever of the NewProductStore indexes the store carries; the
+// others fall back to a scan.
+func NewProductRepositoryFromStore(repo *jsonstore.JSONRepository[product.Product]) repository.Repository {
+	r := &productRepository{repo: repo}
+	r.byCategory, _ = repo.Index(CategoryIndex).(*jsonstore.KeywordIndex[product.Product])
+	r.byPrice, _ = repo.Index(PriceIndex).(*jsonstore.RangeIndex[product.Product])
+	r.byStock, _ = repo.Index(InStockIndex).(*jsonstore.FlagIndex[product.Product])
+	r.byText, _ = repo.Index(TextIndex).(*jsonstore.TextIndex[product.Product])
+	return r
 }
 
 func (r *productRepository) GetAll(filters product.ProductFilter) ([]product.Product, int, error) {
+	return r.findAll(context.Background(), filters)
+}
+
+func (r *productRepository) GetByID(productId string) (*product.Product, error) {
+	return r.GetByIDWithContext(context.Background(), productId)
+}
+
+func (r *productRepository) GetAllWithContext(ctx context.Context, filters product.ProductFilter) ([]product.Product, int, error) {
+	return r.findAll(ctx, filters)
+}
+
+func (r *productRepository) findAll(ctx context.Context, filters product.ProductFilter) ([]product.Product, int, error) {
 	var result []product.Product
 
-	total, err := r.repo.FindAllWherePaginated(
-		func(p product.Product) bool {
-			return matchProduct(p, filters)
-		},
-		filters.Page,
-		filters.PageSize,
-		func(p product.Product) error {
-			result = append(result, p)
-			return nil
-		},
-	)
+	predicate := func(p product.Product) bool {
+		return filters.Matches(p)
+	}
+	handler := func(p product.Product) error {
+		result = append(result, p)
+		return nil
+	}
 
+	var total int
+	var err error
+	if filters.After != nil {
+		total, err = r.repo.FindAllIndexedAfterWithContext(ctx, r.narrow(filters), predicate, filters.Less, func(p product.Product) bool { return filters.IsAfter(p, *filters.After) }, filters.PageSize, handler)
+	} else {
+		// without a sort products are ordered by ID rather than file order,
+		// the order the cursor of the page resumes in
+		total, err = r.repo.FindAllIndexedPaginatedWithContext(ctx, r.narrow(filters), predicate, filters.Less, filters.Page, filters.PageSize, handler)
+	}
 	if err != nil {
 		return nil, 0, err
 	}
@@ -46,67 +129,145 @@ func (r *productRepository) GetAll(filters product.ProductFilter) ([]product.Pro
 	return result, total, nil
 }
 
-func (r *productRepository) GetByID(productId string) (*product.Product, error) {
-	product, err := r.repo.FindByID(productId)
+// narrow intersects the indexes covering filters. matchProduct still runs on
+// every candidate, so the indexes only have to return a superset.
+func (r *productRepository) narrow(f product.ProductFilter) jsonstore.Narrow {
+	return func() *jsonstore.Bitmap {
+		var sets []*jsonstore.Bitmap
+
+		if r.byCategory != nil && len(f.Categories) > 0 {
+			keys := make([]string, len(f.Categories))
+			for i, cat := range f.Categories {
+				keys[i] = strings.ToLower(cat)
+			}
+			sets = append(sets, r.byCategory.Lookup(keys...))
+		}
+
+		if r.byPrice != nil && (f.MinPrice > 0 || f.MaxPrice > 0) {
+			min, max := math.Inf(-1), math.Inf(1)
+			if f.MinPrice > 0 {
+				min = f.MinPrice
+			}
+			if f.MaxPrice > 0 {
+				max = f.MaxPrice
+			}
+			sets = append(sets, r.byPrice.Range(min, max))
+		}
+
+		if r.byStock != nil && f.InStock != nil {
+			sets = append(sets, r.byStock.Lookup(*f.InStock))
+		}
+
+		return jsonstore.Intersect(sets...)
+	}
+}
+
+func (r *productRepository) GetByIDWithContext(ctx context.Context, productId string) (*product.Product, error) {
+	product, err := r.repo.FindByIDWithContext(ctx, productId)
 	if err != nil {
 		return nil, err
 	}
 	return &product, nil
 }
 
-func (r *productRepository) GetAllWithContext(ctx context.Context, filters product.ProductFilter) ([]product.Product, int, error) {
-	var result []product.Product
+func (r *productRepository) GetFacets(filters product.ProductFilter) (product.Facets, error) {
+	return r.facets(context.Background(), filters)
+}
 
-	total, err := r.repo.FindAllWherePaginated(
-		func(p product.Product) bool {
-			return matchProduct(p, filters)
-		},
-		filters.Page,
-		filters.PageSize,
-		func(p product.Product) error {
-			result = append(result, p)
-			return nil
-		},
-	)
+func (r *productRepository) GetFacetsWithContext(ctx context.Context, filters product.ProductFilter) (product.Facets, error) {
+	return r.facets(ctx, filters)
+}
 
+// facets counts the requested facets over every product matching filters,
+// regardless of pagination.
+func (r *productRepository) facets(ctx context.Context, filters product.ProductFilter) (product.Facets, error) {
+	counter := product.NewFacetCounter(filters.Facets, filters.PriceBuckets)
+	predicate := func(p product.Product) bool {
+		return filters.Matches(p)
+	}
+	err := r.repo.FindAllIndexedWithContext(ctx, r.narrow(filters), predicate, func(p product.Product) error {
+		counter.Add(p)
+		return nil
+	})
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
+	return counter.Facets(), nil
+}
 
-	return result, total, nil
+func (r *productRepository) Search(filters product.SearchFilter) ([]product.Product, int, error) {
+	return r.search(context.Background(), filters)
 }
 
-func (r *productRepository) GetByIDWithContext(ctx context.Context, productId string) (*product.Product, error) {
-	product, err := r.repo.FindByID(productId)
-	if err != nil {
-		return nil, err
-	}
-	return &product, nil
+func (r *productRepository) SearchWithContext(ctx context.Context, filters product.SearchFilter) ([]product.Product, int, error) {
+	return r.search(ctx, filters)
 }
 
-func matchProduct(p product.Product, f product.ProductFilter) bool {
-	if f.Name != "" && !strings.Contains(strings.ToLower(p.Name), strings.ToLower(f.Name)) {
-		return false
+func (r *productRepository) search(ctx context.Context, filters product.SearchFilter) ([]product.Product, int, error) {
+	if r.byText == nil {
+		return nil, 0, fmt.Errorf("%w: product store has no text index", apperrors.ErrInternalError)
 	}
 
-	if len(f.Categories) > 0 {
-		found := false
-		for _, cat := range f.Categories {
-			if strings.EqualFold(cat, p.Category) {
-				found = true
-				break
+	narrow := r.narrow(product.ProductFilter{Categories: filters.Categories})
+	rank := func() []int {
+		candidates := narrow()
+		hits := r.byText.Search(filters.Query)
+		lines := make([]int, 0, len(hits))
+		for _, hit := range hits {
+			if candidates == nil || candidates.Has(hit.Line) {
+				lines = append(lines, hit.Line)
 			}
 		}
-		if !found {
-			return false
-		}
+		return lines
 	}
 
-	if f.MinPrice > 0 && p.Price < f.MinPrice {
-		return false
+	var result []product.Product
+	total, err := r.repo.FindAllRankedPaginatedWithContext(ctx, rank, filters.Page, filters.PageSize, func(p product.Product) error {
+		result = append(result, p)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
 	}
-	if f.MaxPrice > 0 && p.Price > f.MaxPrice {
-		return false
+
+	return result, total, nil
+}
+
+func (r *productRepository) Create(p product.Product) error {
+	return r.repo.Save(p)
+}
+
+func (r *productRepository) CreateWithContext(ctx context.Context, p product.Product) error {
+	return r.repo.Save(p)
+}
+
+func (r *productRepository) Update(p product.Product) error {
+	return r.repo.Update(p)
+}
+
+func (r *productRepository) UpdateWithContext(ctx context.Context, p product.Product) error {
+	return r.repo.Update(p)
+}
+
+func (r *productRepository) Modify(productId string, modify func(p product.Product) (product.Product, error)) (*product.Product, error) {
+	return r.ModifyWithContext(context.Background(), productId, modify)
+}
+
+func (r *productRepository) ModifyWithContext(ctx context.Context, productId string, modify func(p product.Product) (product.Product, error)) (*product.Product, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	p, err := r.repo.Modify(productId, modify)
+	if err != nil {
+		return nil, err
 	}
-	return true
+	return &p, nil
+}
+
+func (r *productRepository) Delete(productId string) error {
+	return r.repo.Delete(productId)
+}
+
+func (r *productRepository) DeleteWithContext(ctx context.Context, productId string) error {
+	return r.repo.Delete(productId)
 }
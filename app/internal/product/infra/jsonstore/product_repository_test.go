@@ -149,7 +149,7 @@ func TestGetByIDWithContext_PropagatesDatastoreError(t *testing.T) {
 	})
 	repo := newRepository(t, fp)
 
-	require.NoError(t, os.Remove(fp))
+	require.NoError(t, os.WriteFile(fp, []byte("not-a-json\n"), 0o600))
 
 	ctx := context.Background()
 	got, err := repo.GetByIDWithContext(ctx, "x")
@@ -244,6 +244,28 @@ func TestGetAllWithContext_BehavesLikeGetAll(t *testing.T) {
 	require.Equal(t, "CtxProduct", products[0].Name)
 }
 
+func TestWithContext_ReturnsContextErrorOnceDone(t *testing.T) {
+	fp := writeProductsJSONL(t, []product.Product{
+		{Id: "1", Name: "Fone", Category: "Audio", Price: 42},
+	})
+	repo := newRepository(t, fp)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := repo.GetAllWithContext(ctx, product.ProductFilter{Page: 1, PageSize: 10})
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = repo.GetFacetsWithContext(ctx, product.ProductFilter{Facets: []string{product.FacetCategory}})
+	require.ErrorIs(t, err, context.Canceled)
+	_, _, err = repo.SearchWithContext(ctx, product.SearchFilter{Query: "fone", Page: 1, PageSize: 10})
+	require.ErrorIs(t, err, context.Canceled)
+
+	expired, cancelExpired := context.WithTimeout(context.Background(), -time.Second)
+	defer cancelExpired()
+	_, err = repo.GetByIDWithContext(expired, "1")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
 func TestGetAll_NoResults(t *testing.T) {
 	fp := writeProductsJSONL(t, []product.Product{
 		{Id: "1", Name: "One", Category: "C1", Price: 10},
@@ -255,3 +277,318 @@ func TestGetAll_NoResults(t *testing.T) {
 	require.Len(t, products, 0)
 	require.Equal(t, 0, total)
 }
+
+func TestCreate_PersistsProduct(t *testing.T) {
+	fp := writeProductsJSONL(t, []product.Product{
+		{Id: "1", Name: "One", Category: "C1", Price: 10},
+	})
+	repo := newRepository(t, fp)
+
+	err := repo.Create(product.Product{Id: "2", Name: "Two", Category: "C2", Price: 20})
+	require.NoError(t, err)
+
+	got, err := repo.GetByID("2")
+	require.NoError(t, err)
+	require.Equal(t, "Two", got.Name)
+}
+
+func TestCreateWithContext_DuplicateID_ReturnsErrResourceAlreadyExists(t *testing.T) {
+	fp := writeProductsJSONL(t, []product.Product{
+		{Id: "1", Name: "One", Category: "C1", Price: 10},
+	})
+	repo := newRepository(t, fp)
+
+	err := repo.CreateWithContext(context.Background(), product.Product{Id: "1", Name: "Again", Category: "C1", Price: 10})
+	require.ErrorIs(t, err, apperrors.ErrResourceAlreadyExists)
+}
+
+func TestUpdateWithContext_ReplacesProduct(t *testing.T) {
+	fp := writeProductsJSONL(t, []product.Product{
+		{Id: "1", Name: "One", Category: "C1", Price: 10},
+		{Id: "2", Name: "Two", Category: "C2", Price: 20},
+	})
+	repo := newRepository(t, fp)
+
+	err := repo.UpdateWithContext(context.Background(), product.Product{Id: "1", Name: "Uno", Category: "C1", Price: 11})
+	require.NoError(t, err)
+
+	got, err := repo.GetByID("1")
+	require.NoError(t, err)
+	require.Equal(t, "Uno", got.Name)
+	require.Equal(t, 11.0, got.Price)
+
+	products, total, err := repo.GetAll(product.ProductFilter{PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, products, 2)
+	require.Equal(t, 2, total)
+}
+
+func TestUpdate_ProductNotFound(t *testing.T) {
+	fp := writeProductsJSONL(t, []product.Product{
+		{Id: "1", Name: "One", Category: "C1", Price: 10},
+	})
+	repo := newRepository(t, fp)
+
+	err := repo.Update(product.Product{Id: "missing", Name: "X", Category: "C", Price: 1})
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+}
+
+func TestDeleteWithContext_RemovesProduct(t *testing.T) {
+	fp := writeProductsJSONL(t, []product.Product{
+		{Id: "1", Name: "One", Category: "C1", Price: 10},
+		{Id: "2", Name: "Two", Category: "C2", Price: 20},
+	})
+	repo := newRepository(t, fp)
+
+	require.NoError(t, repo.DeleteWithContext(context.Background(), "1"))
+
+	_, err := repo.GetByID("1")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+
+	err = repo.Delete("1")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+}
+
+func TestGetAll_IndexedFiltersFollowWrites(t *testing.T) {
+	fp := writeProductsJSONL(t, []product.Product{
+		{Id: "1", Name: "Phone", Category: "Electronics", Price: 100},
+		{Id: "2", Name: "Laptop", Category: "Electronics", Price: 900},
+		{Id: "3", Name: "T-shirt", Category: "Fashion", Price: 20},
+	})
+	repo := newRepository(t, fp)
+	filter := product.ProductFilter{Categories: []string{"ELECTRONICS"}, MaxPrice: 500, PageSize: 10}
+
+	products, total, err := repo.GetAll(filter)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1"}, productIDs(products))
+	require.Equal(t, 1, total)
+
+	require.NoError(t, repo.Update(product.Product{Id: "2", Name: "Laptop", Category: "Electronics", Price: 450}))
+	require.NoError(t, repo.Update(product.Product{Id: "1", Name: "Phone", Category: "Fashion", Price: 100}))
+	require.NoError(t, repo.Create(product.Product{Id: "4", Name: "Tablet", Category: "electronics", Price: 300}))
+
+	products, total, err = repo.GetAll(filter)
+	require.NoError(t, err)
+	require.Equal(t, []string{"2", "4"}, productIDs(products))
+	require.Equal(t, 2, total)
+}
+
+func productIDs(products []product.Product) []string {
+	ids := make([]string, 0, len(products))
+	for _, p := range products {
+		ids = append(ids, p.Id)
+	}
+	return ids
+}
+
+func TestGetAll_SortByPrice(t *testing.T) {
+	fp := writeProductsJSONL(t, []product.Product{
+		{Id: "a", Name: "Mid", Category: "C", Price: 50},
+		{Id: "b", Name: "Cheap", Category: "C", Price: 5},
+		{Id: "c", Name: "Expensive", Category: "C", Price: 500},
+		{Id: "d", Name: "Mid too", Category: "C", Price: 50},
+	})
+	repo := newRepository(t, fp)
+
+	products, total, err := repo.GetAll(product.ProductFilter{Sort: "price", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, 4, total)
+	require.Equal(t, []string{"b", "a", "d", "c"}, productIDs(products))
+
+	products, _, err = repo.GetAll(product.ProductFilter{Sort: "-price", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, []string{"c", "a", "d", "b"}, productIDs(products), "ties keep ascending ID order")
+}
+
+func TestGetAll_SortPaginatesAfterOrdering(t *testing.T) {
+	fp := writeProductsJSONL(t, []product.Product{
+		{Id: "1", Name: "delta", Category: "C", Price: 1},
+		{Id: "2", Name: "Alpha", Category: "C", Price: 1},
+		{Id: "3", Name: "charlie", Category: "C", Price: 1},
+		{Id: "4", Name: "Bravo", Category: "C", Price: 1},
+	})
+	repo := newRepository(t, fp)
+
+	products, total, err := repo.GetAll(product.ProductFilter{Sort: "name", Page: 2, PageSize: 2})
+	require.NoError(t, err)
+	require.Equal(t, 4, total)
+	require.Equal(t, []string{"3", "1"}, productIDs(products))
+}
+
+func TestGetAllWithContext_SortByDiscountRatingAndReviews(t *testing.T) {
+	fp := writeProductsJSONL(t, []product.Product{
+		{Id: "none", Category: "C", Price: 100, Rating: product.Float(3), Reviews: 10},
+		{Id: "half", Category: "C", Price: 50, OriginalPrice: product.Float(100), Rating: product.Float(5), Reviews: 1},
+		{Id: "quarter", Category: "C", Price: 75, OriginalPrice: product.Float(100), Rating: product.Float(1), Reviews: 100},
+	})
+	repo := newRepository(t, fp)
+	ctx := context.Background()
+
+	products, _, err := repo.GetAllWithContext(ctx, product.ProductFilter{Sort: "-discount", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, []string{"half", "quarter", "none"}, productIDs(products))
+
+	products, _, err = repo.GetAllWithContext(ctx, product.ProductFilter{Sort: "-rating", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, []string{"half", "none", "quarter"}, productIDs(products))
+
+	products, _, err = repo.GetAllWithContext(ctx, product.ProductFilter{Sort: "reviews", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, []string{"half", "none", "quarter"}, productIDs(products))
+}
+
+func TestGetAll_CursorWalksEveryProductOnceWhileCatalogChanges(t *testing.T) {
+	fp := writeProductsJSONL(t, []product.Product{
+		{Id: "1", Name: "A", Category: "C", Price: 30},
+		{Id: "2", Name: "B", Category: "C", Price: 10},
+		{Id: "3", Name: "C", Category: "C", Price: 20},
+		{Id: "4", Name: "D", Category: "C", Price: 20},
+		{Id: "5", Name: "E", Category: "C", Price: 50},
+	})
+	repo := newRepository(t, fp)
+
+	filter := product.ProductFilter{Sort: "price", Page: 1, PageSize: 2}
+	var seen []string
+	for {
+		products, total, err := repo.GetAll(filter)
+		require.NoError(t, err)
+		if len(products) == 0 {
+			break
+		}
+		seen = append(seen, productIDs(products)...)
+
+		if len(seen) == 2 {
+			require.Equal(t, 5, total)
+			// a product inserted before the cursor must not shift the next page
+			require.NoError(t, repo.Create(product.Product{Id: "0", Name: "Z", Category: "C", Price: 1}))
+		}
+
+		after := product.NewCursor(products[len(products)-1], filter.Sort)
+		filter.After = &after
+	}
+
+	require.Equal(t, []string{"2", "3", "4", "1", "5"}, seen)
+}
+
+func TestGetAll_CursorWithoutSortOrdersByID(t *testing.T) {
+	fp := writeProductsJSONL(t, []product.Product{
+		{Id: "b", Name: "B", Category: "C", Price: 1},
+		{Id: "c", Name: "C", Category: "C", Price: 1},
+		{Id: "a", Name: "A", Category: "C", Price: 1},
+	})
+	repo := newRepository(t, fp)
+
+	after := product.Cursor{Id: "a"}
+	products, total, err := repo.GetAll(product.ProductFilter{PageSize: 10, After: &after})
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "c"}, productIDs(products))
+	require.Equal(t, 3, total)
+}
+
+func TestSearch_RanksByRelevanceIgnoringAccentsAndMatchingPrefixes(t *testing.T) {
+	fp := writeProductsJSONL(t, []product.Product{
+		{Id: "1", Name: "Camiseta básica", Description: "Algodão, ideal para o verão", Category: "Moda", Price: 50},
+		{Id: "2", Name: "Fone de ouvido", Description: "Cancelamento de ruído e estojo de algodão", Category: "Eletrônicos", Price: 300},
+		{Id: "3", Name: "Algodón orgánico", Description: "Paquete de algodón", Category: "Hogar", Price: 20},
+		{Id: "4", Name: "Mouse", Description: "Sem fio", Category: "Eletrônicos", Price: 80},
+	})
+	repo := newRepository(t, fp)
+
+	products, total, err := repo.Search(product.SearchFilter{Query: "ALGODAO", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2"}, productIDs(products))
+	require.Equal(t, 2, total)
+
+	products, total, err = repo.Search(product.SearchFilter{Query: "algod", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, "3", products[0].Id, "the name matches twice")
+	require.Equal(t, 3, total)
+
+	products, _, err = repo.SearchWithContext(context.Background(), product.SearchFilter{Query: "eletronicos", Page: 1, PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+
+	products, total, err = repo.Search(product.SearchFilter{Query: "algodao", Categories: []string{"moda"}, Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1"}, productIDs(products))
+	require.Equal(t, 1, total)
+}
+
+func TestSearch_FollowsWrites(t *testing.T) {
+	fp := writeProductsJSONL(t, []product.Product{
+		{Id: "1", Name: "Teclado mecânico", Category: "Eletrônicos", Price: 250},
+		{Id: "2", Name: "Mouse", Category: "Eletrônicos", Price: 80},
+	})
+	repo := newRepository(t, fp)
+
+	require.NoError(t, repo.Update(product.Product{Id: "1", Name: "Teclado sem fio", Category: "Eletrônicos", Price: 200}))
+	require.NoError(t, repo.Create(product.Product{Id: "3", Name: "Teclado numérico", Category: "Eletrônicos", Price: 90}))
+	require.NoError(t, repo.Delete("2"))
+
+	products, _, err := repo.Search(product.SearchFilter{Query: "mecanico", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Empty(t, products)
+
+	products, total, err := repo.Search(product.SearchFilter{Query: "teclado", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"1", "3"}, productIDs(products))
+	require.Equal(t, 2, total)
+
+	products, _, err = repo.Search(product.SearchFilter{Query: "mouse", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Empty(t, products)
+}
+
+func TestGetFacets_CountsOverFilteredProducts(t *testing.T) {
+	fp := writeProductsJSONL(t, []product.Product{
+		{Id: "1", Name: "Phone", Category: "Electronics", Price: 100, InStock: true, Rating: product.Float(4.5)},
+		{Id: "2", Name: "Phone case", Category: "Accessories", Price: 20, InStock: true, Rating: product.Float(5)},
+		{Id: "3", Name: "Phone charger", Category: "Accessories", Price: 45, Rating: product.Float(3.2)},
+		{Id: "4", Name: "Laptop", Category: "Electronics", Price: 900, InStock: true, Rating: product.Float(4)},
+	})
+	repo := newRepository(t, fp)
+
+	facets, err := repo.GetFacets(product.ProductFilter{
+		Name:         "phone",
+		Facets:       []string{product.FacetCategory, product.FacetPriceRange, product.FacetInStock, product.FacetRating},
+		PriceBuckets: []float64{50, 500},
+	})
+	require.NoError(t, err)
+
+	fifty, fiveHundred := 50.0, 500.0
+	require.Equal(t, []product.FacetBucket{
+		{Value: "Accessories", Count: 2},
+		{Value: "Electronics", Count: 1},
+	}, facets[product.FacetCategory])
+	require.Equal(t, []product.FacetBucket{
+		{Value: "*-50", To: &fifty, Count: 2},
+		{Value: "50-500", From: &fifty, To: &fiveHundred, Count: 1},
+		{Value: "500-*", From: &fiveHundred, Count: 0},
+	}, facets[product.FacetPriceRange])
+	require.Equal(t, []product.FacetBucket{
+		{Value: "true", Count: 2},
+		{Value: "false", Count: 1},
+	}, facets[product.FacetInStock])
+
+	ratingCounts := make([]int, 0, 5)
+	for _, b := range facets[product.FacetRating] {
+		ratingCounts = append(ratingCounts, b.Count)
+	}
+	require.Equal(t, []int{0, 0, 0, 1, 2}, ratingCounts)
+	require.Equal(t, "4-5", facets[product.FacetRating][4].Value)
+}
+
+func TestGetFacetsWithContext_OnlyRequestedFacets(t *testing.T) {
+	fp := writeProductsJSONL(t, []product.Product{
+		{Id: "1", Name: "Phone", Category: "Electronics", Price: 100},
+	})
+	repo := newRepository(t, fp)
+
+	facets, err := repo.GetFacetsWithContext(context.Background(), product.ProductFilter{
+		Categories: []string{"electronics"},
+		Facets:     []string{product.FacetCategory},
+	})
+	require.NoError(t, err)
+	require.Len(t, facets, 1)
+	require.Equal(t, []product.FacetBucket{{Value: "Electronics", Count: 1}}, facets[product.FacetCategory])
+}
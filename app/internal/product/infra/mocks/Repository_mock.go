@@ -38,6 +38,222 @@ func (_m *RepositoryMock) EXPECT() *RepositoryMock_Expecter {
 	return &RepositoryMock_Expecter{mock: &_m.Mock}
 }
 
+// Create provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) Create(p product.Product) error {
+	ret := _mock.Called(p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(product.Product) error); ok {
+		r0 = returnFunc(p)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// RepositoryMock_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type RepositoryMock_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - p product.Product
+func (_e *RepositoryMock_Expecter) Create(p interface{}) *RepositoryMock_Create_Call {
+	return &RepositoryMock_Create_Call{Call: _e.mock.On("Create", p)}
+}
+
+func (_c *RepositoryMock_Create_Call) Run(run func(p product.Product)) *RepositoryMock_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 product.Product
+		if args[0] != nil {
+			arg0 = args[0].(product.Product)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_Create_Call) Return(err error) *RepositoryMock_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RepositoryMock_Create_Call) RunAndReturn(run func(p product.Product) error) *RepositoryMock_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWithContext provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) CreateWithContext(ctx context.Context, p product.Product) error {
+	ret := _mock.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWithContext")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.Product) error); ok {
+		r0 = returnFunc(ctx, p)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// RepositoryMock_CreateWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWithContext'
+type RepositoryMock_CreateWithContext_Call struct {
+	*mock.Call
+}
+
+// CreateWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p product.Product
+func (_e *RepositoryMock_Expecter) CreateWithContext(ctx interface{}, p interface{}) *RepositoryMock_CreateWithContext_Call {
+	return &RepositoryMock_CreateWithContext_Call{Call: _e.mock.On("CreateWithContext", ctx, p)}
+}
+
+func (_c *RepositoryMock_CreateWithContext_Call) Run(run func(ctx context.Context, p product.Product)) *RepositoryMock_CreateWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 product.Product
+		if args[1] != nil {
+			arg1 = args[1].(product.Product)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_CreateWithContext_Call) Return(err error) *RepositoryMock_CreateWithContext_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RepositoryMock_CreateWithContext_Call) RunAndReturn(run func(ctx context.Context, p product.Product) error) *RepositoryMock_CreateWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) Delete(productId string) error {
+	ret := _mock.Called(productId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(productId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// RepositoryMock_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type RepositoryMock_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - productId string
+func (_e *RepositoryMock_Expecter) Delete(productId interface{}) *RepositoryMock_Delete_Call {
+	return &RepositoryMock_Delete_Call{Call: _e.mock.On("Delete", productId)}
+}
+
+func (_c *RepositoryMock_Delete_Call) Run(run func(productId string)) *RepositoryMock_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_Delete_Call) Return(err error) *RepositoryMock_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RepositoryMock_Delete_Call) RunAndReturn(run func(productId string) error) *RepositoryMock_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWithContext provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) DeleteWithContext(ctx context.Context, productId string) error {
+	ret := _mock.Called(ctx, productId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWithContext")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, productId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// RepositoryMock_DeleteWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWithContext'
+type RepositoryMock_DeleteWithContext_Call struct {
+	*mock.Call
+}
+
+// DeleteWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productId string
+func (_e *RepositoryMock_Expecter) DeleteWithContext(ctx interface{}, productId interface{}) *RepositoryMock_DeleteWithContext_Call {
+	return &RepositoryMock_DeleteWithContext_Call{Call: _e.mock.On("DeleteWithContext", ctx, productId)}
+}
+
+func (_c *RepositoryMock_DeleteWithContext_Call) Run(run func(ctx context.Context, productId string)) *RepositoryMock_DeleteWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_DeleteWithContext_Call) Return(err error) *RepositoryMock_DeleteWithContext_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RepositoryMock_DeleteWithContext_Call) RunAndReturn(run func(ctx context.Context, productId string) error) *RepositoryMock_DeleteWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAll provides a mock function for the type RepositoryMock
 func (_mock *RepositoryMock) GetAll(filters product.ProductFilter) ([]product.Product, int, error) {
 	ret := _mock.Called(filters)
@@ -309,3 +525,521 @@ func (_c *RepositoryMock_GetByIDWithContext_Call) RunAndReturn(run func(ctx cont
 	_c.Call.Return(run)
 	return _c
 }
+
+// GetFacets provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) GetFacets(filters product.ProductFilter) (product.Facets, error) {
+	ret := _mock.Called(filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFacets")
+	}
+
+	var r0 product.Facets
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(product.ProductFilter) (product.Facets, error)); ok {
+		return returnFunc(filters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(product.ProductFilter) product.Facets); ok {
+		r0 = returnFunc(filters)
+	} else {
+		r0 = ret.Get(0).(product.Facets)
+	}
+	if returnFunc, ok := ret.Get(1).(func(product.ProductFilter) error); ok {
+		r1 = returnFunc(filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// RepositoryMock_GetFacets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFacets'
+type RepositoryMock_GetFacets_Call struct {
+	*mock.Call
+}
+
+// GetFacets is a helper method to define mock.On call
+//   - filters product.ProductFilter
+func (_e *RepositoryMock_Expecter) GetFacets(filters interface{}) *RepositoryMock_GetFacets_Call {
+	return &RepositoryMock_GetFacets_Call{Call: _e.mock.On("GetFacets", filters)}
+}
+
+func (_c *RepositoryMock_GetFacets_Call) Run(run func(filters product.ProductFilter)) *RepositoryMock_GetFacets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 product.ProductFilter
+		if args[0] != nil {
+			arg0 = args[0].(product.ProductFilter)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_GetFacets_Call) Return(facets product.Facets, err error) *RepositoryMock_GetFacets_Call {
+	_c.Call.Return(facets, err)
+	return _c
+}
+
+func (_c *RepositoryMock_GetFacets_Call) RunAndReturn(run func(filters product.ProductFilter) (product.Facets, error)) *RepositoryMock_GetFacets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFacetsWithContext provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) GetFacetsWithContext(ctx context.Context, filters product.ProductFilter) (product.Facets, error) {
+	ret := _mock.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFacetsWithContext")
+	}
+
+	var r0 product.Facets
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.ProductFilter) (product.Facets, error)); ok {
+		return returnFunc(ctx, filters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.ProductFilter) product.Facets); ok {
+		r0 = returnFunc(ctx, filters)
+	} else {
+		r0 = ret.Get(0).(product.Facets)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, product.ProductFilter) error); ok {
+		r1 = returnFunc(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// RepositoryMock_GetFacetsWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFacetsWithContext'
+type RepositoryMock_GetFacetsWithContext_Call struct {
+	*mock.Call
+}
+
+// GetFacetsWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters product.ProductFilter
+func (_e *RepositoryMock_Expecter) GetFacetsWithContext(ctx interface{}, filters interface{}) *RepositoryMock_GetFacetsWithContext_Call {
+	return &RepositoryMock_GetFacetsWithContext_Call{Call: _e.mock.On("GetFacetsWithContext", ctx, filters)}
+}
+
+func (_c *RepositoryMock_GetFacetsWithContext_Call) Run(run func(ctx context.Context, filters product.ProductFilter)) *RepositoryMock_GetFacetsWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 product.ProductFilter
+		if args[1] != nil {
+			arg1 = args[1].(product.ProductFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_GetFacetsWithContext_Call) Return(facets product.Facets, err error) *RepositoryMock_GetFacetsWithContext_Call {
+	_c.Call.Return(facets, err)
+	return _c
+}
+
+func (_c *RepositoryMock_GetFacetsWithContext_Call) RunAndReturn(run func(ctx context.Context, filters product.ProductFilter) (product.Facets, error)) *RepositoryMock_GetFacetsWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Modify provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) Modify(productId string, modify func(p product.Product) (product.Product, error)) (*product.Product, error) {
+	ret := _mock.Called(productId, modify)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Modify")
+	}
+
+	var r0 *product.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, func(p product.Product) (product.Product, error)) (*product.Product, error)); ok {
+		return returnFunc(productId, modify)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, func(p product.Product) (product.Product, error)) *product.Product); ok {
+		r0 = returnFunc(productId, modify)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*product.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, func(p product.Product) (product.Product, error)) error); ok {
+		r1 = returnFunc(productId, modify)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// RepositoryMock_Modify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Modify'
+type RepositoryMock_Modify_Call struct {
+	*mock.Call
+}
+
+// Modify is a helper method to define mock.On call
+//   - productId string
+//   - modify func(p product.Product) (product.Product, error)
+func (_e *RepositoryMock_Expecter) Modify(productId interface{}, modify interface{}) *RepositoryMock_Modify_Call {
+	return &RepositoryMock_Modify_Call{Call: _e.mock.On("Modify", productId, modify)}
+}
+
+func (_c *RepositoryMock_Modify_Call) Run(run func(productId string, modify func(p product.Product) (product.Product, error))) *RepositoryMock_Modify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 func(p product.Product) (product.Product, error)
+		if args[1] != nil {
+			arg1 = args[1].(func(p product.Product) (product.Product, error))
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_Modify_Call) Return(product1 *product.Product, err error) *RepositoryMock_Modify_Call {
+	_c.Call.Return(product1, err)
+	return _c
+}
+
+func (_c *RepositoryMock_Modify_Call) RunAndReturn(run func(productId string, modify func(p product.Product) (product.Product, error)) (*product.Product, error)) *RepositoryMock_Modify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ModifyWithContext provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) ModifyWithContext(ctx context.Context, productId string, modify func(p product.Product) (product.Product, error)) (*product.Product, error) {
+	ret := _mock.Called(ctx, productId, modify)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ModifyWithContext")
+	}
+
+	var r0 *product.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, func(p product.Product) (product.Product, error)) (*product.Product, error)); ok {
+		return returnFunc(ctx, productId, modify)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, func(p product.Product) (product.Product, error)) *product.Product); ok {
+		r0 = returnFunc(ctx, productId, modify)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*product.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, func(p product.Product) (product.Product, error)) error); ok {
+		r1 = returnFunc(ctx, productId, modify)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// RepositoryMock_ModifyWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ModifyWithContext'
+type RepositoryMock_ModifyWithContext_Call struct {
+	*mock.Call
+}
+
+// ModifyWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productId string
+//   - modify func(p product.Product) (product.Product, error)
+func (_e *RepositoryMock_Expecter) ModifyWithContext(ctx interface{}, productId interface{}, modify interface{}) *RepositoryMock_ModifyWithContext_Call {
+	return &RepositoryMock_ModifyWithContext_Call{Call: _e.mock.On("ModifyWithContext", ctx, productId, modify)}
+}
+
+func (_c *RepositoryMock_ModifyWithContext_Call) Run(run func(ctx context.Context, productId string, modify func(p product.Product) (product.Product, error))) *RepositoryMock_ModifyWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 func(p product.Product) (product.Product, error)
+		if args[2] != nil {
+			arg2 = args[2].(func(p product.Product) (product.Product, error))
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_ModifyWithContext_Call) Return(product1 *product.Product, err error) *RepositoryMock_ModifyWithContext_Call {
+	_c.Call.Return(product1, err)
+	return _c
+}
+
+func (_c *RepositoryMock_ModifyWithContext_Call) RunAndReturn(run func(ctx context.Context, productId string, modify func(p product.Product) (product.Product, error)) (*product.Product, error)) *RepositoryMock_ModifyWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) Search(filters product.SearchFilter) ([]product.Product, int, error) {
+	ret := _mock.Called(filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []product.Product
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(product.SearchFilter) ([]product.Product, int, error)); ok {
+		return returnFunc(filters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(product.SearchFilter) []product.Product); ok {
+		r0 = returnFunc(filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]product.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(product.SearchFilter) int); ok {
+		r1 = returnFunc(filters)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(product.SearchFilter) error); ok {
+		r2 = returnFunc(filters)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// RepositoryMock_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type RepositoryMock_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - filters product.SearchFilter
+func (_e *RepositoryMock_Expecter) Search(filters interface{}) *RepositoryMock_Search_Call {
+	return &RepositoryMock_Search_Call{Call: _e.mock.On("Search", filters)}
+}
+
+func (_c *RepositoryMock_Search_Call) Run(run func(filters product.SearchFilter)) *RepositoryMock_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 product.SearchFilter
+		if args[0] != nil {
+			arg0 = args[0].(product.SearchFilter)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_Search_Call) Return(products []product.Product, n int, err error) *RepositoryMock_Search_Call {
+	_c.Call.Return(products, n, err)
+	return _c
+}
+
+func (_c *RepositoryMock_Search_Call) RunAndReturn(run func(filters product.SearchFilter) ([]product.Product, int, error)) *RepositoryMock_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchWithContext provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) SearchWithContext(ctx context.Context, filters product.SearchFilter) ([]product.Product, int, error) {
+	ret := _mock.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchWithContext")
+	}
+
+	var r0 []product.Product
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.SearchFilter) ([]product.Product, int, error)); ok {
+		return returnFunc(ctx, filters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.SearchFilter) []product.Product); ok {
+		r0 = returnFunc(ctx, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]product.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, product.SearchFilter) int); ok {
+		r1 = returnFunc(ctx, filters)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, product.SearchFilter) error); ok {
+		r2 = returnFunc(ctx, filters)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// RepositoryMock_SearchWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchWithContext'
+type RepositoryMock_SearchWithContext_Call struct {
+	*mock.Call
+}
+
+// SearchWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters product.SearchFilter
+func (_e *RepositoryMock_Expecter) SearchWithContext(ctx interface{}, filters interface{}) *RepositoryMock_SearchWithContext_Call {
+	return &RepositoryMock_SearchWithContext_Call{Call: _e.mock.On("SearchWithContext", ctx, filters)}
+}
+
+func (_c *RepositoryMock_SearchWithContext_Call) Run(run func(ctx context.Context, filters product.SearchFilter)) *RepositoryMock_SearchWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 product.SearchFilter
+		if args[1] != nil {
+			arg1 = args[1].(product.SearchFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_SearchWithContext_Call) Return(products []product.Product, n int, err error) *RepositoryMock_SearchWithContext_Call {
+	_c.Call.Return(products, n, err)
+	return _c
+}
+
+func (_c *RepositoryMock_SearchWithContext_Call) RunAndReturn(run func(ctx context.Context, filters product.SearchFilter) ([]product.Product, int, error)) *RepositoryMock_SearchWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) Update(p product.Product) error {
+	ret := _mock.Called(p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(product.Product) error); ok {
+		r0 = returnFunc(p)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// RepositoryMock_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type RepositoryMock_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - p product.Product
+func (_e *RepositoryMock_Expecter) Update(p interface{}) *RepositoryMock_Update_Call {
+	return &RepositoryMock_Update_Call{Call: _e.mock.On("Update", p)}
+}
+
+func (_c *RepositoryMock_Update_Call) Run(run func(p product.Product)) *RepositoryMock_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 product.Product
+		if args[0] != nil {
+			arg0 = args[0].(product.Product)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_Update_Call) Return(err error) *RepositoryMock_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RepositoryMock_Update_Call) RunAndReturn(run func(p product.Product) error) *RepositoryMock_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateWithContext provides a mock function for the type RepositoryMock
+func (_mock *RepositoryMock) UpdateWithContext(ctx context.Context, p product.Product) error {
+	ret := _mock.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWithContext")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.Product) error); ok {
+		r0 = returnFunc(ctx, p)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// RepositoryMock_UpdateWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWithContext'
+type RepositoryMock_UpdateWithContext_Call struct {
+	*mock.Call
+}
+
+// UpdateWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p product.Product
+func (_e *RepositoryMock_Expecter) UpdateWithContext(ctx interface{}, p interface{}) *RepositoryMock_UpdateWithContext_Call {
+	return &RepositoryMock_UpdateWithContext_Call{Call: _e.mock.On("UpdateWithContext", ctx, p)}
+}
+
+func (_c *RepositoryMock_UpdateWithContext_Call) Run(run func(ctx context.Context, p product.Product)) *RepositoryMock_UpdateWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 product.Product
+		if args[1] != nil {
+			arg1 = args[1].(product.Product)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *RepositoryMock_UpdateWithContext_Call) Return(err error) *RepositoryMock_UpdateWithContext_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *RepositoryMock_UpdateWithContext_Call) RunAndReturn(run func(ctx context.Context, p product.Product) error) *RepositoryMock_UpdateWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
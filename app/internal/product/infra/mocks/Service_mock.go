@@ -38,33 +38,1069 @@ func (_m *ServiceMock) EXPECT() *ServiceMock_Expecter {
 	return &ServiceMock_Expecter{mock: &_m.Mock}
 }
 
+// AdjustStock provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) AdjustStock(productId string, adj product.StockAdjustment) (*product.Product, error) {
+	ret := _mock.Called(productId, adj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdjustStock")
+	}
+
+	var r0 *product.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, product.StockAdjustment) (*product.Product, error)); ok {
+		return returnFunc(productId, adj)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, product.StockAdjustment) *product.Product); ok {
+		r0 = returnFunc(productId, adj)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*product.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, product.StockAdjustment) error); ok {
+		r1 = returnFunc(productId, adj)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ServiceMock_AdjustStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdjustStock'
+type ServiceMock_AdjustStock_Call struct {
+	*mock.Call
+}
+
+// AdjustStock is a helper method to define mock.On call
+//   - productId string
+//   - adj product.StockAdjustment
+func (_e *ServiceMock_Expecter) AdjustStock(productId interface{}, adj interface{}) *ServiceMock_AdjustStock_Call {
+	return &ServiceMock_AdjustStock_Call{Call: _e.mock.On("AdjustStock", productId, adj)}
+}
+
+func (_c *ServiceMock_AdjustStock_Call) Run(run func(productId string, adj product.StockAdjustment)) *ServiceMock_AdjustStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 product.StockAdjustment
+		if args[1] != nil {
+			arg1 = args[1].(product.StockAdjustment)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_AdjustStock_Call) Return(product1 *product.Product, err error) *ServiceMock_AdjustStock_Call {
+	_c.Call.Return(product1, err)
+	return _c
+}
+
+func (_c *ServiceMock_AdjustStock_Call) RunAndReturn(run func(productId string, adj product.StockAdjustment) (*product.Product, error)) *ServiceMock_AdjustStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AdjustStockWithContext provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) AdjustStockWithContext(ctx context.Context, productId string, adj product.StockAdjustment) (*product.Product, error) {
+	ret := _mock.Called(ctx, productId, adj)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdjustStockWithContext")
+	}
+
+	var r0 *product.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, product.StockAdjustment) (*product.Product, error)); ok {
+		return returnFunc(ctx, productId, adj)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, product.StockAdjustment) *product.Product); ok {
+		r0 = returnFunc(ctx, productId, adj)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*product.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, product.StockAdjustment) error); ok {
+		r1 = returnFunc(ctx, productId, adj)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ServiceMock_AdjustStockWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdjustStockWithContext'
+type ServiceMock_AdjustStockWithContext_Call struct {
+	*mock.Call
+}
+
+// AdjustStockWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productId string
+//   - adj product.StockAdjustment
+func (_e *ServiceMock_Expecter) AdjustStockWithContext(ctx interface{}, productId interface{}, adj interface{}) *ServiceMock_AdjustStockWithContext_Call {
+	return &ServiceMock_AdjustStockWithContext_Call{Call: _e.mock.On("AdjustStockWithContext", ctx, productId, adj)}
+}
+
+func (_c *ServiceMock_AdjustStockWithContext_Call) Run(run func(ctx context.Context, productId string, adj product.StockAdjustment)) *ServiceMock_AdjustStockWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 product.StockAdjustment
+		if args[2] != nil {
+			arg2 = args[2].(product.StockAdjustment)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_AdjustStockWithContext_Call) Return(product1 *product.Product, err error) *ServiceMock_AdjustStockWithContext_Call {
+	_c.Call.Return(product1, err)
+	return _c
+}
+
+func (_c *ServiceMock_AdjustStockWithContext_Call) RunAndReturn(run func(ctx context.Context, productId string, adj product.StockAdjustment) (*product.Product, error)) *ServiceMock_AdjustStockWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) Create(p product.Product) (*product.Product, error) {
+	ret := _mock.Called(p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *product.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(product.Product) (*product.Product, error)); ok {
+		return returnFunc(p)
+	}
+	if returnFunc, ok := ret.Get(0).(func(product.Product) *product.Product); ok {
+		r0 = returnFunc(p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*product.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(product.Product) error); ok {
+		r1 = returnFunc(p)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ServiceMock_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type ServiceMock_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - p product.Product
+func (_e *ServiceMock_Expecter) Create(p interface{}) *ServiceMock_Create_Call {
+	return &ServiceMock_Create_Call{Call: _e.mock.On("Create", p)}
+}
+
+func (_c *ServiceMock_Create_Call) Run(run func(p product.Product)) *ServiceMock_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 product.Product
+		if args[0] != nil {
+			arg0 = args[0].(product.Product)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_Create_Call) Return(product1 *product.Product, err error) *ServiceMock_Create_Call {
+	_c.Call.Return(product1, err)
+	return _c
+}
+
+func (_c *ServiceMock_Create_Call) RunAndReturn(run func(p product.Product) (*product.Product, error)) *ServiceMock_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWithContext provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) CreateWithContext(ctx context.Context, p product.Product) (*product.Product, error) {
+	ret := _mock.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWithContext")
+	}
+
+	var r0 *product.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.Product) (*product.Product, error)); ok {
+		return returnFunc(ctx, p)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.Product) *product.Product); ok {
+		r0 = returnFunc(ctx, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*product.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, product.Product) error); ok {
+		r1 = returnFunc(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ServiceMock_CreateWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWithContext'
+type ServiceMock_CreateWithContext_Call struct {
+	*mock.Call
+}
+
+// CreateWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - p product.Product
+func (_e *ServiceMock_Expecter) CreateWithContext(ctx interface{}, p interface{}) *ServiceMock_CreateWithContext_Call {
+	return &ServiceMock_CreateWithContext_Call{Call: _e.mock.On("CreateWithContext", ctx, p)}
+}
+
+func (_c *ServiceMock_CreateWithContext_Call) Run(run func(ctx context.Context, p product.Product)) *ServiceMock_CreateWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 product.Product
+		if args[1] != nil {
+			arg1 = args[1].(product.Product)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_CreateWithContext_Call) Return(product1 *product.Product, err error) *ServiceMock_CreateWithContext_Call {
+	_c.Call.Return(product1, err)
+	return _c
+}
+
+func (_c *ServiceMock_CreateWithContext_Call) RunAndReturn(run func(ctx context.Context, p product.Product) (*product.Product, error)) *ServiceMock_CreateWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) Delete(productId string) error {
+	ret := _mock.Called(productId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(productId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ServiceMock_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type ServiceMock_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - productId string
+func (_e *ServiceMock_Expecter) Delete(productId interface{}) *ServiceMock_Delete_Call {
+	return &ServiceMock_Delete_Call{Call: _e.mock.On("Delete", productId)}
+}
+
+func (_c *ServiceMock_Delete_Call) Run(run func(productId string)) *ServiceMock_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_Delete_Call) Return(err error) *ServiceMock_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceMock_Delete_Call) RunAndReturn(run func(productId string) error) *ServiceMock_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWithContext provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) DeleteWithContext(ctx context.Context, productId string) error {
+	ret := _mock.Called(ctx, productId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWithContext")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, productId)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ServiceMock_DeleteWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWithContext'
+type ServiceMock_DeleteWithContext_Call struct {
+	*mock.Call
+}
+
+// DeleteWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productId string
+func (_e *ServiceMock_Expecter) DeleteWithContext(ctx interface{}, productId interface{}) *ServiceMock_DeleteWithContext_Call {
+	return &ServiceMock_DeleteWithContext_Call{Call: _e.mock.On("DeleteWithContext", ctx, productId)}
+}
+
+func (_c *ServiceMock_DeleteWithContext_Call) Run(run func(ctx context.Context, productId string)) *ServiceMock_DeleteWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_DeleteWithContext_Call) Return(err error) *ServiceMock_DeleteWithContext_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceMock_DeleteWithContext_Call) RunAndReturn(run func(ctx context.Context, productId string) error) *ServiceMock_DeleteWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Export provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) Export(filters product.ProductFilter, handler func(p product.Product) error) error {
+	ret := _mock.Called(filters, handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(product.ProductFilter, func(p product.Product) error) error); ok {
+		r0 = returnFunc(filters, handler)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ServiceMock_Export_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Export'
+type ServiceMock_Export_Call struct {
+	*mock.Call
+}
+
+// Export is a helper method to define mock.On call
+//   - filters product.ProductFilter
+//   - handler func(p product.Product) error
+func (_e *ServiceMock_Expecter) Export(filters interface{}, handler interface{}) *ServiceMock_Export_Call {
+	return &ServiceMock_Export_Call{Call: _e.mock.On("Export", filters, handler)}
+}
+
+func (_c *ServiceMock_Export_Call) Run(run func(filters product.ProductFilter, handler func(p product.Product) error)) *ServiceMock_Export_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 product.ProductFilter
+		if args[0] != nil {
+			arg0 = args[0].(product.ProductFilter)
+		}
+		var arg1 func(p product.Product) error
+		if args[1] != nil {
+			arg1 = args[1].(func(p product.Product) error)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_Export_Call) Return(err error) *ServiceMock_Export_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceMock_Export_Call) RunAndReturn(run func(filters product.ProductFilter, handler func(p product.Product) error) error) *ServiceMock_Export_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportWithContext provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) ExportWithContext(ctx context.Context, filters product.ProductFilter, handler func(p product.Product) error) error {
+	ret := _mock.Called(ctx, filters, handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportWithContext")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.ProductFilter, func(p product.Product) error) error); ok {
+		r0 = returnFunc(ctx, filters, handler)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ServiceMock_ExportWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportWithContext'
+type ServiceMock_ExportWithContext_Call struct {
+	*mock.Call
+}
+
+// ExportWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters product.ProductFilter
+//   - handler func(p product.Product) error
+func (_e *ServiceMock_Expecter) ExportWithContext(ctx interface{}, filters interface{}, handler interface{}) *ServiceMock_ExportWithContext_Call {
+	return &ServiceMock_ExportWithContext_Call{Call: _e.mock.On("ExportWithContext", ctx, filters, handler)}
+}
+
+func (_c *ServiceMock_ExportWithContext_Call) Run(run func(ctx context.Context, filters product.ProductFilter, handler func(p product.Product) error)) *ServiceMock_ExportWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 product.ProductFilter
+		if args[1] != nil {
+			arg1 = args[1].(product.ProductFilter)
+		}
+		var arg2 func(p product.Product) error
+		if args[2] != nil {
+			arg2 = args[2].(func(p product.Product) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_ExportWithContext_Call) Return(err error) *ServiceMock_ExportWithContext_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ServiceMock_ExportWithContext_Call) RunAndReturn(run func(ctx context.Context, filters product.ProductFilter, handler func(p product.Product) error) error) *ServiceMock_ExportWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAll provides a mock function for the type ServiceMock
 func (_mock *ServiceMock) GetAll(filters product.ProductFilter) ([]product.Product, int, error) {
 	ret := _mock.Called(filters)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAll")
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []product.Product
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(product.ProductFilter) ([]product.Product, int, error)); ok {
+		return returnFunc(filters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(product.ProductFilter) []product.Product); ok {
+		r0 = returnFunc(filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]product.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(product.ProductFilter) int); ok {
+		r1 = returnFunc(filters)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(product.ProductFilter) error); ok {
+		r2 = returnFunc(filters)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// ServiceMock_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type ServiceMock_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - filters product.ProductFilter
+func (_e *ServiceMock_Expecter) GetAll(filters interface{}) *ServiceMock_GetAll_Call {
+	return &ServiceMock_GetAll_Call{Call: _e.mock.On("GetAll", filters)}
+}
+
+func (_c *ServiceMock_GetAll_Call) Run(run func(filters product.ProductFilter)) *ServiceMock_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 product.ProductFilter
+		if args[0] != nil {
+			arg0 = args[0].(product.ProductFilter)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_GetAll_Call) Return(products []product.Product, n int, err error) *ServiceMock_GetAll_Call {
+	_c.Call.Return(products, n, err)
+	return _c
+}
+
+func (_c *ServiceMock_GetAll_Call) RunAndReturn(run func(filters product.ProductFilter) ([]product.Product, int, error)) *ServiceMock_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllWithContext provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) GetAllWithContext(ctx context.Context, filters product.ProductFilter) ([]product.Product, int, error) {
+	ret := _mock.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllWithContext")
+	}
+
+	var r0 []product.Product
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.ProductFilter) ([]product.Product, int, error)); ok {
+		return returnFunc(ctx, filters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.ProductFilter) []product.Product); ok {
+		r0 = returnFunc(ctx, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]product.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, product.ProductFilter) int); ok {
+		r1 = returnFunc(ctx, filters)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, product.ProductFilter) error); ok {
+		r2 = returnFunc(ctx, filters)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// ServiceMock_GetAllWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllWithContext'
+type ServiceMock_GetAllWithContext_Call struct {
+	*mock.Call
+}
+
+// GetAllWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters product.ProductFilter
+func (_e *ServiceMock_Expecter) GetAllWithContext(ctx interface{}, filters interface{}) *ServiceMock_GetAllWithContext_Call {
+	return &ServiceMock_GetAllWithContext_Call{Call: _e.mock.On("GetAllWithContext", ctx, filters)}
+}
+
+func (_c *ServiceMock_GetAllWithContext_Call) Run(run func(ctx context.Context, filters product.ProductFilter)) *ServiceMock_GetAllWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 product.ProductFilter
+		if args[1] != nil {
+			arg1 = args[1].(product.ProductFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_GetAllWithContext_Call) Return(products []product.Product, n int, err error) *ServiceMock_GetAllWithContext_Call {
+	_c.Call.Return(products, n, err)
+	return _c
+}
+
+func (_c *ServiceMock_GetAllWithContext_Call) RunAndReturn(run func(ctx context.Context, filters product.ProductFilter) ([]product.Product, int, error)) *ServiceMock_GetAllWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) GetByID(productId string) (*product.Product, error) {
+	ret := _mock.Called(productId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *product.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*product.Product, error)); ok {
+		return returnFunc(productId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *product.Product); ok {
+		r0 = returnFunc(productId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*product.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(productId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ServiceMock_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type ServiceMock_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - productId string
+func (_e *ServiceMock_Expecter) GetByID(productId interface{}) *ServiceMock_GetByID_Call {
+	return &ServiceMock_GetByID_Call{Call: _e.mock.On("GetByID", productId)}
+}
+
+func (_c *ServiceMock_GetByID_Call) Run(run func(productId string)) *ServiceMock_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_GetByID_Call) Return(product1 *product.Product, err error) *ServiceMock_GetByID_Call {
+	_c.Call.Return(product1, err)
+	return _c
+}
+
+func (_c *ServiceMock_GetByID_Call) RunAndReturn(run func(productId string) (*product.Product, error)) *ServiceMock_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIDWithContext provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) GetByIDWithContext(ctx context.Context, productId string) (*product.Product, error) {
+	ret := _mock.Called(ctx, productId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDWithContext")
+	}
+
+	var r0 *product.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*product.Product, error)); ok {
+		return returnFunc(ctx, productId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *product.Product); ok {
+		r0 = returnFunc(ctx, productId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*product.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, productId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ServiceMock_GetByIDWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDWithContext'
+type ServiceMock_GetByIDWithContext_Call struct {
+	*mock.Call
+}
+
+// GetByIDWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productId string
+func (_e *ServiceMock_Expecter) GetByIDWithContext(ctx interface{}, productId interface{}) *ServiceMock_GetByIDWithContext_Call {
+	return &ServiceMock_GetByIDWithContext_Call{Call: _e.mock.On("GetByIDWithContext", ctx, productId)}
+}
+
+func (_c *ServiceMock_GetByIDWithContext_Call) Run(run func(ctx context.Context, productId string)) *ServiceMock_GetByIDWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_GetByIDWithContext_Call) Return(product1 *product.Product, err error) *ServiceMock_GetByIDWithContext_Call {
+	_c.Call.Return(product1, err)
+	return _c
+}
+
+func (_c *ServiceMock_GetByIDWithContext_Call) RunAndReturn(run func(ctx context.Context, productId string) (*product.Product, error)) *ServiceMock_GetByIDWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFacets provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) GetFacets(filters product.ProductFilter) (product.Facets, error) {
+	ret := _mock.Called(filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFacets")
+	}
+
+	var r0 product.Facets
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(product.ProductFilter) (product.Facets, error)); ok {
+		return returnFunc(filters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(product.ProductFilter) product.Facets); ok {
+		r0 = returnFunc(filters)
+	} else {
+		r0 = ret.Get(0).(product.Facets)
+	}
+	if returnFunc, ok := ret.Get(1).(func(product.ProductFilter) error); ok {
+		r1 = returnFunc(filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ServiceMock_GetFacets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFacets'
+type ServiceMock_GetFacets_Call struct {
+	*mock.Call
+}
+
+// GetFacets is a helper method to define mock.On call
+//   - filters product.ProductFilter
+func (_e *ServiceMock_Expecter) GetFacets(filters interface{}) *ServiceMock_GetFacets_Call {
+	return &ServiceMock_GetFacets_Call{Call: _e.mock.On("GetFacets", filters)}
+}
+
+func (_c *ServiceMock_GetFacets_Call) Run(run func(filters product.ProductFilter)) *ServiceMock_GetFacets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 product.ProductFilter
+		if args[0] != nil {
+			arg0 = args[0].(product.ProductFilter)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_GetFacets_Call) Return(facets product.Facets, err error) *ServiceMock_GetFacets_Call {
+	_c.Call.Return(facets, err)
+	return _c
+}
+
+func (_c *ServiceMock_GetFacets_Call) RunAndReturn(run func(filters product.ProductFilter) (product.Facets, error)) *ServiceMock_GetFacets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFacetsWithContext provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) GetFacetsWithContext(ctx context.Context, filters product.ProductFilter) (product.Facets, error) {
+	ret := _mock.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFacetsWithContext")
+	}
+
+	var r0 product.Facets
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.ProductFilter) (product.Facets, error)); ok {
+		return returnFunc(ctx, filters)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.ProductFilter) product.Facets); ok {
+		r0 = returnFunc(ctx, filters)
+	} else {
+		r0 = ret.Get(0).(product.Facets)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, product.ProductFilter) error); ok {
+		r1 = returnFunc(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ServiceMock_GetFacetsWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFacetsWithContext'
+type ServiceMock_GetFacetsWithContext_Call struct {
+	*mock.Call
+}
+
+// GetFacetsWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters product.ProductFilter
+func (_e *ServiceMock_Expecter) GetFacetsWithContext(ctx interface{}, filters interface{}) *ServiceMock_GetFacetsWithContext_Call {
+	return &ServiceMock_GetFacetsWithContext_Call{Call: _e.mock.On("GetFacetsWithContext", ctx, filters)}
+}
+
+func (_c *ServiceMock_GetFacetsWithContext_Call) Run(run func(ctx context.Context, filters product.ProductFilter)) *ServiceMock_GetFacetsWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 product.ProductFilter
+		if args[1] != nil {
+			arg1 = args[1].(product.ProductFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_GetFacetsWithContext_Call) Return(facets product.Facets, err error) *ServiceMock_GetFacetsWithContext_Call {
+	_c.Call.Return(facets, err)
+	return _c
+}
+
+func (_c *ServiceMock_GetFacetsWithContext_Call) RunAndReturn(run func(ctx context.Context, filters product.ProductFilter) (product.Facets, error)) *ServiceMock_GetFacetsWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Import provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) Import(rows []product.ImportRow, opts product.ImportOptions) (product.ImportReport, error) {
+	ret := _mock.Called(rows, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 product.ImportReport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]product.ImportRow, product.ImportOptions) (product.ImportReport, error)); ok {
+		return returnFunc(rows, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]product.ImportRow, product.ImportOptions) product.ImportReport); ok {
+		r0 = returnFunc(rows, opts)
+	} else {
+		r0 = ret.Get(0).(product.ImportReport)
+	}
+	if returnFunc, ok := ret.Get(1).(func([]product.ImportRow, product.ImportOptions) error); ok {
+		r1 = returnFunc(rows, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ServiceMock_Import_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Import'
+type ServiceMock_Import_Call struct {
+	*mock.Call
+}
+
+// Import is a helper method to define mock.On call
+//   - rows []product.ImportRow
+//   - opts product.ImportOptions
+func (_e *ServiceMock_Expecter) Import(rows interface{}, opts interface{}) *ServiceMock_Import_Call {
+	return &ServiceMock_Import_Call{Call: _e.mock.On("Import", rows, opts)}
+}
+
+func (_c *ServiceMock_Import_Call) Run(run func(rows []product.ImportRow, opts product.ImportOptions)) *ServiceMock_Import_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []product.ImportRow
+		if args[0] != nil {
+			arg0 = args[0].([]product.ImportRow)
+		}
+		var arg1 product.ImportOptions
+		if args[1] != nil {
+			arg1 = args[1].(product.ImportOptions)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_Import_Call) Return(importReport product.ImportReport, err error) *ServiceMock_Import_Call {
+	_c.Call.Return(importReport, err)
+	return _c
+}
+
+func (_c *ServiceMock_Import_Call) RunAndReturn(run func(rows []product.ImportRow, opts product.ImportOptions) (product.ImportReport, error)) *ServiceMock_Import_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportWithContext provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) ImportWithContext(ctx context.Context, rows []product.ImportRow, opts product.ImportOptions) (product.ImportReport, error) {
+	ret := _mock.Called(ctx, rows, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportWithContext")
+	}
+
+	var r0 product.ImportReport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []product.ImportRow, product.ImportOptions) (product.ImportReport, error)); ok {
+		return returnFunc(ctx, rows, opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []product.ImportRow, product.ImportOptions) product.ImportReport); ok {
+		r0 = returnFunc(ctx, rows, opts)
+	} else {
+		r0 = ret.Get(0).(product.ImportReport)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []product.ImportRow, product.ImportOptions) error); ok {
+		r1 = returnFunc(ctx, rows, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ServiceMock_ImportWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportWithContext'
+type ServiceMock_ImportWithContext_Call struct {
+	*mock.Call
+}
+
+// ImportWithContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rows []product.ImportRow
+//   - opts product.ImportOptions
+func (_e *ServiceMock_Expecter) ImportWithContext(ctx interface{}, rows interface{}, opts interface{}) *ServiceMock_ImportWithContext_Call {
+	return &ServiceMock_ImportWithContext_Call{Call: _e.mock.On("ImportWithContext", ctx, rows, opts)}
+}
+
+func (_c *ServiceMock_ImportWithContext_Call) Run(run func(ctx context.Context, rows []product.ImportRow, opts product.ImportOptions)) *ServiceMock_ImportWithContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []product.ImportRow
+		if args[1] != nil {
+			arg1 = args[1].([]product.ImportRow)
+		}
+		var arg2 product.ImportOptions
+		if args[2] != nil {
+			arg2 = args[2].(product.ImportOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ServiceMock_ImportWithContext_Call) Return(importReport product.ImportReport, err error) *ServiceMock_ImportWithContext_Call {
+	_c.Call.Return(importReport, err)
+	return _c
+}
+
+func (_c *ServiceMock_ImportWithContext_Call) RunAndReturn(run func(ctx context.Context, rows []product.ImportRow, opts product.ImportOptions) (product.ImportReport, error)) *ServiceMock_ImportWithContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) Search(filters product.SearchFilter) ([]product.Product, int, error) {
+	ret := _mock.Called(filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
 	}
 
 	var r0 []product.Product
 	var r1 int
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(product.ProductFilter) ([]product.Product, int, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(product.SearchFilter) ([]product.Product, int, error)); ok {
 		return returnFunc(filters)
 	}
-	if returnFunc, ok := ret.Get(0).(func(product.ProductFilter) []product.Product); ok {
+	if returnFunc, ok := ret.Get(0).(func(product.SearchFilter) []product.Product); ok {
 		r0 = returnFunc(filters)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]product.Product)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(product.ProductFilter) int); ok {
+	if returnFunc, ok := ret.Get(1).(func(product.SearchFilter) int); ok {
 		r1 = returnFunc(filters)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
-	if returnFunc, ok := ret.Get(2).(func(product.ProductFilter) error); ok {
+	if returnFunc, ok := ret.Get(2).(func(product.SearchFilter) error); ok {
 		r2 = returnFunc(filters)
 	} else {
 		r2 = ret.Error(2)
@@ -72,22 +1108,22 @@ func (_mock *ServiceMock) GetAll(filters product.ProductFilter) ([]product.Produ
 	return r0, r1, r2
 }
 
-// ServiceMock_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
-type ServiceMock_GetAll_Call struct {
+// ServiceMock_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type ServiceMock_Search_Call struct {
 	*mock.Call
 }
 
-// GetAll is a helper method to define mock.On call
-//   - filters product.ProductFilter
-func (_e *ServiceMock_Expecter) GetAll(filters interface{}) *ServiceMock_GetAll_Call {
-	return &ServiceMock_GetAll_Call{Call: _e.mock.On("GetAll", filters)}
+// Search is a helper method to define mock.On call
+//   - filters product.SearchFilter
+func (_e *ServiceMock_Expecter) Search(filters interface{}) *ServiceMock_Search_Call {
+	return &ServiceMock_Search_Call{Call: _e.mock.On("Search", filters)}
 }
 
-func (_c *ServiceMock_GetAll_Call) Run(run func(filters product.ProductFilter)) *ServiceMock_GetAll_Call {
+func (_c *ServiceMock_Search_Call) Run(run func(filters product.SearchFilter)) *ServiceMock_Search_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 product.ProductFilter
+		var arg0 product.SearchFilter
 		if args[0] != nil {
-			arg0 = args[0].(product.ProductFilter)
+			arg0 = args[0].(product.SearchFilter)
 		}
 		run(
 			arg0,
@@ -96,43 +1132,43 @@ func (_c *ServiceMock_GetAll_Call) Run(run func(filters product.ProductFilter))
 	return _c
 }
 
-func (_c *ServiceMock_GetAll_Call) Return(products []product.Product, n int, err error) *ServiceMock_GetAll_Call {
+func (_c *ServiceMock_Search_Call) Return(products []product.Product, n int, err error) *ServiceMock_Search_Call {
 	_c.Call.Return(products, n, err)
 	return _c
 }
 
-func (_c *ServiceMock_GetAll_Call) RunAndReturn(run func(filters product.ProductFilter) ([]product.Product, int, error)) *ServiceMock_GetAll_Call {
+func (_c *ServiceMock_Search_Call) RunAndReturn(run func(filters product.SearchFilter) ([]product.Product, int, error)) *ServiceMock_Search_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllWithContext provides a mock function for the type ServiceMock
-func (_mock *ServiceMock) GetAllWithContext(ctx context.Context, filters product.ProductFilter) ([]product.Product, int, error) {
+// SearchWithContext provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) SearchWithContext(ctx context.Context, filters product.SearchFilter) ([]product.Product, int, error) {
 	ret := _mock.Called(ctx, filters)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllWithContext")
+		panic("no return value specified for SearchWithContext")
 	}
 
 	var r0 []product.Product
 	var r1 int
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, product.ProductFilter) ([]product.Product, int, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.SearchFilter) ([]product.Product, int, error)); ok {
 		return returnFunc(ctx, filters)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, product.ProductFilter) []product.Product); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.SearchFilter) []product.Product); ok {
 		r0 = returnFunc(ctx, filters)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]product.Product)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, product.ProductFilter) int); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, product.SearchFilter) int); ok {
 		r1 = returnFunc(ctx, filters)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, product.ProductFilter) error); ok {
+	if returnFunc, ok := ret.Get(2).(func(context.Context, product.SearchFilter) error); ok {
 		r2 = returnFunc(ctx, filters)
 	} else {
 		r2 = ret.Error(2)
@@ -140,27 +1176,27 @@ func (_mock *ServiceMock) GetAllWithContext(ctx context.Context, filters product
 	return r0, r1, r2
 }
 
-// ServiceMock_GetAllWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllWithContext'
-type ServiceMock_GetAllWithContext_Call struct {
+// ServiceMock_SearchWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchWithContext'
+type ServiceMock_SearchWithContext_Call struct {
 	*mock.Call
 }
 
-// GetAllWithContext is a helper method to define mock.On call
+// SearchWithContext is a helper method to define mock.On call
 //   - ctx context.Context
-//   - filters product.ProductFilter
-func (_e *ServiceMock_Expecter) GetAllWithContext(ctx interface{}, filters interface{}) *ServiceMock_GetAllWithContext_Call {
-	return &ServiceMock_GetAllWithContext_Call{Call: _e.mock.On("GetAllWithContext", ctx, filters)}
+//   - filters product.SearchFilter
+func (_e *ServiceMock_Expecter) SearchWithContext(ctx interface{}, filters interface{}) *ServiceMock_SearchWithContext_Call {
+	return &ServiceMock_SearchWithContext_Call{Call: _e.mock.On("SearchWithContext", ctx, filters)}
 }
 
-func (_c *ServiceMock_GetAllWithContext_Call) Run(run func(ctx context.Context, filters product.ProductFilter)) *ServiceMock_GetAllWithContext_Call {
+func (_c *ServiceMock_SearchWithContext_Call) Run(run func(ctx context.Context, filters product.SearchFilter)) *ServiceMock_SearchWithContext_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 product.ProductFilter
+		var arg1 product.SearchFilter
 		if args[1] != nil {
-			arg1 = args[1].(product.ProductFilter)
+			arg1 = args[1].(product.SearchFilter)
 		}
 		run(
 			arg0,
@@ -170,60 +1206,60 @@ func (_c *ServiceMock_GetAllWithContext_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *ServiceMock_GetAllWithContext_Call) Return(products []product.Product, n int, err error) *ServiceMock_GetAllWithContext_Call {
+func (_c *ServiceMock_SearchWithContext_Call) Return(products []product.Product, n int, err error) *ServiceMock_SearchWithContext_Call {
 	_c.Call.Return(products, n, err)
 	return _c
 }
 
-func (_c *ServiceMock_GetAllWithContext_Call) RunAndReturn(run func(ctx context.Context, filters product.ProductFilter) ([]product.Product, int, error)) *ServiceMock_GetAllWithContext_Call {
+func (_c *ServiceMock_SearchWithContext_Call) RunAndReturn(run func(ctx context.Context, filters product.SearchFilter) ([]product.Product, int, error)) *ServiceMock_SearchWithContext_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByID provides a mock function for the type ServiceMock
-func (_mock *ServiceMock) GetByID(productId string) (*product.Product, error) {
-	ret := _mock.Called(productId)
+// Update provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) Update(p product.Product) (*product.Product, error) {
+	ret := _mock.Called(p)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByID")
+		panic("no return value specified for Update")
 	}
 
 	var r0 *product.Product
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (*product.Product, error)); ok {
-		return returnFunc(productId)
+	if returnFunc, ok := ret.Get(0).(func(product.Product) (*product.Product, error)); ok {
+		return returnFunc(p)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) *product.Product); ok {
-		r0 = returnFunc(productId)
+	if returnFunc, ok := ret.Get(0).(func(product.Product) *product.Product); ok {
+		r0 = returnFunc(p)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*product.Product)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(productId)
+	if returnFunc, ok := ret.Get(1).(func(product.Product) error); ok {
+		r1 = returnFunc(p)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// ServiceMock_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
-type ServiceMock_GetByID_Call struct {
+// ServiceMock_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type ServiceMock_Update_Call struct {
 	*mock.Call
 }
 
-// GetByID is a helper method to define mock.On call
-//   - productId string
-func (_e *ServiceMock_Expecter) GetByID(productId interface{}) *ServiceMock_GetByID_Call {
-	return &ServiceMock_GetByID_Call{Call: _e.mock.On("GetByID", productId)}
+// Update is a helper method to define mock.On call
+//   - p product.Product
+func (_e *ServiceMock_Expecter) Update(p interface{}) *ServiceMock_Update_Call {
+	return &ServiceMock_Update_Call{Call: _e.mock.On("Update", p)}
 }
 
-func (_c *ServiceMock_GetByID_Call) Run(run func(productId string)) *ServiceMock_GetByID_Call {
+func (_c *ServiceMock_Update_Call) Run(run func(p product.Product)) *ServiceMock_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 product.Product
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(product.Product)
 		}
 		run(
 			arg0,
@@ -232,65 +1268,65 @@ func (_c *ServiceMock_GetByID_Call) Run(run func(productId string)) *ServiceMock
 	return _c
 }
 
-func (_c *ServiceMock_GetByID_Call) Return(product1 *product.Product, err error) *ServiceMock_GetByID_Call {
+func (_c *ServiceMock_Update_Call) Return(product1 *product.Product, err error) *ServiceMock_Update_Call {
 	_c.Call.Return(product1, err)
 	return _c
 }
 
-func (_c *ServiceMock_GetByID_Call) RunAndReturn(run func(productId string) (*product.Product, error)) *ServiceMock_GetByID_Call {
+func (_c *ServiceMock_Update_Call) RunAndReturn(run func(p product.Product) (*product.Product, error)) *ServiceMock_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByIDWithContext provides a mock function for the type ServiceMock
-func (_mock *ServiceMock) GetByIDWithContext(ctx context.Context, productId string) (*product.Product, error) {
-	ret := _mock.Called(ctx, productId)
+// UpdateWithContext provides a mock function for the type ServiceMock
+func (_mock *ServiceMock) UpdateWithContext(ctx context.Context, p product.Product) (*product.Product, error) {
+	ret := _mock.Called(ctx, p)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByIDWithContext")
+		panic("no return value specified for UpdateWithContext")
 	}
 
 	var r0 *product.Product
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*product.Product, error)); ok {
-		return returnFunc(ctx, productId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.Product) (*product.Product, error)); ok {
+		return returnFunc(ctx, p)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *product.Product); ok {
-		r0 = returnFunc(ctx, productId)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, product.Product) *product.Product); ok {
+		r0 = returnFunc(ctx, p)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*product.Product)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, productId)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, product.Product) error); ok {
+		r1 = returnFunc(ctx, p)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// ServiceMock_GetByIDWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDWithContext'
-type ServiceMock_GetByIDWithContext_Call struct {
+// ServiceMock_UpdateWithContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWithContext'
+type ServiceMock_UpdateWithContext_Call struct {
 	*mock.Call
 }
 
-// GetByIDWithContext is a helper method to define mock.On call
+// UpdateWithContext is a helper method to define mock.On call
 //   - ctx context.Context
-//   - productId string
-func (_e *ServiceMock_Expecter) GetByIDWithContext(ctx interface{}, productId interface{}) *ServiceMock_GetByIDWithContext_Call {
-	return &ServiceMock_GetByIDWithContext_Call{Call: _e.mock.On("GetByIDWithContext", ctx, productId)}
+//   - p product.Product
+func (_e *ServiceMock_Expecter) UpdateWithContext(ctx interface{}, p interface{}) *ServiceMock_UpdateWithContext_Call {
+	return &ServiceMock_UpdateWithContext_Call{Call: _e.mock.On("UpdateWithContext", ctx, p)}
 }
 
-func (_c *ServiceMock_GetByIDWithContext_Call) Run(run func(ctx context.Context, productId string)) *ServiceMock_GetByIDWithContext_Call {
+func (_c *ServiceMock_UpdateWithContext_Call) Run(run func(ctx context.Context, p product.Product)) *ServiceMock_UpdateWithContext_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 product.Product
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(product.Product)
 		}
 		run(
 			arg0,
@@ -300,12 +1336,12 @@ func (_c *ServiceMock_GetByIDWithContext_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *ServiceMock_GetByIDWithContext_Call) Return(product1 *product.Product, err error) *ServiceMock_GetByIDWithContext_Call {
+func (_c *ServiceMock_UpdateWithContext_Call) Return(product1 *product.Product, err error) *ServiceMock_UpdateWithContext_Call {
 	_c.Call.Return(product1, err)
 	return _c
 }
 
-func (_c *ServiceMock_GetByIDWithContext_Call) RunAndReturn(run func(ctx context.Context, productId string) (*product.Product, error)) *ServiceMock_GetByIDWithContext_Call {
+func (_c *ServiceMock_UpdateWithContext_Call) RunAndReturn(run func(ctx context.Context, p product.Product) (*product.Product, error)) *ServiceMock_UpdateWithContext_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,65 @@
+package product
+
+import "math"
+
+// AddRating counts one more review of stars in the aggregate Rating and
+// Reviews of p. The aggregate is updated rather than recomputed from the
+// reviews, since the ratings a catalog is seeded with come from reviews it
+// does not hold.
+func (p *Product) AddRating(stars int) {
+	p.setRating(p.ratingSum()+float64(stars), p.Reviews+1)
+}
+
+// RemoveRating takes a review of stars out of the aggregate Rating and
+// Reviews of p. Rating becomes null when no review is left.
+func (p *Product) RemoveRating(stars int) {
+	if p.Rating == nil {
+		p.setRating(0, 0)
+		return
+	}
+	p.setRating(p.ratingSum()-float64(stars), p.Reviews-1)
+}
+
+// KeepRatingSum carries the RatingSum of current over to p, a replacement
+// written without one, as long as p leaves the rating as it was.
+func (p *Product) KeepRatingSum(current Product) {
+	if p.RatingSum != nil || p.Reviews != current.Reviews {
+		return
+	}
+	if (p.Rating == nil) != (current.Rating == nil) || (p.Rating != nil && *p.Rating != *current.Rating) {
+		return
+	}
+	p.RatingSum = current.RatingSum
+}
+
+// ratingSum returns the stars of every review of p added up. Products seeded
+// with a rating have no RatingSum, which is then taken from their rating.
+func (p Product) ratingSum() float64 {
+	if p.RatingSum != nil {
+		return *p.RatingSum
+	}
+	if p.Rating == nil {
+		return 0
+	}
+	return *p.Rating * float64(p.Reviews)
+}
+
+// setRating stores the sum of the stars of p's reviews as it is and derives
+// Rating from it, so the rounding of Rating does not build up over writes.
+func (p *Product) setRating(sum float64, reviews int) {
+	p.Version++
+	if reviews <= 0 {
+		p.Reviews = 0
+		p.Rating = nil
+		p.RatingSum = nil
+		return
+	}
+	sum = min(max(sum, 0), 5*float64(reviews))
+	p.Reviews = reviews
+	p.RatingSum = Float(sum)
+	p.Rating = Float(roundRating(sum / float64(reviews)))
+}
+
+func roundRating(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -11,4 +11,19 @@ type Repository interface {
 	GetByID(productId string) (*product.Product, error)
 	GetAllWithContext(ctx context.Context, filters product.ProductFilter) ([]product.Product, int, error)
 	GetByIDWithContext(ctx context.Context, productId string) (*product.Product, error)
+	GetFacets(filters product.ProductFilter) (product.Facets, error)
+	GetFacetsWithContext(ctx context.Context, filters product.ProductFilter) (product.Facets, error)
+	Search(filters product.SearchFilter) ([]product.Product, int, error)
+	SearchWithContext(ctx context.Context, filters product.SearchFilter) ([]product.Product, int, error)
+	Create(p product.Product) error
+	CreateWithContext(ctx context.Context, p product.Product) error
+	Update(p product.Product) error
+	UpdateWithContext(ctx context.Context, p product.Product) error
+	// Modify replaces a product with what modify returns from it, atomically:
+	// no other write to the product happens in between. Errors from modify
+	// are returned as they are and nothing is written.
+	Modify(productId string, modify func(p product.Product) (product.Product, error)) (*product.Product, error)
+	ModifyWithContext(ctx context.Context, productId string, modify func(p product.Product) (product.Product, error)) (*product.Product, error)
+	Delete(productId string) error
+	DeleteWithContext(ctx context.Context, productId string) error
 }
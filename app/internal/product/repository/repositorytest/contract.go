@@ -0,0 +1,386 @@
+// Package repositorytest holds the contract every product repository
+// implementation must satisfy, run by the tests of each backend.
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/internal/product/repository"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Factory returns a repository backed by a new store holding products,
+// written in the given order.
+type Factory func(t *testing.T, products []product.Product) repository.Repository
+
+// Catalog is the data set the contract runs against.
+func Catalog() []product.Product {
+	return []product.Product{
+		{Id: "p1", Name: "Wireless Mouse", Description: "Ergonomic mouse with bluetooth", Category: "Electronics", Price: 25, Rating: product.Float(4.5), Reviews: 100, InStock: true},
+		{Id: "p2", Name: "Mechanical Keyboard", Description: "Keyboard with blue switches", Category: "Electronics", Price: 120, OriginalPrice: product.Float(150), Rating: product.Float(4.8), Reviews: 50, InStock: true},
+		{Id: "p3", Name: "Coffee Mug", Description: "Ceramic mug", Category: "Home", Price: 12.5, Rating: product.Float(4.1), Reviews: 10},
+		{Id: "p4", Name: "Desk Lamp", Description: "LED lamp for the desk", Category: "Home", Price: 45, OriginalPrice: product.Float(60), Rating: product.Float(3.9), Reviews: 30, InStock: true},
+		{Id: "p5", Name: "Bluetooth Speaker", Description: "Portable speaker", Category: "electronics", Price: 80, Rating: product.Float(4.3), Reviews: 75},
+		{Id: "p6", Name: "Notebook", Description: "Paper notebook, 100 pages", Category: "Office", Price: 5, Rating: product.Float(4.0), Reviews: 5, InStock: true},
+	}
+}
+
+// Run checks the repositories made by newRepository against the contract.
+func Run(t *testing.T, newRepository Factory) {
+	t.Run("GetByID", func(t *testing.T) { testGetByID(t, newRepository) })
+	t.Run("GetAllByID", func(t *testing.T) { testGetAllByID(t, newRepository) })
+	t.Run("GetAllFilters", func(t *testing.T) { testGetAllFilters(t, newRepository) })
+	t.Run("GetAllSorts", func(t *testing.T) { testGetAllSorts(t, newRepository) })
+	t.Run("OptionalFields", func(t *testing.T) { testOptionalFields(t, newRepository) })
+	t.Run("GetAllAfterCursor", func(t *testing.T) { testGetAllAfterCursor(t, newRepository) })
+	t.Run("GetFacets", func(t *testing.T) { testGetFacets(t, newRepository) })
+	t.Run("Search", func(t *testing.T) { testSearch(t, newRepository) })
+	t.Run("Writes", func(t *testing.T) { testWrites(t, newRepository) })
+	t.Run("Modify", func(t *testing.T) { testModify(t, newRepository) })
+	t.Run("CanceledContext", func(t *testing.T) { testCanceledContext(t, newRepository) })
+}
+
+func ids(products []product.Product) []string {
+	result := make([]string, len(products))
+	for i, p := range products {
+		result[i] = p.Id
+	}
+	return result
+}
+
+func getAll(t *testing.T, repo repository.Repository, filters product.ProductFilter) ([]string, int) {
+	t.Helper()
+	if filters.Page == 0 {
+		filters.Page = 1
+	}
+	if filters.PageSize == 0 {
+		filters.PageSize = 10
+	}
+	got, total, err := repo.GetAll(filters)
+	require.NoError(t, err)
+	return ids(got), total
+}
+
+func testGetByID(t *testing.T, newRepository Factory) {
+	repo := newRepository(t, Catalog())
+
+	got, err := repo.GetByID("p4")
+	require.NoError(t, err)
+	require.Equal(t, Catalog()[3], *got)
+
+	_, err = repo.GetByID("missing")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+}
+
+func testGetAllByID(t *testing.T, newRepository Factory) {
+	catalog := Catalog()
+	// written out of ID order, as products with random IDs are
+	catalog[0], catalog[5] = catalog[5], catalog[0]
+	catalog[2], catalog[3] = catalog[3], catalog[2]
+	repo := newRepository(t, catalog)
+
+	got, total := getAll(t, repo, product.ProductFilter{})
+	assert.Equal(t, []string{"p1", "p2", "p3", "p4", "p5", "p6"}, got)
+	assert.Equal(t, 6, total)
+
+	got, total = getAll(t, repo, product.ProductFilter{Page: 2, PageSize: 4})
+	assert.Equal(t, []string{"p5", "p6"}, got)
+	assert.Equal(t, 6, total)
+
+	got, total = getAll(t, repo, product.ProductFilter{Page: 3, PageSize: 4})
+	assert.Empty(t, got)
+	assert.Equal(t, 6, total)
+
+	// the cursor of an unsorted page resumes right after it
+	page, _, err := repo.GetAll(product.ProductFilter{Page: 1, PageSize: 4})
+	require.NoError(t, err)
+	after := product.NewCursor(page[len(page)-1], "")
+	rest, _, err := repo.GetAll(product.ProductFilter{After: &after, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"p1", "p2", "p3", "p4", "p5", "p6"}, append(ids(page), ids(rest)...))
+}
+
+func testGetAllFilters(t *testing.T, newRepository Factory) {
+	repo := newRepository(t, Catalog())
+	inStock, outOfStock := true, false
+
+	cases := map[string]struct {
+		filters product.ProductFilter
+		want    []string
+	}{
+		"category ignoring case":   {product.ProductFilter{Categories: []string{"ELECTRONICS"}}, []string{"p1", "p2", "p5"}},
+		"several categories":       {product.ProductFilter{Categories: []string{"home", "office"}}, []string{"p3", "p4", "p6"}},
+		"unknown category":         {product.ProductFilter{Categories: []string{"garden"}}, []string{}},
+		"name substring":           {product.ProductFilter{Name: "MOUSE"}, []string{"p1"}},
+		"price range is inclusive": {product.ProductFilter{MinPrice: 25, MaxPrice: 80}, []string{"p1", "p4", "p5"}},
+		"min price only":           {product.ProductFilter{MinPrice: 81}, []string{"p2"}},
+		"max price only":           {product.ProductFilter{MaxPrice: 12.5}, []string{"p3", "p6"}},
+		"category and price":       {product.ProductFilter{Categories: []string{"Home"}, MinPrice: 20}, []string{"p4"}},
+		"category price and name":  {product.ProductFilter{Categories: []string{"electronics"}, MaxPrice: 100, Name: "speaker"}, []string{"p5"}},
+		"in stock":                 {product.ProductFilter{InStock: &inStock}, []string{"p1", "p2", "p4", "p6"}},
+		"out of stock":             {product.ProductFilter{InStock: &outOfStock}, []string{"p3", "p5"}},
+		"category and in stock":    {product.ProductFilter{Categories: []string{"electronics"}, InStock: &inStock}, []string{"p1", "p2"}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, total := getAll(t, repo, tc.filters)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, len(tc.want), total)
+		})
+	}
+}
+
+func testGetAllSorts(t *testing.T, newRepository Factory) {
+	repo := newRepository(t, Catalog())
+
+	cases := map[string][]string{
+		"price":     {"p6", "p3", "p1", "p4", "p5", "p2"},
+		"-rating":   {"p2", "p1", "p5", "p3", "p6", "p4"},
+		"name":      {"p5", "p3", "p4", "p2", "p6", "p1"},
+		"-discount": {"p4", "p2", "p1", "p3", "p5", "p6"},
+		"reviews":   {"p6", "p3", "p4", "p2", "p5", "p1"},
+	}
+	for sort, want := range cases {
+		t.Run(sort, func(t *testing.T) {
+			got, total := getAll(t, repo, product.ProductFilter{Sort: sort})
+			assert.Equal(t, want, got)
+			assert.Equal(t, 6, total)
+
+			got, total = getAll(t, repo, product.ProductFilter{Sort: sort, Page: 2, PageSize: 4})
+			assert.Equal(t, want[4:], got)
+			assert.Equal(t, 6, total)
+		})
+	}
+
+	got, total := getAll(t, repo, product.ProductFilter{Sort: "-price", Categories: []string{"home"}})
+	assert.Equal(t, []string{"p4", "p3"}, got)
+	assert.Equal(t, 2, total)
+}
+
+func testOptionalFields(t *testing.T, newRepository Factory) {
+	unrated := product.Product{Id: "p7", Name: "Pencil", Category: "Office", Price: 1}
+	repo := newRepository(t, append(Catalog(), unrated))
+
+	got, err := repo.GetByID("p7")
+	require.NoError(t, err)
+	assert.Nil(t, got.Rating)
+	assert.Nil(t, got.OriginalPrice)
+
+	// unrated products sort as rated 0
+	sorted, _ := getAll(t, repo, product.ProductFilter{Sort: "-rating"})
+	assert.Equal(t, []string{"p2", "p1", "p5", "p3", "p6", "p4", "p7"}, sorted)
+
+	facets, err := repo.GetFacets(product.ProductFilter{Facets: []string{product.FacetRating}})
+	require.NoError(t, err)
+	rated := 0
+	for _, bucket := range facets[product.FacetRating] {
+		rated += bucket.Count
+	}
+	assert.Equal(t, 6, rated)
+}
+
+func testGetAllAfterCursor(t *testing.T, newRepository Factory) {
+	repo := newRepository(t, Catalog())
+
+	for _, sort := range []string{"", "-price", "name"} {
+		filters := product.ProductFilter{Sort: sort, PageSize: 4}
+		var seen []string
+		for {
+			page, total, err := repo.GetAll(filters)
+			require.NoError(t, err)
+			require.Equal(t, 6, total, sort)
+			seen = append(seen, ids(page)...)
+			if len(page) < filters.PageSize {
+				break
+			}
+			after := product.NewCursor(page[len(page)-1], sort)
+			filters.After = &after
+		}
+
+		want, _ := getAll(t, repo, product.ProductFilter{Sort: sort})
+		if sort == "" {
+			want = []string{"p1", "p2", "p3", "p4", "p5", "p6"}
+		}
+		assert.Equal(t, want, seen, sort)
+	}
+}
+
+func testGetFacets(t *testing.T, newRepository Factory) {
+	repo := newRepository(t, Catalog())
+
+	fields := []string{product.FacetCategory, product.FacetPriceRange, product.FacetInStock, product.FacetRating}
+	for _, filters := range []product.ProductFilter{
+		{Facets: fields},
+		{Facets: fields, MinPrice: 20},
+		{Facets: fields, Categories: []string{"electronics"}, PriceBuckets: []float64{50, 100}},
+	} {
+		want := product.NewFacetCounter(filters.Facets, filters.PriceBuckets)
+		for _, p := range Catalog() {
+			if filters.Matches(p) {
+				want.Add(p)
+			}
+		}
+
+		got, err := repo.GetFacets(filters)
+		require.NoError(t, err)
+		assert.Equal(t, want.Facets(), got)
+	}
+}
+
+func testSearch(t *testing.T, newRepository Factory) {
+	repo := newRepository(t, Catalog())
+
+	search := func(filters product.SearchFilter) ([]string, int) {
+		t.Helper()
+		if filters.Page == 0 {
+			filters.Page = 1
+		}
+		if filters.PageSize == 0 {
+			filters.PageSize = 10
+		}
+		got, total, err := repo.Search(filters)
+		require.NoError(t, err)
+		return ids(got), total
+	}
+
+	// the exact match ranks first, then the prefix matched in a name before
+	// the one matched in a description
+	got, total := search(product.SearchFilter{Query: "blue"})
+	assert.Equal(t, []string{"p2", "p5", "p1"}, got)
+	assert.Equal(t, 3, total)
+
+	got, total = search(product.SearchFilter{Query: "blue", Page: 2, PageSize: 2})
+	assert.Equal(t, []string{"p1"}, got)
+	assert.Equal(t, 3, total)
+
+	got, total = search(product.SearchFilter{Query: "blue", Categories: []string{"ELECTRONICS"}, Page: 1, PageSize: 1})
+	assert.Equal(t, []string{"p2"}, got)
+	assert.Equal(t, 3, total)
+
+	got, _ = search(product.SearchFilter{Query: "lamp", Categories: []string{"office"}})
+	assert.Empty(t, got)
+
+	got, _ = search(product.SearchFilter{Query: "Cerâmica MUG"})
+	assert.Equal(t, []string{"p3"}, got)
+
+	got, total = search(product.SearchFilter{Query: "x"})
+	assert.Empty(t, got)
+	assert.Zero(t, total)
+}
+
+func testWrites(t *testing.T, newRepository Factory) {
+	repo := newRepository(t, Catalog())
+
+	created := product.Product{Id: "p7", Name: "Garden Hose", Description: "Hose", Category: "Garden", Price: 30}
+	require.NoError(t, repo.CreateWithContext(context.Background(), created))
+	got, err := repo.GetByID("p7")
+	require.NoError(t, err)
+	require.Equal(t, created, *got)
+
+	err = repo.Create(product.Product{Id: "p1", Name: "Duplicate", Category: "Home", Price: 1})
+	require.ErrorIs(t, err, apperrors.ErrResourceAlreadyExists)
+
+	// an update moves the product out of the indexes of its previous version
+	updated := Catalog()[0]
+	updated.Category = "Garden"
+	updated.Price = 300
+	updated.Name = "Garden Mouse"
+	require.NoError(t, repo.UpdateWithContext(context.Background(), updated))
+	got, err = repo.GetByID("p1")
+	require.NoError(t, err)
+	require.Equal(t, updated, *got)
+
+	ids, _ := getAll(t, repo, product.ProductFilter{})
+	assert.Equal(t, []string{"p1", "p2", "p3", "p4", "p5", "p6", "p7"}, ids, "listings without a sort are ordered by ID")
+	ids, _ = getAll(t, repo, product.ProductFilter{Categories: []string{"electronics"}})
+	assert.Equal(t, []string{"p2", "p5"}, ids)
+	ids, _ = getAll(t, repo, product.ProductFilter{Categories: []string{"garden"}, MinPrice: 100})
+	assert.Equal(t, []string{"p1"}, ids)
+
+	hits, _, err := repo.Search(product.SearchFilter{Query: "mouse", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, "Garden Mouse", hits[0].Name)
+	hits, _, err = repo.Search(product.SearchFilter{Query: "wireless", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+
+	err = repo.Update(product.Product{Id: "missing", Name: "Missing", Category: "Home", Price: 1})
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+
+	require.NoError(t, repo.DeleteWithContext(context.Background(), "p3"))
+	_, err = repo.GetByID("p3")
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+	ids, total := getAll(t, repo, product.ProductFilter{Categories: []string{"home"}})
+	assert.Equal(t, []string{"p4"}, ids)
+	assert.Equal(t, 1, total)
+	hits, _, err = repo.Search(product.SearchFilter{Query: "mug", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+
+	require.ErrorIs(t, repo.Delete("p3"), apperrors.ErrResourceNotExists)
+
+	// a deleted ID can be used again
+	require.NoError(t, repo.Create(product.Product{Id: "p3", Name: "Tea Cup", Category: "Home", Price: 9}))
+	got, err = repo.GetByID("p3")
+	require.NoError(t, err)
+	require.Equal(t, "Tea Cup", got.Name)
+}
+
+func testModify(t *testing.T, newRepository Factory) {
+	repo := newRepository(t, Catalog())
+
+	got, err := repo.ModifyWithContext(context.Background(), "p3", func(p product.Product) (product.Product, error) {
+		p.Stock = product.Int(4)
+		p.InStock = true
+		p.Version++
+		return p, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, product.Int(4), got.Stock)
+	stored, err := repo.GetByID("p3")
+	require.NoError(t, err)
+	require.Equal(t, *got, *stored)
+
+	// the indexes follow the modified product
+	inStock := true
+	ids, _ := getAll(t, repo, product.ProductFilter{Categories: []string{"home"}, InStock: &inStock})
+	assert.Equal(t, []string{"p3", "p4"}, ids)
+
+	// errors from modify leave the product as it was
+	_, err = repo.Modify("p3", func(p product.Product) (product.Product, error) {
+		p.Stock = product.Int(0)
+		return p, product.ErrNotAvailable
+	})
+	require.ErrorIs(t, err, product.ErrNotAvailable)
+	stored, err = repo.GetByID("p3")
+	require.NoError(t, err)
+	assert.Equal(t, product.Int(4), stored.Stock)
+
+	_, err = repo.Modify("missing", func(p product.Product) (product.Product, error) {
+		t.Error("modify called for a missing product")
+		return p, nil
+	})
+	require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+}
+
+func testCanceledContext(t *testing.T, newRepository Factory) {
+	repo := newRepository(t, Catalog())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := repo.GetAllWithContext(ctx, product.ProductFilter{Page: 1, PageSize: 10})
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = repo.GetByIDWithContext(ctx, "p1")
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = repo.GetFacetsWithContext(ctx, product.ProductFilter{Facets: []string{product.FacetCategory}})
+	require.ErrorIs(t, err, context.Canceled)
+	_, _, err = repo.SearchWithContext(ctx, product.SearchFilter{Query: "mouse", Page: 1, PageSize: 10})
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = repo.ModifyWithContext(ctx, "p1", func(p product.Product) (product.Product, error) { return p, nil })
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -2,13 +2,41 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
+	"github.com/lucasti79/meli-interview/internal/category"
 	"github.com/lucasti79/meli-interview/internal/product"
 	"github.com/lucasti79/meli-interview/internal/product/repository"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
 )
 
+// ErrUnknownCategory is returned by writes of a product whose category is not
+// in the category store.
+var ErrUnknownCategory = fmt.Errorf("%w: unknown category", apperrors.ErrValidation)
+
+// CategoryFinder resolves the category a product belongs to and the category
+// hierarchy. The category repository satisfies it.
+type CategoryFinder interface {
+	GetAllWithContext(ctx context.Context) ([]category.Category, error)
+	GetByNameWithContext(ctx context.Context, name string) (*category.Category, error)
+}
+
 type service struct {
-	repo repository.Repository
+	repo       repository.Repository
+	categories CategoryFinder
+}
+
+type Option func(*service)
+
+// WithCategories validates product writes against the category store and
+// lets listings include subcategories. Without it any category is accepted
+// and IncludeSubcategories has no effect.
+func WithCategories(categories CategoryFinder) Option {
+	return func(s *service) {
+		s.categories = categories
+	}
 }
 
 type Service interface {
@@ -16,13 +44,37 @@ type Service interface {
 	GetByID(productId string) (*product.Product, error)
 	GetAllWithContext(ctx context.Context, filters product.ProductFilter) ([]product.Product, int, error)
 	GetByIDWithContext(ctx context.Context, productId string) (*product.Product, error)
+	GetFacets(filters product.ProductFilter) (product.Facets, error)
+	GetFacetsWithContext(ctx context.Context, filters product.ProductFilter) (product.Facets, error)
+	Search(filters product.SearchFilter) ([]product.Product, int, error)
+	SearchWithContext(ctx context.Context, filters product.SearchFilter) ([]product.Product, int, error)
+	Create(p product.Product) (*product.Product, error)
+	CreateWithContext(ctx context.Context, p product.Product) (*product.Product, error)
+	Update(p product.Product) (*product.Product, error)
+	UpdateWithContext(ctx context.Context, p product.Product) (*product.Product, error)
+	AdjustStock(productId string, adj product.StockAdjustment) (*product.Product, error)
+	AdjustStockWithContext(ctx context.Context, productId string, adj product.StockAdjustment) (*product.Product, error)
+	Delete(productId string) error
+	DeleteWithContext(ctx context.Context, productId string) error
+	Export(filters product.ProductFilter, handler func(p product.Product) error) error
+	ExportWithContext(ctx context.Context, filters product.ProductFilter, handler func(p product.Product) error) error
+	Import(rows []product.ImportRow, opts product.ImportOptions) (product.ImportReport, error)
+	ImportWithContext(ctx context.Context, rows []product.ImportRow, opts product.ImportOptions) (product.ImportReport, error)
 }
 
-func NewService(repo repository.Repository) Service {
-	return &service{repo: repo}
+func NewService(repo repository.Repository, opts ...Option) Service {
+	s := &service{repo: repo}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *service) GetAll(filters product.ProductFilter) ([]product.Product, int, error) {
+	filters, err := s.expandCategories(context.Background(), filters)
+	if err != nil {
+		return nil, 0, err
+	}
 	return s.repo.GetAll(filters)
 }
 
@@ -31,6 +83,10 @@ func (s *service) GetByID(productId string) (*product.Product, error) {
 }
 
 func (s *service) GetAllWithContext(ctx context.Context, filters product.ProductFilter) ([]product.Product, int, error) {
+	filters, err := s.expandCategories(ctx, filters)
+	if err != nil {
+		return nil, 0, err
+	}
 	return s.repo.GetAllWithContext(ctx, filters)
 }
 
@@ -43,3 +99,160 @@ func (s *service) GetByIDWithContext(ctx context.Context, productId string) (*pr
 
 	return pr, nil
 }
+
+func (s *service) GetFacets(filters product.ProductFilter) (product.Facets, error) {
+	filters, err := s.expandCategories(context.Background(), filters)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetFacets(filters)
+}
+
+func (s *service) GetFacetsWithContext(ctx context.Context, filters product.ProductFilter) (product.Facets, error) {
+	filters, err := s.expandCategories(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetFacetsWithContext(ctx, filters)
+}
+
+func (s *service) Search(filters product.SearchFilter) ([]product.Product, int, error) {
+	return s.repo.Search(filters)
+}
+
+func (s *service) SearchWithContext(ctx context.Context, filters product.SearchFilter) ([]product.Product, int, error) {
+	return s.repo.SearchWithContext(ctx, filters)
+}
+
+func (s *service) Create(p product.Product) (*product.Product, error) {
+	return s.CreateWithContext(context.Background(), p)
+}
+
+// CreateWithContext stores p as the first version of a new product, with
+// InStock derived from its stock, and returns what was stored.
+func (s *service) CreateWithContext(ctx context.Context, p product.Product) (*product.Product, error) {
+	if err := s.checkCategory(ctx, p); err != nil {
+		return nil, err
+	}
+	p.Version = 0
+	p.DeriveInStock()
+	if err := s.repo.CreateWithContext(ctx, p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (s *service) Update(p product.Product) (*product.Product, error) {
+	return s.UpdateWithContext(context.Background(), p)
+}
+
+// UpdateWithContext replaces a product with p and returns what was stored.
+// A p.Version other than 0 must be the current version of the product, or
+// ErrVersionConflict is returned. The reserved units are kept, as only stock
+// adjustments change them, so the stock cannot drop below them.
+func (s *service) UpdateWithContext(ctx context.Context, p product.Product) (*product.Product, error) {
+	if err := s.checkCategory(ctx, p); err != nil {
+		return nil, err
+	}
+	var version *int
+	if p.Version != 0 {
+		version = &p.Version
+	}
+	return s.repo.ModifyWithContext(ctx, p.Id, replace(p, version))
+}
+
+// replace returns the modification that stores p in place of the current
+// product, failing with ErrVersionConflict when version is set and the
+// product is at another one. The reserved units and the rating sum are kept,
+// as only stock adjustments and reviews change them.
+func replace(p product.Product, version *int) func(current product.Product) (product.Product, error) {
+	return func(current product.Product) (product.Product, error) {
+		if version != nil && *version != current.Version {
+			return p, fmt.Errorf("%w: product %s is at version %d, not %d", product.ErrVersionConflict, p.Id, current.Version, *version)
+		}
+		p.Reserved = current.Reserved
+		p.KeepRatingSum(current)
+		if p.Reserved > 0 && (p.Stock == nil || *p.Stock < p.Reserved) {
+			return p, fmt.Errorf("%w: product %s has %d reserved units", product.ErrNotAvailable, p.Id, p.Reserved)
+		}
+		p.Version = current.Version + 1
+		p.DeriveInStock()
+		return p, nil
+	}
+}
+
+func (s *service) AdjustStock(productId string, adj product.StockAdjustment) (*product.Product, error) {
+	return s.AdjustStockWithContext(context.Background(), productId, adj)
+}
+
+// AdjustStockWithContext applies adj to the stock of a product atomically and
+// returns the product as it was stored. See product.AdjustStock for the
+// errors.
+func (s *service) AdjustStockWithContext(ctx context.Context, productId string, adj product.StockAdjustment) (*product.Product, error) {
+	return s.repo.ModifyWithContext(ctx, productId, func(p product.Product) (product.Product, error) {
+		err := p.AdjustStock(adj)
+		return p, err
+	})
+}
+
+func (s *service) Delete(productId string) error {
+	return s.repo.Delete(productId)
+}
+
+func (s *service) DeleteWithContext(ctx context.Context, productId string) error {
+	return s.repo.DeleteWithContext(ctx, productId)
+}
+
+// checkCategory makes sure the product's category is the name of an existing
+// category. Case is ignored, as it is when filtering products by category.
+func (s *service) checkCategory(ctx context.Context, p product.Product) error {
+	if s.categories == nil {
+		return nil
+	}
+
+	c, err := s.categories.GetByNameWithContext(ctx, p.Category)
+	if err != nil && !errors.Is(err, apperrors.ErrResourceNotExists) {
+		return err
+	}
+	if c == nil || !strings.EqualFold(c.Name, p.Category) {
+		return fmt.Errorf("%w: %s", ErrUnknownCategory, p.Category)
+	}
+	return nil
+}
+
+// expandCategories adds the names of every subcategory of the filtered
+// categories when IncludeSubcategories is set. Filtered categories are matched
+// by name or slug; unknown ones are kept as they are.
+func (s *service) expandCategories(ctx context.Context, filters product.ProductFilter) (product.ProductFilter, error) {
+	if !filters.IncludeSubcategories || len(filters.Categories) == 0 || s.categories == nil {
+		return filters, nil
+	}
+
+	categories, err := s.categories.GetAllWithContext(ctx)
+	if err != nil {
+		return filters, err
+	}
+
+	seen := make(map[string]bool)
+	expanded := make([]string, 0, len(filters.Categories))
+	add := func(name string) {
+		if key := strings.ToLower(name); !seen[key] {
+			seen[key] = true
+			expanded = append(expanded, name)
+		}
+	}
+	for _, name := range filters.Categories {
+		add(name)
+		for _, c := range categories {
+			if strings.EqualFold(c.Name, name) || strings.EqualFold(c.Slug, name) {
+				add(c.Name)
+				for _, d := range category.Descendants(categories, c.Id) {
+					add(d.Name)
+				}
+			}
+		}
+	}
+
+	filters.Categories = expanded
+	return filters, nil
+}
@@ -3,12 +3,17 @@ package service_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/lucasti79/meli-interview/internal/category"
 	"github.com/lucasti79/meli-interview/internal/product"
 	"github.com/lucasti79/meli-interview/internal/product/infra/mocks"
 	"github.com/lucasti79/meli-interview/internal/product/service"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestService_GetAll(t *testing.T) {
@@ -117,3 +122,541 @@ func TestService_GetByIDWithContext_Error(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestService_CreateWithContext(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	ctx := context.Background()
+	p := product.Product{Id: "1", Name: "Product 1", Category: "Cat", Price: 10}
+
+	mockRepo.On("CreateWithContext", ctx, p).Return(nil).Once()
+
+	created, err := svc.CreateWithContext(ctx, p)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &p, created)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_CreateWithContext_ChecksCategoryExists(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	categories := new(mocks.CategoryFinderMock)
+	svc := service.NewService(mockRepo, service.WithCategories(categories))
+
+	ctx := context.Background()
+	p := product.Product{Id: "1", Name: "Product 1", Category: "books", Price: 10}
+
+	categories.On("GetByNameWithContext", ctx, "books").Return(&category.Category{Id: "c1", Slug: "books", Name: "Books"}, nil).Once()
+	mockRepo.On("CreateWithContext", ctx, p).Return(nil).Once()
+
+	_, err := svc.CreateWithContext(ctx, p)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	categories.AssertExpectations(t)
+}
+
+func TestService_Create_RejectsUnknownCategory(t *testing.T) {
+	tests := map[string]struct {
+		found *category.Category
+		err   error
+	}{
+		"missing":         {found: nil, err: apperrors.ErrResourceNotExists},
+		"matched by slug": {found: &category.Category{Id: "c1", Slug: "cat", Name: "Category"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockRepo := new(mocks.RepositoryMock)
+			categories := new(mocks.CategoryFinderMock)
+			svc := service.NewService(mockRepo, service.WithCategories(categories))
+
+			p := product.Product{Id: "1", Name: "Product 1", Category: "cat", Price: 10}
+			categories.On("GetByNameWithContext", context.Background(), "cat").Return(tt.found, tt.err).Once()
+
+			_, err := svc.Create(p)
+
+			assert.ErrorIs(t, err, service.ErrUnknownCategory)
+			assert.ErrorIs(t, err, apperrors.ErrValidation)
+			mockRepo.AssertNotCalled(t, "Create", p)
+		})
+	}
+}
+
+func TestService_UpdateWithContext_PropagatesCategoryLookupError(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	categories := new(mocks.CategoryFinderMock)
+	svc := service.NewService(mockRepo, service.WithCategories(categories))
+
+	ctx := context.Background()
+	p := product.Product{Id: "1", Name: "Product 1", Category: "Cat", Price: 10}
+	lookupErr := errors.New("boom")
+
+	categories.On("GetByNameWithContext", ctx, "Cat").Return(nil, lookupErr).Once()
+
+	_, err := svc.UpdateWithContext(ctx, p)
+
+	assert.ErrorIs(t, err, lookupErr)
+	mockRepo.AssertNotCalled(t, "ModifyWithContext", ctx, p.Id, mock.Anything)
+}
+
+func TestService_GetAllWithContext_IncludesSubcategories(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	categories := new(mocks.CategoryFinderMock)
+	svc := service.NewService(mockRepo, service.WithCategories(categories))
+
+	ctx := context.Background()
+	categories.On("GetAllWithContext", ctx).Return([]category.Category{
+		{Id: "c1", Slug: "electronics", Name: "Electronics"},
+		{Id: "c2", Slug: "audio", Name: "Audio", ParentId: "c1"},
+		{Id: "c3", Slug: "headphones", Name: "Headphones", ParentId: "c2"},
+		{Id: "c4", Slug: "books", Name: "Books"},
+	}, nil).Once()
+
+	filters := product.ProductFilter{Categories: []string{"audio", "Unknown"}, IncludeSubcategories: true}
+	expanded := filters
+	expanded.Categories = []string{"audio", "Headphones", "Unknown"}
+	mockRepo.On("GetAllWithContext", ctx, expanded).Return([]product.Product{}, 0, nil).Once()
+
+	_, _, err := svc.GetAllWithContext(ctx, filters)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	categories.AssertExpectations(t)
+}
+
+func TestService_GetFacets_WithoutIncludeSubcategoriesKeepsFilters(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	categories := new(mocks.CategoryFinderMock)
+	svc := service.NewService(mockRepo, service.WithCategories(categories))
+
+	filters := product.ProductFilter{Categories: []string{"Audio"}}
+	mockRepo.On("GetFacets", filters).Return(product.Facets{}, nil).Once()
+
+	_, err := svc.GetFacets(filters)
+
+	assert.NoError(t, err)
+	categories.AssertNotCalled(t, "GetAllWithContext", mock.Anything)
+}
+
+func TestService_UpdateWithContext_Error(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	ctx := context.Background()
+	p := product.Product{Id: "1", Name: "Product 1", Category: "Cat", Price: 10}
+
+	mockRepo.On("ModifyWithContext", ctx, "1", mock.Anything).Return(nil, errors.New("not found")).Once()
+
+	_, err := svc.UpdateWithContext(ctx, p)
+
+	assert.Error(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// modifying returns what the repository's ModifyWithContext returns when the
+// product stored is current.
+func modifying(current product.Product) func(context.Context, string, func(product.Product) (product.Product, error)) (*product.Product, error) {
+	return func(_ context.Context, _ string, modify func(product.Product) (product.Product, error)) (*product.Product, error) {
+		p, err := modify(current)
+		if err != nil {
+			return nil, err
+		}
+		return &p, nil
+	}
+}
+
+func TestService_UpdateWithContext_MovesToTheNextVersion(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	ctx := context.Background()
+	current := product.Product{Id: "1", Name: "Product 1", Category: "Cat", Price: 10, Stock: product.Int(5), Reserved: 2, Version: 3}
+	mockRepo.On("ModifyWithContext", ctx, "1", mock.Anything).Return(modifying(current))
+
+	// reserved units are kept and in stock is derived from the stock
+	updated, err := svc.UpdateWithContext(ctx, product.Product{Id: "1", Name: "Renamed", Category: "Cat", Price: 10, Stock: product.Int(2)})
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", updated.Name)
+	assert.Equal(t, 2, updated.Reserved)
+	assert.Equal(t, 4, updated.Version)
+	assert.False(t, updated.InStock)
+
+	_, err = svc.UpdateWithContext(ctx, product.Product{Id: "1", Name: "Stale", Category: "Cat", Price: 10, Stock: product.Int(5), Version: 2})
+	assert.ErrorIs(t, err, product.ErrVersionConflict)
+
+	_, err = svc.UpdateWithContext(ctx, product.Product{Id: "1", Name: "Product 1", Category: "Cat", Price: 10, Stock: product.Int(1)})
+	assert.ErrorIs(t, err, product.ErrNotAvailable)
+}
+
+func TestService_UpdateWithContext_KeepsTheRatingSumOfAnUnchangedRating(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	ctx := context.Background()
+	current := product.Product{Id: "1", Name: "Product 1", Category: "Cat", Price: 10, Rating: product.Float(3.67), RatingSum: product.Float(11), Reviews: 3}
+	mockRepo.On("ModifyWithContext", ctx, "1", mock.Anything).Return(modifying(current))
+
+	updated, err := svc.UpdateWithContext(ctx, product.Product{Id: "1", Name: "Renamed", Category: "Cat", Price: 10, Rating: product.Float(3.67), Reviews: 3})
+	require.NoError(t, err)
+	assert.Equal(t, product.Float(11), updated.RatingSum)
+
+	// a rating set by hand is taken as it is
+	updated, err = svc.UpdateWithContext(ctx, product.Product{Id: "1", Name: "Renamed", Category: "Cat", Price: 10, Rating: product.Float(4), Reviews: 3})
+	require.NoError(t, err)
+	assert.Nil(t, updated.RatingSum)
+}
+
+func TestService_AdjustStockWithContext(t *testing.T) {
+	untracked := product.Product{Id: "1", Name: "Product 1", Category: "Cat", Price: 10, InStock: true}
+	tracked := product.Product{Id: "1", Name: "Product 1", Category: "Cat", Price: 10, Stock: product.Int(5), Reserved: 2, InStock: true, Version: 7}
+
+	tests := map[string]struct {
+		current product.Product
+		adj     product.StockAdjustment
+		want    product.StockLevel
+		err     error
+	}{
+		"starts tracking from 0": {
+			current: untracked,
+			adj:     product.StockAdjustment{Quantity: 3},
+			want:    product.StockLevel{ProductId: "1", Stock: product.Int(3), Available: 3, InStock: true, Version: 1},
+		},
+		"removing units from an untracked product": {
+			current: untracked,
+			adj:     product.StockAdjustment{Quantity: -1},
+			err:     product.ErrNotAvailable,
+		},
+		"reserves the last units": {
+			current: tracked,
+			adj:     product.StockAdjustment{Reserved: 3, Version: product.Int(7)},
+			want:    product.StockLevel{ProductId: "1", Stock: product.Int(5), Reserved: 5, InStock: false, Version: 8},
+		},
+		"ships reserved units": {
+			current: tracked,
+			adj:     product.StockAdjustment{Quantity: -2, Reserved: -2},
+			want:    product.StockLevel{ProductId: "1", Stock: product.Int(3), Available: 3, InStock: true, Version: 8},
+		},
+		"reserves more than available": {
+			current: tracked,
+			adj:     product.StockAdjustment{Reserved: 4},
+			err:     product.ErrNotAvailable,
+		},
+		"removes reserved units": {
+			current: tracked,
+			adj:     product.StockAdjustment{Quantity: -4},
+			err:     product.ErrNotAvailable,
+		},
+		"releases more than reserved": {
+			current: tracked,
+			adj:     product.StockAdjustment{Reserved: -3},
+			err:     apperrors.ErrValidation,
+		},
+		"stale version": {
+			current: tracked,
+			adj:     product.StockAdjustment{Quantity: 1, Version: product.Int(6)},
+			err:     product.ErrVersionConflict,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockRepo := new(mocks.RepositoryMock)
+			svc := service.NewService(mockRepo)
+			ctx := context.Background()
+			mockRepo.On("ModifyWithContext", ctx, "1", mock.Anything).Return(modifying(tt.current)).Once()
+
+			got, err := svc.AdjustStockWithContext(ctx, "1", tt.adj)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.StockLevel())
+		})
+	}
+}
+
+func TestService_DeleteWithContext(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	ctx := context.Background()
+
+	mockRepo.On("DeleteWithContext", ctx, "1").Return(nil).Once()
+
+	err := svc.DeleteWithContext(ctx, "1")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_SearchWithContext(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	filters := product.SearchFilter{Query: "fone", Page: 1, PageSize: 10}
+	expectedProducts := []product.Product{{Id: "1", Name: "Fone de ouvido"}}
+
+	mockRepo.On("SearchWithContext", context.Background(), filters).Return(expectedProducts, 1, nil).Once()
+
+	products, total, err := svc.SearchWithContext(context.Background(), filters)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedProducts, products)
+	assert.Equal(t, 1, total)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_GetFacetsWithContext(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	filters := product.ProductFilter{Facets: []string{product.FacetInStock}}
+	expected := product.Facets{product.FacetInStock: {{Value: "true", Count: 3}}}
+
+	mockRepo.On("GetFacetsWithContext", context.Background(), filters).Return(expected, nil).Once()
+
+	facets, err := svc.GetFacetsWithContext(context.Background(), filters)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, facets)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_ExportWithContext_PagesWithCursorInIDOrder(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	ctx := context.Background()
+	first := make([]product.Product, 100)
+	for i := range first {
+		first[i] = product.Product{Id: fmt.Sprintf("p%03d", i)}
+	}
+	second := []product.Product{{Id: "p100"}}
+
+	mockRepo.On("GetAllWithContext", ctx, mock.MatchedBy(func(f product.ProductFilter) bool {
+		return f.After != nil && f.After.Id == "" && f.PageSize == 100 && f.Name == "p" && f.Facets == nil
+	})).Return(first, 101, nil).Once()
+	mockRepo.On("GetAllWithContext", ctx, mock.MatchedBy(func(f product.ProductFilter) bool {
+		return f.After != nil && f.After.Id == "p099" && f.Page == 0
+	})).Return(second, 101, nil).Once()
+
+	var ids []string
+	err := svc.ExportWithContext(ctx, product.ProductFilter{Name: "p", Page: 3, Facets: []string{"category"}}, func(p product.Product) error {
+		ids = append(ids, p.Id)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, ids, 101)
+	assert.Equal(t, "p100", ids[100])
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_ExportWithContext_SortedStartsOnFirstPage(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	ctx := context.Background()
+	mockRepo.On("GetAllWithContext", ctx, mock.MatchedBy(func(f product.ProductFilter) bool {
+		return f.After == nil && f.Page == 1 && f.Sort == "-price"
+	})).Return([]product.Product{{Id: "1"}}, 1, nil).Once()
+
+	handlerErr := errors.New("client gone")
+	err := svc.ExportWithContext(ctx, product.ProductFilter{Sort: "-price"}, func(p product.Product) error {
+		return handlerErr
+	})
+
+	assert.ErrorIs(t, err, handlerErr)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_ImportWithContext_ReportsEveryRowAndWritesNothing(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	categories := new(mocks.CategoryFinderMock)
+	svc := service.NewService(mockRepo, service.WithCategories(categories))
+
+	ctx := context.Background()
+	categories.On("GetByNameWithContext", ctx, "Books").Return(&category.Category{Id: "c1", Slug: "books", Name: "Books"}, nil)
+	categories.On("GetByNameWithContext", ctx, "Toys").Return(nil, apperrors.ErrResourceNotExists)
+	mockRepo.On("GetByIDWithContext", ctx, "existing").Return(&product.Product{Id: "existing"}, nil)
+	mockRepo.On("GetByIDWithContext", ctx, mock.Anything).Return(nil, apperrors.ErrResourceNotExists)
+
+	rows := []product.ImportRow{
+		{Line: 2, Product: product.Product{Id: "new", Name: "Go", Category: "Books", Price: 10}},
+		{Line: 3, Product: product.Product{Id: "existing", Name: "Rust", Category: "Books", Price: 10}},
+		{Line: 4, Product: product.Product{Id: "bad", Category: "Toys", Price: 0, Rating: product.Float(6)}},
+		{Line: 5, Product: product.Product{Id: "new", Name: "Go again", Category: "Books", Price: 10}},
+		{Line: 6, Errors: []product.ImportError{{Line: 6, Field: "price", Message: "must be a number"}}},
+	}
+
+	report, err := svc.ImportWithContext(ctx, rows, product.ImportOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, product.ImportReport{
+		Mode:    product.ImportInsert,
+		Rows:    5,
+		Created: 1,
+		Failed:  4,
+		Errors: []product.ImportError{
+			{Line: 3, Field: "productId", Message: "already exists"},
+			{Line: 4, Field: "name", Message: "is required"},
+			{Line: 4, Field: "price", Message: "must be greater than 0"},
+			{Line: 4, Field: "rating", Message: "must be at most 5"},
+			{Line: 4, Field: "category", Message: "is not an existing category"},
+			{Line: 5, Field: "productId", Message: "is repeated from line 2"},
+			{Line: 6, Field: "price", Message: "must be a number"},
+		},
+	}, report)
+	mockRepo.AssertNotCalled(t, "CreateWithContext", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "ModifyWithContext", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_ImportWithContext_UpsertCreatesAndReplaces(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	ctx := context.Background()
+	current := product.Product{Id: "existing", Name: "Rust", Category: "Books", Price: 12, Stock: product.Int(5), Reserved: 2,
+		Rating: product.Float(4), Reviews: 2, RatingSum: product.Float(8), Version: 3}
+	mockRepo.On("GetByIDWithContext", ctx, "existing").Return(&current, nil)
+	mockRepo.On("GetByIDWithContext", ctx, mock.Anything).Return(nil, apperrors.ErrResourceNotExists)
+	var stored product.Product
+	mockRepo.On("ModifyWithContext", ctx, "existing", mock.Anything).Return(
+		func(_ context.Context, _ string, modify func(product.Product) (product.Product, error)) (*product.Product, error) {
+			var err error
+			stored, err = modify(current)
+			return &stored, err
+		}).Once()
+	mockRepo.On("CreateWithContext", ctx, mock.MatchedBy(func(p product.Product) bool {
+		return p.Name == "Go" && p.Id != ""
+	})).Return(nil).Once()
+
+	rows := []product.ImportRow{
+		{Line: 1, Product: product.Product{Name: "Go", Category: "Books", Price: 10}},
+		{Line: 2, Product: product.Product{Id: "existing", Name: "Rust 2", Category: "Books", Price: 15, Stock: product.Int(10),
+			Rating: product.Float(4), Reviews: 2}},
+	}
+
+	report, err := svc.ImportWithContext(ctx, rows, product.ImportOptions{Mode: product.ImportUpsert})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Updated)
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, product.Product{Id: "existing", Name: "Rust 2", Category: "Books", Price: 15, Stock: product.Int(10), Reserved: 2,
+		InStock: true, Rating: product.Float(4), Reviews: 2, RatingSum: product.Float(8), Version: 4}, stored,
+		"keeps the reserved units and the rating sum")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_ImportWithContext_UpsertChecksTheReplacedProduct(t *testing.T) {
+	current := product.Product{Id: "existing", Name: "Rust", Category: "Books", Price: 12, Stock: product.Int(5), Reserved: 2, Version: 3}
+
+	t.Run("stock below the reserved units", func(t *testing.T) {
+		mockRepo := new(mocks.RepositoryMock)
+		svc := service.NewService(mockRepo)
+
+		ctx := context.Background()
+		mockRepo.On("GetByIDWithContext", ctx, "existing").Return(&current, nil)
+
+		report, err := svc.ImportWithContext(ctx, []product.ImportRow{
+			{Line: 1, Product: product.Product{Id: "existing", Name: "Rust", Category: "Books", Price: 12, Stock: product.Int(1)}},
+		}, product.ImportOptions{Mode: product.ImportUpsert})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []product.ImportError{{Line: 1, Field: "stock", Message: "must be at least the 2 reserved units"}}, report.Errors)
+		mockRepo.AssertNotCalled(t, "ModifyWithContext", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("written to since it was checked", func(t *testing.T) {
+		mockRepo := new(mocks.RepositoryMock)
+		svc := service.NewService(mockRepo)
+
+		ctx := context.Background()
+		mockRepo.On("GetByIDWithContext", ctx, "existing").Return(&current, nil)
+		mockRepo.On("ModifyWithContext", ctx, "existing", mock.Anything).Return(
+			func(_ context.Context, _ string, modify func(product.Product) (product.Product, error)) (*product.Product, error) {
+				changed := current
+				changed.Version = 4
+				_, err := modify(changed)
+				return nil, err
+			}).Once()
+
+		_, err := svc.ImportWithContext(ctx, []product.ImportRow{
+			{Line: 1, Product: product.Product{Id: "existing", Name: "Rust", Category: "Books", Price: 12, Stock: product.Int(5)}},
+		}, product.ImportOptions{Mode: product.ImportUpsert})
+
+		assert.ErrorIs(t, err, product.ErrVersionConflict)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestService_ImportWithContext_DryRunWritesNothing(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	ctx := context.Background()
+	mockRepo.On("GetByIDWithContext", ctx, "1").Return(nil, apperrors.ErrResourceNotExists)
+
+	report, err := svc.ImportWithContext(ctx, []product.ImportRow{
+		{Line: 1, Product: product.Product{Id: "1", Name: "Go", Category: "Books", Price: 10}},
+	}, product.ImportOptions{DryRun: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.True(t, report.DryRun)
+	mockRepo.AssertNotCalled(t, "CreateWithContext", mock.Anything, mock.Anything)
+}
+
+func TestService_ImportWithContext_RollsBackWhenAWriteFails(t *testing.T) {
+	mockRepo := new(mocks.RepositoryMock)
+	svc := service.NewService(mockRepo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	previous := product.Product{Id: "existing", Name: "Rust", Category: "Books", Price: 12, Version: 3}
+	mockRepo.On("GetByIDWithContext", ctx, "existing").Return(&previous, nil)
+	mockRepo.On("GetByIDWithContext", ctx, mock.Anything).Return(nil, apperrors.ErrResourceNotExists)
+	mockRepo.On("CreateWithContext", ctx, mock.MatchedBy(func(p product.Product) bool { return p.Id == "new" })).Return(nil).Once()
+	mockRepo.On("ModifyWithContext", ctx, "existing", mock.Anything).Return(
+		func(_ context.Context, _ string, modify func(product.Product) (product.Product, error)) (*product.Product, error) {
+			imported, err := modify(previous)
+			return &imported, err
+		}).Once()
+	mockRepo.On("CreateWithContext", ctx, mock.MatchedBy(func(p product.Product) bool { return p.Id == "failing" })).
+		Run(func(mock.Arguments) { cancel() }).
+		Return(context.Canceled).Once()
+
+	var rolledBack []string
+	mockRepo.On("DeleteWithContext", mock.Anything, "new").
+		Run(func(args mock.Arguments) {
+			assert.NoError(t, args.Get(0).(context.Context).Err(), "rolls back even though the request was canceled")
+			rolledBack = append(rolledBack, "new")
+		}).
+		Return(nil).Once()
+	var restored product.Product
+	mockRepo.On("ModifyWithContext", mock.Anything, "existing", mock.Anything).Return(
+		func(_ context.Context, _ string, modify func(product.Product) (product.Product, error)) (*product.Product, error) {
+			rolledBack = append(rolledBack, "existing")
+			restored, _ = modify(product.Product{Id: "existing", Name: "Imported", Version: 4})
+			return &restored, nil
+		}).Once()
+
+	rows := []product.ImportRow{
+		{Line: 1, Product: product.Product{Id: "new", Name: "Go", Category: "Books", Price: 10}},
+		{Line: 2, Product: product.Product{Id: "existing", Name: "Imported", Category: "Books", Price: 15}},
+		{Line: 3, Product: product.Product{Id: "failing", Name: "Zig", Category: "Books", Price: 20}},
+	}
+
+	_, err := svc.ImportWithContext(ctx, rows, product.ImportOptions{Mode: product.ImportUpsert})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{"existing", "new"}, rolledBack, "rolls back in reverse order")
+	expected := previous
+	expected.Version = 5
+	assert.Equal(t, expected, restored)
+	mockRepo.AssertExpectations(t)
+}
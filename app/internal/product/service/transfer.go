@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+)
+
+// exportPageSize is how many products Export reads from the repository at a
+// time.
+const exportPageSize = 100
+
+func (s *service) Export(filters product.ProductFilter, handler func(p product.Product) error) error {
+	return s.ExportWithContext(context.Background(), filters, handler)
+}
+
+// ExportWithContext calls handler with every product matching filters, in
+// the order of filters.Sort or by product ID when it is empty. Products are
+// read a page at a time with a cursor, so every product is exported once
+// even while the catalog changes.
+func (s *service) ExportWithContext(ctx context.Context, filters product.ProductFilter, handler func(p product.Product) error) error {
+	filters, err := s.expandCategories(ctx, filters)
+	if err != nil {
+		return err
+	}
+	filters.Facets = nil
+	filters.Cursor = ""
+	filters.PageSize = exportPageSize
+	if filters.Sort == "" {
+		// every ID comes after the empty one, so the first page is already
+		// in cursor order
+		filters.After = &product.Cursor{}
+		filters.Page = 0
+	} else {
+		filters.After = nil
+		filters.Page = 1
+	}
+
+	for {
+		products, _, err := s.repo.GetAllWithContext(ctx, filters)
+		if err != nil {
+			return err
+		}
+		for _, p := range products {
+			if err := handler(p); err != nil {
+				return err
+			}
+		}
+		if len(products) < filters.PageSize {
+			return nil
+		}
+		after := product.NewCursor(products[len(products)-1], filters.Sort)
+		filters.After = &after
+		filters.Page = 0
+	}
+}
+
+func (s *service) Import(rows []product.ImportRow, opts product.ImportOptions) (product.ImportReport, error) {
+	return s.ImportWithContext(context.Background(), rows, opts)
+}
+
+// ImportWithContext validates every row before writing any: rows are written
+// only when none has errors and opts.DryRun is not set. Products without an
+// ID get a new one. Replaced products keep their reserved units and rating
+// sum, as they do when updated, and a product written to since its row was
+// checked fails the import with ErrVersionConflict. When a write fails the
+// rows written before it are rolled back, so the import is written whole or
+// not at all.
+func (s *service) ImportWithContext(ctx context.Context, rows []product.ImportRow, opts product.ImportOptions) (product.ImportReport, error) {
+	if opts.Mode == "" {
+		opts.Mode = product.ImportInsert
+	}
+	report := product.ImportReport{Mode: opts.Mode, DryRun: opts.DryRun, Rows: len(rows), Errors: []product.ImportError{}}
+	if opts.Mode != product.ImportInsert && opts.Mode != product.ImportUpsert {
+		return report, fmt.Errorf("%w: unknown import mode %q", apperrors.ErrValidation, opts.Mode)
+	}
+
+	type write struct {
+		product product.Product
+		// previous is the product replaced, nil for a new one
+		previous *product.Product
+	}
+	writes := make([]write, 0, len(rows))
+	lines := make(map[string]int, len(rows))
+
+	for _, row := range rows {
+		rowErrors, previous, err := s.checkImportRow(ctx, &row, opts.Mode, lines)
+		if err != nil {
+			return report, err
+		}
+		if len(rowErrors) > 0 {
+			report.Failed++
+			report.Errors = append(report.Errors, rowErrors...)
+			continue
+		}
+		writes = append(writes, write{product: row.Product, previous: previous})
+		if previous != nil {
+			report.Updated++
+		} else {
+			report.Created++
+		}
+	}
+
+	if report.Failed > 0 || opts.DryRun {
+		return report, nil
+	}
+
+	for i, w := range writes {
+		var err error
+		if w.previous != nil {
+			_, err = s.repo.ModifyWithContext(ctx, w.product.Id, replace(w.product, &w.previous.Version))
+		} else {
+			err = s.repo.CreateWithContext(ctx, w.product)
+		}
+		if err != nil {
+			err = fmt.Errorf("importing product %s: %w", w.product.Id, err)
+			// the rollback runs even when the request was canceled, which
+			// is often why the write failed
+			rollbackCtx := context.WithoutCancel(ctx)
+			for _, written := range slices.Backward(writes[:i]) {
+				if rbErr := s.rollbackImport(rollbackCtx, written.product.Id, written.previous); rbErr != nil {
+					err = errors.Join(err, fmt.Errorf("rolling back product %s: %w", written.product.Id, rbErr))
+				}
+			}
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// rollbackImport undoes the import of a product: a new one is deleted and a
+// replaced one is written back as it was, with the units reserved since, under
+// a new version so clients holding the imported version see a conflict.
+func (s *service) rollbackImport(ctx context.Context, productId string, previous *product.Product) error {
+	if previous == nil {
+		return s.repo.DeleteWithContext(ctx, productId)
+	}
+	_, err := s.repo.ModifyWithContext(ctx, productId, func(current product.Product) (product.Product, error) {
+		restored := *previous
+		restored.Reserved = current.Reserved
+		restored.Version = current.Version + 1
+		return restored, nil
+	})
+	return err
+}
+
+// checkImportRow returns the errors of a row, and the existing product it
+// replaces, if any. lines maps the IDs of the rows checked so far to their
+// line, to catch IDs repeated in the file.
+func (s *service) checkImportRow(ctx context.Context, row *product.ImportRow, mode string, lines map[string]int) ([]product.ImportError, *product.Product, error) {
+	if len(row.Errors) > 0 {
+		return row.Errors, nil, nil
+	}
+
+	p := &row.Product
+	if p.Id == "" {
+		p.Id = uuid.NewString()
+	}
+
+	var rowErrors []product.ImportError
+	fail := func(field, message string) {
+		rowErrors = append(rowErrors, product.ImportError{Line: row.Line, Field: field, Message: message})
+	}
+
+	for _, fe := range product.Validate(*p) {
+		fail(fe.Field, fe.Message)
+	}
+
+	if line, ok := lines[p.Id]; ok {
+		fail("productId", fmt.Sprintf("is repeated from line %d", line))
+	} else {
+		lines[p.Id] = row.Line
+	}
+
+	if p.Category != "" {
+		err := s.checkCategory(ctx, *p)
+		if errors.Is(err, ErrUnknownCategory) {
+			fail("category", "is not an existing category")
+		} else if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	existing, err := s.repo.GetByIDWithContext(ctx, p.Id)
+	exists := err == nil
+	if err != nil && !errors.Is(err, apperrors.ErrResourceNotExists) {
+		return nil, nil, err
+	}
+	if exists && mode == product.ImportInsert {
+		fail("productId", "already exists")
+	}
+
+	p.Version = 0
+	if !exists {
+		p.DeriveInStock()
+		return rowErrors, nil, nil
+	}
+	if existing.Reserved > 0 && (p.Stock == nil || *p.Stock < existing.Reserved) {
+		fail("stock", fmt.Sprintf("must be at least the %d reserved units", existing.Reserved))
+	}
+	return rowErrors, existing, nil
+}
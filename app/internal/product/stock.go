@@ -0,0 +1,108 @@
+package product
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+)
+
+var (
+	// ErrVersionConflict is returned by writes made against a version of a
+	// product that is no longer the current one.
+	ErrVersionConflict = errors.New("product was changed since the given version")
+	// ErrNotAvailable is returned by stock adjustments that would leave fewer
+	// units in stock than are reserved.
+	ErrNotAvailable = errors.New("not enough stock available")
+)
+
+// MaxQuantity is the most units of a product a cart line or an order line can
+// hold. The validate tags of the requests that carry units repeat it.
+const MaxQuantity = 10000
+
+// StockAdjustment changes the stock of a product. Quantity is added to the
+// units in stock and Reserved to the units held for pending orders; negative
+// values remove units.
+type StockAdjustment struct {
+	Quantity int `json:"quantity"`
+	Reserved int `json:"reserved"`
+	// Version, when set, must be the current version of the product, so an
+	// adjustment based on a stale read is rejected instead of applied.
+	Version *int `json:"version,omitempty"`
+}
+
+// StockLevel is the stock of a product as the stock endpoints report it.
+type StockLevel struct {
+	ProductId string `json:"productId"`
+	// Stock is null for products whose stock is not tracked.
+	Stock     *int `json:"stock"`
+	Reserved  int  `json:"reserved"`
+	Available int  `json:"available"`
+	InStock   bool `json:"inStock"`
+	Version   int  `json:"version"`
+}
+
+// TracksStock reports whether the stock of p is counted. Products that do not
+// track it keep the InStock they were written with.
+func (p Product) TracksStock() bool {
+	return p.Stock != nil
+}
+
+// Available returns how many units can still be sold: the stock that is not
+// reserved, or 0 when the stock is not tracked.
+func (p Product) Available() int {
+	if p.Stock == nil {
+		return 0
+	}
+	return max(*p.Stock-p.Reserved, 0)
+}
+
+// DeriveInStock sets InStock from the available units when the stock is
+// tracked. Writes call it so InStock never disagrees with the stock.
+func (p *Product) DeriveInStock() {
+	if p.TracksStock() {
+		p.InStock = p.Available() > 0
+	}
+}
+
+// StockLevel returns the stock fields of p.
+func (p Product) StockLevel() StockLevel {
+	return StockLevel{
+		ProductId: p.Id,
+		Stock:     p.Stock,
+		Reserved:  p.Reserved,
+		Available: p.Available(),
+		InStock:   p.InStock,
+		Version:   p.Version,
+	}
+}
+
+// AdjustStock applies adj to p and moves it to the next version. Adjusting a
+// product that does not track its stock starts tracking it from 0. It fails
+// with ErrVersionConflict when adj.Version is stale and with ErrNotAvailable
+// when more units would be reserved than are in stock; p is left unchanged
+// then.
+func (p *Product) AdjustStock(adj StockAdjustment) error {
+	if adj.Version != nil && *adj.Version != p.Version {
+		return fmt.Errorf("%w: product %s is at version %d, not %d", ErrVersionConflict, p.Id, p.Version, *adj.Version)
+	}
+
+	stock := 0
+	if p.Stock != nil {
+		stock = *p.Stock
+	}
+	stock += adj.Quantity
+	reserved := p.Reserved + adj.Reserved
+	switch {
+	case reserved < 0:
+		return fmt.Errorf("%w: cannot release %d units of product %s, %d are reserved", apperrors.ErrValidation, -adj.Reserved, p.Id, p.Reserved)
+	case stock < reserved:
+		return fmt.Errorf("%w: product %s has %d units available", ErrNotAvailable, p.Id, p.Available())
+	}
+
+	p.Stock = &stock
+	p.Reserved = reserved
+	p.Version++
+	p.DeriveInStock()
+	return nil
+}
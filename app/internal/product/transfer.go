@@ -0,0 +1,159 @@
+package product
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Formats products are imported from and exported to.
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// Import modes: insert rejects products whose ID already exists, upsert
+// replaces them.
+const (
+	ImportInsert = "insert"
+	ImportUpsert = "upsert"
+)
+
+// ErrInvalidImport is returned for import files that cannot be read at all.
+var ErrInvalidImport = errors.New("invalid import file")
+
+// ImportError is a problem with a row of an import file. Field is the JSON
+// name of the product field at fault, empty when the whole row is.
+type ImportError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e ImportError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("line %d: %s: %s", e.Line, e.Field, e.Message)
+}
+
+// ImportRow is a product read from line Line of an import file, with the
+// errors found while decoding it.
+type ImportRow struct {
+	Line    int
+	Product Product
+	Errors  []ImportError
+}
+
+type ImportOptions struct {
+	// Mode is ImportInsert or ImportUpsert; empty means ImportInsert.
+	Mode string
+	// DryRun checks every row without writing any.
+	DryRun bool
+}
+
+// ImportReport is the outcome of an import. Rows are only written when none
+// of them has errors, so Created and Updated count what was written, or what
+// would have been on a dry run.
+type ImportReport struct {
+	Mode    string        `json:"mode"`
+	DryRun  bool          `json:"dryRun"`
+	Rows    int           `json:"rows"`
+	Created int           `json:"created"`
+	Updated int           `json:"updated"`
+	Failed  int           `json:"failed"`
+	Errors  []ImportError `json:"errors"`
+}
+
+// DecodeImport reads the products of an import file in the given format.
+func DecodeImport(r io.Reader, format string) ([]ImportRow, error) {
+	switch format {
+	case FormatCSV:
+		return DecodeCSV(r)
+	case FormatJSONL:
+		return DecodeJSONL(r)
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q", ErrInvalidImport, format)
+	}
+}
+
+// DecodeJSONL reads one product per line, skipping blank lines. Lines that
+// are not a JSON product are reported in the Errors of their row.
+func DecodeJSONL(r io.Reader) ([]ImportRow, error) {
+	var rows []ImportRow
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			row := ImportRow{Line: line}
+			if jsonErr := json.Unmarshal(data, &row.Product); jsonErr != nil {
+				row.Errors = append(row.Errors, jsonImportError(line, jsonErr))
+			}
+			rows = append(rows, row)
+		}
+		if err != nil {
+			return rows, nil
+		}
+	}
+}
+
+func jsonImportError(line int, err error) ImportError {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		kind := typeErr.Type.String()
+		switch typeErr.Type.Kind() {
+		case reflect.Float64, reflect.Int:
+			kind = "number"
+		case reflect.Bool:
+			kind = "boolean"
+		}
+		return ImportError{Line: line, Field: typeErr.Field, Message: "must be a " + kind}
+	}
+	return ImportError{Line: line, Message: err.Error()}
+}
+
+// Writer writes products to an export file.
+type Writer interface {
+	Write(p Product) error
+	// Flush writes any buffered products to the underlying writer.
+	Flush() error
+}
+
+// NewWriter returns a Writer of the given format. CSV files start with their
+// header.
+func NewWriter(w io.Writer, format string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w)
+	case FormatJSONL:
+		return NewJSONLWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// JSONLWriter writes one product per line, as they are stored.
+type JSONLWriter struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	buf := bufio.NewWriter(w)
+	return &JSONLWriter{buf: buf, enc: json.NewEncoder(buf)}
+}
+
+func (jw *JSONLWriter) Write(p Product) error {
+	return jw.enc.Encode(p)
+}
+
+func (jw *JSONLWriter) Flush() error {
+	return jw.buf.Flush()
+}
@@ -0,0 +1,60 @@
+package product
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator"
+)
+
+// FieldError is a field of a product that breaks a validation rule. Field is
+// named as in the JSON and CSV files.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+var productValidator = func() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.Split(field.Tag.Get("json"), ",")[0]
+	})
+	return v
+}()
+
+// Validate checks p against the rules of its validate tags, the ones the
+// handlers apply to writes, and returns every field that breaks one.
+func Validate(p Product) []FieldError {
+	err := productValidator.Struct(p)
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return nil
+	}
+	result := make([]FieldError, len(fieldErrors))
+	for i, fe := range fieldErrors {
+		result[i] = FieldError{Field: fe.Field(), Message: validationMessage(fe)}
+	}
+	return result
+}
+
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "min":
+		return "must be at least " + fe.Param()
+	case "max":
+		return "must be at most " + fe.Param()
+	case "url":
+		return "must be a URL"
+	case "gtfield":
+		return "must be greater than " + strings.ToLower(fe.Param()[:1]) + fe.Param()[1:]
+	case "ltefield":
+		return "must be at most " + strings.ToLower(fe.Param()[:1]) + fe.Param()[1:]
+	default:
+		return "is invalid (" + fe.Tag() + ")"
+	}
+}
@@ -0,0 +1,223 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/lucasti79/meli-interview/config"
+	"github.com/lucasti79/meli-interview/internal/auth"
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/lucasti79/meli-interview/pkg/web/response"
+)
+
+// sweepInterval is how often the buckets that filled up again are dropped,
+// so clients that stopped sending requests do not keep using memory.
+const sweepInterval = time.Minute
+
+// Limiter keeps a token bucket per client: each request takes a token, and
+// tokens are put back at the configured rate up to the burst.
+type Limiter struct {
+	rate  float64
+	burst int
+	now   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Result is the state of a client's bucket after a request.
+type Result struct {
+	Allowed bool
+	// Limit is the size of the bucket.
+	Limit int
+	// Remaining is how many requests can be sent right away.
+	Remaining int
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is how long until the next request is allowed, 0 when this
+	// one was.
+	RetryAfter time.Duration
+}
+
+type Option func(*Limiter)
+
+// WithClock sets where the limiter reads the time tokens are put back at.
+func WithClock(now func() time.Time) Option {
+	return func(l *Limiter) {
+		l.now = now
+	}
+}
+
+// NewLimiter returns a limiter allowing limit.Burst requests at once and
+// limit.Rate requests a second after that. limit.Rate must be above 0.
+func NewLimiter(limit config.RateLimit, opts ...Option) *Limiter {
+	l := &Limiter{
+		rate:    limit.Rate,
+		burst:   max(limit.Burst, 1),
+		now:     time.Now,
+		buckets: map[string]*bucket{},
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	l.swept = l.now()
+	return l
+}
+
+// Take takes a token from the bucket of key, reporting whether there was one.
+func (l *Limiter) Take(key string) Result {
+	return l.take(key, 1)
+}
+
+// Peek reports whether the bucket of key has a token, without taking it.
+func (l *Limiter) Peek(key string) Result {
+	return l.take(key, 0)
+}
+
+// take takes n tokens from the bucket of key when it has one.
+func (l *Limiter) take(key string, n float64) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.burst), updated: now}
+		l.buckets[key] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.updated = now
+
+	result := Result{Limit: l.burst}
+	if b.tokens >= 1 {
+		b.tokens -= n
+		result.Allowed = true
+	} else {
+		result.RetryAfter = l.duration(1 - b.tokens)
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = l.duration(float64(l.burst) - b.tokens)
+	return result
+}
+
+// refill returns the tokens of b at now.
+func (l *Limiter) refill(b *bucket, now time.Time) float64 {
+	return min(float64(l.burst), b.tokens+now.Sub(b.updated).Seconds()*l.rate)
+}
+
+// duration returns how long putting back tokens takes.
+func (l *Limiter) duration(tokens float64) time.Duration {
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
+
+// sweep drops the buckets that are full at now: a client without a bucket
+// gets a full one.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < sweepInterval {
+		return
+	}
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= float64(l.burst) {
+			delete(l.buckets, key)
+		}
+	}
+	l.swept = now
+}
+
+// Len returns how many clients the limiter keeps a bucket for.
+func (l *Limiter) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
+
+// Key returns the client a request is counted against: the API key it was
+// sent with, or else the IP it came from.
+func Key(r *http.Request) string {
+	if claims, ok := auth.ClaimsFromContext(r.Context()); ok && claims.FromAPIKey() {
+		return "key:" + claims.KeyId
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// Limit answers with 429 the requests of clients that ran out of tokens.
+// Every response carries the X-RateLimit-Limit, X-RateLimit-Remaining and
+// X-RateLimit-Reset headers, the last in seconds; 429 responses also carry
+// Retry-After.
+func (l *Limiter) Limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result := l.Take(Key(r))
+
+		writeHeaders(w, result)
+		if result.Allowed {
+			next.ServeHTTP(w, r)
+			return
+		}
+		writeTooManyRequests(w, result)
+	})
+}
+
+// LimitFailures counts against the bucket of a client only the requests
+// answered with 401, and answers with 429 the clients that ran out of tokens.
+// It goes in front of authentication, so a client sending bad tokens or API
+// keys cannot guess at them faster than the limit allows, while the requests
+// that authenticate are left to the limits of their route groups.
+func (l *Limiter) LimitFailures(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := Key(r)
+		if result := l.Peek(key); !result.Allowed {
+			writeHeaders(w, result)
+			writeTooManyRequests(w, result)
+			return
+		}
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+		if ww.Status() == http.StatusUnauthorized {
+			l.Take(key)
+		}
+	})
+}
+
+// writeHeaders sets the X-RateLimit headers of result.
+func writeHeaders(w http.ResponseWriter, result Result) {
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.Itoa(seconds(result.Reset)))
+}
+
+// writeTooManyRequests answers a request the bucket of result had no token
+// for.
+func writeTooManyRequests(w http.ResponseWriter, result Result) {
+	retryAfter := seconds(result.RetryAfter)
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	response.JSON(w, http.StatusTooManyRequests, httpdto.ErrorResponse{
+		Code:    apperrors.ErrTooManyRequests.Error(),
+		Message: fmt.Sprintf("rate limit of %d requests exceeded, retry in %d seconds", result.Limit, retryAfter),
+		Status:  http.StatusText(http.StatusTooManyRequests),
+	})
+}
+
+// seconds rounds d up to whole seconds, as the headers carry them.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
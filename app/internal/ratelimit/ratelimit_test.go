@@ -0,0 +1,159 @@
+package ratelimit_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lucasti79/meli-interview/config"
+	"github.com/lucasti79/meli-interview/internal/auth"
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
+	"github.com/lucasti79/meli-interview/internal/ratelimit"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clock is a time that only moves when told to.
+type clock struct{ now time.Time }
+
+func newClock() *clock {
+	return &clock{now: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newLimiter(c *clock, rate float64, burst int) *ratelimit.Limiter {
+	return ratelimit.NewLimiter(config.RateLimit{Rate: rate, Burst: burst}, ratelimit.WithClock(c.Now))
+}
+
+func TestLimiter_TakesTokensAndPutsThemBackAtTheRate(t *testing.T) {
+	c := newClock()
+	l := newLimiter(c, 2, 3)
+
+	for want := 2; want >= 0; want-- {
+		result := l.Take("a")
+		require.True(t, result.Allowed)
+		require.Equal(t, want, result.Remaining)
+		require.Equal(t, 3, result.Limit)
+	}
+
+	denied := l.Take("a")
+	require.False(t, denied.Allowed)
+	assert.Equal(t, 0, denied.Remaining)
+	assert.Equal(t, 500*time.Millisecond, denied.RetryAfter)
+	assert.Equal(t, 1500*time.Millisecond, denied.Reset)
+
+	// other clients have their own bucket
+	require.True(t, l.Take("b").Allowed)
+
+	c.Advance(500 * time.Millisecond)
+	require.True(t, l.Take("a").Allowed)
+	require.False(t, l.Take("a").Allowed)
+
+	// buckets do not fill past the burst
+	c.Advance(time.Hour)
+	for range 3 {
+		require.True(t, l.Take("a").Allowed)
+	}
+	require.False(t, l.Take("a").Allowed)
+}
+
+func TestLimiter_DropsTheBucketsOfIdleClients(t *testing.T) {
+	c := newClock()
+	l := newLimiter(c, 1, 5)
+
+	l.Take("a")
+	l.Take("b")
+	require.Equal(t, 2, l.Len())
+
+	c.Advance(2 * time.Minute)
+	l.Take("c")
+	require.Equal(t, 1, l.Len())
+}
+
+func TestKey(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+	req.RemoteAddr = "203.0.113.7:5123"
+	assert.Equal(t, "ip:203.0.113.7", ratelimit.Key(req))
+
+	// tokens are counted by IP, API keys by key wherever they are sent from
+	withToken := req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Roles: []string{auth.RoleAdmin}}))
+	assert.Equal(t, "ip:203.0.113.7", ratelimit.Key(withToken))
+	withKey := req.WithContext(auth.WithClaims(req.Context(), auth.KeyClaims("k1", nil)))
+	assert.Equal(t, "key:k1", ratelimit.Key(withKey))
+}
+
+func TestLimit_AnswersWith429OnceTheBucketIsEmpty(t *testing.T) {
+	c := newClock()
+	handler := newLimiter(c, 0.5, 2).Limit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	send := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/products", nil))
+		return rec
+	}
+
+	first := send()
+	require.Equal(t, http.StatusNoContent, first.Code)
+	assert.Equal(t, "2", first.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "2", first.Header().Get("X-RateLimit-Reset"))
+	assert.Empty(t, first.Header().Get("Retry-After"))
+
+	require.Equal(t, http.StatusNoContent, send().Code)
+
+	limited := send()
+	require.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "2", limited.Header().Get("Retry-After"))
+	assert.Equal(t, "0", limited.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "4", limited.Header().Get("X-RateLimit-Reset"))
+	var body httpdto.ErrorResponse
+	require.NoError(t, json.Unmarshal(limited.Body.Bytes(), &body))
+	assert.Equal(t, apperrors.ErrTooManyRequests.Error(), body.Code)
+	assert.Equal(t, http.StatusText(http.StatusTooManyRequests), body.Status)
+
+	c.Advance(2 * time.Second)
+	require.Equal(t, http.StatusNoContent, send().Code)
+}
+
+func TestLimitFailures_CountsOnlyTheRequestsAnsweredWith401(t *testing.T) {
+	c := newClock()
+	handler := newLimiter(c, 0.5, 2).LimitFailures(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	send := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for range 5 {
+		require.Equal(t, http.StatusNoContent, send("good").Code, "authenticated requests are not counted")
+	}
+	require.Equal(t, http.StatusUnauthorized, send("bad").Code)
+	require.Equal(t, http.StatusUnauthorized, send("bad").Code)
+
+	limited := send("bad")
+	require.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "2", limited.Header().Get("Retry-After"))
+	require.Equal(t, http.StatusTooManyRequests, send("good").Code, "the client is turned away until a failure is forgiven")
+
+	c.Advance(2 * time.Second)
+	require.Equal(t, http.StatusUnauthorized, send("bad").Code)
+}
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator"
+	"github.com/lucasti79/meli-interview/internal/auth"
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/internal/review"
+	"github.com/lucasti79/meli-interview/internal/review/service"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/lucasti79/meli-interview/pkg/web/request"
+	"github.com/lucasti79/meli-interview/pkg/web/response"
+)
+
+type Handler struct {
+	service   service.Service
+	validator *validator.Validate
+}
+
+func NewHandler(service service.Service) *Handler {
+	return &Handler{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// GetAll godoc
+// @Summary      List the reviews of a product
+// @Description  List the reviews of a product, newest first unless sorted by stars
+// @Tags         Reviews
+// @Produce      json
+// @Param        productId  path   string               true   "Product ID"
+// @Param        filters    query  review.ReviewFilter  false  "Review filters"
+// @Success      200  {object}  ReviewPaginatedResult
+// @Success      204  "No content"
+// @Failure      400  {object}  httpdto.ErrorResponse
+// @Failure      404  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Failure      504  {object}  httpdto.ErrorResponse
+// @Router       /api/v1/products/{productId}/reviews [get]
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+	filters := review.ReviewFilter{
+		ProductId: chi.URLParam(r, "productId"),
+		Sort:      r.URL.Query().Get("sort"),
+		Page:      1,
+		PageSize:  10,
+	}
+	if page := r.URL.Query().Get("page"); page != "" {
+		if p, err := strconv.Atoi(page); err == nil {
+			filters.Page = p
+		}
+	}
+	if size := r.URL.Query().Get("pageSize"); size != "" {
+		if s, err := strconv.Atoi(size); err == nil {
+			filters.PageSize = s
+		}
+	}
+
+	if err := h.validator.Struct(filters); err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    apperrors.ErrValidation.Error(),
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	reviews, total, err := h.service.GetAllWithContext(r.Context(), filters)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if len(reviews) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, ReviewPaginatedResult{
+		Data:       reviews,
+		TotalCount: total,
+		Page:       filters.Page,
+		PageSize:   filters.PageSize,
+	})
+}
+
+// Create godoc
+// @Summary      Review a product
+// @Description  Store a review of a product, counting its stars in the rating and reviews of the product. Requires a token issued to a user
+// @Description  The author of the review is the user of the token: an author naming someone else is rejected with 403. Admin tokens without a user must send the author
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Param        productId  path  string                true  "Product ID"
+// @Param        review     body  review.ReviewRequest  true  "Review"
+// @Success      201  {object}  ReviewResult
+// @Failure      400  {object}  httpdto.ErrorResponse
+// @Failure      401  {object}  httpdto.ErrorResponse
+// @Failure      403  {object}  httpdto.ErrorResponse
+// @Failure      404  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/products/{productId}/reviews [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var req review.ReviewRequest
+	err := request.JSON(r, &req)
+	if err == nil {
+		if claims, ok := auth.ClaimsFromContext(r.Context()); ok && !claims.FromAPIKey() {
+			req.UserId = claims.Subject
+		}
+		// a user writes reviews in their own name only
+		if req.UserId != "" {
+			if req.Author != "" && req.Author != req.UserId {
+				response.JSON(w, http.StatusForbidden, httpdto.ErrorResponse{
+					Code:    review.ErrReviewAuthorMismatch,
+					Message: "the author of a review must be the user writing it",
+					Status:  http.StatusText(http.StatusForbidden),
+				})
+				return
+			}
+			req.Author = req.UserId
+		}
+		err = h.validator.Struct(req)
+	}
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, httpdto.ErrorResponse{
+			Code:    review.ErrReviewInvalidData,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusBadRequest),
+		})
+		return
+	}
+
+	created, err := h.service.CreateWithContext(r.Context(), chi.URLParam(r, "productId"), req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", r.URL.Path+"/"+created.Id)
+	response.JSON(w, http.StatusCreated, ReviewResult{Data: *created})
+}
+
+// Delete godoc
+// @Summary      Delete a review
+// @Description  Remove a review of a product, taking its stars out of the rating and reviews of the product
+// @Tags         Reviews
+// @Produce      json
+// @Param        productId  path  string  true  "Product ID"
+// @Param        reviewId   path  string  true  "Review ID"
+// @Success      204  "No content"
+// @Failure      401  {object}  httpdto.ErrorResponse
+// @Failure      403  {object}  httpdto.ErrorResponse
+// @Failure      404  {object}  httpdto.ErrorResponse
+// @Failure      500  {object}  httpdto.ErrorResponse
+// @Security     BearerAuth
+// @Router       /api/v1/products/{productId}/reviews/{reviewId} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteWithContext(r.Context(), chi.URLParam(r, "productId"), chi.URLParam(r, "reviewId")); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeError maps an error from the review service to its response.
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrUnknownProduct):
+		response.JSON(w, http.StatusNotFound, httpdto.ErrorResponse{
+			Code:    product.ErrProductNotFound,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusNotFound),
+		})
+	case errors.Is(err, apperrors.ErrResourceNotExists):
+		response.JSON(w, http.StatusNotFound, httpdto.ErrorResponse{
+			Code:    review.ErrReviewNotFound,
+			Message: err.Error(),
+			Status:  http.StatusText(http.StatusNotFound),
+		})
+	default:
+		writeInternalError(w, err)
+	}
+}
+
+// writeInternalError answers an unexpected service error, telling apart the
+// requests whose context ended before the reviews could be read or written.
+func writeInternalError(w http.ResponseWriter, err error) {
+	if status, body, ok := httpdto.ContextError(err); ok {
+		response.JSON(w, status, body)
+		return
+	}
+	response.JSON(w, http.StatusInternalServerError, httpdto.ErrorResponse{
+		Code:    apperrors.ErrInternalError.Error(),
+		Message: "internal server error",
+		Status:  http.StatusText(http.StatusInternalServerError),
+	})
+}
@@ -0,0 +1,225 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lucasti79/meli-interview/internal/auth"
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/internal/review"
+	"github.com/lucasti79/meli-interview/internal/review/api"
+	"github.com/lucasti79/meli-interview/internal/review/infra/mocks"
+	"github.com/lucasti79/meli-interview/internal/review/service"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/lucasti79/meli-interview/pkg/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func jsonRequest(t *testing.T, method, target, body string, params map[string]string) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return testutil.WithUrlParamst(t, req, params)
+}
+
+func errorCode(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	var body httpdto.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body.Code
+}
+
+func TestGetAll_Success(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetAllWithContext", mock.Anything, review.ReviewFilter{ProductId: "p1", Sort: "-stars", Page: 2, PageSize: 5}).
+		Return([]review.Review{{Id: "r1", ProductId: "p1", Stars: 5}}, 6, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := jsonRequest(t, http.MethodGet, "/api/v1/products/p1/reviews?sort=-stars&page=2&pageSize=5", "", map[string]string{"productId": "p1"})
+	rec := httptest.NewRecorder()
+
+	h.GetAll(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body api.ReviewPaginatedResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, 6, body.TotalCount)
+	require.Equal(t, "r1", body.Data[0].Id)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAll_NoContent(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("GetAllWithContext", mock.Anything, review.ReviewFilter{ProductId: "p1", Page: 1, PageSize: 10}).Return([]review.Review{}, 0, nil)
+
+	h := api.NewHandler(mockService)
+
+	rec := httptest.NewRecorder()
+	h.GetAll(rec, jsonRequest(t, http.MethodGet, "/api/v1/products/p1/reviews", "", map[string]string{"productId": "p1"}))
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestGetAll_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		err    error
+		status int
+		code   string
+	}{
+		{name: "invalid sort", query: "?sort=author", status: http.StatusBadRequest, code: apperrors.ErrValidation.Error()},
+		{name: "page too large", query: "?pageSize=500", status: http.StatusBadRequest, code: apperrors.ErrValidation.Error()},
+		{name: "unknown product", err: fmt.Errorf("%w: p1", service.ErrUnknownProduct), status: http.StatusNotFound, code: product.ErrProductNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ServiceMock)
+			if tt.err != nil {
+				mockService.On("GetAllWithContext", mock.Anything, mock.AnythingOfType("review.ReviewFilter")).Return(nil, 0, tt.err)
+			}
+
+			h := api.NewHandler(mockService)
+
+			rec := httptest.NewRecorder()
+			h.GetAll(rec, jsonRequest(t, http.MethodGet, "/api/v1/products/p1/reviews"+tt.query, "", map[string]string{"productId": "p1"}))
+
+			require.Equal(t, tt.status, rec.Code)
+			require.Equal(t, tt.code, errorCode(t, rec))
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreate_Success(t *testing.T) {
+	mockService := new(mocks.ServiceMock)
+	mockService.On("CreateWithContext", mock.Anything, "p1", review.ReviewRequest{Author: "Ana", Stars: 4, Title: "Nice"}).
+		Return(&review.Review{Id: "r1", ProductId: "p1", Author: "Ana", Stars: 4, Title: "Nice"}, nil)
+
+	h := api.NewHandler(mockService)
+
+	req := jsonRequest(t, http.MethodPost, "/api/v1/products/p1/reviews", `{"author":"Ana","stars":4,"title":"Nice"}`, map[string]string{"productId": "p1"})
+	rec := httptest.NewRecorder()
+
+	h.Create(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Equal(t, "/api/v1/products/p1/reviews/r1", rec.Header().Get("Location"))
+	require.Contains(t, rec.Body.String(), `"reviewId":"r1"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestCreate_AuthorIsTheUserOfTheToken(t *testing.T) {
+	withUser := func(req *http.Request, subject string) *http.Request {
+		claims := &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: subject}}
+		return req.WithContext(auth.WithClaims(req.Context(), claims))
+	}
+
+	t.Run("author left out", func(t *testing.T) {
+		mockService := new(mocks.ServiceMock)
+		mockService.On("CreateWithContext", mock.Anything, "p1", review.ReviewRequest{Author: "u1", Stars: 4, UserId: "u1"}).
+			Return(&review.Review{Id: "r1", ProductId: "p1", Author: "u1", UserId: "u1", Stars: 4}, nil)
+
+		h := api.NewHandler(mockService)
+
+		req := jsonRequest(t, http.MethodPost, "/api/v1/products/p1/reviews", `{"stars":4}`, map[string]string{"productId": "p1"})
+		rec := httptest.NewRecorder()
+
+		h.Create(rec, withUser(req, "u1"))
+
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("author of another user", func(t *testing.T) {
+		mockService := new(mocks.ServiceMock)
+		h := api.NewHandler(mockService)
+
+		req := jsonRequest(t, http.MethodPost, "/api/v1/products/p1/reviews", `{"author":"u2","stars":4}`, map[string]string{"productId": "p1"})
+		rec := httptest.NewRecorder()
+
+		h.Create(rec, withUser(req, "u1"))
+
+		require.Equal(t, http.StatusForbidden, rec.Code)
+		require.Equal(t, review.ErrReviewAuthorMismatch, errorCode(t, rec))
+		mockService.AssertNotCalled(t, "CreateWithContext", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCreate_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		err    error
+		status int
+		code   string
+	}{
+		{name: "malformed body", body: `{"author":`, status: http.StatusBadRequest, code: review.ErrReviewInvalidData},
+		{name: "missing author", body: `{"stars":4}`, status: http.StatusBadRequest, code: review.ErrReviewInvalidData},
+		{name: "too many stars", body: `{"author":"Ana","stars":6}`, status: http.StatusBadRequest, code: review.ErrReviewInvalidData},
+		{name: "no stars", body: `{"author":"Ana"}`, status: http.StatusBadRequest, code: review.ErrReviewInvalidData},
+		{name: "unknown product", body: `{"author":"Ana","stars":4}`, err: fmt.Errorf("%w: p1", service.ErrUnknownProduct), status: http.StatusNotFound, code: product.ErrProductNotFound},
+		{name: "store failure", body: `{"author":"Ana","stars":4}`, err: errors.New("disk failure"), status: http.StatusInternalServerError, code: apperrors.ErrInternalError.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ServiceMock)
+			if tt.err != nil {
+				mockService.On("CreateWithContext", mock.Anything, "p1", mock.AnythingOfType("review.ReviewRequest")).Return(nil, tt.err)
+			}
+
+			h := api.NewHandler(mockService)
+
+			req := jsonRequest(t, http.MethodPost, "/api/v1/products/p1/reviews", tt.body, map[string]string{"productId": "p1"})
+			rec := httptest.NewRecorder()
+
+			h.Create(rec, req)
+
+			require.Equal(t, tt.status, rec.Code)
+			require.Equal(t, tt.code, errorCode(t, rec))
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "deleted", status: http.StatusNoContent},
+		{name: "unknown review", err: apperrors.ErrResourceNotExists, status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mocks.ServiceMock)
+			mockService.On("DeleteWithContext", mock.Anything, "p1", "r1").Return(tt.err)
+
+			h := api.NewHandler(mockService)
+
+			req := jsonRequest(t, http.MethodDelete, "/api/v1/products/p1/reviews/r1", "", map[string]string{"productId": "p1", "reviewId": "r1"})
+			rec := httptest.NewRecorder()
+
+			h.Delete(rec, req)
+
+			require.Equal(t, tt.status, rec.Code)
+			if tt.err != nil {
+				require.Equal(t, review.ErrReviewNotFound, errorCode(t, rec))
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package api
+
+import "github.com/lucasti79/meli-interview/internal/review"
+
+// swagger:model ReviewResult
+type ReviewResult struct {
+	Data review.Review `json:"data"`
+}
+
+// swagger:model ReviewPaginatedResult
+type ReviewPaginatedResult struct {
+	Data       []review.Review `json:"data"`
+	TotalCount int             `json:"totalCount"`
+	Page       int             `json:"page,omitempty"`
+	PageSize   int             `json:"pageSize"`
+}
@@ -0,0 +1,80 @@
+package review
+
+import (
+	"strings"
+	"time"
+)
+
+// Sort orders of a review listing. Prefixed with "-" they sort descending.
+const (
+	SortByCreatedAt = "createdAt"
+	SortByStars     = "stars"
+)
+
+// DefaultSort lists the newest reviews first.
+const DefaultSort = "-" + SortByCreatedAt
+
+// Review is stored in its own file and counted in the Rating and Reviews of
+// its product.
+type Review struct {
+	Id        string `json:"reviewId"`
+	ProductId string `json:"productId"`
+	Author    string `json:"author"`
+	// UserId is the user who wrote the review. Reviews written without a user
+	// have none.
+	UserId    string    `json:"userId,omitempty"`
+	Stars     int       `json:"stars"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ReviewRequest writes a review of a product. The author of a review
+// written by a user is the user, so Author can be left out.
+type ReviewRequest struct {
+	Author string `json:"author" validate:"required,max=100"`
+	Stars  int    `json:"stars" validate:"min=1,max=5"`
+	Title  string `json:"title" validate:"max=200"`
+	Body   string `json:"body" validate:"max=5000"`
+	// UserId is the subject of the token the review is written with.
+	UserId string `json:"-"`
+}
+
+// ReviewFilter selects a page of the reviews of a product.
+type ReviewFilter struct {
+	ProductId string `json:"-"`
+	// Sort field, prefixed with "-" for descending order: createdAt or stars
+	Sort     string `json:"sort,omitempty" validate:"omitempty,oneof=createdAt -createdAt stars -stars"`
+	Page     int    `json:"page,omitempty" validate:"omitempty,min=1"`
+	PageSize int    `json:"pageSize,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// Matches reports whether r is a review of the filtered product.
+func (f ReviewFilter) Matches(r Review) bool {
+	return r.ProductId == f.ProductId
+}
+
+// Less orders reviews by f.Sort, newest first when it is empty, and by ID
+// when they tie.
+func (f ReviewFilter) Less(r, other Review) bool {
+	sort := f.Sort
+	if sort == "" {
+		sort = DefaultSort
+	}
+	field, desc := strings.CutPrefix(sort, "-")
+
+	var cmp int
+	switch field {
+	case SortByStars:
+		cmp = r.Stars - other.Stars
+	default:
+		cmp = r.CreatedAt.Compare(other.CreatedAt)
+	}
+	if desc {
+		cmp = -cmp
+	}
+	if cmp != 0 {
+		return cmp < 0
+	}
+	return r.Id < other.Id
+}
@@ -0,0 +1,7 @@
+package review
+
+const (
+	ErrReviewNotFound       = "review/not-found"
+	ErrReviewInvalidData    = "review/invalid-data"
+	ErrReviewAuthorMismatch = "review/author-mismatch"
+)
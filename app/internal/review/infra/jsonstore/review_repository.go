@@ -0,0 +1,101 @@
+package jsonstore
+
+import (
+	"context"
+
+	"github.com/lucasti79/meli-interview/internal/infra/jsonstore"
+	"github.com/lucasti79/meli-interview/internal/review"
+	"github.com/lucasti79/meli-interview/internal/review/repository"
+)
+
+// ProductIndex is the secondary index registered by NewReviewStore.
+const ProductIndex = "productId"
+
+type reviewRepository struct {
+	repo      *jsonstore.JSONRepository[review.Review]
+	byProduct *jsonstore.KeywordIndex[review.Review]
+}
+
+// NewReviewStore opens the reviews file keyed by review ID, indexed by the
+// product each review is of.
+func NewReviewStore(fileName string, opts ...jsonstore.Option) (*jsonstore.JSONRepository[review.Review], error) {
+	getID := func(entity review.Review) string {
+		return entity.Id
+	}
+	opts = append([]jsonstore.Option{
+		jsonstore.WithIndex(ProductIndex, jsonstore.NewKeywordIndex(func(r review.Review) string {
+			return r.ProductId
+		})),
+	}, opts...)
+	return jsonstore.NewJSONRepository(fileName, getID, opts...)
+}
+
+func NewReviewRepository(fileName string, opts ...jsonstore.Option) (repository.Repository, error) {
+	repo, err := NewReviewStore(fileName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewReviewRepositoryFromStore(repo), nil
+}
+
+// NewReviewRepositoryFromStore wraps a store opened with NewReviewStore.
+func NewReviewRepositoryFromStore(repo *jsonstore.JSONRepository[review.Review]) repository.Repository {
+	r := &reviewRepository{repo: repo}
+	r.byProduct, _ = repo.Index(ProductIndex).(*jsonstore.KeywordIndex[review.Review])
+	return r
+}
+
+func (r *reviewRepository) GetAll(filters review.ReviewFilter) ([]review.Review, int, error) {
+	return r.GetAllWithContext(context.Background(), filters)
+}
+
+func (r *reviewRepository) GetAllWithContext(ctx context.Context, filters review.ReviewFilter) ([]review.Review, int, error) {
+	var narrow jsonstore.Narrow
+	if r.byProduct != nil {
+		narrow = func() *jsonstore.Bitmap { return r.byProduct.Lookup(filters.ProductId) }
+	}
+
+	reviews := make([]review.Review, 0, filters.PageSize)
+	total, err := r.repo.FindAllIndexedPaginatedWithContext(ctx, narrow, filters.Matches, filters.Less, filters.Page, filters.PageSize, func(rv review.Review) error {
+		reviews = append(reviews, rv)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+func (r *reviewRepository) GetByID(reviewId string) (*review.Review, error) {
+	return r.GetByIDWithContext(context.Background(), reviewId)
+}
+
+func (r *reviewRepository) GetByIDWithContext(ctx context.Context, reviewId string) (*review.Review, error) {
+	rv, err := r.repo.FindByIDWithContext(ctx, reviewId)
+	if err != nil {
+		return nil, err
+	}
+	return &rv, nil
+}
+
+func (r *reviewRepository) Create(rv review.Review) error {
+	return r.repo.Save(rv)
+}
+
+func (r *reviewRepository) CreateWithContext(ctx context.Context, rv review.Review) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return r.repo.Save(rv)
+}
+
+func (r *reviewRepository) Delete(reviewId string) error {
+	return r.repo.Delete(reviewId)
+}
+
+func (r *reviewRepository) DeleteWithContext(ctx context.Context, reviewId string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return r.repo.Delete(reviewId)
+}
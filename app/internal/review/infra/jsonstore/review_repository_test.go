@@ -0,0 +1,67 @@
+package jsonstore_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lucasti79/meli-interview/internal/review"
+	"github.com/lucasti79/meli-interview/internal/review/infra/jsonstore"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/stretchr/testify/require"
+)
+
+func ids(reviews []review.Review) []string {
+	out := make([]string, 0, len(reviews))
+	for _, r := range reviews {
+		out = append(out, r.Id)
+	}
+	return out
+}
+
+func TestReviewRepository_ListsTheReviewsOfAProductSorted(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "reviews.jsonl")
+	repo, err := jsonstore.NewReviewRepository(fp)
+	require.NoError(t, err)
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Create(review.Review{Id: "r1", ProductId: "p1", Stars: 3, CreatedAt: now}))
+	require.NoError(t, repo.Create(review.Review{Id: "r2", ProductId: "p1", Stars: 5, CreatedAt: now.Add(time.Hour)}))
+	require.NoError(t, repo.Create(review.Review{Id: "r3", ProductId: "p1", Stars: 3, CreatedAt: now.Add(2 * time.Hour)}))
+	require.NoError(t, repo.Create(review.Review{Id: "r4", ProductId: "p2", Stars: 1, CreatedAt: now}))
+	require.NoError(t, repo.Delete("r4"))
+	require.ErrorIs(t, repo.Delete("r4"), apperrors.ErrResourceNotExists)
+
+	reopened, err := jsonstore.NewReviewRepository(fp)
+	require.NoError(t, err)
+
+	tests := []struct {
+		sort string
+		want []string
+	}{
+		{sort: "", want: []string{"r3", "r2", "r1"}},
+		{sort: "createdAt", want: []string{"r1", "r2", "r3"}},
+		{sort: "-stars", want: []string{"r2", "r1", "r3"}},
+		{sort: "stars", want: []string{"r1", "r3", "r2"}},
+	}
+	for _, tt := range tests {
+		reviews, total, err := reopened.GetAll(review.ReviewFilter{ProductId: "p1", Sort: tt.sort, Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		require.Equal(t, 3, total)
+		require.Equal(t, tt.want, ids(reviews), "sort %q", tt.sort)
+	}
+
+	page, total, err := reopened.GetAll(review.ReviewFilter{ProductId: "p1", Page: 2, PageSize: 2})
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Equal(t, []string{"r1"}, ids(page))
+
+	none, total, err := reopened.GetAll(review.ReviewFilter{ProductId: "p2", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Zero(t, total)
+	require.Empty(t, none)
+
+	got, err := reopened.GetByID("r2")
+	require.NoError(t, err)
+	require.Equal(t, 5, got.Stars)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/lucasti79/meli-interview/internal/review"
+)
+
+type Repository interface {
+	// GetAll returns a page of the reviews of filters.ProductId and how many
+	// it has.
+	GetAll(filters review.ReviewFilter) ([]review.Review, int, error)
+	GetAllWithContext(ctx context.Context, filters review.ReviewFilter) ([]review.Review, int, error)
+	GetByID(reviewId string) (*review.Review, error)
+	GetByIDWithContext(ctx context.Context, reviewId string) (*review.Review, error)
+	Create(r review.Review) error
+	CreateWithContext(ctx context.Context, r review.Review) error
+	Delete(reviewId string) error
+	DeleteWithContext(ctx context.Context, reviewId string) error
+}
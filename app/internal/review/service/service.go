@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/internal/review"
+	"github.com/lucasti79/meli-interview/internal/review/repository"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+)
+
+// ErrUnknownProduct is returned when reading or writing the reviews of a
+// product that is not in the catalog.
+var ErrUnknownProduct = fmt.Errorf("%w: unknown product", apperrors.ErrResourceNotExists)
+
+// ProductStore keeps the aggregate rating of the products reviewed. The
+// product repository satisfies it.
+type ProductStore interface {
+	GetByIDWithContext(ctx context.Context, productId string) (*product.Product, error)
+	ModifyWithContext(ctx context.Context, productId string, modify func(p product.Product) (product.Product, error)) (*product.Product, error)
+}
+
+type service struct {
+	repo     repository.Repository
+	products ProductStore
+	now      func() time.Time
+}
+
+type Option func(*service)
+
+// WithClock sets where the service reads the time reviews are written at.
+func WithClock(now func() time.Time) Option {
+	return func(s *service) {
+		s.now = now
+	}
+}
+
+type Service interface {
+	GetAll(filters review.ReviewFilter) ([]review.Review, int, error)
+	GetAllWithContext(ctx context.Context, filters review.ReviewFilter) ([]review.Review, int, error)
+	Create(productId string, req review.ReviewRequest) (*review.Review, error)
+	CreateWithContext(ctx context.Context, productId string, req review.ReviewRequest) (*review.Review, error)
+	Delete(productId, reviewId string) error
+	DeleteWithContext(ctx context.Context, productId, reviewId string) error
+}
+
+func NewService(repo repository.Repository, products ProductStore, opts ...Option) Service {
+	s := &service{repo: repo, products: products, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *service) GetAll(filters review.ReviewFilter) ([]review.Review, int, error) {
+	return s.GetAllWithContext(context.Background(), filters)
+}
+
+// GetAllWithContext returns a page of the reviews of filters.ProductId.
+func (s *service) GetAllWithContext(ctx context.Context, filters review.ReviewFilter) ([]review.Review, int, error) {
+	_, err := s.products.GetByIDWithContext(ctx, filters.ProductId)
+	if errors.Is(err, apperrors.ErrResourceNotExists) {
+		return nil, 0, fmt.Errorf("%w: %s", ErrUnknownProduct, filters.ProductId)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.repo.GetAllWithContext(ctx, filters)
+}
+
+func (s *service) Create(productId string, req review.ReviewRequest) (*review.Review, error) {
+	return s.CreateWithContext(context.Background(), productId, req)
+}
+
+// CreateWithContext stores a review of the product and counts it in the
+// product's rating.
+func (s *service) CreateWithContext(ctx context.Context, productId string, req review.ReviewRequest) (*review.Review, error) {
+	r := review.Review{
+		Id:        uuid.NewString(),
+		ProductId: productId,
+		Author:    req.Author,
+		UserId:    req.UserId,
+		Stars:     req.Stars,
+		Title:     req.Title,
+		Body:      req.Body,
+		CreatedAt: s.now().UTC(),
+	}
+
+	err := s.rate(ctx, productId, func(p *product.Product) { p.AddRating(r.Stars) })
+	if errors.Is(err, apperrors.ErrResourceNotExists) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProduct, productId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateWithContext(ctx, r); err != nil {
+		s.undo(productId, func(p *product.Product) { p.RemoveRating(r.Stars) })
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (s *service) Delete(productId, reviewId string) error {
+	return s.DeleteWithContext(context.Background(), productId, reviewId)
+}
+
+// DeleteWithContext removes a review of the product and takes it out of the
+// product's rating. Reviews of products deleted since are removed all the
+// same.
+func (s *service) DeleteWithContext(ctx context.Context, productId, reviewId string) error {
+	r, err := s.repo.GetByIDWithContext(ctx, reviewId)
+	if err != nil {
+		return err
+	}
+	if r.ProductId != productId {
+		return fmt.Errorf("%w: review %s is not of product %s", apperrors.ErrResourceNotExists, reviewId, productId)
+	}
+
+	err = s.rate(ctx, productId, func(p *product.Product) { p.RemoveRating(r.Stars) })
+	rated := err == nil
+	if err != nil && !errors.Is(err, apperrors.ErrResourceNotExists) {
+		return err
+	}
+
+	if err := s.repo.DeleteWithContext(ctx, reviewId); err != nil {
+		if rated {
+			s.undo(productId, func(p *product.Product) { p.AddRating(r.Stars) })
+		}
+		return err
+	}
+	return nil
+}
+
+// rate applies change to the product atomically.
+func (s *service) rate(ctx context.Context, productId string, change func(p *product.Product)) error {
+	_, err := s.products.ModifyWithContext(ctx, productId, func(p product.Product) (product.Product, error) {
+		change(&p)
+		return p, nil
+	})
+	return err
+}
+
+// undo reverts a change to the rating of a product after the review write it
+// was made for failed. It does not use the context of the request, which may
+// be what ended.
+func (s *service) undo(productId string, change func(p *product.Product)) {
+	if err := s.rate(context.Background(), productId, change); err != nil {
+		log.Printf("review: restoring the rating of product %s failed: %v", productId, err)
+	}
+}
@@ -0,0 +1,243 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lucasti79/meli-interview/internal/product"
+	"github.com/lucasti79/meli-interview/internal/review"
+	"github.com/lucasti79/meli-interview/internal/review/infra/mocks"
+	"github.com/lucasti79/meli-interview/internal/review/service"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// catalog answers product writes from stored as the product store does.
+func catalog(stored map[string]product.Product) *mocks.ProductStoreMock {
+	store := new(mocks.ProductStoreMock)
+	store.On("ModifyWithContext", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, productId string, modify func(product.Product) (product.Product, error)) (*product.Product, error) {
+			current, ok := stored[productId]
+			if !ok {
+				return nil, apperrors.ErrResourceNotExists
+			}
+			p, err := modify(current)
+			if err != nil {
+				return nil, err
+			}
+			stored[productId] = p
+			return &p, nil
+		}).Maybe()
+	return store
+}
+
+func newService(repo *mocks.RepositoryMock, products *mocks.ProductStoreMock) service.Service {
+	return service.NewService(repo, products, service.WithClock(func() time.Time { return now }))
+}
+
+func TestService_CreateWithContext_CountsTheStarsInTheProductRating(t *testing.T) {
+	tests := []struct {
+		name        string
+		product     product.Product
+		stars       int
+		wantRating  float64
+		wantReviews int
+	}{
+		{name: "first review", product: product.Product{Id: "p1"}, stars: 4, wantRating: 4, wantReviews: 1},
+		{name: "seeded rating", product: product.Product{Id: "p1", Rating: product.Float(4.5), Reviews: 2}, stars: 3, wantRating: 4, wantReviews: 3},
+		{name: "rounded to cents", product: product.Product{Id: "p1", Rating: product.Float(4), Reviews: 2}, stars: 5, wantRating: 4.33, wantReviews: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := map[string]product.Product{"p1": tt.product}
+			mockRepo := new(mocks.RepositoryMock)
+			svc := newService(mockRepo, catalog(stored))
+
+			ctx := context.Background()
+			mockRepo.On("CreateWithContext", ctx, mock.MatchedBy(func(r review.Review) bool {
+				return r.Id != "" && r.ProductId == "p1" && r.Stars == tt.stars && r.CreatedAt.Equal(now)
+			})).Return(nil).Once()
+
+			created, err := svc.CreateWithContext(ctx, "p1", review.ReviewRequest{Author: "Ana", Stars: tt.stars, Title: "Nice", UserId: "u1"})
+
+			require.NoError(t, err)
+			assert.Equal(t, "Ana", created.Author)
+			assert.Equal(t, "u1", created.UserId)
+			require.NotNil(t, stored["p1"].Rating)
+			assert.Equal(t, tt.wantRating, *stored["p1"].Rating)
+			assert.Equal(t, tt.wantReviews, stored["p1"].Reviews)
+			assert.Equal(t, tt.product.Version+1, stored["p1"].Version)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_CreateWithContext_Errors(t *testing.T) {
+	t.Run("unknown product", func(t *testing.T) {
+		mockRepo := new(mocks.RepositoryMock)
+		svc := newService(mockRepo, catalog(map[string]product.Product{}))
+
+		_, err := svc.CreateWithContext(context.Background(), "p9", review.ReviewRequest{Author: "Ana", Stars: 4})
+
+		require.ErrorIs(t, err, service.ErrUnknownProduct)
+		mockRepo.AssertNotCalled(t, "CreateWithContext", mock.Anything, mock.Anything)
+	})
+
+	t.Run("store failure restores the rating", func(t *testing.T) {
+		stored := map[string]product.Product{"p1": {Id: "p1", Rating: product.Float(4), Reviews: 1}}
+		mockRepo := new(mocks.RepositoryMock)
+		svc := newService(mockRepo, catalog(stored))
+		mockRepo.On("CreateWithContext", mock.Anything, mock.Anything).Return(errors.New("disk failure")).Once()
+
+		_, err := svc.CreateWithContext(context.Background(), "p1", review.ReviewRequest{Author: "Ana", Stars: 1})
+
+		require.Error(t, err)
+		assert.Equal(t, 4.0, *stored["p1"].Rating)
+		assert.Equal(t, 1, stored["p1"].Reviews)
+	})
+}
+
+func TestService_DeleteWithContext_TakesTheStarsOutOfTheProductRating(t *testing.T) {
+	tests := []struct {
+		name        string
+		product     product.Product
+		wantRating  *float64
+		wantReviews int
+	}{
+		{name: "other reviews left", product: product.Product{Id: "p1", Rating: product.Float(4), Reviews: 3}, wantRating: product.Float(3.5), wantReviews: 2},
+		{name: "last review", product: product.Product{Id: "p1", Rating: product.Float(5), Reviews: 1}, wantRating: nil, wantReviews: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := map[string]product.Product{"p1": tt.product}
+			mockRepo := new(mocks.RepositoryMock)
+			svc := newService(mockRepo, catalog(stored))
+
+			ctx := context.Background()
+			mockRepo.On("GetByIDWithContext", ctx, "r1").Return(&review.Review{Id: "r1", ProductId: "p1", Stars: 5}, nil).Once()
+			mockRepo.On("DeleteWithContext", ctx, "r1").Return(nil).Once()
+
+			require.NoError(t, svc.DeleteWithContext(ctx, "p1", "r1"))
+
+			assert.Equal(t, tt.wantRating, stored["p1"].Rating)
+			assert.Equal(t, tt.wantReviews, stored["p1"].Reviews)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_RatingStaysExactOverManyReviews(t *testing.T) {
+	stored := map[string]product.Product{"p1": {Id: "p1"}}
+	reviews := map[string]review.Review{}
+	mockRepo := new(mocks.RepositoryMock)
+	mockRepo.On("CreateWithContext", mock.Anything, mock.Anything).Return(func(_ context.Context, r review.Review) error {
+		reviews[r.Id] = r
+		return nil
+	})
+	mockRepo.On("GetByIDWithContext", mock.Anything, mock.Anything).Return(func(_ context.Context, reviewId string) (*review.Review, error) {
+		r := reviews[reviewId]
+		return &r, nil
+	})
+	mockRepo.On("DeleteWithContext", mock.Anything, mock.Anything).Return(func(_ context.Context, reviewId string) error {
+		delete(reviews, reviewId)
+		return nil
+	})
+	svc := newService(mockRepo, catalog(stored))
+	ctx := context.Background()
+
+	var ids []string
+	for i := range 5000 {
+		r, err := svc.CreateWithContext(ctx, "p1", review.ReviewRequest{Author: "Ana", Stars: i*7%5 + 1})
+		require.NoError(t, err)
+		ids = append(ids, r.Id)
+	}
+	// keep three reviews of 5, 2 and 4 stars
+	kept := map[string]bool{ids[2]: true, ids[3]: true, ids[4]: true}
+	for _, id := range ids {
+		if !kept[id] {
+			require.NoError(t, svc.DeleteWithContext(ctx, "p1", id))
+		}
+	}
+
+	require.Len(t, reviews, 3)
+	assert.Equal(t, 3, stored["p1"].Reviews)
+	require.NotNil(t, stored["p1"].Rating)
+	assert.Equal(t, 3.67, *stored["p1"].Rating)
+}
+
+func TestService_DeleteWithContext_Errors(t *testing.T) {
+	t.Run("review of another product", func(t *testing.T) {
+		stored := map[string]product.Product{"p1": {Id: "p1", Rating: product.Float(4), Reviews: 1}}
+		mockRepo := new(mocks.RepositoryMock)
+		svc := newService(mockRepo, catalog(stored))
+		mockRepo.On("GetByIDWithContext", mock.Anything, "r1").Return(&review.Review{Id: "r1", ProductId: "p2", Stars: 5}, nil).Once()
+
+		err := svc.DeleteWithContext(context.Background(), "p1", "r1")
+
+		require.ErrorIs(t, err, apperrors.ErrResourceNotExists)
+		assert.Equal(t, 1, stored["p1"].Reviews)
+		mockRepo.AssertNotCalled(t, "DeleteWithContext", mock.Anything, mock.Anything)
+	})
+
+	t.Run("store failure restores the rating", func(t *testing.T) {
+		stored := map[string]product.Product{"p1": {Id: "p1", Rating: product.Float(4), Reviews: 2}}
+		mockRepo := new(mocks.RepositoryMock)
+		svc := newService(mockRepo, catalog(stored))
+		mockRepo.On("GetByIDWithContext", mock.Anything, "r1").Return(&review.Review{Id: "r1", ProductId: "p1", Stars: 5}, nil).Once()
+		mockRepo.On("DeleteWithContext", mock.Anything, "r1").Return(errors.New("disk failure")).Once()
+
+		err := svc.DeleteWithContext(context.Background(), "p1", "r1")
+
+		require.Error(t, err)
+		assert.Equal(t, 4.0, *stored["p1"].Rating)
+		assert.Equal(t, 2, stored["p1"].Reviews)
+	})
+
+	t.Run("deleted product", func(t *testing.T) {
+		mockRepo := new(mocks.RepositoryMock)
+		svc := newService(mockRepo, catalog(map[string]product.Product{}))
+		mockRepo.On("GetByIDWithContext", mock.Anything, "r1").Return(&review.Review{Id: "r1", ProductId: "p1", Stars: 5}, nil).Once()
+		mockRepo.On("DeleteWithContext", mock.Anything, "r1").Return(nil).Once()
+
+		require.NoError(t, svc.DeleteWithContext(context.Background(), "p1", "r1"))
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestService_GetAllWithContext(t *testing.T) {
+	filters := review.ReviewFilter{ProductId: "p1", Page: 1, PageSize: 10}
+
+	t.Run("reviews of the product", func(t *testing.T) {
+		mockRepo := new(mocks.RepositoryMock)
+		products := new(mocks.ProductStoreMock)
+		svc := newService(mockRepo, products)
+		products.On("GetByIDWithContext", mock.Anything, "p1").Return(&product.Product{Id: "p1"}, nil).Once()
+		mockRepo.On("GetAllWithContext", mock.Anything, filters).Return([]review.Review{{Id: "r1"}}, 1, nil).Once()
+
+		reviews, total, err := svc.GetAllWithContext(context.Background(), filters)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, reviews, 1)
+	})
+
+	t.Run("unknown product", func(t *testing.T) {
+		mockRepo := new(mocks.RepositoryMock)
+		products := new(mocks.ProductStoreMock)
+		svc := newService(mockRepo, products)
+		products.On("GetByIDWithContext", mock.Anything, "p1").Return(nil, apperrors.ErrResourceNotExists).Once()
+
+		_, _, err := svc.GetAllWithContext(context.Background(), filters)
+
+		require.ErrorIs(t, err, service.ErrUnknownProduct)
+		mockRepo.AssertNotCalled(t, "GetAllWithContext", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	chi "github.com/go-chi/chi/v5"
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
+	"github.com/lucasti79/meli-interview/internal/infra/jsonstore"
+	"github.com/lucasti79/meli-interview/pkg/apperrors"
+	"github.com/lucasti79/meli-interview/pkg/web/response"
+)
+
+// Store is a data store whose state is reported by the status endpoints.
+type Store interface {
+	Stats() jsonstore.Stats
+	Report() jsonstore.Report
+}
+
+type Handler struct {
+	stores map[string]Store
+}
+
+// NewHandler reports the state of stores under their map keys.
+func NewHandler(stores map[string]Store) *Handler {
+	return &Handler{stores: stores}
+}
+
+// GetStatus godoc
+// @Summary Get the status of the data stores
+// @Description Lines and last load time of every JSONL store. Stores are loaded at startup, after a compaction and whenever their file is replaced on disk
+// @Tags status
+// @Produce json
+// @Success 200 {object} StatusResult
+// @Router /api/v1/status [get]
+func (h *Handler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	stats := make(map[string]jsonstore.Stats, len(h.stores))
+	for name, store := range h.stores {
+		stats[name] = store.Stats()
+	}
+	response.JSON(w, http.StatusOK, StatusResult{Data: stats})
+}
+
+// GetReport godoc
+// @Summary Get what is wrong with a data store
+// @Description Lines of the store's file that cannot be decoded and records that break the validation rules, e.g. a product without a name or with a rating above 5. Both are found when the file is loaded and kept up to date on every write; invalid records are still served
+// @Tags status
+// @Produce json
+// @Param store path string true "Store name, as in the status: products or categories"
+// @Success 200 {object} ReportResult
+// @Failure 401 {object} httpdto.ErrorResponse
+// @Failure 403 {object} httpdto.ErrorResponse
+// @Failure 404 {object} httpdto.ErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/status/{store}/report [get]
+func (h *Handler) GetReport(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "store")
+	store, ok := h.stores[name]
+	if !ok {
+		response.JSON(w, http.StatusNotFound, httpdto.ErrorResponse{
+			Code:    apperrors.ErrResourceNotExists.Error(),
+			Message: fmt.Sprintf("there is no store named %q", name),
+			Status:  http.StatusText(http.StatusNotFound),
+		})
+		return
+	}
+	response.JSON(w, http.StatusOK, ReportResult{Data: store.Report()})
+}
@@ -0,0 +1,96 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpdto "github.com/lucasti79/meli-interview/internal/infra/http"
+	"github.com/lucasti79/meli-interview/internal/infra/jsonstore"
+	api "github.com/lucasti79/meli-interview/internal/status/api"
+	"github.com/lucasti79/meli-interview/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type storeStub jsonstore.Stats
+
+func (s storeStub) Stats() jsonstore.Stats {
+	return jsonstore.Stats(s)
+}
+
+func (s storeStub) Report() jsonstore.Report {
+	return jsonstore.Report{}
+}
+
+type reportStub jsonstore.Report
+
+func (s reportStub) Stats() jsonstore.Stats {
+	return jsonstore.Stats{}
+}
+
+func (s reportStub) Report() jsonstore.Report {
+	return jsonstore.Report(s)
+}
+
+func TestHandler_GetStatus_ReportsEveryStore(t *testing.T) {
+	loadedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	h := api.NewHandler(map[string]api.Store{
+		"products":   storeStub{TotalLines: 10, LiveLines: 8, LoadedAt: loadedAt},
+		"categories": storeStub{TotalLines: 2, LiveLines: 2, LoadedAt: loadedAt},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/status", nil)
+	w := httptest.NewRecorder()
+
+	h.GetStatus(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var body api.StatusResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, 10, body.Data["products"].TotalLines)
+	assert.Equal(t, 8, body.Data["products"].LiveLines)
+	assert.True(t, loadedAt.Equal(body.Data["products"].LoadedAt))
+	assert.Equal(t, 2, body.Data["categories"].TotalLines)
+}
+
+func TestHandler_GetReport_ReturnsTheProblemsOfTheStore(t *testing.T) {
+	h := api.NewHandler(map[string]api.Store{
+		"products": reportStub{
+			CorruptedLines: []jsonstore.CorruptedLine{{Line: 2, Offset: 120, Error: "unexpected end of JSON input"}},
+			InvalidRecords: []jsonstore.InvalidRecord{{Line: 5, Offset: 400, ID: "p5", Errors: []jsonstore.FieldError{{Field: "rating", Message: "must be at most 5"}}}},
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/status/products/report", nil)
+	req = testutil.WithUrlParam(t, req, "store", "products")
+	w := httptest.NewRecorder()
+
+	h.GetReport(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var body api.ReportResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Data.CorruptedLines, 1)
+	assert.Equal(t, 2, body.Data.CorruptedLines[0].Line)
+	require.Len(t, body.Data.InvalidRecords, 1)
+	assert.Equal(t, "p5", body.Data.InvalidRecords[0].ID)
+	assert.Equal(t, []jsonstore.FieldError{{Field: "rating", Message: "must be at most 5"}}, body.Data.InvalidRecords[0].Errors)
+}
+
+func TestHandler_GetReport_UnknownStore(t *testing.T) {
+	h := api.NewHandler(map[string]api.Store{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/status/orders/report", nil)
+	req = testutil.WithUrlParam(t, req, "store", "orders")
+	w := httptest.NewRecorder()
+
+	h.GetReport(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	var body httpdto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "resource does not exist", body.Code)
+}
@@ -0,0 +1,13 @@
+package api
+
+import "github.com/lucasti79/meli-interview/internal/infra/jsonstore"
+
+// swagger:model StatusResult
+type StatusResult struct {
+	Data map[string]jsonstore.Stats `json:"data"`
+}
+
+// swagger:model ReportResult
+type ReportResult struct {
+	Data jsonstore.Report `json:"data"`
+}
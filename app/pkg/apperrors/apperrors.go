@@ -10,4 +10,7 @@ var (
 	ErrUnauthorized          = errors.New("unauthorized")
 	ErrForbidden             = errors.New("forbidden")
 	ErrInvalidDataFormat     = errors.New("invalid data format")
+	ErrRequestCanceled       = errors.New("request canceled")
+	ErrRequestTimeout        = errors.New("request timeout")
+	ErrTooManyRequests       = errors.New("too many requests")
 )
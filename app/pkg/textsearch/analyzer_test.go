@@ -0,0 +1,28 @@
+package textsearch_test
+
+import (
+	"testing"
+
+	"github.com/lucasti79/meli-interview/pkg/textsearch"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFold(t *testing.T) {
+	cases := map[string]string{
+		"Açúcar Mascavo": "acucar mascavo",
+		"Niño":           "nino",
+		"CAFÉ com PÃO":   "cafe com pao",
+		"plain":          "plain",
+	}
+	for in, want := range cases {
+		require.Equal(t, want, textsearch.Fold(in), in)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	require.Equal(t,
+		[]string{"fone", "de", "ouvido", "bluetooth", "5", "0", "cancelamento", "de", "ruido"},
+		textsearch.Tokenize("Fone de Ouvido Bluetooth 5.0 — cancelamento de ruído!"),
+	)
+	require.Empty(t, textsearch.Tokenize(" ,.- "))
+}
@@ -0,0 +1,22 @@
+package textsearch
+
+import "math"
+
+const (
+	// BM25 term frequency saturation and length normalization.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+	// PrefixMatchWeight discounts terms matched by prefix against exact matches.
+	PrefixMatchWeight = 0.7
+	// MinPrefixLength keeps one letter queries from expanding to the whole vocabulary.
+	MinPrefixLength = 2
+)
+
+// BM25 scores a term found tf times in a document of docLen terms, among docs
+// documents averaging avgLen terms, df of which hold the term. Frequencies and
+// lengths may be weighted by field, as in BM25F.
+func BM25(tf, docLen, avgLen float64, df, docs int) float64 {
+	idf := math.Log(1 + (float64(docs)-float64(df)+0.5)/(float64(df)+0.5))
+	norm := tf + bm25K1*(1-bm25B+bm25B*docLen/avgLen)
+	return idf * tf * (bm25K1 + 1) / norm
+}
@@ -0,0 +1,85 @@
+package request
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+)
+
+var (
+	// ErrRequestContentTypeNotMergePatch is used when the request content type is neither application/merge-patch+json nor application/json.
+	ErrRequestContentTypeNotMergePatch = errors.New("request content type is not application/merge-patch+json")
+	// ErrRequestMergePatchInvalid is used when the merge patch document is not a JSON object.
+	ErrRequestMergePatchInvalid = errors.New("request merge patch must be a json object")
+)
+
+// MergePatch applies the JSON Merge Patch (RFC 7396) from the request body onto ptr,
+// which must point to the current state of the resource.
+func MergePatch(r *http.Request, ptr any) (err error) {
+	// check content type
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/merge-patch+json" && mediaType != "application/json" {
+		err = ErrRequestContentTypeNotMergePatch
+		return
+	}
+
+	// get patch
+	var patch any
+	if err = json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		err = fmt.Errorf("%w. %v", ErrRequestJSONInvalid, err)
+		return
+	}
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		err = ErrRequestMergePatchInvalid
+		return
+	}
+
+	// get current document
+	current, err := json.Marshal(ptr)
+	if err != nil {
+		return
+	}
+	var document map[string]any
+	if err = json.Unmarshal(current, &document); err != nil {
+		return
+	}
+
+	merged, err := json.Marshal(mergeObject(document, patchObject))
+	if err != nil {
+		return
+	}
+
+	// reset ptr so fields removed by the patch do not keep their old value
+	target := reflect.ValueOf(ptr).Elem()
+	target.Set(reflect.Zero(target.Type()))
+	if err = json.Unmarshal(merged, ptr); err != nil {
+		err = fmt.Errorf("%w. %v", ErrRequestJSONInvalid, err)
+		return
+	}
+	return
+}
+
+// mergeObject implements the MergePatch algorithm described in RFC 7396 section 2.
+func mergeObject(target, patch map[string]any) map[string]any {
+	if target == nil {
+		target = map[string]any{}
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		patchValue, ok := value.(map[string]any)
+		if !ok {
+			target[key] = value
+			continue
+		}
+		targetValue, _ := target[key].(map[string]any)
+		target[key] = mergeObject(targetValue, patchValue)
+	}
+	return target
+}
@@ -0,0 +1,110 @@
+package request_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/lucasti79/meli-interview/pkg/web/request"
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for MergePatch function
+func TestRequestMergePatch(t *testing.T) {
+	type nested struct {
+		Color string `json:"color,omitempty"`
+		Size  string `json:"size,omitempty"`
+	}
+	type schema struct {
+		Name  string  `json:"name"`
+		Price float64 `json:"price"`
+		Tags  []string
+		Attrs *nested `json:"attrs,omitempty"`
+	}
+
+	t.Run("success - replaces, merges and removes members", func(t *testing.T) {
+		// arrange
+		inputSchema := schema{
+			Name:  "test",
+			Price: 10,
+			Tags:  []string{"a"},
+			Attrs: &nested{Color: "red", Size: "M"},
+		}
+		inputRequest := http.Request{
+			Header: http.Header{"Content-Type": []string{"application/merge-patch+json"}},
+			Body:   io.NopCloser(strings.NewReader(`{"price":12.5,"Tags":null,"attrs":{"size":null}}`)),
+		}
+
+		// act
+		err := request.MergePatch(&inputRequest, &inputSchema)
+
+		// assert
+		expectedSchema := schema{Name: "test", Price: 12.5, Attrs: &nested{Color: "red"}}
+		require.NoError(t, err)
+		require.Equal(t, expectedSchema, inputSchema)
+	})
+
+	t.Run("success - accepts application/json", func(t *testing.T) {
+		// arrange
+		inputSchema := schema{Name: "test"}
+		inputRequest := http.Request{
+			Header: http.Header{"Content-Type": []string{"application/json; charset=utf-8"}},
+			Body:   io.NopCloser(strings.NewReader(`{"name":"other"}`)),
+		}
+
+		// act
+		err := request.MergePatch(&inputRequest, &inputSchema)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, schema{Name: "other"}, inputSchema)
+	})
+
+	t.Run("error - content-type", func(t *testing.T) {
+		// arrange
+		inputSchema := schema{Name: "test"}
+		inputRequest := http.Request{
+			Header: http.Header{"Content-Type": []string{"application/xml"}},
+			Body:   io.NopCloser(strings.NewReader(`{"name":"other"}`)),
+		}
+
+		// act
+		err := request.MergePatch(&inputRequest, &inputSchema)
+
+		// assert
+		require.ErrorIs(t, err, request.ErrRequestContentTypeNotMergePatch)
+		require.Equal(t, schema{Name: "test"}, inputSchema)
+	})
+
+	t.Run("error - patch is not an object", func(t *testing.T) {
+		// arrange
+		inputSchema := schema{Name: "test"}
+		inputRequest := http.Request{
+			Header: http.Header{"Content-Type": []string{"application/merge-patch+json"}},
+			Body:   io.NopCloser(strings.NewReader(`["name"]`)),
+		}
+
+		// act
+		err := request.MergePatch(&inputRequest, &inputSchema)
+
+		// assert
+		require.ErrorIs(t, err, request.ErrRequestMergePatchInvalid)
+		require.Equal(t, schema{Name: "test"}, inputSchema)
+	})
+
+	t.Run("error - json", func(t *testing.T) {
+		// arrange
+		inputSchema := schema{Name: "test"}
+		inputRequest := http.Request{
+			Header: http.Header{"Content-Type": []string{"application/merge-patch+json"}},
+			Body:   io.NopCloser(strings.NewReader(`{"price":"not-a-number"}`)),
+		}
+
+		// act
+		err := request.MergePatch(&inputRequest, &inputSchema)
+
+		// assert
+		require.ErrorIs(t, err, request.ErrRequestJSONInvalid)
+	})
+}
@@ -7,6 +7,7 @@ import { ProductDetail } from "@/components/product-detail"
 import { ProductGrid } from "@/components/product-grid"
 import { LoadingSpinner } from "@/components/loading-spinner"
 import { ErrorMessage } from "@/components/error-message"
+import { CategoryBreadcrumbs } from "@/components/category-breadcrumbs"
 import { ApiResponse, apiService } from "@/lib/api"
 import type { Product } from "@/types/product"
 
@@ -75,6 +76,11 @@ export default async function ProductPage({ params }: ProductPageProps) {
                         Voltar aos Produtos
                     </Link>
                 </Button>
+                {product && (
+                    <Suspense fallback={null}>
+                        <CategoryBreadcrumbs category={product.category} current={product.name} />
+                    </Suspense>
+                )}
             </div>
 
             <Suspense
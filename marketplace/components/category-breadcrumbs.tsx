@@ -0,0 +1,55 @@
+import Link from "next/link"
+import { Fragment } from "react"
+import {
+  Breadcrumb,
+  BreadcrumbItem,
+  BreadcrumbLink,
+  BreadcrumbList,
+  BreadcrumbPage,
+  BreadcrumbSeparator,
+} from "@/components/ui/breadcrumb"
+import { apiService } from "@/lib/api"
+
+interface CategoryBreadcrumbsProps {
+  category: string
+  current?: string
+}
+
+export async function CategoryBreadcrumbs({ category, current }: CategoryBreadcrumbsProps) {
+  let path
+  try {
+    path = (await apiService.getCategoryBreadcrumbs(category)).data
+  } catch (error) {
+    return null
+  }
+
+  return (
+    <Breadcrumb className="mb-6">
+      <BreadcrumbList>
+        <BreadcrumbItem>
+          <BreadcrumbLink asChild>
+            <Link href="/products">Produtos</Link>
+          </BreadcrumbLink>
+        </BreadcrumbItem>
+        {path.map((c) => (
+          <Fragment key={c.categoryId}>
+            <BreadcrumbSeparator />
+            <BreadcrumbItem>
+              <BreadcrumbLink asChild>
+                <Link href={`/products?categories=${encodeURIComponent(c.name)}`}>{c.name}</Link>
+              </BreadcrumbLink>
+            </BreadcrumbItem>
+          </Fragment>
+        ))}
+        {current && (
+          <>
+            <BreadcrumbSeparator />
+            <BreadcrumbItem>
+              <BreadcrumbPage>{current}</BreadcrumbPage>
+            </BreadcrumbItem>
+          </>
+        )}
+      </BreadcrumbList>
+    </Breadcrumb>
+  )
+}
@@ -1,4 +1,4 @@
-import { Category } from "@/types/product"
+import { Category, CategoryNode } from "@/types/product"
 
 export interface Product {
   productId: string
@@ -8,7 +8,7 @@ export interface Product {
   image: string
   category: string
   inStock: boolean
-  rating?: number
+  rating?: number | null
   reviews?: number
 }
 
@@ -19,8 +19,9 @@ export interface ApiResponse<T> {
 export interface PaginatedResponse<T> {
   data: T[]
   totalCount: number
-  page: number;
+  page?: number;
   pageSize: number
+  nextCursor?: string
 }
 
 export interface ProductFilters {
@@ -34,8 +35,10 @@ export interface ProductFilters {
 }
 
 export interface ProductsParams extends ProductFilters {
+  includeSubcategories?: boolean
   page?: number
   pageSize?: number
+  cursor?: string
 }
 
 export interface IApiService {
@@ -44,6 +47,8 @@ export interface IApiService {
   searchProducts(query: string): Promise<PaginatedResponse<Product>>
   getProductsByCategory(category: string): Promise<PaginatedResponse<Product>>
   getCategories(): Promise<ApiResponse<Category[]>>
+  getCategoryTree(): Promise<ApiResponse<CategoryNode[]>>
+  getCategoryBreadcrumbs(category: string): Promise<ApiResponse<Category[]>>
 }
 
 class ApiService implements IApiService {
@@ -90,7 +95,9 @@ class ApiService implements IApiService {
 
     if (params?.page) searchParams.set("page", params.page.toString())
     if (params?.pageSize) searchParams.set("pageSize", params.pageSize.toString())
+    if (params?.cursor) searchParams.set("cursor", params.cursor)
     if (params?.categories?.length) searchParams.set("categories", String(params.categories))
+    if (params?.includeSubcategories) searchParams.set("includeSubcategories", "true")
     if (params?.name) searchParams.set("name", params.name)
     if (params?.minPrice) searchParams.set("minPrice", params.minPrice.toString())
     if (params?.maxPrice) searchParams.set("maxPrice", params.maxPrice.toString())
@@ -119,6 +126,14 @@ class ApiService implements IApiService {
   async getCategories(): Promise<ApiResponse<Category[]>> {
     return this.request<ApiResponse<Category[]>>(`/api/v1/categories`)  
   }
+
+  async getCategoryTree(): Promise<ApiResponse<CategoryNode[]>> {
+    return this.request<ApiResponse<CategoryNode[]>>(`/api/v1/categories/tree`)
+  }
+
+  async getCategoryBreadcrumbs(category: string): Promise<ApiResponse<Category[]>> {
+    return this.request<ApiResponse<Category[]>>(`/api/v1/categories/${encodeURIComponent(category)}/breadcrumbs`)
+  }
 }
 
 export const apiService = new ApiService()
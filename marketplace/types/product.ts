@@ -3,11 +3,14 @@ export interface Product {
   name: string
   description: string
   price: number
-  originalPrice?: number;
+  originalPrice?: number | null
   image: string
   category: string
   inStock: boolean
-  rating?: number
+  stock?: number | null
+  reserved?: number
+  version?: number
+  rating?: number | null
   reviews?: number
 }
 
@@ -16,7 +19,26 @@ export interface CartItem extends Product {
 }
 
 export interface Category {
+  categoryId: string
+  slug: string
   name: string
+  description?: string
+  parentId?: string
+  image?: string
+  stats?: CategoryStats
+}
+
+export interface CategoryStats {
+  productCount: number
+  inStockCount: number
+  minPrice: number
+  maxPrice: number
+  avgPrice: number
+  avgRating: number
+}
+
+export interface CategoryNode extends Category {
+  children: CategoryNode[]
 }
 
 export interface ApiError {